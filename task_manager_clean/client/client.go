@@ -0,0 +1,221 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"task_manager/domain"
+	"time"
+)
+
+// The duration after which a request is abandoned.
+const requestTimeout = 30 * time.Second
+
+// Client is a client of the REST API. Its requests and responses are the types of the domain package, so that it
+// cannot drift from the server.
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// A constructor that creates a new instance of Client. The token is sent as a bearer token if it is set.
+func New(baseURL string, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Error is the error returned when the API refuses a request. It holds the problem details of the response.
+type Error struct {
+	domain.Problem
+}
+
+// A method that implements the error interface, with the code, the detail and the invalid fields of the problem.
+func (e *Error) Error() string {
+	message := e.Detail
+	if message == "" {
+		message = e.Title
+	}
+
+	if len(e.Errors) > 0 && e.Code != domain.CodeValidationFailed {
+		fields := make([]string, len(e.Errors))
+		for i, field := range e.Errors {
+			fields[i] = field.Field + " " + field.Reason
+		}
+		message += ": " + strings.Join(fields, "; ")
+	}
+
+	return fmt.Sprintf("%s (%d %s)", message, e.Status, e.Code)
+}
+
+// A method that logs a user in. Users with two-factor authentication get an MFA token instead of a login token.
+func (c *Client) Login(ctx context.Context, credentials *domain.AuthUserData) (*domain.LoginResult, error) {
+	result := &domain.LoginResult{}
+	err := c.do(ctx, http.MethodPost, "/login", nil, credentials, result)
+	return result, err
+}
+
+// A method that completes the login of a user with two-factor authentication and returns the login token.
+func (c *Client) VerifyLogin(ctx context.Context, data *domain.TwoFactorLoginData) (string, error) {
+	var result struct {
+		Token string `json:"token"`
+	}
+	err := c.do(ctx, http.MethodPost, "/login/2fa", nil, data, &result)
+	return result.Token, err
+}
+
+// A method that returns the tasks the user can view, filtered by the query.
+func (c *Client) GetTasks(ctx context.Context, query *domain.TaskQuery) ([]domain.Task, error) {
+	var result struct {
+		Tasks []domain.Task `json:"tasks"`
+	}
+	err := c.do(ctx, http.MethodGet, "/tasks", encodeQuery(query), nil, &result)
+	return result.Tasks, err
+}
+
+// A method that returns a task with the given ID.
+func (c *Client) GetTask(ctx context.Context, id string) (*domain.Task, error) {
+	task := &domain.Task{}
+	err := c.do(ctx, http.MethodGet, "/tasks/"+url.PathEscape(id), nil, nil, task)
+	return task, err
+}
+
+// A method that creates a new task.
+func (c *Client) CreateTask(ctx context.Context, taskData *domain.CreateTaskData) (*domain.TaskView, error) {
+	taskView := &domain.TaskView{}
+	err := c.do(ctx, http.MethodPost, "/tasks", nil, taskData, taskView)
+	return taskView, err
+}
+
+// A method that partially updates a task with the given ID. The empty fields are left unchanged.
+func (c *Client) UpdateTask(ctx context.Context, id string, taskData *domain.UpdateTaskData) (*domain.TaskView, error) {
+	taskView := &domain.TaskView{}
+	err := c.do(ctx, http.MethodPatch, "/tasks/"+url.PathEscape(id), nil, taskData, taskView)
+	return taskView, err
+}
+
+// A method that deletes a task with the given ID.
+func (c *Client) DeleteTask(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/tasks/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// A method that returns all the users.
+func (c *Client) GetUsers(ctx context.Context) ([]domain.User, error) {
+	var result struct {
+		Users []domain.User `json:"users"`
+	}
+	err := c.do(ctx, http.MethodGet, "/users", nil, nil, &result)
+	return result.Users, err
+}
+
+// A method that returns a user with the given ID.
+func (c *Client) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	user := &domain.User{}
+	err := c.do(ctx, http.MethodGet, "/users/"+url.PathEscape(id), nil, nil, user)
+	return user, err
+}
+
+// A method that adds a new user.
+func (c *Client) CreateUser(ctx context.Context, userData *domain.CreateUserData) (*domain.User, error) {
+	user := &domain.User{}
+	err := c.do(ctx, http.MethodPost, "/users", nil, userData, user)
+	return user, err
+}
+
+// A method that updates a user with the given ID. The empty fields are left unchanged.
+func (c *Client) UpdateUser(ctx context.Context, id string, userData *domain.UpdateUserData) (*domain.User, error) {
+	user := &domain.User{}
+	err := c.do(ctx, http.MethodPatch, "/users/"+url.PathEscape(id), nil, userData, user)
+	return user, err
+}
+
+// A method that deletes a user with the given ID, and handles their tasks as the query says.
+func (c *Client) DeleteUser(ctx context.Context, id string, query *domain.DeleteUserQuery) error {
+	return c.do(ctx, http.MethodDelete, "/users/"+url.PathEscape(id), encodeQuery(query), nil, nil)
+}
+
+// A helper method that sends a request with the body encoded as JSON, and decodes the response into result.
+// Responses with an error status are decoded as problem details.
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, body any, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiError := &Error{}
+		err = json.NewDecoder(resp.Body).Decode(&apiError.Problem)
+		if err != nil || apiError.Status == 0 {
+			apiError.Problem = domain.Problem{
+				Title:  http.StatusText(resp.StatusCode),
+				Status: resp.StatusCode,
+				Code:   domain.DefaultCode(resp.StatusCode),
+			}
+		}
+		return apiError
+	}
+
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// A helper function that encodes the non-empty fields of a struct as query parameters, named by their form tags as
+// the server binds them.
+func encodeQuery(data any) url.Values {
+	values := url.Values{}
+	value := reflect.Indirect(reflect.ValueOf(data))
+	if !value.IsValid() {
+		return values
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		name := strings.Split(value.Type().Field(i).Tag.Get("form"), ",")[0]
+		field := value.Field(i)
+		if name == "" || name == "-" || field.IsZero() {
+			continue
+		}
+
+		values.Set(name, fmt.Sprint(field.Interface()))
+	}
+
+	return values
+}
@@ -0,0 +1,138 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"task_manager/client"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite that contains tests for the Client.
+type ClientTestSuite struct {
+	suite.Suite
+	server  *httptest.Server
+	handler http.HandlerFunc
+	client  *client.Client
+}
+
+// A method that starts a server for each testcase, which serves with the handler of the testcase.
+func (suite *ClientTestSuite) SetupSubTest() {
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.handler(w, r)
+	}))
+	suite.client = client.New(suite.server.URL+"/", "token")
+}
+
+// A method that stops the server of each testcase.
+func (suite *ClientTestSuite) TearDownSubTest() {
+	suite.server.Close()
+}
+
+// A helper method that writes a JSON response.
+func (suite *ClientTestSuite) respond(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	suite.Require().NoError(json.NewEncoder(w).Encode(body))
+}
+
+// A test for the Client.GetTasks method.
+func (suite *ClientTestSuite) TestGetTasks() {
+	// A testcase where the filters are sent as query parameters with the token.
+	suite.Run("GetTasks_Filtered", func() {
+		tasks := mocks.GetManyTasks()
+		suite.handler = func(w http.ResponseWriter, r *http.Request) {
+			suite.Equal(http.MethodGet, r.Method)
+			suite.Equal("/tasks", r.URL.Path)
+			suite.Equal("project_id=p1", r.URL.RawQuery)
+			suite.Equal("Bearer token", r.Header.Get("Authorization"))
+			suite.respond(w, http.StatusOK, map[string]any{"count": len(tasks), "tasks": tasks})
+		}
+
+		result, err := suite.client.GetTasks(context.Background(), &domain.TaskQuery{ProjectID: "p1"})
+		suite.Require().NoError(err)
+		suite.Len(result, len(tasks))
+		suite.Equal(tasks[0].ID, result[0].ID)
+	})
+
+	// A testcase where the problem details of a refused request are returned as an Error.
+	suite.Run("GetTasks_Problem", func() {
+		suite.handler = func(w http.ResponseWriter, r *http.Request) {
+			suite.respond(w, http.StatusForbidden, (&domain.Error{
+				StatusCode: http.StatusForbidden,
+				Code:       domain.CodeForbiddenScope,
+				Message:    "Forbidden Hint: the tasks:read scope is required",
+			}).Problem())
+		}
+
+		_, err := suite.client.GetTasks(context.Background(), &domain.TaskQuery{})
+		apiError, ok := err.(*client.Error)
+		suite.Require().True(ok)
+		suite.Equal(http.StatusForbidden, apiError.Status)
+		suite.Equal(domain.CodeForbiddenScope, apiError.Code)
+		suite.Equal("Forbidden Hint: the tasks:read scope is required (403 FORBIDDEN_SCOPE)", err.Error())
+	})
+
+	// A testcase where a response without problem details gets the generic problem of its status.
+	suite.Run("GetTasks_NoProblem", func() {
+		suite.handler = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+		}
+
+		_, err := suite.client.GetTasks(context.Background(), &domain.TaskQuery{})
+		apiError, ok := err.(*client.Error)
+		suite.Require().True(ok)
+		suite.Equal(http.StatusBadGateway, apiError.Status)
+		suite.Equal(domain.CodeInternal, apiError.Code)
+	})
+}
+
+// A test for the Client.CreateTask method.
+func (suite *ClientTestSuite) TestCreateTask() {
+	// A testcase where the task data is sent as the JSON body.
+	suite.Run("CreateTask_Success", func() {
+		taskData := mocks.GetCreateTaskData()
+		taskView := mocks.GetView(taskData, mocks.GetClaims())
+		suite.handler = func(w http.ResponseWriter, r *http.Request) {
+			suite.Equal(http.MethodPost, r.Method)
+			suite.Equal("application/json", r.Header.Get("Content-Type"))
+
+			received := &domain.CreateTaskData{}
+			suite.Require().NoError(json.NewDecoder(r.Body).Decode(received))
+			suite.Equal(taskData.Title, received.Title)
+			suite.True(taskData.DueDate.Equal(received.DueDate))
+			suite.respond(w, http.StatusCreated, taskView)
+		}
+
+		result, err := suite.client.CreateTask(context.Background(), taskData)
+		suite.Require().NoError(err)
+		suite.Equal(taskView.ID, result.ID)
+	})
+}
+
+// A test for the Client.DeleteUser method.
+func (suite *ClientTestSuite) TestDeleteUser() {
+	// A testcase where the deletion policy is sent as query parameters.
+	suite.Run("DeleteUser_Reassign", func() {
+		suite.handler = func(w http.ResponseWriter, r *http.Request) {
+			suite.Equal(http.MethodDelete, r.Method)
+			suite.Equal("/users/u1", r.URL.Path)
+			suite.Equal("reassign", r.URL.Query().Get("tasks"))
+			suite.Equal("u2", r.URL.Query().Get("reassign_to"))
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+		err := suite.client.DeleteUser(context.Background(), "u1", &domain.DeleteUserQuery{Tasks: "reassign", ReassignTo: "u2"})
+		suite.NoError(err)
+	})
+}
+
+// A method that runs the ClientTestSuite.
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
@@ -0,0 +1,25 @@
+// Command taskadmin runs the maintenance operations of the task manager directly against the configured store, such
+// as recovering the root user, applying the migrations and backing up the database.
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Load the environment variables of the .env file, if there is one, as the server does
+	err := godotenv.Load(".env")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Fatal(err)
+	}
+
+	err = newRootCommand(openStore).Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
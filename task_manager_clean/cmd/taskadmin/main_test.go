@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite that contains tests for the commands of taskadmin.
+type CommandTestSuite struct {
+	suite.Suite
+	admin  *mocks.AdminUsecase
+	closed bool
+}
+
+// A method that sets up each testcase with a new admin usecase.
+func (suite *CommandTestSuite) SetupSubTest() {
+	suite.admin = new(mocks.AdminUsecase)
+	suite.closed = false
+}
+
+// A method that checks the expectations of each testcase.
+func (suite *CommandTestSuite) TearDownSubTest() {
+	suite.admin.AssertExpectations(suite.T())
+}
+
+// A helper method that runs a command with the input against the mocked store, and returns its output.
+func (suite *CommandTestSuite) run(input string, args ...string) (string, error) {
+	cmd := newRootCommand(func(configFile string) (*store, error) {
+		return &store{admin: suite.admin, close: func() { suite.closed = true }}, nil
+	})
+	output := &bytes.Buffer{}
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetOut(output)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return output.String(), err
+}
+
+// A test for the create-root command.
+func (suite *CommandTestSuite) TestCreateRoot() {
+	// A testcase where the root user is created with the password of the standard input.
+	suite.Run("CreateRoot_Success", func() {
+		user := mocks.GetNewUser()
+		user.Role = "root"
+		suite.admin.On("CreateRootUser", mock.Anything, &domain.AuthUserData{Username: user.Username, Password: "password123"}).Return(user, nil).Once()
+
+		output, err := suite.run("password123\n", "create-root", "-u", user.Username)
+		suite.Require().NoError(err)
+		suite.Contains(output, user.ID.Hex())
+		suite.True(suite.closed)
+	})
+
+	// A testcase where the invalid fields are reported.
+	suite.Run("CreateRoot_Invalid", func() {
+		suite.admin.On("CreateRootUser", mock.Anything, mock.Anything).Return(nil, &domain.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Validation failed",
+			Fields:     []domain.FieldError{{Field: "password", Reason: "must be at least 8 characters"}},
+		}).Once()
+
+		_, err := suite.run("short\n", "create-root", "-u", "admin")
+		suite.EqualError(err, "Validation failed: password must be at least 8 characters")
+	})
+
+	// A testcase where no password is given, so the store is not opened.
+	suite.Run("CreateRoot_NoPassword", func() {
+		_, err := suite.run("", "create-root", "-u", "admin")
+		suite.Error(err)
+		suite.False(suite.closed)
+	})
+}
+
+// A test for the reset-password command.
+func (suite *CommandTestSuite) TestResetPassword() {
+	// A testcase where the password is reset and the two-factor authentication disabled.
+	suite.Run("ResetPassword_DisableTwoFactor", func() {
+		suite.admin.On("ResetPassword", mock.Anything, "alice", "newpassword", true).Return(nil).Once()
+
+		output, err := suite.run("newpassword\n", "reset-password", "-u", "alice", "--disable-2fa")
+		suite.Require().NoError(err)
+		suite.Contains(output, "Two-factor authentication of alice disabled")
+	})
+}
+
+// A test for the set-role command.
+func (suite *CommandTestSuite) TestSetRole() {
+	// A testcase where an unknown role is reported.
+	suite.Run("SetRole_UnknownRole", func() {
+		suite.admin.On("SetRole", mock.Anything, "alice", "auditor").Return(nil, &domain.Error{
+			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeRoleNotFound,
+			Message:    "Role not found",
+		}).Once()
+
+		_, err := suite.run("", "set-role", "-u", "alice", "--role", "auditor")
+		suite.EqualError(err, "Role not found")
+	})
+}
+
+// A test for the users command.
+func (suite *CommandTestSuite) TestUsers() {
+	// A testcase where the users are listed with their roles.
+	suite.Run("Users_Table", func() {
+		user := mocks.GetNewUser()
+		user.TOTPEnabled = true
+		suite.admin.On("GetUsers", mock.Anything).Return([]domain.User{*user}, nil).Once()
+
+		output, err := suite.run("", "users")
+		suite.Require().NoError(err)
+		suite.Contains(output, "TWO-FACTOR")
+		suite.Regexp(user.Username+`\s+`+user.Role+`\s+on`, output)
+	})
+}
+
+// A function that runs the CommandTestSuite.
+func TestCommandTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandTestSuite))
+}
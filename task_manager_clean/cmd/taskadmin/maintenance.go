@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"task_manager/database"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// A function that creates the migrate command, which applies the pending migrations.
+func newMigrateCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply the pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.withStore(func(s *store) error {
+				applied, err := database.Migrate(cmd.Context(), s.db, database.Migrations)
+				fmt.Fprintf(cmd.OutOrStdout(), "%d migration(s) applied\n", len(applied))
+				return err
+			})
+		},
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "List the migrations and when they were applied",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.withStore(func(s *store) error {
+				statuses, err := database.GetMigrationStatus(cmd.Context(), s.db, database.Migrations)
+				if err != nil {
+					return err
+				}
+
+				return database.WriteMigrationStatus(cmd.OutOrStdout(), statuses)
+			})
+		},
+	})
+
+	return cmd
+}
+
+// A function that creates the backup command, which groups the export and the import of a full backup.
+func newBackupCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Export or import a full backup of the database",
+		Long: `Export or import a full backup of the database.
+
+A backup is a JSON Lines file in canonical extended JSON, with the indexes and the documents of every collection,
+including the password hashes of the users. Keep it as safe as the database itself.`,
+	}
+
+	cmd.AddCommand(newBackupExportCommand(opts), newBackupImportCommand(opts))
+	return cmd
+}
+
+// A function that creates the backup export command.
+func newBackupExportCommand(opts *options) *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write a backup of the database",
+		Long:  "Write a backup of the database to the file, or to the standard output without --file.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.withStore(func(s *store) error {
+				var w io.Writer = cmd.OutOrStdout()
+				if file != "" {
+					f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+					if err != nil {
+						return err
+					}
+					defer f.Close()
+					w = f
+				}
+
+				summaries, err := database.Export(cmd.Context(), s.db, w)
+				if err != nil {
+					return err
+				}
+
+				return printSummaries(cmd.ErrOrStderr(), summaries)
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "path of the backup file")
+	return cmd
+}
+
+// A function that creates the backup import command.
+func newBackupImportCommand(opts *options) *cobra.Command {
+	var file, preBackup string
+	var replace bool
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Restore a backup of the database",
+		Long: `Restore a backup of the database from the file, or from the standard input without --file.
+
+A database that already has documents is refused, unless --replace is given, in which case all its collections
+are dropped first. Before that, the database is exported to the --pre-backup file, and the whole backup is read and
+checked, so that a corrupt backup leaves the database as it was. The migrations added since the backup was made are
+applied afterwards.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var r io.Reader = cmd.InOrStdin()
+			if file != "" {
+				f, err := os.Open(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				r = f
+			}
+
+			return opts.withStore(func(s *store) error {
+				if replace {
+					err := exportPreBackup(cmd, s, preBackup)
+					if err != nil {
+						return err
+					}
+				}
+
+				summaries, err := database.Import(cmd.Context(), s.db, r, replace)
+				if err != nil {
+					return err
+				}
+
+				err = printSummaries(cmd.OutOrStdout(), summaries)
+				if err != nil {
+					return err
+				}
+
+				applied, err := database.Migrate(cmd.Context(), s.db, database.Migrations)
+				fmt.Fprintf(cmd.OutOrStdout(), "%d migration(s) applied\n", len(applied))
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "path of the backup file")
+	cmd.Flags().BoolVar(&replace, "replace", false, "drop the collections of a database that is not empty")
+	cmd.Flags().StringVar(&preBackup, "pre-backup", "", "path of the backup of the database written before --replace (default <database>-pre-import-<time>.jsonl)")
+	return cmd
+}
+
+// A helper function that exports the database to the file, or to a file named after the database and the time, before
+// an import replaces it.
+func exportPreBackup(cmd *cobra.Command, s *store, file string) error {
+	if file == "" {
+		file = fmt.Sprintf("%s-pre-import-%s.jsonl", s.db.Name(), time.Now().UTC().Format("20060102T150405Z"))
+	}
+
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = database.Export(cmd.Context(), s.db, f)
+	if err != nil {
+		return fmt.Errorf("exporting the database before replacing it: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "the database was exported to %s\n", file)
+	return f.Close()
+}
+
+// A helper function that prints the number of indexes and documents of each collection of a backup.
+func printSummaries(w io.Writer, summaries []database.BackupCollection) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "COLLECTION\tINDEXES\tDOCUMENTS")
+	for _, summary := range summaries {
+		fmt.Fprintf(writer, "%s\t%d\t%d\n", summary.Name, summary.Indexes, summary.Documents)
+	}
+
+	return writer.Flush()
+}
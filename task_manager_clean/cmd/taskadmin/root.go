@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"task_manager/config"
+	"task_manager/database"
+	"task_manager/delivery/router"
+	"task_manager/domain"
+
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A struct that holds the connection to the store and the usecase of the maintenance operations.
+type store struct {
+	db    *mongo.Database
+	admin domain.AdminUsecase
+	close func()
+}
+
+// A function that opens the store described by the configuration file. It is replaced in the tests.
+type openFunc func(configFile string) (*store, error)
+
+// A struct that holds the global options of the commands.
+type options struct {
+	configFile string
+	open       openFunc
+}
+
+// A function that creates the root command, with the global flags and the subcommands.
+func newRootCommand(open openFunc) *cobra.Command {
+	opts := &options{open: open}
+	cmd := &cobra.Command{
+		Use:   "taskadmin",
+		Short: "Maintenance operations of the task manager",
+		Long: `Maintenance operations of the task manager, which run directly against the configured store.
+
+The configuration is loaded as the server loads it: from the file given with --config or CONFIG_FILE, then from
+the environment, including the .env file of the working directory.`,
+		SilenceUsage: true,
+	}
+
+	cmd.PersistentFlags().StringVar(&opts.configFile, "config", "", "path of the YAML configuration file")
+
+	cmd.AddCommand(
+		newCreateRootCommand(opts),
+		newResetPasswordCommand(opts),
+		newSetRoleCommand(opts),
+		newUsersCommand(opts),
+		newMigrateCommand(opts),
+		newBackupCommand(opts),
+	)
+
+	return cmd
+}
+
+// A method that opens the store and runs the function with it, closing the store afterwards.
+func (opts *options) withStore(run func(*store) error) error {
+	s, err := opts.open(opts.configFile)
+	if err != nil {
+		return err
+	}
+	defer s.close()
+
+	return run(s)
+}
+
+// A function that loads the configuration and connects to its database, without the caches and the metrics of the
+// server, so that the changes are visible to the running servers as soon as their caches expire.
+func openStore(configFile string) (*store, error) {
+	args := []string{}
+	if configFile != "" {
+		args = append(args, "-config", configFile)
+	}
+
+	cfg, err := config.Load(args)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := database.Init(cfg.Database)
+	if err != nil {
+		return nil, err
+	}
+
+	db := client.Database(cfg.Database.Name)
+	return &store{
+		db:    db,
+		admin: router.GetAdminUsecase(db, nil, nil),
+		close: func() { client.Disconnect(context.Background()) },
+	}, nil
+}
+
+// A helper function that converts a domain error into an error with its message and its invalid fields, as the
+// underlying error of a domain error is meant for the logs.
+func adminError(_err *domain.Error) error {
+	if _err == nil {
+		return nil
+	}
+
+	message := _err.Message
+	if len(_err.Fields) > 0 {
+		fields := []string{}
+		for _, field := range _err.Fields {
+			fields = append(fields, field.Field+" "+field.Reason)
+		}
+		message = fmt.Sprintf("%s: %s", message, strings.Join(fields, ", "))
+	}
+
+	return errors.New(message)
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"task_manager/domain"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// A function that creates the create-root command, which creates a user with the root role.
+func newCreateRootCommand(opts *options) *cobra.Command {
+	var username string
+	cmd := &cobra.Command{
+		Use:   "create-root",
+		Short: "Create a root user",
+		Long: `Create a user with the root role.
+
+The password is prompted for twice, or read from the first line of the standard input if it is not a terminal.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			password, err := readNewPassword(cmd)
+			if err != nil {
+				return err
+			}
+
+			return opts.withStore(func(s *store) error {
+				user, _err := s.admin.CreateRootUser(cmd.Context(), &domain.AuthUserData{Username: username, Password: password})
+				if _err != nil {
+					return adminError(_err)
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Root user %s created with ID %s\n", user.Username, user.ID.Hex())
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&username, "username", "u", "", "username of the root user")
+	cmd.MarkFlagRequired("username")
+
+	return cmd
+}
+
+// A function that creates the reset-password command, which sets the password of a user.
+func newResetPasswordCommand(opts *options) *cobra.Command {
+	var username string
+	var disableTwoFactor bool
+	cmd := &cobra.Command{
+		Use:   "reset-password",
+		Short: "Set the password of a user",
+		Long: `Set the password of a user and revoke their pending password reset links.
+
+The password is prompted for twice, or read from the first line of the standard input if it is not a terminal.
+Users who lost their authenticator can also have their two-factor authentication disabled with --disable-2fa.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			password, err := readNewPassword(cmd)
+			if err != nil {
+				return err
+			}
+
+			return opts.withStore(func(s *store) error {
+				_err := s.admin.ResetPassword(cmd.Context(), username, password, disableTwoFactor)
+				if _err != nil {
+					return adminError(_err)
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Password of %s reset\n", username)
+				if disableTwoFactor {
+					fmt.Fprintf(cmd.OutOrStdout(), "Two-factor authentication of %s disabled\n", username)
+				}
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&username, "username", "u", "", "username of the user")
+	cmd.Flags().BoolVar(&disableTwoFactor, "disable-2fa", false, "also disable the two-factor authentication of the user")
+	cmd.MarkFlagRequired("username")
+
+	return cmd
+}
+
+// A function that creates the set-role command, which changes the role of a user.
+func newSetRoleCommand(opts *options) *cobra.Command {
+	var username, role string
+	cmd := &cobra.Command{
+		Use:   "set-role",
+		Short: "Change the role of a user",
+		Long:  "Change the role of a user to a built-in role, such as root, admin or user, or to a custom role.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.withStore(func(s *store) error {
+				user, _err := s.admin.SetRole(cmd.Context(), username, role)
+				if _err != nil {
+					return adminError(_err)
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Role of %s set to %s\n", user.Username, user.Role)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&username, "username", "u", "", "username of the user")
+	cmd.Flags().StringVar(&role, "role", "", "name of the role")
+	cmd.MarkFlagRequired("username")
+	cmd.MarkFlagRequired("role")
+
+	return cmd
+}
+
+// A function that creates the users command, which lists the users with their roles.
+func newUsersCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "users",
+		Short: "List the users",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.withStore(func(s *store) error {
+				users, _err := s.admin.GetUsers(cmd.Context())
+				if _err != nil {
+					return adminError(_err)
+				}
+
+				writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(writer, "ID\tUSERNAME\tROLE\tTWO-FACTOR")
+				for _, user := range users {
+					twoFactor := "off"
+					if user.TOTPEnabled {
+						twoFactor = "on"
+					}
+
+					fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", user.ID.Hex(), user.Username, user.Role, twoFactor)
+				}
+
+				return writer.Flush()
+			})
+		},
+	}
+}
+
+// A helper function that prompts for a new password twice without echoing it, or reads the first line of the
+// standard input if it is not a terminal.
+func readNewPassword(cmd *cobra.Command) (string, error) {
+	if cmd.InOrStdin() == os.Stdin && term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprint(cmd.ErrOrStderr(), "New password: ")
+		password, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(cmd.ErrOrStderr())
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprint(cmd.ErrOrStderr(), "Repeat the password: ")
+		repeated, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(cmd.ErrOrStderr())
+		if err != nil {
+			return "", err
+		}
+
+		if string(password) != string(repeated) {
+			return "", errors.New("the passwords do not match")
+		}
+
+		return string(password), nil
+	}
+
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.New("no password given on the standard input")
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
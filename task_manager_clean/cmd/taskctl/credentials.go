@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A struct that holds the token cached by the login command, and the server that issued it.
+type credentials struct {
+	Server string `yaml:"server"`
+	Token  string `yaml:"token"`
+}
+
+// A function that returns the path of the cached credentials. TASKCTL_CREDENTIALS overrides the default path in the
+// configuration directory of the user.
+func credentialsPath() (string, error) {
+	path := os.Getenv("TASKCTL_CREDENTIALS")
+	if path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "taskctl", "credentials.yaml"), nil
+}
+
+// A function that reads the cached credentials. There are no credentials before the first login.
+func loadCredentials() (*credentials, error) {
+	path, err := credentialsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &credentials{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cached := &credentials{}
+	err = yaml.Unmarshal(data, cached)
+	if err != nil {
+		return nil, err
+	}
+
+	return cached, nil
+}
+
+// A function that caches the credentials, readable only by the user.
+func saveCredentials(cached *credentials) error {
+	path, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(cached)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+// A function that removes the cached credentials.
+func removeCredentials() error {
+	path, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"task_manager/client"
+	"task_manager/domain"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// A function that creates the login command, which caches the token of the user.
+func newLoginCommand(opts *options) *cobra.Command {
+	var username, code string
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in and cache the token",
+		Long: `Log in and cache the token for the next commands.
+
+The password is prompted for, or read from the first line of the standard input if it is not a terminal.
+Users with two-factor authentication are also prompted for a code, unless --code is given.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cached, err := loadCredentials()
+			if err != nil {
+				return err
+			}
+			server := opts.serverOr(cached.Server)
+
+			stdin := bufio.NewReader(cmd.InOrStdin())
+			password, err := readSecret(cmd, stdin, "Password: ")
+			if err != nil {
+				return err
+			}
+
+			c := client.New(server, "")
+			result, err := c.Login(cmd.Context(), &domain.AuthUserData{Username: username, Password: password})
+			if err != nil {
+				return err
+			}
+
+			token := result.Token
+			switch {
+			case result.MFASetupRequired:
+				return errors.New("two-factor authentication must be set up before logging in")
+			case result.MFARequired:
+				if code == "" {
+					code, err = readSecret(cmd, stdin, "Two-factor code: ")
+					if err != nil {
+						return err
+					}
+				}
+
+				token, err = c.VerifyLogin(cmd.Context(), &domain.TwoFactorLoginData{MFAToken: result.MFAToken, Code: code})
+				if err != nil {
+					return err
+				}
+			}
+
+			err = saveCredentials(&credentials{Server: server, Token: token})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Logged in to %s as %s\n", server, username)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&username, "username", "u", "", "username to log in with")
+	cmd.Flags().StringVar(&code, "code", "", "two-factor code or recovery code")
+	cmd.MarkFlagRequired("username")
+
+	return cmd
+}
+
+// A function that creates the logout command, which removes the cached token.
+func newLogoutCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Remove the cached token",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return removeCredentials()
+		},
+	}
+}
+
+// A helper function that prompts for a secret without echoing it, or reads the first line of the standard input if
+// it is not a terminal.
+func readSecret(cmd *cobra.Command, stdin *bufio.Reader, prompt string) (string, error) {
+	if cmd.InOrStdin() == os.Stdin && term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprint(cmd.ErrOrStderr(), prompt)
+		secret, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(cmd.ErrOrStderr())
+		return string(secret), err
+	}
+
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.New("no " + strings.ToLower(strings.TrimSuffix(prompt, ": ")) + " given on the standard input")
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
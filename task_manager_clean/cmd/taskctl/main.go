@@ -0,0 +1,13 @@
+// Command taskctl is a command-line client of the task manager API.
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	err := newRootCommand().Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
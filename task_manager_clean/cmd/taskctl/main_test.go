@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite that contains tests for the commands of taskctl.
+type CommandTestSuite struct {
+	suite.Suite
+	server  *httptest.Server
+	handler http.HandlerFunc
+}
+
+// A method that starts a server for each testcase, and keeps the credentials of the testcase in a temporary file.
+func (suite *CommandTestSuite) SetupSubTest() {
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.handler(w, r)
+	}))
+	suite.T().Setenv("TASKCTL_CREDENTIALS", filepath.Join(suite.T().TempDir(), "credentials.yaml"))
+	suite.T().Setenv("TASKCTL_SERVER", "")
+	suite.T().Setenv("TASKCTL_TOKEN", "")
+}
+
+// A method that stops the server of each testcase.
+func (suite *CommandTestSuite) TearDownSubTest() {
+	suite.server.Close()
+}
+
+// A helper method that runs a command with the input, and returns its output.
+func (suite *CommandTestSuite) run(input string, args ...string) (string, error) {
+	cmd := newRootCommand()
+	output := &bytes.Buffer{}
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetOut(output)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return output.String(), err
+}
+
+// A test for the login command.
+func (suite *CommandTestSuite) TestLogin() {
+	// A testcase where the token of the login is cached and sent by the next commands.
+	suite.Run("Login_CachesToken", func() {
+		suite.handler = func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/login":
+				credentials := &domain.AuthUserData{}
+				suite.Require().NoError(json.NewDecoder(r.Body).Decode(credentials))
+				suite.Equal(domain.AuthUserData{Username: "alice", Password: "secret"}, *credentials)
+				json.NewEncoder(w).Encode(domain.LoginResult{Token: "cached"})
+			case "/users":
+				suite.Equal("Bearer cached", r.Header.Get("Authorization"))
+				json.NewEncoder(w).Encode(map[string]any{"users": []domain.User{}})
+			}
+		}
+
+		_, err := suite.run("secret\n", "login", "--server", suite.server.URL, "-u", "alice")
+		suite.Require().NoError(err)
+
+		_, err = suite.run("", "users", "list")
+		suite.NoError(err)
+	})
+
+	// A testcase where the two-factor code is exchanged for the token.
+	suite.Run("Login_TwoFactor", func() {
+		suite.handler = func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/login":
+				json.NewEncoder(w).Encode(domain.LoginResult{MFARequired: true, MFAToken: "mfa"})
+			case "/login/2fa":
+				data := &domain.TwoFactorLoginData{}
+				suite.Require().NoError(json.NewDecoder(r.Body).Decode(data))
+				suite.Equal(domain.TwoFactorLoginData{MFAToken: "mfa", Code: "123456"}, *data)
+				json.NewEncoder(w).Encode(map[string]string{"token": "verified"})
+			}
+		}
+
+		_, err := suite.run("secret\n123456\n", "login", "--server", suite.server.URL, "-u", "alice")
+		suite.Require().NoError(err)
+
+		cached, err := loadCredentials()
+		suite.Require().NoError(err)
+		suite.Equal(credentials{Server: suite.server.URL, Token: "verified"}, *cached)
+	})
+}
+
+// A test for the tasks commands.
+func (suite *CommandTestSuite) TestTasks() {
+	// A testcase where the tasks are listed as a table, with the filters as query parameters.
+	suite.Run("List_Table", func() {
+		tasks := mocks.GetManyTasks()
+		suite.handler = func(w http.ResponseWriter, r *http.Request) {
+			suite.Equal("workspace_id=w1", r.URL.RawQuery)
+			json.NewEncoder(w).Encode(map[string]any{"tasks": tasks})
+		}
+
+		output, err := suite.run("", "--server", suite.server.URL, "tasks", "list", "--workspace", "w1")
+		suite.Require().NoError(err)
+
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		suite.Len(lines, len(tasks)+1)
+		suite.Equal([]string{"ID", "TITLE", "STATUS", "PRIORITY", "DUE", "OWNER", "PROJECT"}, strings.Fields(lines[0]))
+		suite.Contains(lines[1], tasks[0].ID.Hex())
+	})
+
+	// A testcase where a task is shown as YAML, with the fields of the API response.
+	suite.Run("Get_YAML", func() {
+		task := mocks.GetNewTask()
+		suite.handler = func(w http.ResponseWriter, r *http.Request) {
+			suite.Equal("/tasks/"+task.ID.Hex(), r.URL.Path)
+			json.NewEncoder(w).Encode(task)
+		}
+
+		output, err := suite.run("", "--server", suite.server.URL, "-o", "yaml", "tasks", "get", task.ID.Hex())
+		suite.Require().NoError(err)
+		suite.True(strings.HasPrefix(output, "id: "+task.ID.Hex()+"\ntitle: "+task.Title+"\n"))
+	})
+
+	// A testcase where only the given fields of a task are updated.
+	suite.Run("Update_Partial", func() {
+		task := mocks.GetNewTask()
+		suite.handler = func(w http.ResponseWriter, r *http.Request) {
+			suite.Equal(http.MethodPatch, r.Method)
+			taskData := &domain.UpdateTaskData{}
+			suite.Require().NoError(json.NewDecoder(r.Body).Decode(taskData))
+			suite.Equal(domain.UpdateTaskData{Status: "Completed"}, *taskData)
+			json.NewEncoder(w).Encode(mocks.GetTaskView(task))
+		}
+
+		output, err := suite.run("", "--server", suite.server.URL, "-o", "json", "tasks", "update", task.ID.Hex(), "--status", "Completed")
+		suite.Require().NoError(err)
+
+		taskView := &domain.TaskView{}
+		suite.Require().NoError(json.Unmarshal([]byte(output), taskView))
+		suite.Equal(task.ID.Hex(), taskView.ID)
+	})
+
+	// A testcase where an invalid due date is refused before any request.
+	suite.Run("Create_InvalidDate", func() {
+		suite.handler = func(w http.ResponseWriter, r *http.Request) {
+			suite.Fail("unexpected request")
+		}
+
+		_, err := suite.run("", "--server", suite.server.URL, "tasks", "create", "--title", "T", "--due", "tomorrow", "--project", mocks.GetPrimitiveID1().Hex())
+		suite.ErrorContains(err, `invalid date "tomorrow"`)
+	})
+}
+
+// A method that runs the CommandTestSuite.
+func TestCommandTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandTestSuite))
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"task_manager/domain"
+	"text/tabwriter"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"gopkg.in/yaml.v3"
+)
+
+// The output formats of the commands.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// The list of all output formats.
+var formats = []string{formatTable, formatJSON, formatYAML}
+
+// A struct that describes how a value is rendered as a table.
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+// A function that writes the value in the format. JSON and YAML have the fields of the API responses, and the table
+// has the columns that fit a terminal.
+func render(w io.Writer, format string, value any, t table) error {
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case formatYAML:
+		// Convert through JSON, so that the fields are named and ordered as in the API responses.
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		var node yaml.Node
+		err = yaml.Unmarshal(data, &node)
+		if err != nil {
+			return err
+		}
+		clearStyle(&node)
+
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		err = encoder.Encode(&node)
+		if err != nil {
+			return err
+		}
+		return encoder.Close()
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(t.headers, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+
+	return fmt.Errorf("unknown output format %q, expected one of %s", format, strings.Join(formats, ", "))
+}
+
+// A helper function that clears the JSON style of the nodes, so that they are written in the block style of YAML.
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
+
+// A function that returns the table of tasks.
+func taskTable(tasks ...domain.Task) table {
+	t := table{headers: []string{"ID", "TITLE", "STATUS", "PRIORITY", "DUE", "OWNER", "PROJECT"}}
+	for _, task := range tasks {
+		t.rows = append(t.rows, []string{
+			task.ID.Hex(),
+			task.Title,
+			task.Status,
+			orNone(task.Priority),
+			formatDate(task.DueDate),
+			task.UserID.Hex(),
+			orNone(hexOrEmpty(task.ProjectID)),
+		})
+	}
+
+	return t
+}
+
+// A function that returns the table of a task view, as returned by the task changes.
+func taskViewTable(taskView *domain.TaskView) table {
+	return table{
+		headers: []string{"ID", "TITLE", "STATUS", "PRIORITY", "URGENCY", "DUE", "PROJECT"},
+		rows: [][]string{{
+			taskView.ID,
+			taskView.Title,
+			taskView.Status,
+			orNone(taskView.Priority),
+			strconv.FormatFloat(taskView.Urgency, 'f', 2, 64),
+			formatDate(taskView.DueDate),
+			orNone(taskView.ProjectID),
+		}},
+	}
+}
+
+// A function that returns the table of users.
+func userTable(users ...domain.User) table {
+	t := table{headers: []string{"ID", "USERNAME", "ROLE", "EMAIL"}}
+	for _, user := range users {
+		t.rows = append(t.rows, []string{user.ID.Hex(), user.Username, user.Role, orNone(user.Email)})
+	}
+
+	return t
+}
+
+// A helper function that formats a due date for tables, in the local time zone.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// A helper function that shows empty cells as a dash.
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
+
+// A helper function that returns the hex of an ObjectID, or the empty string for the zero ObjectID.
+func hexOrEmpty(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+
+	return id.Hex()
+}
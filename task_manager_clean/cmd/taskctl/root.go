@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"task_manager/client"
+
+	"github.com/spf13/cobra"
+)
+
+// The address of the API when none is configured.
+const defaultServer = "http://localhost:8080"
+
+// A struct that holds the global options of the commands.
+type options struct {
+	server string
+	token  string
+	output string
+}
+
+// A function that creates the root command, with the global flags and the subcommands.
+func newRootCommand() *cobra.Command {
+	opts := &options{}
+	cmd := &cobra.Command{
+		Use:   "taskctl",
+		Short: "A command-line client of the task manager API",
+		Long: `A command-line client of the task manager API.
+
+Log in once with "taskctl login", and the token is cached for the next commands.
+The server and the token can also be set with TASKCTL_SERVER and TASKCTL_TOKEN.`,
+		SilenceUsage: true,
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&opts.server, "server", os.Getenv("TASKCTL_SERVER"), "address of the API (default "+defaultServer+")")
+	flags.StringVar(&opts.token, "token", os.Getenv("TASKCTL_TOKEN"), "bearer token, instead of the cached token")
+	flags.StringVarP(&opts.output, "output", "o", formatTable, "output format: table, json or yaml")
+	cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(formats, cobra.ShellCompDirectiveNoFileComp))
+
+	cmd.AddCommand(
+		newLoginCommand(opts),
+		newLogoutCommand(),
+		newTasksCommand(opts),
+		newUsersCommand(opts),
+	)
+
+	return cmd
+}
+
+// A method that returns a client of the server, authenticated with the given or the cached token.
+// The cached token is only used for the server it was issued by.
+func (opts *options) client() (*client.Client, error) {
+	cached, err := loadCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	server := opts.serverOr(cached.Server)
+	token := opts.token
+	if token == "" && cached.Server == server {
+		token = cached.Token
+	}
+
+	return client.New(server, token), nil
+}
+
+// A method that returns the server of the options, or the fallback, or the default server.
+func (opts *options) serverOr(fallback string) string {
+	switch {
+	case opts.server != "":
+		return opts.server
+	case fallback != "":
+		return fallback
+	}
+
+	return defaultServer
+}
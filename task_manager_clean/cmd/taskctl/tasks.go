@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"task_manager/domain"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The layouts accepted for due dates, from the most to the least precise. Dates without a time zone are local.
+var dateLayouts = []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02"}
+
+// A function that creates the tasks command and its subcommands.
+func newTasksCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "tasks",
+		Aliases: []string{"task"},
+		Short:   "List, show, create, update and delete tasks",
+	}
+
+	cmd.AddCommand(
+		newTasksListCommand(opts),
+		newTasksGetCommand(opts),
+		newTasksCreateCommand(opts),
+		newTasksUpdateCommand(opts),
+		newTasksDeleteCommand(opts),
+	)
+
+	return cmd
+}
+
+// A function that creates the command that lists the tasks. Its filters are the query parameters of GET /tasks.
+func newTasksListCommand(opts *options) *cobra.Command {
+	query := &domain.TaskQuery{}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the tasks you can view",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			tasks, err := c.GetTasks(cmd.Context(), query)
+			if err != nil {
+				return err
+			}
+
+			return render(cmd.OutOrStdout(), opts.output, tasks, taskTable(tasks...))
+		},
+	}
+
+	cmd.Flags().StringVar(&query.WorkspaceID, "workspace", "", "only list the tasks of the workspace with this ID")
+	cmd.Flags().StringVar(&query.ProjectID, "project", "", "only list the tasks of the project with this ID")
+	cmd.Flags().StringVar(&query.Sort, "sort", "", "sort the tasks by due_date, priority or urgency")
+	cmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(domain.TaskSorts, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// A function that creates the command that shows a task.
+func newTasksGetCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get ID",
+		Short:             "Show a task",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeTaskIDs(opts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			task, err := c.GetTask(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			return render(cmd.OutOrStdout(), opts.output, task, taskTable(*task))
+		},
+	}
+}
+
+// A function that creates the command that creates a task. The status is "Pending" by default.
+func newTasksCreateCommand(opts *options) *cobra.Command {
+	taskData := &domain.CreateTaskData{}
+	var due, projectID string
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a task",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			taskData.DueDate, err = parseDate(due)
+			if err != nil {
+				return err
+			}
+
+			taskData.ProjectID, err = primitive.ObjectIDFromHex(projectID)
+			if err != nil {
+				return fmt.Errorf("invalid project ID %q", projectID)
+			}
+
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			taskView, err := c.CreateTask(cmd.Context(), taskData)
+			if err != nil {
+				return err
+			}
+
+			return render(cmd.OutOrStdout(), opts.output, taskView, taskViewTable(taskView))
+		},
+	}
+
+	cmd.Flags().StringVar(&taskData.Title, "title", "", "title of the task")
+	cmd.Flags().StringVar(&taskData.Description, "description", "", "description of the task")
+	cmd.Flags().StringVar(&due, "due", "", "due date, as 2006-01-02, 2006-01-02 15:04 or RFC 3339")
+	cmd.Flags().StringVar(&taskData.Status, "status", "", "status of the task (default Pending)")
+	cmd.Flags().StringVar(&taskData.Priority, "priority", "", "priority of the task: low, medium, high or urgent (default medium)")
+	cmd.Flags().StringVar(&projectID, "project", "", "ID of the project of the task")
+	cmd.MarkFlagRequired("title")
+	cmd.MarkFlagRequired("due")
+	cmd.MarkFlagRequired("project")
+	cmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions(domain.TaskStatuses, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("priority", cobra.FixedCompletions(domain.TaskPriorities, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// A function that creates the command that partially updates a task. Only the given flags are changed.
+func newTasksUpdateCommand(opts *options) *cobra.Command {
+	taskData := &domain.UpdateTaskData{}
+	var due string
+	cmd := &cobra.Command{
+		Use:               "update ID",
+		Short:             "Update a task",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeTaskIDs(opts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if due != "" {
+				var err error
+				taskData.DueDate, err = parseDate(due)
+				if err != nil {
+					return err
+				}
+			}
+
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			taskView, err := c.UpdateTask(cmd.Context(), args[0], taskData)
+			if err != nil {
+				return err
+			}
+
+			return render(cmd.OutOrStdout(), opts.output, taskView, taskViewTable(taskView))
+		},
+	}
+
+	cmd.Flags().StringVar(&taskData.Title, "title", "", "new title of the task")
+	cmd.Flags().StringVar(&taskData.Description, "description", "", "new description of the task")
+	cmd.Flags().StringVar(&due, "due", "", "new due date, as 2006-01-02, 2006-01-02 15:04 or RFC 3339")
+	cmd.Flags().StringVar(&taskData.Status, "status", "", "new status of the task")
+	cmd.Flags().StringVar(&taskData.Priority, "priority", "", "new priority of the task")
+	cmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions(domain.TaskStatuses, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("priority", cobra.FixedCompletions(domain.TaskPriorities, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// A function that creates the command that deletes tasks.
+func newTasksDeleteCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete ID...",
+		Aliases:           []string{"rm"},
+		Short:             "Delete tasks",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeTaskIDs(opts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			for _, id := range args {
+				err = c.DeleteTask(cmd.Context(), id)
+				if err != nil {
+					return fmt.Errorf("deleting task %s: %w", id, err)
+				}
+				fmt.Fprintln(cmd.ErrOrStderr(), "Deleted task", id)
+			}
+
+			return nil
+		},
+	}
+}
+
+// A function that completes the arguments of a command.
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// A function that returns a completion of the IDs of the tasks the user can view, described by their titles.
+func completeTaskIDs(opts *options) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		c, err := opts.client()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		tasks, err := c.GetTasks(cmd.Context(), &domain.TaskQuery{})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		completions := make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			completions = append(completions, task.ID.Hex()+"\t"+task.Title)
+		}
+
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// A helper function that parses a due date in one of the accepted layouts.
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		t, err := time.ParseInLocation(layout, value, time.Local)
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q, expected 2006-01-02, 2006-01-02 15:04 or RFC 3339", value)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"task_manager/domain"
+
+	"github.com/spf13/cobra"
+)
+
+// A function that creates the users command and its subcommands.
+func newUsersCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "users",
+		Aliases: []string{"user"},
+		Short:   "List, show, create, update and delete users",
+	}
+
+	cmd.AddCommand(
+		newUsersListCommand(opts),
+		newUsersGetCommand(opts),
+		newUsersCreateCommand(opts),
+		newUsersUpdateCommand(opts),
+		newUsersDeleteCommand(opts),
+	)
+
+	return cmd
+}
+
+// A function that creates the command that lists the users.
+func newUsersListCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the users",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			users, err := c.GetUsers(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			return render(cmd.OutOrStdout(), opts.output, users, userTable(users...))
+		},
+	}
+}
+
+// A function that creates the command that shows a user.
+func newUsersGetCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get ID",
+		Short:             "Show a user",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeUserIDs(opts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			user, err := c.GetUser(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			return render(cmd.OutOrStdout(), opts.output, user, userTable(*user))
+		},
+	}
+}
+
+// A function that creates the command that adds a user. The password is prompted for, as for the login command.
+func newUsersCreateCommand(opts *options) *cobra.Command {
+	userData := &domain.CreateUserData{}
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Add a user",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			userData.Password, err = readSecret(cmd, bufio.NewReader(cmd.InOrStdin()), "Password: ")
+			if err != nil {
+				return err
+			}
+
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			user, err := c.CreateUser(cmd.Context(), userData)
+			if err != nil {
+				return err
+			}
+
+			return render(cmd.OutOrStdout(), opts.output, user, userTable(*user))
+		},
+	}
+
+	cmd.Flags().StringVarP(&userData.Username, "username", "u", "", "username of the user")
+	cmd.Flags().StringVar(&userData.Role, "role", "user", "role of the user")
+	cmd.Flags().StringVar(&userData.Email, "email", "", "email address of the user")
+	cmd.MarkFlagRequired("username")
+
+	return cmd
+}
+
+// A function that creates the command that updates a user. Only the given flags are changed, and the password is
+// prompted for with --password.
+func newUsersUpdateCommand(opts *options) *cobra.Command {
+	userData := &domain.UpdateUserData{}
+	var changePassword bool
+	cmd := &cobra.Command{
+		Use:               "update ID",
+		Short:             "Update a user",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeUserIDs(opts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if changePassword {
+				var err error
+				userData.Password, err = readSecret(cmd, bufio.NewReader(cmd.InOrStdin()), "New password: ")
+				if err != nil {
+					return err
+				}
+			}
+
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			user, err := c.UpdateUser(cmd.Context(), args[0], userData)
+			if err != nil {
+				return err
+			}
+
+			return render(cmd.OutOrStdout(), opts.output, user, userTable(*user))
+		},
+	}
+
+	cmd.Flags().StringVarP(&userData.Username, "username", "u", "", "new username of the user")
+	cmd.Flags().StringVar(&userData.Role, "role", "", "new role of the user")
+	cmd.Flags().StringVar(&userData.Email, "email", "", "new email address of the user")
+	cmd.Flags().BoolVar(&changePassword, "password", false, "prompt for a new password")
+
+	return cmd
+}
+
+// A function that creates the command that deletes a user. Its flags are the query parameters of DELETE /users/{id}.
+func newUsersDeleteCommand(opts *options) *cobra.Command {
+	query := &domain.DeleteUserQuery{}
+	cmd := &cobra.Command{
+		Use:               "delete ID",
+		Aliases:           []string{"rm"},
+		Short:             "Delete a user",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeUserIDs(opts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			err = c.DeleteUser(cmd.Context(), args[0], query)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.ErrOrStderr(), "Deleted user", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&query.Tasks, "tasks", "", "what happens to the tasks of the user: restrict, cascade or reassign")
+	cmd.Flags().StringVar(&query.ReassignTo, "reassign-to", "", "ID of the user who gets the tasks")
+	cmd.RegisterFlagCompletionFunc("tasks", cobra.FixedCompletions(domain.UserDeletionPolicies, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("reassign-to", completeUserIDs(opts))
+
+	return cmd
+}
+
+// A function that returns a completion of the IDs of the users, described by their usernames.
+func completeUserIDs(opts *options) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		c, err := opts.client()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		users, err := c.GetUsers(cmd.Context())
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		completions := make([]string, 0, len(users))
+		for _, user := range users {
+			completions = append(completions, user.ID.Hex()+"\t"+user.Username)
+		}
+
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,447 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A struct that holds the whole configuration of the API.
+// Each setting can be read from the YAML file, from the environment variable of its env tag and from the command line
+// flag of its flag tag. Flags take precedence over the environment, which takes precedence over the file, which takes
+// precedence over the defaults.
+type Config struct {
+	Server      ServerConfig      `yaml:"server"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Auth        AuthConfig        `yaml:"auth"`
+	Root        RootConfig        `yaml:"root"`
+	Users       UsersConfig       `yaml:"users"`
+	Log         LogConfig         `yaml:"log"`
+	Reset       ResetConfig       `yaml:"reset"`
+	Cache       CacheConfig       `yaml:"cache"`
+	Attachments AttachmentsConfig `yaml:"attachments"`
+}
+
+// A struct that holds the settings of the HTTP and gRPC servers.
+type ServerConfig struct {
+	Addr               string        `yaml:"addr" env:"SERVER_ADDR" flag:"addr" usage:"address of the API server"`
+	MetricsAddr        string        `yaml:"metrics_addr" env:"METRICS_ADDR" flag:"metrics-addr" usage:"address of the admin server that exposes the metrics"`
+	GRPCAddr           string        `yaml:"grpc_addr" env:"GRPC_ADDR" flag:"grpc-addr" usage:"address of the gRPC server, which is disabled if empty"`
+	ShutdownDrainDelay time.Duration `yaml:"shutdown_drain_delay" env:"SHUTDOWN_DRAIN_DELAY" flag:"shutdown-drain-delay" usage:"time given to load balancers to stop sending traffic before shutting down"`
+	ShutdownTimeout    time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" flag:"shutdown-timeout" usage:"maximum time to finish the requests in flight when shutting down"`
+	OpenAPIValidate    bool          `yaml:"openapi_validate" env:"OPENAPI_VALIDATE" flag:"openapi-validate" usage:"check requests and responses against the OpenAPI specification"`
+}
+
+// A struct that holds the settings of the MongoDB connection.
+type DatabaseConfig struct {
+	URI            string `yaml:"uri" env:"MONGODB_URI" flag:"mongodb-uri" usage:"MongoDB connection string"`
+	Name           string `yaml:"name" env:"MONGODB_DB" flag:"mongodb-db" usage:"name of the MongoDB database"`
+	MigrateOnStart bool   `yaml:"migrate_on_start" env:"MIGRATE_ON_START" flag:"migrate-on-start" usage:"apply the pending migrations when the server starts"`
+}
+
+// A struct that holds the settings of the login tokens.
+type AuthConfig struct {
+	JWTKey   string        `yaml:"jwt_key" env:"JWT_KEY"`
+	TokenTTL time.Duration `yaml:"token_ttl" env:"TOKEN_TTL" flag:"token-ttl" usage:"lifetime of the login tokens"`
+}
+
+// A struct that holds the credentials of the root user created at startup.
+type RootConfig struct {
+	Username string `yaml:"username" env:"ROOT_USERNAME"`
+	Password string `yaml:"password" env:"ROOT_PASSWORD"`
+}
+
+// A struct that holds the settings of the user management.
+type UsersConfig struct {
+	DeletionPolicy string `yaml:"deletion_policy" env:"USER_DELETION_POLICY" flag:"user-deletion-policy" usage:"what happens to the tasks of a deleted user when the request does not choose: restrict or cascade"`
+}
+
+// A struct that holds the settings of the logger.
+type LogConfig struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL" flag:"log-level" usage:"log level: debug, info, warn or error"`
+	Format string `yaml:"format" env:"LOG_FORMAT" flag:"log-format" usage:"log format: json or text"`
+}
+
+// A struct that holds the settings of the delivery of password reset tokens.
+type ResetConfig struct {
+	Sender    string     `yaml:"sender" env:"RESET_SENDER" flag:"reset-sender" usage:"password reset sender: none, file, log (development only) or smtp"`
+	TokenFile string     `yaml:"token_file" env:"RESET_TOKEN_FILE" flag:"reset-token-file" usage:"file the file sender writes the reset tokens to"`
+	SMTP      SMTPConfig `yaml:"smtp"`
+}
+
+// A struct that holds the settings of the SMTP server used to send password reset tokens.
+type SMTPConfig struct {
+	Host     string `yaml:"host" env:"SMTP_HOST"`
+	Port     string `yaml:"port" env:"SMTP_PORT"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string `yaml:"from" env:"SMTP_FROM"`
+}
+
+// A struct that holds the settings of the cache of the tasks and users read by the API.
+type CacheConfig struct {
+	Enabled bool          `yaml:"enabled" env:"CACHE_ENABLED" flag:"cache" usage:"cache the tasks and users read by ID in memory"`
+	Size    int           `yaml:"size" env:"CACHE_SIZE" flag:"cache-size" usage:"maximum number of tasks, and of users, held in the cache"`
+	TTL     time.Duration `yaml:"ttl" env:"CACHE_TTL" flag:"cache-ttl" usage:"maximum time an entry is served from the cache"`
+}
+
+// A struct that holds the settings of the files attached to tasks.
+type AttachmentsConfig struct {
+	Store        string `yaml:"store" env:"ATTACHMENTS_STORE" flag:"attachments-store" usage:"where the contents of attachments are stored: local or gridfs"`
+	Dir          string `yaml:"dir" env:"ATTACHMENTS_DIR" flag:"attachments-dir" usage:"directory of the local attachment store"`
+	MaxSize      int    `yaml:"max_size" env:"ATTACHMENTS_MAX_SIZE" flag:"attachments-max-size" usage:"maximum size of an attachment in bytes"`
+	AllowedTypes string `yaml:"allowed_types" env:"ATTACHMENTS_ALLOWED_TYPES" flag:"attachments-allowed-types" usage:"comma-separated media types an attachment can have"`
+}
+
+// A method that returns the media types an attachment can have.
+func (c AttachmentsConfig) Types() []string {
+	types := []string{}
+	for _, t := range strings.Split(c.AllowedTypes, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "" {
+			types = append(types, t)
+		}
+	}
+
+	return types
+}
+
+// A function that returns the default configuration.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:               ":8080",
+			MetricsAddr:        ":9090",
+			GRPCAddr:           ":50051",
+			ShutdownDrainDelay: 5 * time.Second,
+			ShutdownTimeout:    5 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Name:           "task_manager",
+			MigrateOnStart: true,
+		},
+		Auth: AuthConfig{
+			TokenTTL: 24 * time.Hour,
+		},
+		Users: UsersConfig{
+			DeletionPolicy: "restrict",
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Reset: ResetConfig{
+			Sender: "none",
+			SMTP:   SMTPConfig{Port: "587"},
+		},
+		Cache: CacheConfig{
+			Size: 10000,
+			TTL:  30 * time.Second,
+		},
+		Attachments: AttachmentsConfig{
+			Store:        "local",
+			Dir:          "attachments",
+			MaxSize:      10 << 20,
+			AllowedTypes: "image/png,image/jpeg,image/gif,image/webp,application/pdf,text/plain",
+		},
+	}
+}
+
+// A function that loads the configuration from the defaults, the YAML file, the environment and the command line
+// arguments, in increasing order of precedence, and validates it.
+// The file is given by the -config flag or the CONFIG_FILE environment variable, and is optional.
+func Load(args []string) (*Config, error) {
+	cfg := Default()
+	fields := cfg.fields()
+
+	// Define a flag for each setting that has one. The values are applied after the file and the environment.
+	flags := flag.NewFlagSet("task_manager", flag.ContinueOnError)
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "path of the YAML configuration file")
+	for _, f := range fields {
+		if f.flag == "" {
+			continue
+		}
+
+		// The boolean settings are boolean flags, so that they can be given without a value, as in -cache.
+		if f.value.Kind() == reflect.Bool {
+			flags.Bool(f.flag, f.value.Bool(), f.usage)
+		} else {
+			flags.String(f.flag, f.String(), f.usage)
+		}
+	}
+
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the file.
+	if *configFile != "" {
+		err = cfg.loadFile(*configFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Read the environment.
+	for _, f := range fields {
+		if f.env == "" {
+			continue
+		}
+
+		value, ok := os.LookupEnv(f.env)
+		if !ok {
+			continue
+		}
+
+		err = f.Set(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %s: %w", f.env, err)
+		}
+	}
+
+	// Read the flags that were given.
+	flags.Visit(func(fl *flag.Flag) {
+		for _, f := range fields {
+			if f.flag == fl.Name && err == nil {
+				if setErr := f.Set(fl.Value.String()); setErr != nil {
+					err = fmt.Errorf("invalid value of -%s: %w", f.flag, setErr)
+				}
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// A method that checks the configuration and returns an error that lists every invalid setting.
+func (c *Config) Validate() error {
+	problems := []string{}
+	invalid := func(path, reason string) {
+		problems = append(problems, c.describe(path)+" "+reason)
+	}
+
+	if c.Server.Addr == "" {
+		invalid("server.addr", "is required")
+	}
+	if c.Server.MetricsAddr == "" {
+		invalid("server.metrics_addr", "is required")
+	}
+	if c.Server.ShutdownDrainDelay < 0 {
+		invalid("server.shutdown_drain_delay", "must not be negative")
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		invalid("server.shutdown_timeout", "must be positive")
+	}
+
+	if c.Database.URI == "" {
+		invalid("database.uri", "is required")
+	}
+	if c.Database.Name == "" {
+		invalid("database.name", "is required")
+	}
+
+	if c.Auth.JWTKey == "" {
+		invalid("auth.jwt_key", "is required")
+	}
+	if c.Auth.TokenTTL <= 0 {
+		invalid("auth.token_ttl", "must be positive")
+	}
+
+	if (c.Root.Username == "") != (c.Root.Password == "") {
+		invalid("root.password", "must be set together with "+c.describe("root.username"))
+	}
+
+	// Reassigning needs a target user, so it can only be chosen by the request.
+	if !oneOf(c.Users.DeletionPolicy, "restrict", "cascade") {
+		invalid("users.deletion_policy", "must be one of: restrict, cascade")
+	}
+
+	if !oneOf(strings.ToLower(c.Log.Level), "debug", "info", "warn", "error") {
+		invalid("log.level", "must be one of: debug, info, warn, error")
+	}
+	if !oneOf(strings.ToLower(c.Log.Format), "json", "text") {
+		invalid("log.format", "must be one of: json, text")
+	}
+
+	switch c.Reset.Sender {
+	case "", "none", "log":
+	case "file":
+		if c.Reset.TokenFile == "" {
+			invalid("reset.token_file", "is required when the reset sender is file")
+		}
+	case "smtp":
+		if c.Reset.SMTP.Host == "" {
+			invalid("reset.smtp.host", "is required when the reset sender is smtp")
+		}
+		if c.Reset.SMTP.From == "" {
+			invalid("reset.smtp.from", "is required when the reset sender is smtp")
+		}
+	default:
+		invalid("reset.sender", "must be one of: none, file, log, smtp")
+	}
+
+	// The cache is checked only when it is used, so that an invalid setting does not prevent starting without it.
+	if c.Cache.Enabled {
+		if c.Cache.Size <= 0 {
+			invalid("cache.size", "must be positive")
+		}
+		if c.Cache.TTL <= 0 {
+			invalid("cache.ttl", "must be positive")
+		}
+	}
+
+	switch c.Attachments.Store {
+	case "local":
+		if c.Attachments.Dir == "" {
+			invalid("attachments.dir", "is required when the attachment store is local")
+		}
+	case "gridfs":
+	default:
+		invalid("attachments.store", "must be one of: local, gridfs")
+	}
+	if c.Attachments.MaxSize <= 0 {
+		invalid("attachments.max_size", "must be positive")
+	}
+	if len(c.Attachments.Types()) == 0 {
+		invalid("attachments.allowed_types", "must list at least one media type")
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid configuration: " + strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// A helper method that reads the YAML file into the configuration. Unknown keys are rejected to catch typos.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading the configuration file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(c)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parsing the configuration file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// A helper method that names a setting by its file key and, if it has one, its environment variable.
+func (c *Config) describe(path string) string {
+	for _, f := range c.fields() {
+		if f.path == path && f.env != "" {
+			return path + " (" + f.env + ")"
+		}
+	}
+
+	return path
+}
+
+// A struct that describes a single setting of the configuration.
+type field struct {
+	path  string
+	env   string
+	flag  string
+	usage string
+	value reflect.Value
+}
+
+// A helper method that lists the settings of the configuration.
+func (c *Config) fields() []field {
+	return collectFields(reflect.ValueOf(c).Elem(), "")
+}
+
+// A helper function that lists the settings of a struct and of its nested structs.
+func collectFields(value reflect.Value, prefix string) []field {
+	fields := []field{}
+
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+		path := prefix + structField.Tag.Get("yaml")
+
+		if structField.Type.Kind() == reflect.Struct {
+			fields = append(fields, collectFields(value.Field(i), path+".")...)
+			continue
+		}
+
+		fields = append(fields, field{
+			path:  path,
+			env:   structField.Tag.Get("env"),
+			flag:  structField.Tag.Get("flag"),
+			usage: structField.Tag.Get("usage"),
+			value: value.Field(i),
+		})
+	}
+
+	return fields
+}
+
+// A method that returns the current value of the setting as a string.
+func (f field) String() string {
+	if duration, ok := f.value.Interface().(time.Duration); ok {
+		return duration.String()
+	}
+
+	return fmt.Sprint(f.value.Interface())
+}
+
+// A method that parses the value and stores it in the setting.
+func (f field) Set(value string) error {
+	switch f.value.Interface().(type) {
+	case time.Duration:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		f.value.SetInt(int64(duration))
+	case bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		f.value.SetBool(b)
+	case int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		f.value.SetInt(int64(n))
+	default:
+		f.value.SetString(value)
+	}
+
+	return nil
+}
+
+// A helper function that checks if a value is one of the allowed values.
+func oneOf(value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+
+	return false
+}
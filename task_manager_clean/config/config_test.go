@@ -0,0 +1,161 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"task_manager/config"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite that tests the loading and validation of the configuration.
+type ConfigTestSuite struct {
+	suite.Suite
+}
+
+// A method that sets the required settings in the environment for each test.
+func (suite *ConfigTestSuite) SetupTest() {
+	suite.T().Setenv("MONGODB_URI", "mongodb://localhost:27017")
+	suite.T().Setenv("JWT_KEY", "test_key")
+}
+
+// A helper method that writes a configuration file and returns its path.
+func (suite *ConfigTestSuite) writeFile(content string) string {
+	path := filepath.Join(suite.T().TempDir(), "config.yaml")
+	suite.Nil(os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+// A test for the config.Load function.
+func (suite *ConfigTestSuite) TestLoad() {
+	// A testcase where only the required settings are given and the defaults apply.
+	suite.Run("Load_Defaults", func() {
+		cfg, err := config.Load(nil)
+		suite.Nil(err)
+		suite.Equal(":8080", cfg.Server.Addr)
+		suite.Equal("task_manager", cfg.Database.Name)
+		suite.Equal(24*time.Hour, cfg.Auth.TokenTTL)
+		suite.Equal("test_key", cfg.Auth.JWTKey)
+	})
+
+	// A testcase where the flags override the environment, which overrides the file.
+	suite.Run("Load_Precedence", func() {
+		path := suite.writeFile("server:\n  addr: \":7000\"\n  metrics_addr: \":7001\"\nauth:\n  token_ttl: 2h\nlog:\n  level: debug\n")
+		suite.T().Setenv("METRICS_ADDR", ":7101")
+		suite.T().Setenv("TOKEN_TTL", "3h")
+
+		cfg, err := config.Load([]string{"-config", path, "-token-ttl", "4h"})
+		suite.Nil(err)
+		suite.Equal(":7000", cfg.Server.Addr)
+		suite.Equal(":7101", cfg.Server.MetricsAddr)
+		suite.Equal(4*time.Hour, cfg.Auth.TokenTTL)
+		suite.Equal("debug", cfg.Log.Level)
+	})
+
+	// A testcase where the file is given by the environment.
+	suite.Run("Load_FileFromEnvironment", func() {
+		suite.T().Setenv("CONFIG_FILE", suite.writeFile("database:\n  name: tasks_test\n"))
+
+		cfg, err := config.Load(nil)
+		suite.Nil(err)
+		suite.Equal("tasks_test", cfg.Database.Name)
+	})
+
+	// A testcase where the cache is enabled and sized from the environment and the flags.
+	suite.Run("Load_Cache", func() {
+		suite.T().Setenv("CACHE_ENABLED", "true")
+		suite.T().Setenv("CACHE_SIZE", "500")
+
+		cfg, err := config.Load([]string{"-cache-ttl", "1m"})
+		suite.Nil(err)
+		suite.True(cfg.Cache.Enabled)
+		suite.Equal(500, cfg.Cache.Size)
+		suite.Equal(time.Minute, cfg.Cache.TTL)
+	})
+
+	// A testcase where the boolean settings are given as flags without a value, or with one.
+	suite.Run("Load_BoolFlags", func() {
+		cfg, err := config.Load([]string{"-cache", "-migrate-on-start=false"})
+		suite.Nil(err)
+		suite.True(cfg.Cache.Enabled)
+		suite.False(cfg.Database.MigrateOnStart)
+	})
+
+	// A testcase where the attachment types are listed in the environment.
+	suite.Run("Load_AttachmentTypes", func() {
+		suite.T().Setenv("ATTACHMENTS_ALLOWED_TYPES", "image/png, Application/PDF,")
+
+		cfg, err := config.Load(nil)
+		suite.Nil(err)
+		suite.Equal([]string{"image/png", "application/pdf"}, cfg.Attachments.Types())
+	})
+
+	// A testcase where the file contains an unknown key.
+	suite.Run("Load_UnknownKey", func() {
+		path := suite.writeFile("server:\n  adress: \":7000\"\n")
+
+		_, err := config.Load([]string{"-config", path})
+		suite.ErrorContains(err, "adress")
+	})
+
+	// A testcase where an environment variable cannot be parsed.
+	suite.Run("Load_InvalidEnvironment", func() {
+		suite.T().Setenv("SHUTDOWN_TIMEOUT", "soon")
+
+		_, err := config.Load(nil)
+		suite.ErrorContains(err, "SHUTDOWN_TIMEOUT")
+	})
+}
+
+// A test for the Config.Validate method.
+func (suite *ConfigTestSuite) TestValidate() {
+	// A testcase where every invalid setting is reported at once.
+	suite.Run("Validate_Failure", func() {
+		cfg := config.Default()
+		cfg.Server.MetricsAddr = ""
+		cfg.Root.Username = "root"
+		cfg.Log.Format = "xml"
+		cfg.Reset.Sender = "file"
+		cfg.Cache.Enabled = true
+		cfg.Cache.Size = 0
+		cfg.Attachments.Store = "s3"
+		cfg.Attachments.AllowedTypes = " , "
+
+		err := cfg.Validate()
+		suite.ErrorContains(err, "server.metrics_addr (METRICS_ADDR) is required")
+		suite.ErrorContains(err, "database.uri (MONGODB_URI) is required")
+		suite.ErrorContains(err, "auth.jwt_key (JWT_KEY) is required")
+		suite.ErrorContains(err, "root.password (ROOT_PASSWORD) must be set together with root.username (ROOT_USERNAME)")
+		suite.ErrorContains(err, "log.format (LOG_FORMAT) must be one of: json, text")
+		suite.ErrorContains(err, "reset.token_file (RESET_TOKEN_FILE) is required")
+		suite.ErrorContains(err, "cache.size (CACHE_SIZE) must be positive")
+		suite.ErrorContains(err, "attachments.store (ATTACHMENTS_STORE) must be one of: local, gridfs")
+		suite.ErrorContains(err, "attachments.allowed_types (ATTACHMENTS_ALLOWED_TYPES) must list at least one media type")
+	})
+
+	// A testcase where the SMTP sender is selected without a server.
+	suite.Run("Validate_SMTP", func() {
+		cfg := config.Default()
+		cfg.Reset.Sender = "smtp"
+
+		err := cfg.Validate()
+		suite.ErrorContains(err, "reset.smtp.host (SMTP_HOST) is required")
+		suite.ErrorContains(err, "reset.smtp.from (SMTP_FROM) is required")
+	})
+
+	// A testcase where the configuration is valid.
+	suite.Run("Validate_Success", func() {
+		cfg := config.Default()
+		cfg.Database.URI = "mongodb://localhost:27017"
+		cfg.Auth.JWTKey = "test_key"
+
+		suite.Nil(cfg.Validate())
+	})
+}
+
+// A function that runs the ConfigTestSuite.
+func TestConfigTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigTestSuite))
+}
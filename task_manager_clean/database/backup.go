@@ -0,0 +1,349 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// The format of the first line of a backup, which tells a backup from any other JSON Lines file.
+	BackupFormat = "task_manager-backup"
+	// The version of the backup format. A backup of a newer version is refused.
+	BackupVersion = 1
+
+	// The number of documents inserted at once when importing a backup.
+	backupBatchSize = 1000
+	// The longest line of a backup. A document is at most 16MB of BSON, which is longer as extended JSON.
+	maxBackupLine = 64 << 20
+)
+
+// BackupCollection is a summary of a collection that was exported or imported.
+type BackupCollection struct {
+	Name      string
+	Indexes   int
+	Documents int
+}
+
+// A struct that defines a line of a backup. The first line is the header, then each collection has a line with its
+// indexes followed by a line for each of its documents.
+type backupLine struct {
+	Format     string    `bson:"format,omitempty"`
+	Version    int       `bson:"version,omitempty"`
+	CreatedAt  time.Time `bson:"created_at,omitempty"`
+	Collection string    `bson:"collection,omitempty"`
+	Indexes    []bson.D  `bson:"indexes,omitempty"`
+	Document   bson.Raw  `bson:"document,omitempty"`
+}
+
+// A function that writes a backup of every collection of the database as JSON Lines in canonical extended JSON, so
+// that the types of the values, such as ObjectIDs and dates, survive the round trip.
+func Export(ctx context.Context, db *mongo.Database, w io.Writer) ([]BackupCollection, error) {
+	writer := bufio.NewWriter(w)
+	err := writeBackupLine(writer, bson.D{
+		{Key: "format", Value: BackupFormat},
+		{Key: "version", Value: BackupVersion},
+		{Key: "created_at", Value: time.Now().UTC()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := backupCollections(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := []BackupCollection{}
+	for _, name := range names {
+		summary, err := exportCollection(ctx, db.Collection(name), writer)
+		if err != nil {
+			return summaries, fmt.Errorf("exporting the %s collection: %w", name, err)
+		}
+
+		summaries = append(summaries, *summary)
+	}
+
+	return summaries, writer.Flush()
+}
+
+// A function that restores a backup written by Export. A database that already has documents is refused unless
+// replace is set, in which case its collections are dropped first. The whole backup is read and checked before the
+// database is changed, so that a corrupt file leaves it as it was. The migrations of the backup are restored with the
+// rest, so the migrations added since should be applied afterwards.
+func Import(ctx context.Context, db *mongo.Database, r io.Reader, replace bool) ([]BackupCollection, error) {
+	// The backup may come from a stream, so it is kept in a temporary file to be read a second time.
+	spool, err := os.CreateTemp("", "task_manager-backup-*.jsonl")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	err = validateBackup(io.TeeReader(r, spool))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = spool.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := newBackupScanner(spool)
+	_, err = readBackupLine(scanner)
+	if err != nil {
+		return nil, err
+	}
+
+	err = clearDatabase(ctx, db, replace)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := []BackupCollection{}
+	var collection *mongo.Collection
+	batch := []interface{}{}
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		_, err := collection.InsertMany(ctx, batch)
+		batch = batch[:0]
+		return err
+	}
+
+	for {
+		line, err := readBackupLine(scanner)
+		if err != nil {
+			return summaries, err
+		}
+		if line == nil {
+			break
+		}
+
+		if line.Document != nil {
+			batch = append(batch, line.Document)
+			summaries[len(summaries)-1].Documents++
+			if len(batch) >= backupBatchSize {
+				err = flush()
+			}
+		} else {
+			err = flush()
+			if err == nil {
+				collection = db.Collection(line.Collection)
+				summaries = append(summaries, BackupCollection{Name: line.Collection})
+				summaries[len(summaries)-1].Indexes, err = importIndexes(ctx, db, line.Collection, line.Indexes)
+			}
+		}
+
+		if err != nil {
+			return summaries, fmt.Errorf("importing the %s collection: %w", line.Collection, err)
+		}
+	}
+
+	if err := flush(); err != nil {
+		return summaries, fmt.Errorf("importing the %s collection: %w", collection.Name(), err)
+	}
+
+	return summaries, nil
+}
+
+// A helper function that reads a whole backup and checks that it can be imported: its header is known, every line is
+// valid, and the documents of each collection follow its indexes.
+func validateBackup(r io.Reader) error {
+	scanner := newBackupScanner(r)
+	header, err := readBackupLine(scanner)
+	if err != nil {
+		return err
+	}
+	if header == nil || header.Format != BackupFormat {
+		return errors.New("not a task manager backup")
+	}
+	if header.Version > BackupVersion {
+		return fmt.Errorf("the backup has version %d, but only versions up to %d are supported", header.Version, BackupVersion)
+	}
+
+	seen := map[string]bool{}
+	current := ""
+	for {
+		line, err := readBackupLine(scanner)
+		if err != nil {
+			return err
+		}
+		if line == nil {
+			return nil
+		}
+
+		switch {
+		case line.Document != nil:
+			if line.Collection != current {
+				return fmt.Errorf("a document of the %s collection comes before its indexes", line.Collection)
+			}
+		case line.Collection != "":
+			if seen[line.Collection] {
+				return fmt.Errorf("the indexes of the %s collection appear twice", line.Collection)
+			}
+
+			seen[line.Collection] = true
+			current = line.Collection
+		default:
+			return errors.New("a line of the backup has neither a collection nor a document")
+		}
+	}
+}
+
+// A helper function that returns the names of the collections to back up, which are all but the system collections.
+func backupCollections(ctx context.Context, db *mongo.Database) ([]string, error) {
+	names, err := db.ListCollectionNames(ctx, bson.M{"name": bson.M{"$not": bson.M{"$regex": "^system\\."}}})
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// A helper function that writes the indexes and the documents of a collection.
+func exportCollection(ctx context.Context, collection *mongo.Collection, writer io.Writer) (*BackupCollection, error) {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var specifications []bson.D
+	err = cursor.All(ctx, &specifications)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the indexes that can be created again, without the fields the server sets itself.
+	indexes := []bson.D{}
+	for _, specification := range specifications {
+		index := bson.D{}
+		for _, element := range specification {
+			if element.Key != "v" && element.Key != "ns" {
+				index = append(index, element)
+			}
+		}
+
+		indexes = append(indexes, index)
+	}
+
+	summary := &BackupCollection{Name: collection.Name(), Indexes: len(indexes)}
+	err = writeBackupLine(writer, bson.D{{Key: "collection", Value: summary.Name}, {Key: "indexes", Value: indexes}})
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err = collection.Find(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		err = writeBackupLine(writer, bson.D{{Key: "collection", Value: summary.Name}, {Key: "document", Value: cursor.Current}})
+		if err != nil {
+			return nil, err
+		}
+
+		summary.Documents++
+	}
+
+	return summary, cursor.Err()
+}
+
+// A helper function that refuses to import into a database that has documents, unless they are to be replaced, and
+// drops its collections so that the backup is restored as it was.
+func clearDatabase(ctx context.Context, db *mongo.Database, replace bool) error {
+	names, err := backupCollections(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	if !replace {
+		for _, name := range names {
+			count, err := db.Collection(name).EstimatedDocumentCount(ctx)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return fmt.Errorf("the database %s is not empty, as the %s collection has documents", db.Name(), name)
+			}
+		}
+	}
+
+	for _, name := range names {
+		err = db.Collection(name).Drop(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// A helper function that creates the indexes of a collection, which also creates the collection. The index of the
+// IDs is created by the server.
+func importIndexes(ctx context.Context, db *mongo.Database, collection string, indexes []bson.D) (int, error) {
+	specifications := bson.A{}
+	for _, index := range indexes {
+		if index.Map()["name"] != "_id_" {
+			specifications = append(specifications, index)
+		}
+	}
+
+	if len(specifications) == 0 {
+		return len(indexes), db.CreateCollection(ctx, collection)
+	}
+
+	command := bson.D{{Key: "createIndexes", Value: collection}, {Key: "indexes", Value: specifications}}
+	return len(indexes), db.RunCommand(ctx, command).Err()
+}
+
+// A helper function that writes a line of a backup.
+func writeBackupLine(writer io.Writer, line bson.D) error {
+	bytes, err := bson.MarshalExtJSON(line, true, false)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(append(bytes, '\n'))
+	return err
+}
+
+// A helper function that returns a scanner of the lines of a backup, which accepts lines as long as a document.
+func newBackupScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxBackupLine)
+	return scanner
+}
+
+// A helper function that reads the next line of a backup, skipping the blank lines. It returns nil at the end.
+func readBackupLine(scanner *bufio.Scanner) (*backupLine, error) {
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		line := &backupLine{}
+		err := bson.UnmarshalExtJSON([]byte(text), true, line)
+		if err != nil {
+			return nil, fmt.Errorf("reading the backup: %w", err)
+		}
+
+		return line, nil
+	}
+
+	return nil, scanner.Err()
+}
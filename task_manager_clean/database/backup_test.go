@@ -0,0 +1,53 @@
+package database_test
+
+import (
+	"context"
+	"strings"
+	"task_manager/database"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// The header of a backup, followed by the indexes and a document of the tasks collection.
+const backupStart = `{"format":"task_manager-backup","version":1}
+{"collection":"tasks","indexes":[{"key":{"_id":1},"name":"_id_"}]}
+{"collection":"tasks","document":{"_id":{"$oid":"64b7f0c2a1b2c3d4e5f60718"},"title":"task"}}
+`
+
+// A suite that contains tests for the backups.
+type BackupTestSuite struct {
+	suite.Suite
+}
+
+// A test for the Import function.
+func (suite *BackupTestSuite) TestImport() {
+	// The database is nil, so the import fails with a panic if it is changed before the whole backup is checked.
+	tests := []struct {
+		name   string
+		backup string
+		err    string
+	}{
+		{"Import_NotABackup", `{"format":"other"}`, "not a task manager backup"},
+		{"Import_NewerVersion", `{"format":"task_manager-backup","version":2}`, "the backup has version 2, but only versions up to 1 are supported"},
+		{"Import_Truncated", backupStart + `{"collection":"tasks","document":{"_id":{"$oid":"64b7`, "reading the backup"},
+		{"Import_DocumentBeforeIndexes", backupStart + `{"collection":"users","document":{"_id":1}}`, "a document of the users collection comes before its indexes"},
+		{"Import_CollectionTwice", backupStart + `{"collection":"tasks","indexes":[]}`, "the indexes of the tasks collection appear twice"},
+		{"Import_EmptyLine", backupStart + `{"indexes":[]}`, "a line of the backup has neither a collection nor a document"},
+	}
+
+	for _, test := range tests {
+		// A testcase where a corrupt backup is refused under replace, before the database is changed.
+		suite.Run(test.name, func() {
+			summaries, err := database.Import(context.Background(), nil, strings.NewReader(test.backup), true)
+			suite.Require().Error(err)
+			suite.Contains(err.Error(), test.err)
+			suite.Empty(summaries)
+		})
+	}
+}
+
+// A function that runs the BackupTestSuite.
+func TestBackupTestSuite(t *testing.T) {
+	suite.Run(t, new(BackupTestSuite))
+}
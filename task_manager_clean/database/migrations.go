@@ -0,0 +1,403 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"task_manager/domain"
+	"task_manager/repository"
+	"text/tabwriter"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// The collection that records the applied migrations, and holds the lock of the instance that migrates.
+	MigrationCollection = "migrations"
+
+	// The ID of the lock document in the migration collection.
+	migrationLockID = "lock"
+
+	// The duration after which the lock of an instance that stopped without releasing it can be taken. The lock is
+	// extended before each migration, so a single migration must not take longer.
+	migrationLockTTL = 10 * time.Minute
+
+	// The interval at which an instance checks if the lock held by another instance was released.
+	migrationLockPollInterval = time.Second
+)
+
+// A struct that defines a versioned change of the schema or of the data.
+// Up must be idempotent, so that a migration interrupted before it is recorded can run again.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// A struct that defines the record of a migration. A migration that failed is recorded with the time and the error
+// of its last failure, and is not applied until AppliedAt is set.
+type MigrationRecord struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at,omitempty"`
+	FailedAt    time.Time `bson:"failed_at,omitempty"`
+	Error       string    `bson:"error,omitempty"`
+}
+
+// A struct that defines the state of a migration, for the status command. Error is the error of the last failure of
+// a migration that is not applied.
+type MigrationStatus struct {
+	Version     int
+	Description string
+	AppliedAt   *time.Time
+	Error       string
+}
+
+// The migrations of the API, in the order in which they are applied.
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "create the unique username index",
+		Up: createIndexes(domain.UserCollection,
+			mongo.IndexModel{Keys: bson.D{{Key: "username", Value: 1}}, Options: options.Index().SetUnique(true)},
+			mongo.IndexModel{Keys: bson.D{{Key: "role", Value: 1}}},
+		),
+	},
+	{
+		Version:     2,
+		Description: "create the task query indexes",
+		Up: createIndexes(domain.TaskCollection,
+			mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}}},
+			mongo.IndexModel{Keys: bson.D{{Key: "workspace_id", Value: 1}, {Key: "project_id", Value: 1}}},
+			mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "due_date", Value: 1}}},
+			mongo.IndexModel{Keys: bson.D{{Key: "due_date", Value: 1}}},
+		),
+	},
+	{
+		Version:     3,
+		Description: "create the workspace, project and token indexes",
+		Up: chain(
+			createIndexes(domain.WorkspaceCollection,
+				mongo.IndexModel{Keys: bson.D{{Key: "members.user_id", Value: 1}}},
+			),
+			createIndexes(domain.ProjectCollection,
+				mongo.IndexModel{Keys: bson.D{{Key: "workspace_id", Value: 1}}},
+			),
+			createIndexes(domain.AccessTokenCollection,
+				mongo.IndexModel{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+				mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}}},
+			),
+			createIndexes(domain.PasswordResetCollection,
+				mongo.IndexModel{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+				mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}}},
+			),
+		),
+	},
+	{
+		Version:     4,
+		Description: "rename the legacy task statuses",
+		Up: renameValues(domain.TaskCollection, "status", map[string]string{
+			"pending":     "Pending",
+			"todo":        "Pending",
+			"To Do":       "Pending",
+			"in progress": "In Progress",
+			"in_progress": "In Progress",
+			"In_Progress": "In Progress",
+			"in-progress": "In Progress",
+			"InProgress":  "In Progress",
+			"completed":   "Completed",
+			"done":        "Completed",
+			"Done":        "Completed",
+		}),
+	},
+	{
+		Version:     5,
+		Description: "create the time entry indexes",
+		Up: createIndexes(domain.TimeEntryCollection,
+			mongo.IndexModel{
+				Keys:    bson.D{{Key: "user_id", Value: 1}},
+				Options: options.Index().SetName("user_id_running").SetUnique(true).SetPartialFilterExpression(bson.M{"running": true}),
+			},
+			mongo.IndexModel{Keys: bson.D{{Key: "task_id", Value: 1}, {Key: "started_at", Value: 1}}},
+			mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "started_at", Value: 1}}},
+			mongo.IndexModel{Keys: bson.D{{Key: "workspace_id", Value: 1}, {Key: "started_at", Value: 1}}},
+		),
+	},
+	{
+		Version:     6,
+		Description: "set the default task priority",
+		Up:          setMissing(domain.TaskCollection, "priority", domain.TaskPriorityMedium),
+	},
+	{
+		Version:     7,
+		Description: "create the task dependency index",
+		Up: createIndexes(domain.TaskCollection,
+			mongo.IndexModel{Keys: bson.D{{Key: "blocked_by", Value: 1}}},
+		),
+	},
+	{
+		// The completion time of the tasks completed before it was recorded is not known, so their due date is taken,
+		// kept between the creation of the task and now.
+		Version:     8,
+		Description: "estimate the completion time of the completed tasks",
+		Up: setMissingExpression(domain.TaskCollection, bson.M{"status": "Completed"}, "completed_at", bson.M{
+			"$max": bson.A{bson.M{"$toDate": "$_id"}, bson.M{"$min": bson.A{"$due_date", "$$NOW"}}},
+		}),
+	},
+}
+
+// A function that applies the migrations that are not recorded yet, in the order of their versions, and returns
+// the applied migrations. It stops at the first migration that fails.
+func Migrate(ctx context.Context, db *mongo.Database, migrations []Migration) ([]Migration, error) {
+	return ApplyMigrations(ctx, db, &repository.MongoCollection{Collection: db.Collection(MigrationCollection)}, migrations)
+}
+
+// A function that applies the migrations like Migrate, with the records kept in the given collection. Only one
+// instance migrates at a time: the others wait for the lock, then skip the migrations it applied.
+func ApplyMigrations(ctx context.Context, db *mongo.Database, records domain.Collection, migrations []Migration) ([]Migration, error) {
+	owner := primitive.NewObjectID().Hex()
+	err := acquireMigrationLock(ctx, records, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseMigrationLock(records, owner)
+
+	// Read the records once the lock is held, so that the migrations of the previous holder are seen.
+	recorded, err := migrationRecords(ctx, records)
+	if err != nil {
+		return nil, err
+	}
+
+	done := []Migration{}
+	for _, migration := range sortedMigrations(migrations) {
+		if !recorded[migration.Version].AppliedAt.IsZero() {
+			continue
+		}
+
+		err = extendMigrationLock(ctx, records, owner)
+		if err != nil {
+			return done, err
+		}
+
+		// Apply the migration, and record its failure so that the status command shows it.
+		start := time.Now()
+		err = migration.Up(ctx, db)
+		if err != nil {
+			err = fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, err)
+			update := bson.M{"$set": bson.M{"description": migration.Description, "failed_at": time.Now(), "error": err.Error()}}
+			_, recordErr := records.UpdateOne(ctx, bson.M{"_id": migration.Version}, update, options.Update().SetUpsert(true))
+			if recordErr != nil {
+				slog.Error("Recording the migration failure failed", "version", migration.Version, "error", recordErr)
+			}
+
+			return done, err
+		}
+
+		update := bson.M{
+			"$set":   bson.M{"description": migration.Description, "applied_at": time.Now()},
+			"$unset": bson.M{"failed_at": "", "error": ""},
+		}
+		_, err = records.UpdateOne(ctx, bson.M{"_id": migration.Version}, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return done, fmt.Errorf("recording migration %d: %w", migration.Version, err)
+		}
+
+		slog.Info("Migration applied", "version", migration.Version, "description", migration.Description, "duration_ms", time.Since(start).Milliseconds())
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// A function that returns the state of each migration, in the order of their versions.
+func GetMigrationStatus(ctx context.Context, db *mongo.Database, migrations []Migration) ([]MigrationStatus, error) {
+	recorded, err := migrationRecords(ctx, &repository.MongoCollection{Collection: db.Collection(MigrationCollection)})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []MigrationStatus{}
+	for _, migration := range sortedMigrations(migrations) {
+		status := MigrationStatus{
+			Version:     migration.Version,
+			Description: migration.Description,
+		}
+
+		if record, ok := recorded[migration.Version]; ok && !record.AppliedAt.IsZero() {
+			status.AppliedAt = &record.AppliedAt
+		} else if ok {
+			status.Error = record.Error
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// A function that writes the states of the migrations as a table, for the status commands of the API and of the
+// admin CLI.
+func WriteMigrationStatus(w io.Writer, statuses []MigrationStatus) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tAPPLIED AT\tDESCRIPTION\tERROR")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		} else if status.Error != "" {
+			appliedAt = "failed"
+		}
+
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", status.Version, appliedAt, status.Description, status.Error)
+	}
+
+	return writer.Flush()
+}
+
+// A helper function that takes the migration lock for the owner, waiting while another instance holds it. The lock
+// is a document that is only replaced once it expired: while it has not, the upsert conflicts with it.
+func acquireMigrationLock(ctx context.Context, records domain.Collection, owner string) error {
+	for {
+		now := time.Now()
+		filter := bson.M{"_id": migrationLockID, "expires_at": bson.M{"$lt": now}}
+		update := bson.M{"$set": bson.M{"owner": owner, "acquired_at": now, "expires_at": now.Add(migrationLockTTL)}}
+		_, err := records.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("taking the migration lock: %w", err)
+		}
+
+		slog.Info("Waiting for the migration lock held by another instance")
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for the migration lock: %w", ctx.Err())
+		case <-time.After(migrationLockPollInterval):
+		}
+	}
+}
+
+// A helper function that extends the migration lock of the owner, and fails if it was taken by another instance.
+func extendMigrationLock(ctx context.Context, records domain.Collection, owner string) error {
+	update := bson.M{"$set": bson.M{"expires_at": time.Now().Add(migrationLockTTL)}}
+	result, err := records.UpdateOne(ctx, bson.M{"_id": migrationLockID, "owner": owner}, update)
+	if err != nil {
+		return fmt.Errorf("extending the migration lock: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("the migration lock expired and was taken by another instance")
+	}
+
+	return nil
+}
+
+// A helper function that releases the migration lock of the owner. It runs even if the migration was cancelled.
+func releaseMigrationLock(records domain.Collection, owner string) {
+	_, err := records.DeleteOne(context.Background(), bson.M{"_id": migrationLockID, "owner": owner})
+	if err != nil {
+		slog.Error("Releasing the migration lock failed", "error", err)
+	}
+}
+
+// A helper function that returns the records of the migrations by version.
+func migrationRecords(ctx context.Context, records domain.Collection) (map[int]MigrationRecord, error) {
+	cursor, err := records.Find(ctx, bson.M{"_id": bson.M{"$ne": migrationLockID}})
+	if err != nil {
+		return nil, err
+	}
+
+	list := []MigrationRecord{}
+	err = cursor.All(ctx, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := map[int]MigrationRecord{}
+	for _, record := range list {
+		recorded[record.Version] = record
+	}
+
+	return recorded, nil
+}
+
+// A helper function that returns a copy of the migrations sorted by version.
+func sortedMigrations(migrations []Migration) []Migration {
+	sorted := append([]Migration{}, migrations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	return sorted
+}
+
+// A helper function that creates a migration step that creates indexes on a collection.
+// Creating an index that already exists with the same options does nothing.
+func createIndexes(collection string, indexes ...mongo.IndexModel) func(context.Context, *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		_, err := db.Collection(collection).Indexes().CreateMany(ctx, indexes)
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("the %s collection contains duplicates that must be removed first: %w", collection, err)
+		}
+
+		return err
+	}
+}
+
+// A helper function that creates a migration step that replaces the values of a field.
+func renameValues(collection, field string, renames map[string]string) func(context.Context, *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		for from, to := range renames {
+			_, err := db.Collection(collection).UpdateMany(ctx, bson.M{field: from}, bson.M{"$set": bson.M{field: to}})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// A helper function that creates a migration step that sets a field to a value in the documents that lack it.
+func setMissing(collection, field string, value any) func(context.Context, *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		_, err := db.Collection(collection).UpdateMany(ctx, bson.M{field: bson.M{"$exists": false}}, bson.M{"$set": bson.M{field: value}})
+		return err
+	}
+}
+
+// A helper function that creates a migration step that sets a field to the value of an aggregation expression in the
+// documents that match the filter and lack it. The expression is computed from each document.
+func setMissingExpression(collection string, filter bson.M, field string, expression any) func(context.Context, *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		missing := bson.M{field: bson.M{"$exists": false}}
+		for key, value := range filter {
+			missing[key] = value
+		}
+
+		update := mongo.Pipeline{{{Key: "$set", Value: bson.M{field: expression}}}}
+		_, err := db.Collection(collection).UpdateMany(ctx, missing, update)
+		return err
+	}
+}
+
+// A helper function that creates a migration step that runs several steps in order.
+func chain(steps ...func(context.Context, *mongo.Database) error) func(context.Context, *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		for _, step := range steps {
+			err := step(ctx, db)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
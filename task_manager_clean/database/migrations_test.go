@@ -0,0 +1,195 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"task_manager/database"
+	"task_manager/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// The filter of the upsert that takes the migration lock.
+	lockFilter = mock.MatchedBy(func(filter bson.M) bool {
+		_, ok := filter["expires_at"]
+		return filter["_id"] == "lock" && ok
+	})
+
+	// The filter of the updates that extend and release the lock of the instance.
+	ownerFilter = mock.MatchedBy(func(filter bson.M) bool {
+		_, ok := filter["owner"]
+		return filter["_id"] == "lock" && ok
+	})
+
+	// The update that records an applied migration.
+	appliedUpdate = mock.MatchedBy(func(update bson.M) bool {
+		set, ok := update["$set"].(bson.M)
+		_, applied := set["applied_at"]
+		return ok && applied
+	})
+)
+
+// A suite that contains tests for the migration runner.
+type MigrationsTestSuite struct {
+	suite.Suite
+	records *mocks.Collection
+	ran     []int
+}
+
+// A method that creates new mocks before each subtest.
+func (suite *MigrationsTestSuite) SetupSubTest() {
+	suite.records = new(mocks.Collection)
+	suite.ran = []int{}
+}
+
+// A method that checks the expectations after each subtest.
+func (suite *MigrationsTestSuite) TearDownSubTest() {
+	suite.records.AssertExpectations(suite.T())
+}
+
+// A helper method that returns a migration that records that it ran, and fails with the given error.
+func (suite *MigrationsTestSuite) migration(version int, description string, err error) database.Migration {
+	return database.Migration{
+		Version:     version,
+		Description: description,
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			suite.ran = append(suite.ran, version)
+			return err
+		},
+	}
+}
+
+// A helper method that expects the lock to be taken, then the given records to be read.
+func (suite *MigrationsTestSuite) expectLock(records ...database.MigrationRecord) {
+	cursor := new(mocks.Cursor)
+	suite.records.On("UpdateOne", mock.Anything, lockFilter, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{UpsertedCount: 1}, nil).Once()
+	suite.records.On("Find", mock.Anything, bson.M{"_id": bson.M{"$ne": "lock"}}).Return(cursor, nil).Once()
+	cursor.On("All", mock.Anything, &[]database.MigrationRecord{}).Return(nil).Once().Run(func(args mock.Arguments) {
+		list := args.Get(1).(*[]database.MigrationRecord)
+		*list = append(*list, records...)
+	})
+	suite.records.On("DeleteOne", mock.Anything, ownerFilter).Return(&mongo.DeleteResult{DeletedCount: 1}, nil).Once()
+}
+
+// A helper method that expects a migration to be applied and recorded.
+func (suite *MigrationsTestSuite) expectApplied(version int) {
+	suite.records.On("UpdateOne", mock.Anything, ownerFilter, mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+	suite.records.On("UpdateOne", mock.Anything, bson.M{"_id": version}, appliedUpdate, mock.Anything).Return(&mongo.UpdateResult{ModifiedCount: 1}, nil).Once()
+}
+
+// A test for the ApplyMigrations function.
+func (suite *MigrationsTestSuite) TestApplyMigrations() {
+	// A testcase where the migrations are applied in the order of their versions, whatever their order in the list.
+	suite.Run("ApplyMigrations_Order", func() {
+		suite.expectLock()
+		suite.expectApplied(1)
+		suite.expectApplied(2)
+		suite.expectApplied(3)
+
+		migrations := []database.Migration{
+			suite.migration(3, "third", nil),
+			suite.migration(1, "first", nil),
+			suite.migration(2, "second", nil),
+		}
+		done, err := database.ApplyMigrations(context.Background(), nil, suite.records, migrations)
+		suite.NoError(err)
+		suite.Len(done, 3)
+		suite.Equal([]int{1, 2, 3}, suite.ran)
+	})
+
+	// A testcase where the applied migrations are skipped, and the migration that failed before runs again.
+	suite.Run("ApplyMigrations_SkipApplied", func() {
+		suite.expectLock(
+			database.MigrationRecord{Version: 1, Description: "first", AppliedAt: time.Now()},
+			database.MigrationRecord{Version: 2, Description: "second", FailedAt: time.Now(), Error: "boom"},
+		)
+		suite.expectApplied(2)
+
+		migrations := []database.Migration{suite.migration(1, "first", nil), suite.migration(2, "second", nil)}
+		done, err := database.ApplyMigrations(context.Background(), nil, suite.records, migrations)
+		suite.NoError(err)
+		suite.Len(done, 1)
+		suite.Equal(2, done[0].Version)
+		suite.Equal([]int{2}, suite.ran)
+	})
+
+	// A testcase where a migration fails, which is recorded with its error and stops the later migrations.
+	suite.Run("ApplyMigrations_Failure", func() {
+		suite.expectLock()
+		suite.expectApplied(1)
+		failed := mock.MatchedBy(func(update bson.M) bool {
+			set := update["$set"].(bson.M)
+			_, applied := set["applied_at"]
+			return set["error"] == "migration 2 (second): boom" && !applied
+		})
+		suite.records.On("UpdateOne", mock.Anything, ownerFilter, mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+		suite.records.On("UpdateOne", mock.Anything, bson.M{"_id": 2}, failed, mock.Anything).Return(&mongo.UpdateResult{UpsertedCount: 1}, nil).Once()
+
+		migrations := []database.Migration{
+			suite.migration(1, "first", nil),
+			suite.migration(2, "second", errors.New("boom")),
+			suite.migration(3, "third", nil),
+		}
+		done, err := database.ApplyMigrations(context.Background(), nil, suite.records, migrations)
+		suite.EqualError(err, "migration 2 (second): boom")
+		suite.Len(done, 1)
+		suite.Equal([]int{1, 2}, suite.ran)
+	})
+
+	// A testcase where another instance holds the lock until the context is done, so nothing is applied.
+	suite.Run("ApplyMigrations_Locked", func() {
+		held := mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000, Message: "duplicate key"}}}
+		suite.records.On("UpdateOne", mock.Anything, lockFilter, mock.Anything, mock.Anything).Return(nil, held)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		done, err := database.ApplyMigrations(ctx, nil, suite.records, []database.Migration{suite.migration(1, "first", nil)})
+		suite.ErrorIs(err, context.DeadlineExceeded)
+		suite.Empty(done)
+		suite.Empty(suite.ran)
+	})
+
+	// A testcase where the lock expired during a migration and was taken by another instance.
+	suite.Run("ApplyMigrations_LockLost", func() {
+		suite.expectLock()
+		suite.records.On("UpdateOne", mock.Anything, ownerFilter, mock.Anything).Return(&mongo.UpdateResult{}, nil).Once()
+
+		done, err := database.ApplyMigrations(context.Background(), nil, suite.records, []database.Migration{suite.migration(1, "first", nil)})
+		suite.Error(err)
+		suite.Empty(done)
+		suite.Empty(suite.ran)
+	})
+}
+
+// A test for the WriteMigrationStatus function.
+func (suite *MigrationsTestSuite) TestWriteMigrationStatus() {
+	// A testcase where the applied, failed and pending migrations are listed in a table.
+	suite.Run("WriteMigrationStatus_Success", func() {
+		appliedAt := time.Date(2024, 9, 2, 10, 0, 0, 0, time.UTC)
+		statuses := []database.MigrationStatus{
+			{Version: 1, Description: "first", AppliedAt: &appliedAt},
+			{Version: 2, Description: "second", Error: "boom"},
+			{Version: 3, Description: "third"},
+		}
+
+		output := &strings.Builder{}
+		suite.Nil(database.WriteMigrationStatus(output, statuses))
+		suite.Equal("VERSION  APPLIED AT            DESCRIPTION  ERROR\n"+
+			"1        2024-09-02T10:00:00Z  first        \n"+
+			"2        failed                second       boom\n"+
+			"3        pending               third        \n", output.String())
+	})
+}
+
+// A function that runs the MigrationsTestSuite.
+func TestMigrationsTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationsTestSuite))
+}
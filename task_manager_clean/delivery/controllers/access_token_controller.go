@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"net/http"
+	"task_manager/domain"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A struct that handles personal access token operations by calling the usecase methods.
+type AccessTokenController struct {
+	usecase domain.AccessTokenUsecase
+}
+
+// A constructor that creates a new instance of AccessTokenController.
+func NewAccessTokenController(usecase domain.AccessTokenUsecase) *AccessTokenController {
+	return &AccessTokenController{usecase: usecase}
+}
+
+// A handler function that creates a personal access token.
+func (ac *AccessTokenController) CreateAccessToken(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the request body to the struct.
+	data := &domain.CreateAccessTokenData{}
+	err := ctx.ShouldBindJSON(data)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	token, _err := ac.usecase.CreateAccessToken(ctx, data, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, token)
+}
+
+// A handler function that returns the personal access tokens of the logged in user.
+func (ac *AccessTokenController) GetAccessTokens(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	tokens, _err := ac.usecase.GetAccessTokens(ctx, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"count":  len(tokens),
+		"tokens": tokens,
+	})
+}
+
+// A handler function that revokes a personal access token.
+func (ac *AccessTokenController) RevokeAccessToken(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	tokenID := ctx.MustGet("token_id").(primitive.ObjectID)
+
+	_err := ac.usecase.RevokeAccessToken(ctx, tokenID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
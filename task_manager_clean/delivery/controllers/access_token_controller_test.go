@@ -0,0 +1,142 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"task_manager/delivery/controllers"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite to test the AccessTokenController.
+type AccessTokenControllerTestSuite struct {
+	suite.Suite
+	controller  *controllers.AccessTokenController
+	mockUsecase *mocks.AccessTokenUsecase
+}
+
+// A method that initializes the AccessTokenControllerTestSuite.
+func (suite *AccessTokenControllerTestSuite) SetupSuite() {
+	suite.mockUsecase = new(mocks.AccessTokenUsecase)
+	suite.controller = controllers.NewAccessTokenController(suite.mockUsecase)
+}
+
+// A method that cleans up the AccessTokenControllerTestSuite.
+func (suite *AccessTokenControllerTestSuite) TearDownSuite() {
+	suite.mockUsecase.AssertExpectations(suite.T())
+}
+
+// A test for the AccessTokenController.CreateAccessToken method.
+func (suite *AccessTokenControllerTestSuite) TestCreateAccessToken() {
+	// A testcase for a successful creation.
+	suite.Run("CreateAccessToken_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		data := &domain.CreateAccessTokenData{Name: "ci", Scopes: []string{domain.ScopeTasksRead}}
+		created := &domain.CreatedAccessToken{AccessToken: *mocks.GetAccessToken("hash"), Token: "tm_pat_secret"}
+		suite.mockUsecase.On("CreateAccessToken", mock.Anything, data, claims).Return(created, nil).Once()
+		ctx.Set("claims", claims)
+
+		body, err := json.Marshal(data)
+		suite.Nil(err)
+		ctx.Request = httptest.NewRequest("POST", "/me/tokens", bytes.NewReader(body))
+
+		serve(ctx, suite.controller.CreateAccessToken)
+		expected, err := json.Marshal(created)
+		suite.Nil(err)
+
+		suite.Equal(201, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+		suite.NotContains(w.Body.String(), "hash")
+	})
+
+	// A testcase for an invalid request.
+	suite.Run("CreateAccessToken_InvalidRequest", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Set("claims", mocks.GetClaims())
+
+		ctx.Request = httptest.NewRequest("POST", "/me/tokens", nil)
+
+		serve(ctx, suite.controller.CreateAccessToken)
+
+		suite.Equal(400, w.Code)
+	})
+}
+
+// A test for the AccessTokenController.GetAccessTokens method.
+func (suite *AccessTokenControllerTestSuite) TestGetAccessTokens() {
+	// A testcase where the tokens of the user are listed.
+	suite.Run("GetAccessTokens_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		tokens := []domain.AccessToken{*mocks.GetAccessToken("hash")}
+		suite.mockUsecase.On("GetAccessTokens", mock.Anything, claims).Return(tokens, nil).Once()
+		ctx.Set("claims", claims)
+
+		serve(ctx, suite.controller.GetAccessTokens)
+		expected, err := json.Marshal(gin.H{"count": 1, "tokens": tokens})
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+}
+
+// A test for the AccessTokenController.RevokeAccessToken method.
+func (suite *AccessTokenControllerTestSuite) TestRevokeAccessToken() {
+	// A testcase for a successful revocation.
+	suite.Run("RevokeAccessToken_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		tokenID := mocks.GetPrimitiveID2()
+		suite.mockUsecase.On("RevokeAccessToken", mock.Anything, tokenID, claims).Return(nil).Once()
+		ctx.Set("claims", claims)
+		ctx.Set("token_id", tokenID)
+
+		serve(ctx, suite.controller.RevokeAccessToken)
+
+		suite.Equal(204, w.Code)
+	})
+
+	// A testcase for a token that does not exist.
+	suite.Run("RevokeAccessToken_NotFound", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		tokenID := mocks.GetPrimitiveID3()
+		suite.mockUsecase.On("RevokeAccessToken", mock.Anything, tokenID, claims).Return(&domain.Error{
+			Err:        errors.New("not found"),
+			StatusCode: http.StatusNotFound,
+			Message:    "Access token not found",
+		}).Once()
+		ctx.Set("claims", claims)
+		ctx.Set("token_id", tokenID)
+
+		serve(ctx, suite.controller.RevokeAccessToken)
+		expected := problem(404, domain.CodeNotFound, "Access token not found")
+
+		suite.Equal(404, w.Code)
+		suite.Equal(expected, w.Body.String())
+	})
+}
+
+// A function that runs the AccessTokenControllerTestSuite.
+func Test_AccessTokenControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(AccessTokenControllerTestSuite))
+}
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Report the JSON names of the fields that fail the binding rules, instead of the names of the struct fields.
+func init() {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	validate.RegisterTagNameFunc(infrastructure.FieldName)
+}
+
+// A helper function that converts an error of binding a request to a domain error, with the invalid fields if they
+// are known.
+func invalidRequest(err error) *domain.Error {
+	_err := &domain.Error{
+		Err:        err,
+		StatusCode: http.StatusBadRequest,
+		Code:       domain.CodeInvalidRequest,
+		Message:    "Invalid request",
+		Fields:     infrastructure.FieldErrors(err),
+	}
+
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) && typeError.Field != "" {
+		_err.Fields = []domain.FieldError{{Field: typeError.Field, Reason: "has an invalid type"}}
+	}
+
+	if len(_err.Fields) > 0 {
+		_err.Code = domain.CodeValidationFailed
+	}
+
+	return _err
+}
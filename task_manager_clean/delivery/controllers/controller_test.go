@@ -0,0 +1,50 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"task_manager/docs"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+
+	"github.com/gin-gonic/gin"
+)
+
+// A validator that checks that the documented handlers behave as the OpenAPI specification describes.
+var specValidator = newSpecValidator()
+
+// A helper function that creates the validator of the OpenAPI specification.
+func newSpecValidator() *infrastructure.OpenAPIValidator {
+	validator, err := infrastructure.NewOpenAPIValidator(docs.OpenAPI, true)
+	if err != nil {
+		panic(err)
+	}
+
+	return validator
+}
+
+// A helper function that runs a handler followed by the error middleware, like the router does.
+// The request and the response are checked against the OpenAPI specification.
+func serve(ctx *gin.Context, handler gin.HandlerFunc) {
+	specValidator.Handle(ctx, func() {
+		handler(ctx)
+		infrastructure.ErrorMiddleware(ctx)
+	})
+}
+
+// A helper function that returns the expected body of an error response.
+func problem(status int, code string, detail string, fields ...domain.FieldError) string {
+	body, err := json.Marshal(&domain.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+		Errors: fields,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return string(body)
+}
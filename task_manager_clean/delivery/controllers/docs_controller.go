@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"io/fs"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// A struct that serves the OpenAPI specification and its documentation page.
+type DocsController struct {
+	spec   []byte
+	ui     []byte
+	assets fs.FS
+}
+
+// A constructor that creates a new instance of DocsController. The assets are the files the page loads.
+func NewDocsController(spec []byte, ui []byte, assets fs.FS) *DocsController {
+	return &DocsController{spec: spec, ui: ui, assets: assets}
+}
+
+// A handler function that returns the OpenAPI specification.
+func (dc *DocsController) GetSpec(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", dc.spec)
+}
+
+// A handler function that returns the page that renders the OpenAPI specification.
+func (dc *DocsController) GetUI(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", dc.ui)
+}
+
+// A handler function that returns a file the documentation page loads.
+func (dc *DocsController) GetAsset(ctx *gin.Context) {
+	ctx.FileFromFS(ctx.Param("name"), http.FS(dc.assets))
+}
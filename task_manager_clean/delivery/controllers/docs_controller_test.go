@@ -0,0 +1,171 @@
+package controllers_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/fs"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"task_manager/delivery/controllers"
+	"task_manager/delivery/router"
+	"task_manager/docs"
+	"task_manager/domain"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite to test the DocsController and the OpenAPI specification it serves.
+type DocsControllerTestSuite struct {
+	suite.Suite
+	controller *controllers.DocsController
+}
+
+// A method that initializes the DocsControllerTestSuite.
+func (suite *DocsControllerTestSuite) SetupSuite() {
+	assets := fstest.MapFS{"swagger-ui.css": &fstest.MapFile{Data: []byte("body {}")}}
+	suite.controller = controllers.NewDocsController(docs.OpenAPI, docs.UI, assets)
+}
+
+// A test for the DocsController.GetSpec method.
+func (suite *DocsControllerTestSuite) TestGetSpec() {
+	// A testcase where the specification is returned.
+	suite.Run("GetSpec_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/openapi.json", nil)
+
+		suite.controller.GetSpec(ctx)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(docs.OpenAPI), w.Body.String())
+	})
+}
+
+// A test for the DocsController.GetUI method.
+func (suite *DocsControllerTestSuite) TestGetUI() {
+	// A testcase where the documentation page is returned.
+	suite.Run("GetUI_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/docs", nil)
+
+		suite.controller.GetUI(ctx)
+
+		suite.Equal(200, w.Code)
+		suite.Contains(w.Header().Get("Content-Type"), "text/html")
+		suite.Contains(w.Body.String(), "/openapi.json")
+		suite.Contains(w.Body.String(), "/docs/assets/swagger-ui-bundle.js")
+		suite.NotContains(w.Body.String(), "https://")
+	})
+}
+
+// A test for the DocsController.GetAsset method.
+func (suite *DocsControllerTestSuite) TestGetAsset() {
+	// A testcase where a file of the page is returned.
+	suite.Run("GetAsset_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/docs/assets/swagger-ui.css", nil)
+		ctx.Params = gin.Params{{Key: "name", Value: "swagger-ui.css"}}
+
+		serve(ctx, suite.controller.GetAsset)
+
+		suite.Equal(200, w.Code)
+		suite.Contains(w.Header().Get("Content-Type"), "text/css")
+		suite.Equal("body {}", w.Body.String())
+	})
+
+	// A testcase where the file is not part of the page.
+	suite.Run("GetAsset_NotFound", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/docs/assets/missing.js", nil)
+		ctx.Params = gin.Params{{Key: "name", Value: "missing.js"}}
+
+		serve(ctx, suite.controller.GetAsset)
+
+		suite.Equal(404, w.Code)
+	})
+}
+
+// A test that checks that the embedded Swagger UI holds every file the page loads, with the recorded checksums.
+func (suite *DocsControllerTestSuite) TestEmbeddedAssets() {
+	assets, err := fs.Sub(docs.Assets, "swagger-ui")
+	suite.Require().Nil(err)
+
+	version, err := fs.ReadFile(assets, "VERSION")
+	suite.Require().Nil(err)
+	sums, err := fs.ReadFile(assets, "SHA256SUMS-"+strings.TrimSpace(string(version)))
+	suite.Require().Nil(err, "the Swagger UI files are missing: run go generate ./docs")
+
+	recorded := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(sums)), "\n") {
+		if sum, name, ok := strings.Cut(line, "  "); ok {
+			recorded[name] = sum
+		}
+	}
+	for _, name := range []string{"swagger-ui.css", "swagger-ui-bundle.js", "LICENSE"} {
+		data, err := fs.ReadFile(assets, name)
+		if !suite.Nil(err, "%s is not embedded", name) {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		suite.Equal(recorded[name], hex.EncodeToString(sum[:]), "%s does not match its checksum", name)
+	}
+}
+
+// A test that checks that every route is documented in the specification.
+func (suite *DocsControllerTestSuite) TestSpecCoversRoutes() {
+	engine := gin.New()
+	router.HealthRoutes(engine, controllers.NewHealthController(nil))
+	router.PublicRoutes(engine, controllers.NewUserController(nil))
+	router.DocsRoutes(engine, suite.controller)
+	router.PasswordRoutes(engine, controllers.NewPasswordController(nil))
+	router.TwoFactorRoutes(engine, controllers.NewTwoFactorController(nil), nil)
+	router.ProtectedTaskRoutes(engine, controllers.NewTaskController(nil, 0))
+	router.ProtectedGraphQLRoutes(engine, controllers.NewGraphQLController(nil))
+	router.ProtectedWorkspaceRoutes(engine, controllers.NewWorkspaceController(nil), controllers.NewTaskController(nil, 0))
+	router.ProtectedTimeRoutes(engine, controllers.NewTimeEntryController(nil))
+	router.ProtectedUserRoutes(engine, controllers.NewUserController(nil))
+	router.ProtectedTwoFactorRoutes(engine, controllers.NewTwoFactorController(nil))
+	router.ProtectedAccessTokenRoutes(engine, controllers.NewAccessTokenController(nil))
+	router.ProtectedRoleRoutes(engine, controllers.NewRoleController(nil))
+
+	spec := struct {
+		Paths map[string]map[string]any `json:"paths"`
+	}{}
+	suite.Nil(json.Unmarshal(docs.OpenAPI, &spec))
+
+	param := regexp.MustCompile(`:(\w+)`)
+	for _, route := range engine.Routes() {
+		path := param.ReplaceAllString(route.Path, "{$1}")
+		_, ok := spec.Paths[path][strings.ToLower(route.Method)]
+		suite.True(ok, "%s %s is not documented", route.Method, path)
+	}
+}
+
+// A test that checks that the controller tests fail on the routes that are not documented.
+func (suite *DocsControllerTestSuite) TestSpecValidatorStrict() {
+	// A testcase where a handler answers a request to a route that is not in the specification.
+	suite.Run("SpecValidator_Undocumented", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/undocumented", nil)
+
+		serve(ctx, suite.controller.GetSpec)
+
+		suite.Equal(500, w.Code)
+		suite.Contains(w.Body.String(), domain.CodeSpecMismatch)
+		suite.Contains(w.Body.String(), "is not documented in the specification")
+	})
+}
+
+// A function that runs the DocsControllerTestSuite.
+func Test_DocsControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(DocsControllerTestSuite))
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"task_manager/delivery/graphql"
+	"task_manager/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// A struct that handles the requests of the GraphQL API by calling the executor.
+type GraphQLController struct {
+	executor *graphql.Executor
+}
+
+// A constructor that creates a new instance of GraphQLController.
+func NewGraphQLController(executor *graphql.Executor) *GraphQLController {
+	return &GraphQLController{executor: executor}
+}
+
+// A handler function that runs a GraphQL request. Requests that accept text/event-stream, which subscriptions
+// require, are answered with server-sent events: a "next" event for each response and a "complete" event at the end.
+func (gc *GraphQLController) Handle(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the request body to the struct.
+	request := &graphql.Request{}
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	// Answer with a single JSON response unless events are accepted. The executor gets the context of the request
+	// rather than the gin context, which is reused once the handler returns.
+	if !strings.Contains(ctx.GetHeader("Accept"), "text/event-stream") {
+		ctx.JSON(http.StatusOK, gc.executor.Exec(ctx.Request.Context(), claims, request))
+		return
+	}
+
+	responses, err := gc.executor.Subscribe(ctx.Request.Context(), claims, request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no")
+
+	// The responses end when the subscription completes, the client disconnects or the server shuts down.
+	for response := range responses {
+		ctx.SSEvent("next", response)
+		ctx.Writer.Flush()
+	}
+
+	ctx.SSEvent("complete", "")
+}
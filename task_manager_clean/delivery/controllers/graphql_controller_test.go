@@ -0,0 +1,92 @@
+package controllers_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"task_manager/delivery/controllers"
+	"task_manager/delivery/graphql"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"task_manager/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite to test the GraphQLController.
+type GraphQLControllerTestSuite struct {
+	suite.Suite
+	controller *controllers.GraphQLController
+	users      *mocks.UserUsecase
+}
+
+// A method that initializes the GraphQLControllerTestSuite.
+func (suite *GraphQLControllerTestSuite) SetupSuite() {
+	suite.users = new(mocks.UserUsecase)
+	executor, err := graphql.NewExecutor(new(mocks.TaskUsecase), suite.users, infrastructure.NewTaskEventBroker())
+	suite.Require().NoError(err)
+	suite.controller = controllers.NewGraphQLController(executor)
+}
+
+// A method that closes the suite.
+func (suite *GraphQLControllerTestSuite) TearDownSuite() {
+	suite.users.AssertExpectations(suite.T())
+}
+
+// A test for the GraphQLController.Handle method.
+func (suite *GraphQLControllerTestSuite) TestHandle() {
+	// A testcase where a query is answered with a JSON response.
+	suite.Run("Handle_Query", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
+		user := mocks.GetNewUser()
+		suite.users.On("GetUserByID", mock.Anything, claims.ID).Return(user, nil).Once()
+
+		ctx.Request = httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"query":"{ me { username } }"}`))
+
+		serve(ctx, suite.controller.Handle)
+
+		suite.Equal(200, w.Code)
+		suite.JSONEq(`{"data":{"me":{"username":"user1"}}}`, w.Body.String())
+	})
+
+	// A testcase where the events are accepted, so the response is streamed and completed.
+	suite.Run("Handle_EventStream", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
+		suite.users.On("GetUserByID", mock.Anything, claims.ID).Return(mocks.GetNewUser(), nil).Once()
+
+		ctx.Request = httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"query":"{ me { username } }"}`))
+		ctx.Request.Header.Set("Accept", "text/event-stream")
+
+		serve(ctx, suite.controller.Handle)
+
+		suite.Equal(200, w.Code)
+		suite.Equal("event:next\ndata:{\"data\":{\"me\":{\"username\":\"user1\"}}}\n\nevent:complete\ndata:\n\n", w.Body.String())
+	})
+
+	// A testcase where the request has no query.
+	suite.Run("Handle_InvalidRequest", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Set("claims", mocks.GetClaims())
+
+		ctx.Request = httptest.NewRequest("POST", "/graphql", strings.NewReader(`{}`))
+
+		serve(ctx, suite.controller.Handle)
+
+		suite.Equal(400, w.Code)
+		suite.Contains(w.Body.String(), domain.CodeValidationFailed)
+	})
+}
+
+// A function that runs the TestSuite.
+func TestGraphQLControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(GraphQLControllerTestSuite))
+}
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"net/http"
+	"task_manager/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// A struct that handles the health endpoints by calling the usecase methods.
+type HealthController struct {
+	usecase domain.HealthUsecase
+}
+
+// A constructor that creates a new instance of HealthController.
+func NewHealthController(usecase domain.HealthUsecase) *HealthController {
+	return &HealthController{usecase: usecase}
+}
+
+// A handler function that reports that the process is up.
+func (hc *HealthController) Liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, hc.usecase.Liveness(ctx))
+}
+
+// A handler function that reports if the API can serve traffic. It returns a 503 response if it cannot.
+func (hc *HealthController) Readiness(ctx *gin.Context) {
+	health := hc.usecase.Readiness(ctx)
+	if !health.Ready() {
+		ctx.JSON(http.StatusServiceUnavailable, health)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, health)
+}
+
+// A handler function that reports if the API can serve traffic, with the reason of the failed checks and whether a
+// root user exists. It is only served on the admin port. It returns a 503 response if the API cannot serve traffic.
+func (hc *HealthController) Report(ctx *gin.Context) {
+	health := hc.usecase.Report(ctx)
+	if !health.Ready() {
+		ctx.JSON(http.StatusServiceUnavailable, health)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, health)
+}
@@ -0,0 +1,161 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"task_manager/delivery/controllers"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite to test the HealthController.
+type HealthControllerTestSuite struct {
+	suite.Suite
+	controller  *controllers.HealthController
+	mockUsecase *mocks.HealthUsecase
+	build       domain.BuildInfo
+}
+
+// A method that initializes the HealthControllerTestSuite.
+func (suite *HealthControllerTestSuite) SetupSuite() {
+	suite.mockUsecase = new(mocks.HealthUsecase)
+	suite.controller = controllers.NewHealthController(suite.mockUsecase)
+	suite.build = domain.BuildInfo{Version: "1.2.0", GoVersion: "go1.22.5"}
+}
+
+// A method that cleans up the HealthControllerTestSuite.
+func (suite *HealthControllerTestSuite) TearDownSuite() {
+	suite.mockUsecase.AssertExpectations(suite.T())
+}
+
+// A test for the HealthController.Liveness method.
+func (suite *HealthControllerTestSuite) TestLiveness() {
+	// A testcase where the process is up.
+	suite.Run("Liveness_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/healthz", nil)
+
+		health := &domain.Health{Status: domain.HealthStatusOK, Build: suite.build}
+		suite.mockUsecase.On("Liveness", mock.Anything).Return(health).Once()
+
+		serve(ctx, suite.controller.Liveness)
+
+		expected, err := json.Marshal(health)
+		suite.Nil(err)
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+}
+
+// A test for the HealthController.Readiness method.
+func (suite *HealthControllerTestSuite) TestReadiness() {
+	// A testcase where the API is ready.
+	suite.Run("Readiness_Ready", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/readyz", nil)
+
+		health := &domain.Health{
+			Status: domain.HealthStatusOK,
+			Build:  suite.build,
+			Checks: map[string]domain.HealthCheck{"database": {Status: domain.HealthStatusOK, DurationMS: 1.5}},
+		}
+		suite.mockUsecase.On("Readiness", mock.Anything).Return(health).Once()
+
+		serve(ctx, suite.controller.Readiness)
+
+		expected, err := json.Marshal(health)
+		suite.Nil(err)
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase where the database is unavailable.
+	suite.Run("Readiness_Unavailable", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/readyz", nil)
+
+		health := &domain.Health{
+			Status: domain.HealthStatusUnavailable,
+			Build:  suite.build,
+			Checks: map[string]domain.HealthCheck{"database": {Status: domain.HealthStatusUnavailable, DurationMS: 2000}},
+		}
+		suite.mockUsecase.On("Readiness", mock.Anything).Return(health).Once()
+
+		serve(ctx, suite.controller.Readiness)
+
+		suite.Equal(http.StatusServiceUnavailable, w.Code)
+	})
+
+	// A testcase where the API is shutting down.
+	suite.Run("Readiness_Draining", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/readyz", nil)
+
+		health := &domain.Health{Status: domain.HealthStatusDraining, Build: suite.build, Checks: map[string]domain.HealthCheck{}}
+		suite.mockUsecase.On("Readiness", mock.Anything).Return(health).Once()
+
+		serve(ctx, suite.controller.Readiness)
+
+		suite.Equal(http.StatusServiceUnavailable, w.Code)
+		suite.Contains(w.Body.String(), `"status":"draining"`)
+	})
+}
+
+// A test for the HealthController.Report method, which is served on the admin port and not documented in the
+// specification of the public API.
+func (suite *HealthControllerTestSuite) TestReport() {
+	// A testcase where the report tells whether a root user exists.
+	suite.Run("Report_Ready", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/health", nil)
+
+		exists := true
+		health := &domain.Health{
+			Status:         domain.HealthStatusOK,
+			Build:          suite.build,
+			Checks:         map[string]domain.HealthCheck{"database": {Status: domain.HealthStatusOK, DurationMS: 1.5}},
+			RootUserExists: &exists,
+		}
+		suite.mockUsecase.On("Report", mock.Anything).Return(health).Once()
+
+		suite.controller.Report(ctx)
+
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Contains(w.Body.String(), `"root_user_exists":true`)
+	})
+
+	// A testcase where the database is unavailable, whose reason is reported.
+	suite.Run("Report_Unavailable", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/health", nil)
+
+		health := &domain.Health{
+			Status: domain.HealthStatusUnavailable,
+			Build:  suite.build,
+			Checks: map[string]domain.HealthCheck{"database": {Status: domain.HealthStatusUnavailable, DurationMS: 2000, Error: "context deadline exceeded"}},
+		}
+		suite.mockUsecase.On("Report", mock.Anything).Return(health).Once()
+
+		suite.controller.Report(ctx)
+
+		suite.Equal(http.StatusServiceUnavailable, w.Code)
+		suite.Contains(w.Body.String(), "context deadline exceeded")
+	})
+}
+
+// A function that runs the HealthControllerTestSuite.
+func Test_HealthControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthControllerTestSuite))
+}
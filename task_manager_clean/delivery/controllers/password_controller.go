@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"net/http"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+
+	"github.com/gin-gonic/gin"
+)
+
+// A struct that handles password recovery operations by calling the usecase methods.
+type PasswordController struct {
+	usecase domain.PasswordUsecase
+}
+
+// A constructor that creates a new instance of PasswordController.
+func NewPasswordController(usecase domain.PasswordUsecase) *PasswordController {
+	return &PasswordController{usecase: usecase}
+}
+
+// A handler function that issues a password reset token.
+func (pc *PasswordController) ForgotPassword(ctx *gin.Context) {
+	data := &domain.ForgotPasswordData{}
+
+	// Bind the request body to the struct.
+	err := ctx.ShouldBindJSON(data)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	// Errors are only logged, the response must not reveal whether the username exists.
+	_err := pc.usecase.ForgotPassword(ctx, data)
+	if _err != nil {
+		infrastructure.Logger(ctx).Warn("password reset request failed", "code", _err.Problem().Code, "error", _err.Error())
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{"message": "If the account exists, a password reset token has been sent"})
+}
+
+// A handler function that resets a password using a reset token.
+func (pc *PasswordController) ResetPassword(ctx *gin.Context) {
+	data := &domain.ResetPasswordData{}
+
+	// Bind the request body to the struct.
+	err := ctx.ShouldBindJSON(data)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	// Reset the password using the PasswordUsecase.
+	_err := pc.usecase.ResetPassword(ctx, data)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Password has been reset"})
+}
@@ -0,0 +1,144 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"task_manager/delivery/controllers"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite to test the PasswordController.
+type PasswordControllerTestSuite struct {
+	suite.Suite
+	controller  *controllers.PasswordController
+	mockUsecase *mocks.PasswordUsecase
+}
+
+// A method that initializes the PasswordControllerTestSuite.
+func (suite *PasswordControllerTestSuite) SetupSuite() {
+	suite.mockUsecase = new(mocks.PasswordUsecase)
+	suite.controller = controllers.NewPasswordController(suite.mockUsecase)
+}
+
+// A method that cleans up the PasswordControllerTestSuite.
+func (suite *PasswordControllerTestSuite) TearDownSuite() {
+	suite.mockUsecase.AssertExpectations(suite.T())
+}
+
+// A test for the PasswordController.ForgotPassword method.
+func (suite *PasswordControllerTestSuite) TestForgotPassword() {
+	expected, err := json.Marshal(gin.H{"message": "If the account exists, a password reset token has been sent"})
+	suite.Nil(err)
+
+	// A testcase for a successful request.
+	suite.Run("ForgotPassword_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		data := &domain.ForgotPasswordData{Username: "user1"}
+		suite.mockUsecase.On("ForgotPassword", mock.Anything, data).Return(nil).Once()
+
+		body, err := json.Marshal(data)
+		suite.Nil(err)
+		ctx.Request = httptest.NewRequest("POST", "/password/forgot", bytes.NewReader(body))
+
+		serve(ctx, suite.controller.ForgotPassword)
+
+		suite.Equal(202, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase where the usecase fails, the response must be the same.
+	suite.Run("ForgotPassword_Error", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		data := &domain.ForgotPasswordData{Username: "user2"}
+		suite.mockUsecase.On("ForgotPassword", mock.Anything, data).Return(&domain.Error{
+			Err:        errors.New("some error"),
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}).Once()
+
+		body, err := json.Marshal(data)
+		suite.Nil(err)
+		ctx.Request = httptest.NewRequest("POST", "/password/forgot", bytes.NewReader(body))
+
+		serve(ctx, suite.controller.ForgotPassword)
+
+		suite.Equal(202, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase for an invalid request.
+	suite.Run("ForgotPassword_InvalidRequest", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		ctx.Request = httptest.NewRequest("POST", "/password/forgot", nil)
+
+		serve(ctx, suite.controller.ForgotPassword)
+
+		suite.Equal(400, w.Code)
+	})
+}
+
+// A test for the PasswordController.ResetPassword method.
+func (suite *PasswordControllerTestSuite) TestResetPassword() {
+	// A testcase for a successful password reset.
+	suite.Run("ResetPassword_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		data := &domain.ResetPasswordData{Token: "token", Password: "new_password"}
+		suite.mockUsecase.On("ResetPassword", mock.Anything, data).Return(nil).Once()
+
+		body, err := json.Marshal(data)
+		suite.Nil(err)
+		ctx.Request = httptest.NewRequest("POST", "/password/reset", bytes.NewReader(body))
+
+		serve(ctx, suite.controller.ResetPassword)
+		expected, err := json.Marshal(gin.H{"message": "Password has been reset"})
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase for an invalid token.
+	suite.Run("ResetPassword_InvalidToken", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		data := &domain.ResetPasswordData{Token: "bad_token", Password: "new_password"}
+		suite.mockUsecase.On("ResetPassword", mock.Anything, data).Return(&domain.Error{
+			Err:        errors.New("invalid reset token"),
+			StatusCode: http.StatusBadRequest,
+			Message:    "Invalid or expired token",
+		}).Once()
+
+		body, err := json.Marshal(data)
+		suite.Nil(err)
+		ctx.Request = httptest.NewRequest("POST", "/password/reset", bytes.NewReader(body))
+
+		serve(ctx, suite.controller.ResetPassword)
+		expected := problem(400, domain.CodeInvalidRequest, "Invalid or expired token")
+
+		suite.Equal(400, w.Code)
+		suite.Equal(expected, w.Body.String())
+	})
+}
+
+// A function that runs the PasswordControllerTestSuite.
+func Test_PasswordControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(PasswordControllerTestSuite))
+}
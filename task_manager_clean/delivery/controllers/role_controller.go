@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"net/http"
+	"task_manager/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// A struct that handles role and authorization operations by calling the usecase methods.
+type RoleController struct {
+	usecase domain.RoleUsecase
+}
+
+// A constructor that creates a new instance of RoleController.
+func NewRoleController(usecase domain.RoleUsecase) *RoleController {
+	return &RoleController{usecase: usecase}
+}
+
+// A handler function that returns all roles.
+func (rc *RoleController) GetRoles(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	roles, _err := rc.usecase.GetRoles(ctx, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"count": len(roles),
+		"roles": roles,
+	})
+}
+
+// A handler function that creates a custom role.
+func (rc *RoleController) CreateRole(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the request body to the struct.
+	roleData := &domain.RoleData{}
+	err := ctx.ShouldBindJSON(roleData)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	role, _err := rc.usecase.CreateRole(ctx, roleData, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, role)
+}
+
+// A handler function that replaces a custom role.
+func (rc *RoleController) ReplaceRole(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the request body to the struct.
+	roleData := &domain.RoleData{}
+	err := ctx.ShouldBindJSON(roleData)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	role, _err := rc.usecase.ReplaceRole(ctx, ctx.Param("name"), roleData, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, role)
+}
+
+// A handler function that deletes a custom role.
+func (rc *RoleController) DeleteRole(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	_err := rc.usecase.DeleteRole(ctx, ctx.Param("name"), claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// A handler function that explains if the logged in user may perform an action, and why.
+func (rc *RoleController) ExplainAccess(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the query parameters to the struct.
+	data := &domain.ExplainData{}
+	err := ctx.ShouldBindQuery(data)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	decision, _err := rc.usecase.ExplainAccess(ctx, data, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, decision)
+}
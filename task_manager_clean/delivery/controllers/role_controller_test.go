@@ -0,0 +1,169 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"task_manager/delivery/controllers"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite to test the RoleController.
+type RoleControllerTestSuite struct {
+	suite.Suite
+	controller  *controllers.RoleController
+	mockUsecase *mocks.RoleUsecase
+}
+
+// A method that initializes the RoleControllerTestSuite.
+func (suite *RoleControllerTestSuite) SetupSuite() {
+	suite.mockUsecase = new(mocks.RoleUsecase)
+	suite.controller = controllers.NewRoleController(suite.mockUsecase)
+}
+
+// A method that cleans up the RoleControllerTestSuite.
+func (suite *RoleControllerTestSuite) TearDownSuite() {
+	suite.mockUsecase.AssertExpectations(suite.T())
+}
+
+// A test for the RoleController.GetRoles method.
+func (suite *RoleControllerTestSuite) TestGetRoles() {
+	// A testcase where the roles are listed.
+	suite.Run("GetRoles_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims3()
+		roles := []domain.Role{*mocks.GetRole()}
+		suite.mockUsecase.On("GetRoles", mock.Anything, claims).Return(roles, nil).Once()
+		ctx.Set("claims", claims)
+
+		serve(ctx, suite.controller.GetRoles)
+		expected, err := json.Marshal(gin.H{
+			"count": len(roles),
+			"roles": roles,
+		})
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+}
+
+// A test for the RoleController.CreateRole method.
+func (suite *RoleControllerTestSuite) TestCreateRole() {
+	// A testcase for a successful creation.
+	suite.Run("CreateRole_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims3()
+		role := mocks.GetRole()
+		roleData := &domain.RoleData{Name: role.Name, Description: role.Description, Level: role.Level, Permissions: role.Permissions}
+		suite.mockUsecase.On("CreateRole", mock.Anything, roleData, claims).Return(role, nil).Once()
+		ctx.Set("claims", claims)
+
+		body, err := json.Marshal(roleData)
+		suite.Nil(err)
+		ctx.Request = httptest.NewRequest("POST", "/roles", bytes.NewReader(body))
+
+		serve(ctx, suite.controller.CreateRole)
+		expected, err := json.Marshal(role)
+		suite.Nil(err)
+
+		suite.Equal(201, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase for an invalid request.
+	suite.Run("CreateRole_InvalidRequest", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Set("claims", mocks.GetClaims3())
+
+		ctx.Request = httptest.NewRequest("POST", "/roles", bytes.NewReader([]byte(`{"name":"editor"}`)))
+
+		serve(ctx, suite.controller.CreateRole)
+
+		suite.Equal(400, w.Code)
+	})
+}
+
+// A test for the RoleController.DeleteRole method.
+func (suite *RoleControllerTestSuite) TestDeleteRole() {
+	// A testcase where the role is still in use.
+	suite.Run("DeleteRole_InUse", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims3()
+		suite.mockUsecase.On("DeleteRole", mock.Anything, "editor", claims).Return(&domain.Error{
+			Err:        errors.New("role in use"),
+			StatusCode: http.StatusConflict,
+			Message:    "Role is assigned to users",
+		}).Once()
+		ctx.Set("claims", claims)
+		ctx.Params = gin.Params{{Key: "name", Value: "editor"}}
+
+		serve(ctx, suite.controller.DeleteRole)
+		expected := problem(409, domain.CodeConflict, "Role is assigned to users")
+
+		suite.Equal(409, w.Code)
+		suite.Equal(expected, w.Body.String())
+	})
+}
+
+// A test for the RoleController.ExplainAccess method.
+func (suite *RoleControllerTestSuite) TestExplainAccess() {
+	// A testcase where the decision is returned.
+	suite.Run("ExplainAccess_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		data := &domain.ExplainData{Action: domain.ActionTaskDelete, TaskID: mocks.GetPrimitiveID2().Hex()}
+		decision := &domain.Decision{
+			Action: domain.ActionTaskDelete,
+			Role:   "user",
+			Denial: domain.DenialNotOwner,
+			Reason: `role "user" only grants task.delete.own and the resource belongs to another user`,
+		}
+		suite.mockUsecase.On("ExplainAccess", mock.Anything, data, claims).Return(decision, nil).Once()
+		ctx.Set("claims", claims)
+
+		ctx.Request = httptest.NewRequest("GET", "/authz/explain?action=task.delete&task_id="+data.TaskID, nil)
+
+		serve(ctx, suite.controller.ExplainAccess)
+		expected, err := json.Marshal(decision)
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase where the action is missing.
+	suite.Run("ExplainAccess_MissingAction", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Set("claims", mocks.GetClaims())
+
+		ctx.Request = httptest.NewRequest("GET", "/authz/explain", nil)
+
+		serve(ctx, suite.controller.ExplainAccess)
+
+		suite.Equal(400, w.Code)
+	})
+}
+
+// A function that runs the RoleControllerTestSuite.
+func Test_RoleControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(RoleControllerTestSuite))
+}
@@ -1,30 +1,53 @@
 package controllers
 
 import (
-	"log"
+	"errors"
+	"mime"
 	"net/http"
+	"strconv"
 	"task_manager/domain"
+	"task_manager/infrastructure"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// The room left in the body of an upload for the headers of the multipart form, beyond the size of the file.
+const multipartOverhead = 64 << 10
+
 // A struct that handles task operations by calling the usecase methods.
+// The body of an upload is limited to maxUploadSize and the room of the multipart form, so that a file far larger
+// than the limit of the usecase is refused before it is read.
 type TaskController struct {
-	usecase domain.TaskUsecase
+	usecase       domain.TaskUsecase
+	maxUploadSize int64
 }
 
 // A constructor that creates a new instance of TaskController.
-func NewTaskController(usecase domain.TaskUsecase) *TaskController {
-	return &TaskController{usecase: usecase}
+func NewTaskController(usecase domain.TaskUsecase, maxUploadSize int64) *TaskController {
+	return &TaskController{usecase: usecase, maxUploadSize: maxUploadSize}
 }
 
-// A handler function that returns all tasks.
+// A handler function that returns the tasks, optionally filtered by workspace and project.
 func (tc *TaskController) GetTasks(ctx *gin.Context) {
-	tasks, _err := tc.usecase.GetTasks()
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the query parameters to the struct.
+	query := &domain.TaskQuery{}
+	err := ctx.ShouldBindQuery(query)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	// Use the workspace of the path for nested routes.
+	if workspaceID, ok := ctx.Get("workspace_id"); ok {
+		query.WorkspaceID = workspaceID.(primitive.ObjectID).Hex()
+	}
+
+	tasks, _err := tc.usecase.GetTasks(ctx, query, claims)
 	if _err != nil {
-		log.Println(_err.Err)
-		ctx.JSON(_err.StatusCode, gin.H{"error": _err.Message})
+		ctx.Error(_err)
 		return
 	}
 
@@ -36,14 +59,15 @@ func (tc *TaskController) GetTasks(ctx *gin.Context) {
 
 // A handler function that returns a task with the given ID.
 func (tc *TaskController) GetTaskByID(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
 	// Get the task ID from the context.
 	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
 
 	// Get the task using the TaskUsecase.
-	task, _err := tc.usecase.GetTaskByID(taskID)
+	task, _err := tc.usecase.GetTaskByID(ctx, taskID, claims)
 	if _err != nil {
-		log.Println(_err.Err)
-		ctx.JSON(_err.StatusCode, gin.H{"error": _err.Message})
+		ctx.Error(_err)
 		return
 	}
 
@@ -56,9 +80,9 @@ func (tc *TaskController) CreateTask(ctx *gin.Context) {
 	taskData := &domain.CreateTaskData{}
 
 	// Bind the request body to the struct.
-	err := ctx.BindJSON(taskData)
+	err := ctx.ShouldBindJSON(taskData)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		ctx.Error(invalidRequest(err))
 		return
 	}
 
@@ -67,17 +91,17 @@ func (tc *TaskController) CreateTask(ctx *gin.Context) {
 		taskData.Status = "Pending"
 	}
 
-	// If the status field is present, check if it is one of the allowed values.
-	if taskData.Status != "Pending" && taskData.Status != "Completed" && taskData.Status != "In Progress" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "status field must be one of: Pending, Completed, In Progress"})
+	// Check the task data against the validation rules.
+	_err := infrastructure.Validate(taskData)
+	if _err != nil {
+		ctx.Error(_err)
 		return
 	}
 
 	// Create the task using the TaskUsecase.
-	taskView, _err := tc.usecase.CreateTask(taskData, claims)
+	taskView, _err := tc.usecase.CreateTask(ctx, taskData, claims)
 	if _err != nil {
-		log.Println(_err.Err)
-		ctx.JSON(_err.StatusCode, gin.H{"error": _err.Message})
+		ctx.Error(_err)
 		return
 	}
 
@@ -92,23 +116,23 @@ func (tc *TaskController) UpdateTaskPut(ctx *gin.Context) {
 
 	// Bind the request body to the struct.
 	taskData := &domain.ReplaceTaskData{}
-	err := ctx.BindJSON(taskData)
+	err := ctx.ShouldBindJSON(taskData)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		ctx.Error(invalidRequest(err))
 		return
 	}
 
-	// Check if the status field is one of the allowed values.
-	if taskData.Status != "Pending" && taskData.Status != "Completed" && taskData.Status != "In Progress" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "status field must be one of: Pending, Completed, In Progress"})
+	// Check the task data against the validation rules.
+	_err := infrastructure.Validate(taskData)
+	if _err != nil {
+		ctx.Error(_err)
 		return
 	}
 
 	// Replace the task using the TaskUsecase.
-	task, _err := tc.usecase.ReplaceTask(taskID, taskData, claims)
+	task, _err := tc.usecase.ReplaceTask(ctx, taskID, taskData, claims)
 	if _err != nil {
-		log.Println(_err.Err)
-		ctx.JSON(_err.StatusCode, gin.H{"error": _err.Message})
+		ctx.Error(_err)
 		return
 	}
 
@@ -123,23 +147,23 @@ func (tc *TaskController) UpdateTaskPatch(ctx *gin.Context) {
 
 	// Bind the request body to the struct.
 	taskData := &domain.UpdateTaskData{}
-	err := ctx.BindJSON(taskData)
+	err := ctx.ShouldBindJSON(taskData)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		ctx.Error(invalidRequest(err))
 		return
 	}
 
-	// Check if the status field is one of the allowed values.
-	if taskData.Status != "" && taskData.Status != "Pending" && taskData.Status != "Completed" && taskData.Status != "In Progress" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "status field must be one of: Pending, Completed, In Progress"})
+	// Check the task data against the validation rules.
+	_err := infrastructure.Validate(taskData)
+	if _err != nil {
+		ctx.Error(_err)
 		return
 	}
 
 	// Update the task using the TaskUsecase.
-	task, _err := tc.usecase.UpdateTask(taskID, taskData, claims)
+	task, _err := tc.usecase.UpdateTask(ctx, taskID, taskData, claims)
 	if _err != nil {
-		log.Println(_err.Err)
-		ctx.JSON(_err.StatusCode, gin.H{"error": _err.Message})
+		ctx.Error(_err)
 		return
 	}
 
@@ -153,10 +177,252 @@ func (tc *TaskController) DeleteTask(ctx *gin.Context) {
 	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
 
 	// Delete the task using the TaskUsecase.
-	_err := tc.usecase.DeleteTask(taskID, claims)
+	_err := tc.usecase.DeleteTask(ctx, taskID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// A handler function that returns the tasks that block a task and the tasks it blocks.
+func (tc *TaskController) GetDependencies(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
+
+	dependencies, _err := tc.usecase.GetDependencies(ctx, taskID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dependencies)
+}
+
+// A handler function that makes a task blocked by another task.
+func (tc *TaskController) AddDependency(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
+	blockerID := ctx.MustGet("blocker_id").(primitive.ObjectID)
+
+	dependencies, _err := tc.usecase.AddDependency(ctx, taskID, blockerID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dependencies)
+}
+
+// A handler function that removes a dependency between two tasks.
+func (tc *TaskController) RemoveDependency(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
+	blockerID := ctx.MustGet("blocker_id").(primitive.ObjectID)
+
+	_err := tc.usecase.RemoveDependency(ctx, taskID, blockerID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// A handler function that returns an order in which the open tasks of a workspace can be completed, optionally
+// limited to a project, with their critical path.
+func (tc *TaskController) GetTaskPlan(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the query parameters to the struct.
+	query := &domain.TaskQuery{}
+	err := ctx.ShouldBindQuery(query)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	query.WorkspaceID = ctx.MustGet("workspace_id").(primitive.ObjectID).Hex()
+
+	plan, _err := tc.usecase.GetTaskPlan(ctx, query, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plan)
+}
+
+// A handler function that returns the tasks in a column per status, optionally filtered by workspace and project.
+func (tc *TaskController) GetBoard(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the query parameters to the struct.
+	query := &domain.TaskQuery{}
+	err := ctx.ShouldBindQuery(query)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	board, _err := tc.usecase.GetBoard(ctx, query, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, board)
+}
+
+// A handler function that returns the statistics of the tasks, optionally filtered by workspace, project and days.
+func (tc *TaskController) GetTaskStats(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the query parameters to the struct.
+	query := &domain.TaskStatsQuery{}
+	err := ctx.ShouldBindQuery(query)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	stats, _err := tc.usecase.GetTaskStats(ctx, query, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
+}
+
+// A handler function that moves a task to a status and a position on the board.
+func (tc *TaskController) MoveTask(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
+
+	// Bind the request body to the struct.
+	moveData := &domain.MoveTaskData{}
+	err := ctx.ShouldBindJSON(moveData)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	// Check the move against the validation rules.
+	_err := infrastructure.Validate(moveData)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	task, _err := tc.usecase.MoveTask(ctx, taskID, moveData, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, task)
+}
+
+// A handler function that returns the files attached to a task.
+func (tc *TaskController) GetAttachments(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
+
+	task, _err := tc.usecase.GetTaskByID(ctx, taskID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	attachments := task.Attachments
+	if attachments == nil {
+		attachments = []domain.Attachment{}
+	}
+
+	ctx.JSON(http.StatusOK, attachments)
+}
+
+// A handler function that attaches the file of the "file" field of a multipart form to a task.
+func (tc *TaskController) AddAttachment(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
+
+	// Read the file of the form, refusing a body larger than the limit before it is read.
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, tc.maxUploadSize+multipartOverhead)
+	header, err := ctx.FormFile("file")
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		ctx.Error(&domain.Error{
+			Err:        err,
+			StatusCode: http.StatusRequestEntityTooLarge,
+			Code:       domain.CodeAttachmentTooLarge,
+			Message:    "The file is larger than the limit of " + strconv.FormatInt(tc.maxUploadSize, 10) + " bytes",
+		})
+		return
+	}
+	if err != nil {
+		ctx.Error(&domain.Error{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "Invalid request",
+			Fields:     []domain.FieldError{{Field: "file", Reason: "is required"}},
+		})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ctx.Error(&domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		})
+		return
+	}
+	defer file.Close()
+
+	upload := &domain.AttachmentUpload{Filename: header.Filename, Size: header.Size, Content: file}
+	attachment, _err := tc.usecase.AddAttachment(ctx, taskID, upload, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, attachment)
+}
+
+// A handler function that downloads a file attached to a task. The file is always served as a download with the type
+// it was detected to have, so that browsers neither display it in place nor guess another type.
+func (tc *TaskController) GetAttachment(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
+	attachmentID := ctx.MustGet("attachment_id").(primitive.ObjectID)
+
+	attachment, content, _err := tc.usecase.GetAttachment(ctx, taskID, attachmentID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+	defer content.Close()
+
+	ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// A handler function that removes a file attached to a task.
+func (tc *TaskController) DeleteAttachment(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
+	attachmentID := ctx.MustGet("attachment_id").(primitive.ObjectID)
+
+	_err := tc.usecase.DeleteAttachment(ctx, taskID, attachmentID, claims)
 	if _err != nil {
-		log.Println(_err.Err)
-		ctx.JSON(_err.StatusCode, gin.H{"error": _err.Message})
+		ctx.Error(_err)
 		return
 	}
 
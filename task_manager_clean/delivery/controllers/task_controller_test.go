@@ -1,17 +1,23 @@
 package controllers_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
 	"strings"
 	"task_manager/delivery/controllers"
 	"task_manager/domain"
 	"task_manager/mocks"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -25,7 +31,7 @@ type TaskControllerTestSuite struct {
 // A method that initializes the TaskControllerTestSuite.
 func (suite *TaskControllerTestSuite) SetupSuite() {
 	suite.usecase = new(mocks.TaskUsecase)
-	suite.controller = controllers.NewTaskController(suite.usecase)
+	suite.controller = controllers.NewTaskController(suite.usecase, 1<<20)
 }
 
 // A method that closes the suite.
@@ -39,12 +45,14 @@ func (suite *TaskControllerTestSuite) TestGetTasks() {
 	suite.Run("Tasks", func() {
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
 		tasks := mocks.GetManyTasks()
-		suite.usecase.On("GetTasks").Return(tasks, nil).Once()
+		suite.usecase.On("GetTasks", mock.Anything, &domain.TaskQuery{}, claims).Return(tasks, nil).Once()
 
 		ctx.Request = httptest.NewRequest("GET", "/tasks", nil)
 
-		suite.controller.GetTasks(ctx)
+		serve(ctx, suite.controller.GetTasks)
 
 		expected, err := json.Marshal(gin.H{
 			"count": len(tasks),
@@ -56,11 +64,32 @@ func (suite *TaskControllerTestSuite) TestGetTasks() {
 		suite.Equal(string(expected), w.Body.String())
 	})
 
+	// A testcase when the tasks of a workspace are listed through the nested route.
+	suite.Run("WorkspaceTasks", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
+		workspaceID := mocks.GetWorkspace().ID
+		ctx.Set("workspace_id", workspaceID)
+		tasks := mocks.GetManyTasks()
+		query := &domain.TaskQuery{WorkspaceID: workspaceID.Hex(), ProjectID: mocks.GetProject().ID.Hex()}
+		suite.usecase.On("GetTasks", mock.Anything, query, claims).Return(tasks, nil).Once()
+
+		ctx.Request = httptest.NewRequest("GET", "/workspaces/"+workspaceID.Hex()+"/tasks?project_id="+query.ProjectID, nil)
+
+		serve(ctx, suite.controller.GetTasks)
+
+		suite.Equal(200, w.Code)
+	})
+
 	// A testcase when the usecase returns an error.
 	suite.Run("Error", func() {
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
-		suite.usecase.On("GetTasks").Return(nil, &domain.Error{
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
+		suite.usecase.On("GetTasks", mock.Anything, &domain.TaskQuery{}, claims).Return(nil, &domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal Server Error",
@@ -68,13 +97,12 @@ func (suite *TaskControllerTestSuite) TestGetTasks() {
 
 		ctx.Request = httptest.NewRequest("GET", "/tasks", nil)
 
-		suite.controller.GetTasks(ctx)
+		serve(ctx, suite.controller.GetTasks)
 
-		expected, err := json.Marshal(gin.H{"error": "Internal Server Error"})
-		suite.Nil(err)
+		expected := problem(500, domain.CodeInternal, "Internal Server Error")
 
 		suite.Equal(500, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 }
 
@@ -84,13 +112,16 @@ func (suite *TaskControllerTestSuite) TestGetTaskByID() {
 	suite.Run("TaskFound", func() {
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
 
 		taskID := mocks.GetPrimitiveID1()
 		task := mocks.GetNewTask()
-		suite.usecase.On("GetTaskByID", taskID).Return(task, nil).Once()
+		suite.usecase.On("GetTaskByID", mock.Anything, taskID, claims).Return(task, nil).Once()
 		ctx.Set("task_id", taskID)
+		ctx.Request = httptest.NewRequest("GET", "/tasks/"+taskID.Hex(), nil)
 
-		suite.controller.GetTaskByID(ctx)
+		serve(ctx, suite.controller.GetTaskByID)
 
 		expected, err := json.Marshal(task)
 		suite.Nil(err)
@@ -103,20 +134,25 @@ func (suite *TaskControllerTestSuite) TestGetTaskByID() {
 	suite.Run("TaskNotFound", func() {
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
 		taskID := mocks.GetPrimitiveID1()
-		suite.usecase.On("GetTaskByID", taskID).Return(nil, &domain.Error{
+		suite.usecase.On("GetTaskByID", mock.Anything, taskID, claims).Return(nil, &domain.Error{
 			Err:        errors.New("task not found"),
 			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeTaskNotFound,
 			Message:    "Task Not Found",
 		}).Once()
 		ctx.Set("task_id", taskID)
+		ctx.Request = httptest.NewRequest("GET", "/tasks/"+taskID.Hex(), nil)
 
-		suite.controller.GetTaskByID(ctx)
-		expected, err := json.Marshal(gin.H{"error": "Task Not Found"})
-		suite.Nil(err)
+		serve(ctx, suite.controller.GetTaskByID)
+		expected := problem(404, domain.CodeTaskNotFound, "Task Not Found")
 
 		suite.Equal(404, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(domain.ProblemContentType, w.Header().Get("Content-Type"))
+		suite.Equal(expected, w.Body.String())
+		suite.NotContains(w.Body.String(), "task not found")
 	})
 }
 
@@ -135,9 +171,9 @@ func (suite *TaskControllerTestSuite) TestCreateTask() {
 		suite.Nil(err)
 
 		ctx.Request = httptest.NewRequest("POST", "/tasks", strings.NewReader(string(body)))
-		suite.usecase.On("CreateTask", taskData, claims).Return(taskView, nil).Once()
+		suite.usecase.On("CreateTask", mock.Anything, taskData, claims).Return(taskView, nil).Once()
 
-		suite.controller.CreateTask(ctx)
+		serve(ctx, suite.controller.CreateTask)
 		expected, err := json.Marshal(taskView)
 		suite.Nil(err)
 
@@ -153,13 +189,33 @@ func (suite *TaskControllerTestSuite) TestCreateTask() {
 		ctx.Set("claims", claims)
 		ctx.Request = httptest.NewRequest("POST", "/tasks", nil)
 
-		suite.controller.CreateTask(ctx)
+		serve(ctx, suite.controller.CreateTask)
 
-		expected, err := json.Marshal(gin.H{"error": "Invalid request"})
-		suite.Nil(err)
+		expected := problem(400, domain.CodeInvalidRequest, "Invalid request")
 
 		suite.Equal(400, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
+	})
+
+	// A testcase when several fields are invalid, which are all reported at once.
+	suite.Run("InvalidFields", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Set("claims", mocks.GetClaims())
+		ctx.Request = httptest.NewRequest("POST", "/tasks", strings.NewReader(`{"title": "  ", "due_date": "2000-01-01T00:00:00Z", "status": "Done"}`))
+
+		serve(ctx, suite.controller.CreateTask)
+
+		expected := problem(400, domain.CodeValidationFailed,
+			"title must not be blank; due_date must not be in the past; status must be one of: Pending, Completed, In Progress; project_id is required",
+			domain.FieldError{Field: "title", Reason: "must not be blank"},
+			domain.FieldError{Field: "due_date", Reason: "must not be in the past"},
+			domain.FieldError{Field: "status", Reason: "must be one of: Pending, Completed, In Progress"},
+			domain.FieldError{Field: "project_id", Reason: "is required"},
+		)
+
+		suite.Equal(400, w.Code)
+		suite.Equal(expected, w.Body.String())
 	})
 
 	// A testcase when the status field is invalid.
@@ -176,13 +232,12 @@ func (suite *TaskControllerTestSuite) TestCreateTask() {
 
 		ctx.Request = httptest.NewRequest("POST", "/tasks", strings.NewReader(string(body)))
 
-		suite.controller.CreateTask(ctx)
+		serve(ctx, suite.controller.CreateTask)
 
-		expected, err := json.Marshal(gin.H{"error": "status field must be one of: Pending, Completed, In Progress"})
-		suite.Nil(err)
+		expected := problem(400, domain.CodeValidationFailed, "status must be one of: Pending, Completed, In Progress", domain.FieldError{Field: "status", Reason: "must be one of: Pending, Completed, In Progress"})
 
 		suite.Equal(400, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 
 	// A testcase when the usecase returns an error.
@@ -196,19 +251,18 @@ func (suite *TaskControllerTestSuite) TestCreateTask() {
 		suite.Nil(err)
 		ctx.Request = httptest.NewRequest("POST", "/tasks", strings.NewReader(string(body)))
 
-		suite.usecase.On("CreateTask", taskData, claims).Return(nil, &domain.Error{
+		suite.usecase.On("CreateTask", mock.Anything, taskData, claims).Return(nil, &domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal Server Error",
 		}).Once()
 
-		suite.controller.CreateTask(ctx)
+		serve(ctx, suite.controller.CreateTask)
 
-		expected, err := json.Marshal(gin.H{"error": "Internal Server Error"})
-		suite.Nil(err)
+		expected := problem(500, domain.CodeInternal, "Internal Server Error")
 
 		suite.Equal(500, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 
 	// A testcase when the status field is missing.
@@ -226,9 +280,9 @@ func (suite *TaskControllerTestSuite) TestCreateTask() {
 
 		taskData.Status = "Pending"
 		taskView := mocks.GetView(taskData, claims)
-		suite.usecase.On("CreateTask", taskData, claims).Return(taskView, nil).Once()
+		suite.usecase.On("CreateTask", mock.Anything, taskData, claims).Return(taskView, nil).Once()
 
-		suite.controller.CreateTask(ctx)
+		serve(ctx, suite.controller.CreateTask)
 
 		expected, err := json.Marshal(taskView)
 		suite.Nil(err)
@@ -254,9 +308,9 @@ func (suite *TaskControllerTestSuite) TestUpdateTaskPut() {
 		body, err := json.Marshal(taskData)
 		suite.Nil(err)
 		ctx.Request = httptest.NewRequest("PUT", "/tasks/"+taskID.Hex(), strings.NewReader(string(body)))
-		suite.usecase.On("ReplaceTask", taskID, taskData, claims).Return(taskView, nil).Once()
+		suite.usecase.On("ReplaceTask", mock.Anything, taskID, taskData, claims).Return(taskView, nil).Once()
 
-		suite.controller.UpdateTaskPut(ctx)
+		serve(ctx, suite.controller.UpdateTaskPut)
 		expected, err := json.Marshal(taskView)
 		suite.Nil(err)
 
@@ -276,13 +330,12 @@ func (suite *TaskControllerTestSuite) TestUpdateTaskPut() {
 
 		ctx.Request = httptest.NewRequest("PUT", "/tasks/"+taskID.Hex(), nil)
 
-		suite.controller.UpdateTaskPut(ctx)
+		serve(ctx, suite.controller.UpdateTaskPut)
 
-		expected, err := json.Marshal(gin.H{"error": "Invalid request"})
-		suite.Nil(err)
+		expected := problem(400, domain.CodeInvalidRequest, "Invalid request")
 
 		suite.Equal(400, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 
 	// A testcase when the status field is invalid.
@@ -300,13 +353,12 @@ func (suite *TaskControllerTestSuite) TestUpdateTaskPut() {
 		suite.Nil(err)
 		ctx.Request = httptest.NewRequest("PUT", "/tasks/"+taskID.Hex(), strings.NewReader(string(body)))
 
-		suite.controller.UpdateTaskPut(ctx)
+		serve(ctx, suite.controller.UpdateTaskPut)
 
-		expected, err := json.Marshal(gin.H{"error": "status field must be one of: Pending, Completed, In Progress"})
-		suite.Nil(err)
+		expected := problem(400, domain.CodeValidationFailed, "status must be one of: Pending, Completed, In Progress", domain.FieldError{Field: "status", Reason: "must be one of: Pending, Completed, In Progress"})
 
 		suite.Equal(400, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 
 	// A testcase when the usecase returns an error.
@@ -323,19 +375,18 @@ func (suite *TaskControllerTestSuite) TestUpdateTaskPut() {
 		suite.Nil(err)
 		ctx.Request = httptest.NewRequest("PUT", "/tasks/"+taskID.Hex(), strings.NewReader(string(body)))
 
-		suite.usecase.On("ReplaceTask", taskID, taskData, claims).Return(nil, &domain.Error{
+		suite.usecase.On("ReplaceTask", mock.Anything, taskID, taskData, claims).Return(nil, &domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal Server Error",
 		}).Once()
 
-		suite.controller.UpdateTaskPut(ctx)
+		serve(ctx, suite.controller.UpdateTaskPut)
 
-		expected, err := json.Marshal(gin.H{"error": "Internal Server Error"})
-		suite.Nil(err)
+		expected := problem(500, domain.CodeInternal, "Internal Server Error")
 
 		suite.Equal(500, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 }
 
@@ -355,9 +406,9 @@ func (suite *TaskControllerTestSuite) TestUpdateTaskPatch() {
 		body, err := json.Marshal(taskData)
 		suite.Nil(err)
 		ctx.Request = httptest.NewRequest("PATCH", "/tasks/"+taskID.Hex(), strings.NewReader(string(body)))
-		suite.usecase.On("UpdateTask", taskID, taskData, claims).Return(taskView, nil).Once()
+		suite.usecase.On("UpdateTask", mock.Anything, taskID, taskData, claims).Return(taskView, nil).Once()
 
-		suite.controller.UpdateTaskPatch(ctx)
+		serve(ctx, suite.controller.UpdateTaskPatch)
 		expected, err := json.Marshal(taskView)
 		suite.Nil(err)
 
@@ -377,13 +428,12 @@ func (suite *TaskControllerTestSuite) TestUpdateTaskPatch() {
 
 		ctx.Request = httptest.NewRequest("PATCH", "/tasks/"+taskID.Hex(), nil)
 
-		suite.controller.UpdateTaskPatch(ctx)
+		serve(ctx, suite.controller.UpdateTaskPatch)
 
-		expected, err := json.Marshal(gin.H{"error": "Invalid request"})
-		suite.Nil(err)
+		expected := problem(400, domain.CodeInvalidRequest, "Invalid request")
 
 		suite.Equal(400, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 
 	// A testcase when the status field is invalid.
@@ -401,13 +451,12 @@ func (suite *TaskControllerTestSuite) TestUpdateTaskPatch() {
 		suite.Nil(err)
 		ctx.Request = httptest.NewRequest("PATCH", "/tasks/"+taskID.Hex(), strings.NewReader(string(body)))
 
-		suite.controller.UpdateTaskPatch(ctx)
+		serve(ctx, suite.controller.UpdateTaskPatch)
 
-		expected, err := json.Marshal(gin.H{"error": "status field must be one of: Pending, Completed, In Progress"})
-		suite.Nil(err)
+		expected := problem(400, domain.CodeValidationFailed, "status must be one of: Pending, Completed, In Progress", domain.FieldError{Field: "status", Reason: "must be one of: Pending, Completed, In Progress"})
 
 		suite.Equal(400, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 
 	// A testcase when the usecase returns an error.
@@ -424,19 +473,18 @@ func (suite *TaskControllerTestSuite) TestUpdateTaskPatch() {
 		suite.Nil(err)
 		ctx.Request = httptest.NewRequest("PATCH", "/tasks/"+taskID.Hex(), strings.NewReader(string(body)))
 
-		suite.usecase.On("UpdateTask", taskID, taskData, claims).Return(nil, &domain.Error{
+		suite.usecase.On("UpdateTask", mock.Anything, taskID, taskData, claims).Return(nil, &domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal Server Error",
 		}).Once()
 
-		suite.controller.UpdateTaskPatch(ctx)
+		serve(ctx, suite.controller.UpdateTaskPatch)
 
-		expected, err := json.Marshal(gin.H{"error": "Internal Server Error"})
-		suite.Nil(err)
+		expected := problem(500, domain.CodeInternal, "Internal Server Error")
 
 		suite.Equal(500, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 }
 
@@ -450,10 +498,11 @@ func (suite *TaskControllerTestSuite) TestDeleteTask() {
 		claims := mocks.GetClaims()
 		ctx.Set("claims", claims)
 		ctx.Set("task_id", taskID)
+		ctx.Request = httptest.NewRequest("DELETE", "/tasks/"+taskID.Hex(), nil)
 
-		suite.usecase.On("DeleteTask", taskID, claims).Return(nil).Once()
+		suite.usecase.On("DeleteTask", mock.Anything, taskID, claims).Return(nil).Once()
 
-		suite.controller.DeleteTask(ctx)
+		serve(ctx, suite.controller.DeleteTask)
 
 		suite.Equal(204, w.Code)
 		suite.Empty(w.Body.String())
@@ -468,23 +517,345 @@ func (suite *TaskControllerTestSuite) TestDeleteTask() {
 		claims := mocks.GetClaims()
 		ctx.Set("claims", claims)
 		ctx.Set("task_id", taskID)
+		ctx.Request = httptest.NewRequest("DELETE", "/tasks/"+taskID.Hex(), nil)
 
-		suite.usecase.On("DeleteTask", taskID, claims).Return(&domain.Error{
+		suite.usecase.On("DeleteTask", mock.Anything, taskID, claims).Return(&domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal Server Error",
 		}).Once()
 
-		suite.controller.DeleteTask(ctx)
+		serve(ctx, suite.controller.DeleteTask)
 
-		expected, err := json.Marshal(gin.H{"error": "Internal Server Error"})
-		suite.Nil(err)
+		expected := problem(500, domain.CodeInternal, "Internal Server Error")
 
 		suite.Equal(500, w.Code)
+		suite.Equal(expected, w.Body.String())
+	})
+}
+
+// A test for the TaskController.AddDependency method.
+func (suite *TaskControllerTestSuite) TestAddDependency() {
+	// A testcase when the task is blocked by another task.
+	suite.Run("Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		taskID := mocks.GetPrimitiveID1()
+		blocker := mocks.GetNewTask2()
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
+		ctx.Set("task_id", taskID)
+		ctx.Set("blocker_id", blocker.ID)
+		ctx.Request = httptest.NewRequest("PUT", "/tasks/"+taskID.Hex()+"/dependencies/"+blocker.ID.Hex(), nil)
+
+		dependencies := &domain.TaskDependencies{BlockedBy: []domain.Task{*blocker}, Blocking: []domain.Task{}}
+		suite.usecase.On("AddDependency", mock.Anything, taskID, blocker.ID, claims).Return(dependencies, nil).Once()
+
+		serve(ctx, suite.controller.AddDependency)
+
+		expected, err := json.Marshal(dependencies)
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase when the dependency would create a cycle.
+	suite.Run("Cycle", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		taskID := mocks.GetPrimitiveID1()
+		blockerID := mocks.GetPrimitiveID2()
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
+		ctx.Set("task_id", taskID)
+		ctx.Set("blocker_id", blockerID)
+		ctx.Request = httptest.NewRequest("PUT", "/tasks/"+taskID.Hex()+"/dependencies/"+blockerID.Hex(), nil)
+
+		suite.usecase.On("AddDependency", mock.Anything, taskID, blockerID, claims).Return(nil, &domain.Error{
+			Err:        errors.New("the blocker depends on the task"),
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeDependencyCycle,
+			Message:    "The dependency would create a cycle",
+		}).Once()
+
+		serve(ctx, suite.controller.AddDependency)
+
+		suite.Equal(409, w.Code)
+		suite.Equal(problem(409, domain.CodeDependencyCycle, "The dependency would create a cycle"), w.Body.String())
+	})
+}
+
+// A test for the TaskController.GetTaskPlan method.
+func (suite *TaskControllerTestSuite) TestGetTaskPlan() {
+	// A testcase when the plan of a project is returned.
+	suite.Run("Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		workspaceID := mocks.GetWorkspace().ID
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
+		ctx.Set("workspace_id", workspaceID)
+		query := &domain.TaskQuery{WorkspaceID: workspaceID.Hex(), ProjectID: mocks.GetProject().ID.Hex(), Sort: domain.TaskSortPriority}
+		ctx.Request = httptest.NewRequest("GET", "/workspaces/"+workspaceID.Hex()+"/plan?project_id="+query.ProjectID+"&sort=priority", nil)
+
+		tasks := mocks.GetManyTasks()
+		plan := &domain.TaskPlan{Order: tasks, CriticalPath: tasks[:1]}
+		suite.usecase.On("GetTaskPlan", mock.Anything, query, claims).Return(plan, nil).Once()
+
+		serve(ctx, suite.controller.GetTaskPlan)
+
+		expected, err := json.Marshal(plan)
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
 		suite.Equal(string(expected), w.Body.String())
 	})
 }
 
+// A test for the TaskController.MoveTask method.
+func (suite *TaskControllerTestSuite) TestMoveTask() {
+	// A testcase when the task is moved after another task.
+	suite.Run("Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		task := mocks.GetNewTask()
+		afterID := mocks.GetPrimitiveID3()
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
+		ctx.Set("task_id", task.ID)
+		ctx.Request = httptest.NewRequest("POST", "/tasks/"+task.ID.Hex()+"/move", bytes.NewReader([]byte(`{"status": "In Progress", "after_id": "`+afterID.Hex()+`"}`)))
+
+		moved := *task
+		moved.Rank = "ci"
+		suite.usecase.On("MoveTask", mock.Anything, task.ID, &domain.MoveTaskData{Status: "In Progress", AfterID: afterID}, claims).Return(&moved, nil).Once()
+
+		serve(ctx, suite.controller.MoveTask)
+
+		expected, err := json.Marshal(moved)
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase when the status is not a status of tasks.
+	suite.Run("InvalidStatus", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		taskID := mocks.GetPrimitiveID1()
+		ctx.Set("claims", mocks.GetClaims())
+		ctx.Set("task_id", taskID)
+		ctx.Request = httptest.NewRequest("POST", "/tasks/"+taskID.Hex()+"/move", bytes.NewReader([]byte(`{"status": "Done"}`)))
+
+		serve(ctx, suite.controller.MoveTask)
+
+		suite.Equal(400, w.Code)
+		suite.Contains(w.Body.String(), domain.CodeValidationFailed)
+	})
+}
+
+// A test for the TaskController.GetBoard method.
+func (suite *TaskControllerTestSuite) TestGetBoard() {
+	// A testcase when the board of a workspace is returned.
+	suite.Run("Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
+		query := &domain.TaskQuery{WorkspaceID: mocks.GetWorkspace().ID.Hex()}
+		ctx.Request = httptest.NewRequest("GET", "/board?workspace_id="+query.WorkspaceID, nil)
+
+		board := &domain.Board{Columns: []domain.BoardColumn{
+			{Status: "Pending", Count: 1, Tasks: []domain.Task{*mocks.GetNewTask()}},
+			{Status: "In Progress", WIPLimit: 3, Count: 0, Tasks: []domain.Task{}},
+			{Status: "Completed", Count: 1, Tasks: []domain.Task{*mocks.GetNewTask2()}},
+		}}
+		suite.usecase.On("GetBoard", mock.Anything, query, claims).Return(board, nil).Once()
+
+		serve(ctx, suite.controller.GetBoard)
+
+		expected, err := json.Marshal(board)
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+}
+
+// A test for the TaskController.GetTaskStats handler.
+func (suite *TaskControllerTestSuite) TestGetTaskStats() {
+	// A testcase when the statistics of a range of days are returned.
+	suite.Run("Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
+		from := time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 9, 15, 0, 0, 0, 0, time.UTC)
+		query := &domain.TaskStatsQuery{From: from, To: to}
+		ctx.Request = httptest.NewRequest("GET", "/stats?from=2024-09-02&to=2024-09-15", nil)
+
+		counts := domain.TaskCounts{
+			Total:                    2,
+			ByStatus:                 map[string]int{"Pending": 1, "In Progress": 0, "Completed": 1},
+			Overdue:                  1,
+			Completed:                1,
+			AverageCompletionSeconds: 3600,
+		}
+		stats := &domain.TaskStats{
+			From:             &from,
+			To:               &to,
+			TaskCounts:       counts,
+			CompletedPerWeek: []domain.WeeklyCompletions{{Week: "2024-09-09", Count: 1}},
+			Users:            []domain.UserTaskStats{{UserID: claims.ID, TaskCounts: counts}},
+		}
+		suite.usecase.On("GetTaskStats", mock.Anything, query, claims).Return(stats, nil).Once()
+
+		serve(ctx, suite.controller.GetTaskStats)
+
+		expected, err := json.Marshal(stats)
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase when a day of the range is not a date.
+	suite.Run("InvalidDate", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		ctx.Set("claims", mocks.GetClaims())
+		ctx.Request = httptest.NewRequest("GET", "/stats?from=yesterday", nil)
+
+		serve(ctx, suite.controller.GetTaskStats)
+
+		expected := problem(400, domain.CodeInvalidRequest, "Invalid request")
+
+		suite.Equal(400, w.Code)
+		suite.Equal(expected, w.Body.String())
+	})
+}
+
+// A helper function that returns a multipart form with a file of the given type in its file field.
+func fileForm(filename, contentType string, content []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+	header.Set("Content-Type", contentType)
+	part, _ := writer.CreatePart(header)
+	part.Write(content)
+	writer.Close()
+
+	return body, writer.FormDataContentType()
+}
+
+// A test for the TaskController.AddAttachment method.
+func (suite *TaskControllerTestSuite) TestAddAttachment() {
+	// A testcase when a file is attached to the task.
+	suite.Run("Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		task := mocks.GetNewTask()
+		claims := mocks.GetClaims()
+		ctx.Set("claims", claims)
+		ctx.Set("task_id", task.ID)
+		body, contentType := fileForm("screenshot.png", "image/png", []byte("image"))
+		ctx.Request = httptest.NewRequest("POST", "/tasks/"+task.ID.Hex()+"/attachments", body)
+		ctx.Request.Header.Set("Content-Type", contentType)
+
+		attachment := &domain.Attachment{ID: mocks.GetPrimitiveID3(), Filename: "screenshot.png", ContentType: "image/png", Size: 5, UserID: claims.ID}
+		suite.usecase.On("AddAttachment", mock.Anything, task.ID, mock.MatchedBy(func(upload *domain.AttachmentUpload) bool {
+			return upload.Filename == "screenshot.png" && upload.Size == 5
+		}), claims).Return(attachment, nil).Once()
+
+		serve(ctx, suite.controller.AddAttachment)
+
+		expected, err := json.Marshal(attachment)
+		suite.Nil(err)
+
+		suite.Equal(201, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase when the form has no file.
+	suite.Run("MissingFile", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		taskID := mocks.GetPrimitiveID1()
+		ctx.Set("claims", mocks.GetClaims())
+		ctx.Set("task_id", taskID)
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("name", "screenshot.png")
+		writer.Close()
+		ctx.Request = httptest.NewRequest("POST", "/tasks/"+taskID.Hex()+"/attachments", body)
+		ctx.Request.Header.Set("Content-Type", writer.FormDataContentType())
+
+		serve(ctx, suite.controller.AddAttachment)
+
+		suite.Equal(400, w.Code)
+		suite.Equal(problem(400, domain.CodeValidationFailed, "Invalid request", domain.FieldError{Field: "file", Reason: "is required"}), w.Body.String())
+	})
+
+	// A testcase when the body is larger than the limit of the controller.
+	suite.Run("TooLarge", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		taskID := mocks.GetPrimitiveID1()
+		ctx.Set("claims", mocks.GetClaims())
+		ctx.Set("task_id", taskID)
+		body, contentType := fileForm("large.png", "image/png", make([]byte, 2<<20))
+		ctx.Request = httptest.NewRequest("POST", "/tasks/"+taskID.Hex()+"/attachments", body)
+		ctx.Request.Header.Set("Content-Type", contentType)
+
+		serve(ctx, suite.controller.AddAttachment)
+
+		suite.Equal(413, w.Code)
+		suite.Equal(problem(413, domain.CodeAttachmentTooLarge, "The file is larger than the limit of 1048576 bytes"), w.Body.String())
+	})
+}
+
+// A test for the TaskController.GetAttachment method.
+func (suite *TaskControllerTestSuite) TestGetAttachment() {
+	// A testcase when the file is downloaded.
+	suite.Run("Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		task := mocks.GetNewTask()
+		claims := mocks.GetClaims()
+		attachment := &domain.Attachment{ID: mocks.GetPrimitiveID3(), Filename: "screen shot.png", ContentType: "image/png", Size: 5, UserID: claims.ID}
+		ctx.Set("claims", claims)
+		ctx.Set("task_id", task.ID)
+		ctx.Set("attachment_id", attachment.ID)
+		ctx.Request = httptest.NewRequest("GET", "/tasks/"+task.ID.Hex()+"/attachments/"+attachment.ID.Hex(), nil)
+
+		suite.usecase.On("GetAttachment", mock.Anything, task.ID, attachment.ID, claims).Return(attachment, io.NopCloser(strings.NewReader("image")), nil).Once()
+
+		serve(ctx, suite.controller.GetAttachment)
+
+		suite.Equal(200, w.Code)
+		suite.Equal("image", w.Body.String())
+		suite.Equal("image/png", w.Header().Get("Content-Type"))
+		suite.Equal(`attachment; filename="screen shot.png"`, w.Header().Get("Content-Disposition"))
+		suite.Equal("nosniff", w.Header().Get("X-Content-Type-Options"))
+	})
+}
+
 // A function that runs the TaskControllerTestSuite.
 func Test_TaskControllerTest(t *testing.T) {
 	suite.Run(t, new(TaskControllerTestSuite))
@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A struct that handles timers, time entries and time reports by calling the usecase methods.
+type TimeEntryController struct {
+	usecase domain.TimeEntryUsecase
+}
+
+// A constructor that creates a new instance of TimeEntryController.
+func NewTimeEntryController(usecase domain.TimeEntryUsecase) *TimeEntryController {
+	return &TimeEntryController{usecase: usecase}
+}
+
+// A handler function that starts a timer on a task. The body is optional.
+func (tc *TimeEntryController) StartTimer(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
+
+	// Bind the request body to the struct, if there is one.
+	timerData := &domain.StartTimerData{}
+	err := ctx.ShouldBindJSON(timerData)
+	if err != nil && !errors.Is(err, io.EOF) {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	// Check the timer data against the validation rules.
+	_err := infrastructure.Validate(timerData)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	entry, _err := tc.usecase.StartTimer(ctx, taskID, timerData, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entry)
+}
+
+// A handler function that stops the timer of the logged in user on a task.
+func (tc *TimeEntryController) StopTimer(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
+
+	entry, _err := tc.usecase.StopTimer(ctx, taskID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+// A handler function that returns the running timer of the logged in user.
+func (tc *TimeEntryController) GetRunningTimer(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	entry, _err := tc.usecase.GetRunningTimer(ctx, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+// A handler function that logs a time entry on a task manually.
+func (tc *TimeEntryController) AddTimeEntry(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
+
+	// Bind the request body to the struct.
+	entryData := &domain.TimeEntryData{}
+	err := ctx.ShouldBindJSON(entryData)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	// Check the entry data against the validation rules.
+	_err := infrastructure.Validate(entryData)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	entry, _err := tc.usecase.AddTimeEntry(ctx, taskID, entryData, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entry)
+}
+
+// A handler function that returns the time entries of a task with the time spent on it.
+func (tc *TimeEntryController) GetTimeEntries(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
+
+	entries, _err := tc.usecase.GetTimeEntries(ctx, taskID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"count":         len(entries),
+		"total_seconds": domain.TimeSpent(entries),
+		"time_entries":  entries,
+	})
+}
+
+// A handler function that deletes a time entry of a task.
+func (tc *TimeEntryController) DeleteTimeEntry(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	taskID := ctx.MustGet("task_id").(primitive.ObjectID)
+	entryID := ctx.MustGet("entry_id").(primitive.ObjectID)
+
+	_err := tc.usecase.DeleteTimeEntry(ctx, taskID, entryID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// A handler function that returns the time spent per user, task, tag or day. The report is written as CSV if the
+// format parameter or the Accept header asks for it, and as JSON otherwise.
+func (tc *TimeEntryController) GetTimeReport(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the query parameters to the struct.
+	query := &domain.TimeReportQuery{}
+	err := ctx.ShouldBindQuery(query)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	// Check the query against the validation rules.
+	_err := infrastructure.Validate(query)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	report, _err := tc.usecase.GetTimeReport(ctx, query, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	if query.Format == "csv" || (query.Format == "" && strings.Contains(ctx.GetHeader("Accept"), "text/csv")) {
+		writeTimeReportCSV(ctx, report)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// A helper function that writes a time report as CSV, with a row per group followed by the total.
+func writeTimeReportCSV(ctx *gin.Context, report *domain.TimeReport) {
+	ctx.Header("Content-Disposition", `attachment; filename="time-report.csv"`)
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Header().Set("Content-Type", "text/csv; charset=utf-8")
+
+	writer := csv.NewWriter(ctx.Writer)
+	writer.Write([]string{"key", "label", "entries", "seconds", "hours"})
+	for _, row := range report.Rows {
+		writer.Write([]string{csvCell(row.Key), csvCell(row.Label), strconv.Itoa(row.Entries), strconv.FormatInt(row.Seconds, 10), hours(row.Seconds)})
+	}
+	writer.Write([]string{"total", "", strconv.Itoa(report.Entries), strconv.FormatInt(report.TotalSeconds, 10), hours(report.TotalSeconds)})
+	writer.Flush()
+}
+
+// A helper function that prefixes the cells that spreadsheets would evaluate as formulas, since labels such as
+// task titles and tags are written by users.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// A helper function that formats a number of seconds as hours with two decimals.
+func hours(seconds int64) string {
+	return strconv.FormatFloat(float64(seconds)/3600, 'f', 2, 64)
+}
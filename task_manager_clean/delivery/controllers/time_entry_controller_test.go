@@ -0,0 +1,191 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"task_manager/delivery/controllers"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite to test the TimeEntryController.
+type TimeEntryControllerTestSuite struct {
+	suite.Suite
+	controller  *controllers.TimeEntryController
+	mockUsecase *mocks.TimeEntryUsecase
+}
+
+// A method that initializes the TimeEntryControllerTestSuite.
+func (suite *TimeEntryControllerTestSuite) SetupSuite() {
+	suite.mockUsecase = new(mocks.TimeEntryUsecase)
+	suite.controller = controllers.NewTimeEntryController(suite.mockUsecase)
+}
+
+// A method that cleans up the TimeEntryControllerTestSuite.
+func (suite *TimeEntryControllerTestSuite) TearDownSuite() {
+	suite.mockUsecase.AssertExpectations(suite.T())
+}
+
+// A test for the TimeEntryController.StartTimer method.
+func (suite *TimeEntryControllerTestSuite) TestStartTimer() {
+	// A testcase where a timer is started without a body.
+	suite.Run("StartTimer_NoBody", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		entry := mocks.GetRunningTimeEntry()
+		suite.mockUsecase.On("StartTimer", mock.Anything, entry.TaskID, &domain.StartTimerData{}, claims).Return(entry, nil).Once()
+		ctx.Set("claims", claims)
+		ctx.Set("task_id", entry.TaskID)
+
+		ctx.Request = httptest.NewRequest("POST", "/tasks/"+entry.TaskID.Hex()+"/timer/start", nil)
+
+		serve(ctx, suite.controller.StartTimer)
+		expected, err := json.Marshal(entry)
+		suite.Nil(err)
+
+		suite.Equal(201, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase where a tag is blank.
+	suite.Run("StartTimer_InvalidTag", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Set("claims", mocks.GetClaims())
+		ctx.Set("task_id", mocks.GetPrimitiveID1())
+
+		ctx.Request = httptest.NewRequest("POST", "/tasks/"+mocks.GetPrimitiveID1().Hex()+"/timer/start", bytes.NewReader([]byte(`{"tags": [" "]}`)))
+
+		serve(ctx, suite.controller.StartTimer)
+
+		suite.Equal(400, w.Code)
+		suite.Contains(w.Body.String(), domain.CodeValidationFailed)
+	})
+}
+
+// A test for the TimeEntryController.AddTimeEntry method.
+func (suite *TimeEntryControllerTestSuite) TestAddTimeEntry() {
+	// A testcase where the end of the entry is missing.
+	suite.Run("AddTimeEntry_MissingEnd", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Set("claims", mocks.GetClaims())
+		ctx.Set("task_id", mocks.GetPrimitiveID1())
+
+		ctx.Request = httptest.NewRequest("POST", "/tasks/"+mocks.GetPrimitiveID1().Hex()+"/time-entries", bytes.NewReader([]byte(`{"started_at": "2024-09-02T09:00:00Z"}`)))
+
+		serve(ctx, suite.controller.AddTimeEntry)
+
+		suite.Equal(400, w.Code)
+		suite.Contains(w.Body.String(), "ended_at")
+	})
+}
+
+// A test for the TimeEntryController.GetTimeEntries method.
+func (suite *TimeEntryControllerTestSuite) TestGetTimeEntries() {
+	// A testcase where the entries are returned with the total of the stopped ones.
+	suite.Run("GetTimeEntries_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		entries := []domain.TimeEntry{*mocks.GetTimeEntry(claims.ID, nil, 1800), *mocks.GetRunningTimeEntry()}
+		suite.mockUsecase.On("GetTimeEntries", mock.Anything, mocks.GetPrimitiveID1(), claims).Return(entries, nil).Once()
+		ctx.Set("claims", claims)
+		ctx.Set("task_id", mocks.GetPrimitiveID1())
+
+		ctx.Request = httptest.NewRequest("GET", "/tasks/"+mocks.GetPrimitiveID1().Hex()+"/time-entries", nil)
+
+		serve(ctx, suite.controller.GetTimeEntries)
+		expected, err := json.Marshal(gin.H{"count": 2, "total_seconds": 1800, "time_entries": entries})
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+}
+
+// A test for the TimeEntryController.GetTimeReport method.
+func (suite *TimeEntryControllerTestSuite) TestGetTimeReport() {
+	// A testcase where the report is downloaded as CSV, with the labels that look like formulas escaped.
+	suite.Run("GetTimeReport_CSV", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		report := &domain.TimeReport{
+			GroupBy:      domain.TimeReportByTask,
+			Entries:      3,
+			TotalSeconds: 9000,
+			Rows: []domain.TimeReportRow{
+				{Key: "66c1f0d2a4b5c6d7e8f90123", Label: "=SUM(A1:A2)", Entries: 1, Seconds: 3600},
+				{Key: "66c1f0d2a4b5c6d7e8f90124", Label: "Release, v2", Entries: 2, Seconds: 5400},
+			},
+		}
+		query := &domain.TimeReportQuery{GroupBy: domain.TimeReportByTask}
+		suite.mockUsecase.On("GetTimeReport", mock.Anything, query, claims).Return(report, nil).Once()
+		ctx.Set("claims", claims)
+
+		ctx.Request = httptest.NewRequest("GET", "/reports/time?group_by=task", nil)
+		ctx.Request.Header.Set("Accept", "text/csv")
+
+		serve(ctx, suite.controller.GetTimeReport)
+
+		suite.Equal(200, w.Code)
+		suite.Equal("text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		suite.Equal("key,label,entries,seconds,hours\n"+
+			"66c1f0d2a4b5c6d7e8f90123,'=SUM(A1:A2),1,3600,1.00\n"+
+			"66c1f0d2a4b5c6d7e8f90124,\"Release, v2\",2,5400,1.50\n"+
+			"total,,3,9000,2.50\n", w.Body.String())
+	})
+
+	// A testcase where the grouping is not supported.
+	suite.Run("GetTimeReport_InvalidGroup", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Set("claims", mocks.GetClaims())
+
+		ctx.Request = httptest.NewRequest("GET", "/reports/time?group_by=project", nil)
+
+		serve(ctx, suite.controller.GetTimeReport)
+
+		suite.Equal(http.StatusBadRequest, w.Code)
+		suite.Contains(w.Body.String(), "group_by")
+	})
+}
+
+// A test for the TimeEntryController.DeleteTimeEntry method.
+func (suite *TimeEntryControllerTestSuite) TestDeleteTimeEntry() {
+	// A testcase where the entry is deleted.
+	suite.Run("DeleteTimeEntry_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		entry := mocks.GetTimeEntry(claims.ID, nil, 60)
+		suite.mockUsecase.On("DeleteTimeEntry", mock.Anything, entry.TaskID, entry.ID, claims).Return(nil).Once()
+		ctx.Set("claims", claims)
+		ctx.Set("task_id", entry.TaskID)
+		ctx.Set("entry_id", entry.ID)
+
+		ctx.Request = httptest.NewRequest("DELETE", "/tasks/"+entry.TaskID.Hex()+"/time-entries/"+entry.ID.Hex(), nil)
+
+		serve(ctx, suite.controller.DeleteTimeEntry)
+
+		suite.Equal(204, w.Code)
+	})
+}
+
+// A function that runs the TimeEntryControllerTestSuite.
+func TestTimeEntryControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(TimeEntryControllerTestSuite))
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"net/http"
+	"task_manager/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// A struct that handles two-factor authentication operations by calling the usecase methods.
+type TwoFactorController struct {
+	usecase domain.TwoFactorUsecase
+}
+
+// A constructor that creates a new instance of TwoFactorController.
+func NewTwoFactorController(usecase domain.TwoFactorUsecase) *TwoFactorController {
+	return &TwoFactorController{usecase: usecase}
+}
+
+// A handler function that starts the two-factor enrollment of the logged in user.
+func (tc *TwoFactorController) SetupTwoFactor(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	setup, _err := tc.usecase.SetupTwoFactor(ctx, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setup)
+}
+
+// A handler function that activates two-factor authentication for the logged in user.
+func (tc *TwoFactorController) ActivateTwoFactor(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the request body to the struct.
+	data := &domain.TwoFactorCodeData{}
+	err := ctx.ShouldBindJSON(data)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	codes, _err := tc.usecase.ActivateTwoFactor(ctx, data, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+// A handler function that disables two-factor authentication for the logged in user.
+func (tc *TwoFactorController) DisableTwoFactor(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the request body to the struct.
+	data := &domain.TwoFactorCodeData{}
+	err := ctx.ShouldBindJSON(data)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	_err := tc.usecase.DisableTwoFactor(ctx, data, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// A handler function that completes a two-factor login.
+func (tc *TwoFactorController) VerifyLogin(ctx *gin.Context) {
+	// Bind the request body to the struct.
+	data := &domain.TwoFactorLoginData{}
+	err := ctx.ShouldBindJSON(data)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	token, _err := tc.usecase.VerifyLogin(ctx, data)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+// A handler function that returns the security settings.
+func (tc *TwoFactorController) GetSecuritySettings(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	settings, _err := tc.usecase.GetSecuritySettings(ctx, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// A handler function that updates the security settings.
+func (tc *TwoFactorController) UpdateSecuritySettings(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the request body to the struct.
+	settings := &domain.SecuritySettings{}
+	err := ctx.ShouldBindJSON(settings)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	updated, _err := tc.usecase.UpdateSecuritySettings(ctx, settings, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, updated)
+}
@@ -0,0 +1,169 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"task_manager/delivery/controllers"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite to test the TwoFactorController.
+type TwoFactorControllerTestSuite struct {
+	suite.Suite
+	controller  *controllers.TwoFactorController
+	mockUsecase *mocks.TwoFactorUsecase
+}
+
+// A method that initializes the TwoFactorControllerTestSuite.
+func (suite *TwoFactorControllerTestSuite) SetupSuite() {
+	suite.mockUsecase = new(mocks.TwoFactorUsecase)
+	suite.controller = controllers.NewTwoFactorController(suite.mockUsecase)
+}
+
+// A method that cleans up the TwoFactorControllerTestSuite.
+func (suite *TwoFactorControllerTestSuite) TearDownSuite() {
+	suite.mockUsecase.AssertExpectations(suite.T())
+}
+
+// A test for the TwoFactorController.SetupTwoFactor method.
+func (suite *TwoFactorControllerTestSuite) TestSetupTwoFactor() {
+	// A testcase for a successful setup.
+	suite.Run("SetupTwoFactor_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		setup := &domain.TwoFactorSetup{Secret: "SECRET", OTPAuthURI: "otpauth://totp/Task%20Manager:user1?secret=SECRET"}
+		suite.mockUsecase.On("SetupTwoFactor", mock.Anything, claims).Return(setup, nil).Once()
+		ctx.Set("claims", claims)
+
+		serve(ctx, suite.controller.SetupTwoFactor)
+		expected, err := json.Marshal(setup)
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+}
+
+// A test for the TwoFactorController.ActivateTwoFactor method.
+func (suite *TwoFactorControllerTestSuite) TestActivateTwoFactor() {
+	// A testcase for a successful activation.
+	suite.Run("ActivateTwoFactor_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		data := &domain.TwoFactorCodeData{Code: "123456"}
+		codes := []string{"aaaa-bbbb", "cccc-dddd"}
+		suite.mockUsecase.On("ActivateTwoFactor", mock.Anything, data, claims).Return(codes, nil).Once()
+		ctx.Set("claims", claims)
+
+		body, err := json.Marshal(data)
+		suite.Nil(err)
+		ctx.Request = httptest.NewRequest("POST", "/me/2fa/activate", bytes.NewReader(body))
+
+		serve(ctx, suite.controller.ActivateTwoFactor)
+		expected, err := json.Marshal(gin.H{"recovery_codes": codes})
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase for an invalid request.
+	suite.Run("ActivateTwoFactor_InvalidRequest", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Set("claims", mocks.GetClaims())
+
+		ctx.Request = httptest.NewRequest("POST", "/me/2fa/activate", nil)
+
+		serve(ctx, suite.controller.ActivateTwoFactor)
+
+		suite.Equal(400, w.Code)
+	})
+}
+
+// A test for the TwoFactorController.VerifyLogin method.
+func (suite *TwoFactorControllerTestSuite) TestVerifyLogin() {
+	// A testcase for a successful two-factor login.
+	suite.Run("VerifyLogin_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		data := &domain.TwoFactorLoginData{MFAToken: "mfa.token", Code: "123456"}
+		suite.mockUsecase.On("VerifyLogin", mock.Anything, data).Return("full.token", nil).Once()
+
+		body, err := json.Marshal(data)
+		suite.Nil(err)
+		ctx.Request = httptest.NewRequest("POST", "/login/2fa", bytes.NewReader(body))
+
+		serve(ctx, suite.controller.VerifyLogin)
+		expected, err := json.Marshal(gin.H{"token": "full.token"})
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase for a wrong code.
+	suite.Run("VerifyLogin_InvalidCode", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		data := &domain.TwoFactorLoginData{MFAToken: "mfa.token", Code: "000000"}
+		suite.mockUsecase.On("VerifyLogin", mock.Anything, data).Return("", &domain.Error{
+			Err:        errors.New("invalid two-factor code"),
+			StatusCode: http.StatusUnauthorized,
+			Message:    "Invalid two-factor code",
+		}).Once()
+
+		body, err := json.Marshal(data)
+		suite.Nil(err)
+		ctx.Request = httptest.NewRequest("POST", "/login/2fa", bytes.NewReader(body))
+
+		serve(ctx, suite.controller.VerifyLogin)
+		expected := problem(401, domain.CodeUnauthorized, "Invalid two-factor code")
+
+		suite.Equal(401, w.Code)
+		suite.Equal(expected, w.Body.String())
+	})
+}
+
+// A test for the TwoFactorController.UpdateSecuritySettings method.
+func (suite *TwoFactorControllerTestSuite) TestUpdateSecuritySettings() {
+	// A testcase for a successful update.
+	suite.Run("UpdateSecuritySettings_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims3()
+		settings := &domain.SecuritySettings{RequireAdminTwoFactor: true}
+		suite.mockUsecase.On("UpdateSecuritySettings", mock.Anything, settings, claims).Return(settings, nil).Once()
+		ctx.Set("claims", claims)
+
+		body, err := json.Marshal(settings)
+		suite.Nil(err)
+		ctx.Request = httptest.NewRequest("PUT", "/settings/security", bytes.NewReader(body))
+
+		serve(ctx, suite.controller.UpdateSecuritySettings)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(body), w.Body.String())
+	})
+}
+
+// A function that runs the TwoFactorControllerTestSuite.
+func Test_TwoFactorControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorControllerTestSuite))
+}
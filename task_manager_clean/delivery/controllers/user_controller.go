@@ -1,9 +1,9 @@
 package controllers
 
 import (
-	"log"
 	"net/http"
 	"task_manager/domain"
+	"task_manager/infrastructure"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -24,17 +24,16 @@ func (uc *UserController) RegisterUser(ctx *gin.Context) {
 	newUser := &domain.AuthUserData{}
 
 	// Bind the request body to the newUser struct.
-	err := ctx.BindJSON(&newUser)
+	err := ctx.ShouldBindJSON(&newUser)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		ctx.Error(invalidRequest(err))
 		return
 	}
 
 	// Add the user to the database using the user usecase.
-	addedUser, _err := uc.usecase.RegisterUser(newUser)
+	addedUser, _err := uc.usecase.RegisterUser(ctx, newUser)
 	if _err != nil {
-		log.Println(_err.Err)
-		ctx.JSON(_err.StatusCode, gin.H{"error": _err.Message})
+		ctx.Error(_err)
 		return
 	}
 
@@ -46,21 +45,20 @@ func (uc *UserController) Login(ctx *gin.Context) {
 	user := &domain.AuthUserData{}
 
 	// Bind the request body to the user struct.
-	err := ctx.BindJSON(&user)
+	err := ctx.ShouldBindJSON(&user)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Request"})
+		ctx.Error(invalidRequest(err))
 		return
 	}
 
 	// Log the user in using the user usecase.
-	token, _err := uc.usecase.LoginUser(user)
+	result, _err := uc.usecase.LoginUser(ctx, user)
 	if _err != nil {
-		log.Println(_err.Err)
-		ctx.JSON(_err.StatusCode, gin.H{"error": _err.Message})
+		ctx.Error(_err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"token": token})
+	ctx.JSON(http.StatusOK, result)
 }
 
 // A handler function that adds a new user.
@@ -69,17 +67,23 @@ func (uc *UserController) AddUser(ctx *gin.Context) {
 
 	// Bind the request body to the user struct.
 	user := &domain.CreateUserData{}
-	err := ctx.BindJSON(user)
+	err := ctx.ShouldBindJSON(user)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Request"})
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	// Check the user data against the validation rules.
+	_err := infrastructure.Validate(user)
+	if _err != nil {
+		ctx.Error(_err)
 		return
 	}
 
 	// Add the user to the database using the user usecase.
-	addedUser, _err := uc.usecase.AddUser(user, claims)
+	addedUser, _err := uc.usecase.AddUser(ctx, user, claims)
 	if _err != nil {
-		log.Println(_err.Err)
-		ctx.JSON(_err.StatusCode, gin.H{"error": _err.Message})
+		ctx.Error(_err)
 		return
 	}
 
@@ -89,17 +93,20 @@ func (uc *UserController) AddUser(ctx *gin.Context) {
 // A handler function that returns all users.
 func (uc *UserController) GetUsers(ctx *gin.Context) {
 	// Get all users using the user usecase.
-	log.Println("GetUsers")
-	users, _err := uc.usecase.GetUsers()
+	users, _err := uc.usecase.GetUsers(ctx)
 	if _err != nil {
-		log.Println(_err.Err)
-		ctx.JSON(_err.StatusCode, gin.H{"error": _err.Message})
+		ctx.Error(_err)
 		return
 	}
 
+	publicUsers := make([]domain.PublicUser, len(users))
+	for i := range users {
+		publicUsers[i] = users[i].Public()
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{
-		"count": len(users),
-		"users": users,
+		"count": len(publicUsers),
+		"users": publicUsers,
 	})
 }
 
@@ -109,14 +116,13 @@ func (uc *UserController) GetUserByID(ctx *gin.Context) {
 	userID := ctx.MustGet("user_id").(primitive.ObjectID)
 
 	// Get the user using the user usecase.
-	user, _err := uc.usecase.GetUserByID(userID)
+	user, _err := uc.usecase.GetUserByID(ctx, userID)
 	if _err != nil {
-		log.Println(_err.Err)
-		ctx.JSON(_err.StatusCode, gin.H{"error": _err.Message})
+		ctx.Error(_err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, user)
+	ctx.JSON(http.StatusOK, user.Public())
 }
 
 // A handler function that updates a user with the given ID.
@@ -126,17 +132,23 @@ func (uc *UserController) UpdateUserPatch(ctx *gin.Context) {
 
 	// Bind the request body to the user struct.
 	userData := &domain.UpdateUserData{}
-	err := ctx.BindJSON(userData)
+	err := ctx.ShouldBindJSON(userData)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Request"})
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	// Check the user data against the validation rules.
+	_err := infrastructure.Validate(userData)
+	if _err != nil {
+		ctx.Error(_err)
 		return
 	}
 
 	// Update the user using the user usecase.
-	user, _err := uc.usecase.UpdateUser(userID, userData, claims)
+	user, _err := uc.usecase.UpdateUser(ctx, userID, userData, claims)
 	if _err != nil {
-		log.Println(_err.Err)
-		ctx.JSON(_err.StatusCode, gin.H{"error": _err.Message})
+		ctx.Error(_err)
 		return
 	}
 
@@ -144,15 +156,23 @@ func (uc *UserController) UpdateUserPatch(ctx *gin.Context) {
 }
 
 // A handler function that deletes a user with the given ID.
+// The query parameters choose what happens to the tasks of the user.
 func (uc *UserController) DeleteUser(ctx *gin.Context) {
 	claims := ctx.MustGet("claims").(*domain.Claims)
 	userID := ctx.MustGet("user_id").(primitive.ObjectID)
 
+	// Bind the query parameters to the struct.
+	query := &domain.DeleteUserQuery{}
+	err := ctx.ShouldBindQuery(query)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
 	// Delete the user using the user usecase.
-	_err := uc.usecase.DeleteUser(userID, claims)
+	_err := uc.usecase.DeleteUser(ctx, userID, query, claims)
 	if _err != nil {
-		log.Println(_err.Err)
-		ctx.JSON(_err.StatusCode, gin.H{"error": _err.Message})
+		ctx.Error(_err)
 		return
 	}
 
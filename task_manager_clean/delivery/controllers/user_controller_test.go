@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -42,13 +43,13 @@ func (suite *UserControllerTestSuite) TestRegisterUser() {
 
 		userData := mocks.GetAuthUserData()
 		user := mocks.GetUser3(userData)
-		suite.mockUsecase.On("RegisterUser", userData).Return(user, nil).Once()
+		suite.mockUsecase.On("RegisterUser", mock.Anything, userData).Return(user, nil).Once()
 
 		body, err := json.Marshal(userData)
 		suite.Nil(err)
-		ctx.Request = httptest.NewRequest("POST", "/users", bytes.NewReader(body))
+		ctx.Request = httptest.NewRequest("POST", "/register", bytes.NewReader(body))
 
-		suite.controller.RegisterUser(ctx)
+		serve(ctx, suite.controller.RegisterUser)
 		expected, err := json.Marshal(user)
 		suite.Nil(err)
 
@@ -61,14 +62,13 @@ func (suite *UserControllerTestSuite) TestRegisterUser() {
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
 
-		ctx.Request = httptest.NewRequest("POST", "/users", nil)
+		ctx.Request = httptest.NewRequest("POST", "/register", nil)
 
-		suite.controller.RegisterUser(ctx)
-		expected, err := json.Marshal(gin.H{"error": "Invalid request"})
-		suite.Nil(err)
+		serve(ctx, suite.controller.RegisterUser)
+		expected := problem(400, domain.CodeInvalidRequest, "Invalid request")
 
 		suite.Equal(400, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 
 	// A testcase for an error during user registration.
@@ -77,7 +77,7 @@ func (suite *UserControllerTestSuite) TestRegisterUser() {
 		ctx, _ := gin.CreateTestContext(w)
 
 		userData := mocks.GetAuthUserData()
-		suite.mockUsecase.On("RegisterUser", userData).Return(nil, &domain.Error{
+		suite.mockUsecase.On("RegisterUser", mock.Anything, userData).Return(nil, &domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal Server Error",
@@ -85,14 +85,13 @@ func (suite *UserControllerTestSuite) TestRegisterUser() {
 
 		body, err := json.Marshal(userData)
 		suite.Nil(err)
-		ctx.Request = httptest.NewRequest("POST", "/users", bytes.NewReader(body))
+		ctx.Request = httptest.NewRequest("POST", "/register", bytes.NewReader(body))
 
-		suite.controller.RegisterUser(ctx)
-		expected, err := json.Marshal(gin.H{"error": "Internal Server Error"})
-		suite.Nil(err)
+		serve(ctx, suite.controller.RegisterUser)
+		expected := problem(500, domain.CodeInternal, "Internal Server Error")
 
 		suite.Equal(500, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 }
 
@@ -105,13 +104,13 @@ func (suite *UserControllerTestSuite) TestLogin() {
 
 		userData := mocks.GetAuthUserData()
 		token := "some.random.token.after.login"
-		suite.mockUsecase.On("LoginUser", userData).Return(token, nil).Once()
+		suite.mockUsecase.On("LoginUser", mock.Anything, userData).Return(&domain.LoginResult{Token: token}, nil).Once()
 
 		body, err := json.Marshal(userData)
 		suite.Nil(err)
-		ctx.Request = httptest.NewRequest("POST", "/users/login", bytes.NewReader(body))
+		ctx.Request = httptest.NewRequest("POST", "/login", bytes.NewReader(body))
 
-		suite.controller.Login(ctx)
+		serve(ctx, suite.controller.Login)
 		expected, err := json.Marshal(gin.H{"token": token})
 		suite.Nil(err)
 
@@ -124,14 +123,13 @@ func (suite *UserControllerTestSuite) TestLogin() {
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
 
-		ctx.Request = httptest.NewRequest("POST", "/users/login", nil)
+		ctx.Request = httptest.NewRequest("POST", "/login", nil)
 
-		suite.controller.Login(ctx)
-		expected, err := json.Marshal(gin.H{"error": "Invalid Request"})
-		suite.Nil(err)
+		serve(ctx, suite.controller.Login)
+		expected := problem(400, domain.CodeInvalidRequest, "Invalid request")
 
 		suite.Equal(400, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 
 	// A testcase for an error during user login.
@@ -140,7 +138,7 @@ func (suite *UserControllerTestSuite) TestLogin() {
 		ctx, _ := gin.CreateTestContext(w)
 
 		userData := mocks.GetAuthUserData()
-		suite.mockUsecase.On("LoginUser", userData).Return("", &domain.Error{
+		suite.mockUsecase.On("LoginUser", mock.Anything, userData).Return(nil, &domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal Server Error",
@@ -148,14 +146,13 @@ func (suite *UserControllerTestSuite) TestLogin() {
 
 		body, err := json.Marshal(userData)
 		suite.Nil(err)
-		ctx.Request = httptest.NewRequest("POST", "/users/login", bytes.NewReader(body))
+		ctx.Request = httptest.NewRequest("POST", "/login", bytes.NewReader(body))
 
-		suite.controller.Login(ctx)
-		expected, err := json.Marshal(gin.H{"error": "Internal Server Error"})
-		suite.Nil(err)
+		serve(ctx, suite.controller.Login)
+		expected := problem(500, domain.CodeInternal, "Internal Server Error")
 
 		suite.Equal(500, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 }
 
@@ -169,14 +166,14 @@ func (suite *UserControllerTestSuite) TestAddUser() {
 		userData := mocks.GetCreateUserData()
 		user := mocks.GetUser(userData)
 		claims := mocks.GetClaims()
-		suite.mockUsecase.On("AddUser", userData, claims).Return(user, nil).Once()
+		suite.mockUsecase.On("AddUser", mock.Anything, userData, claims).Return(user, nil).Once()
 
 		body, err := json.Marshal(userData)
 		suite.Nil(err)
 		ctx.Request = httptest.NewRequest("POST", "/users", bytes.NewReader(body))
 		ctx.Set("claims", claims)
 
-		suite.controller.AddUser(ctx)
+		serve(ctx, suite.controller.AddUser)
 		expected, err := json.Marshal(user)
 		suite.Nil(err)
 
@@ -193,12 +190,34 @@ func (suite *UserControllerTestSuite) TestAddUser() {
 		ctx.Set("claims", claims)
 		ctx.Request = httptest.NewRequest("POST", "/users", nil)
 
-		suite.controller.AddUser(ctx)
-		expected, err := json.Marshal(gin.H{"error": "Invalid Request"})
+		serve(ctx, suite.controller.AddUser)
+		expected := problem(400, domain.CodeInvalidRequest, "Invalid request")
+
+		suite.Equal(400, w.Code)
+		suite.Equal(expected, w.Body.String())
+	})
+
+	// A testcase for user data that breaks the validation rules.
+	suite.Run("AddUser_InvalidData", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		userData := &domain.CreateUserData{Username: "a b", Password: "short", Role: "user", Email: "invalid"}
+		body, err := json.Marshal(userData)
 		suite.Nil(err)
+		ctx.Set("claims", mocks.GetClaims())
+		ctx.Request = httptest.NewRequest("POST", "/users", bytes.NewReader(body))
+
+		serve(ctx, suite.controller.AddUser)
+		expected := problem(400, domain.CodeValidationFailed,
+			"username must be 3 to 32 letters, digits, '.', '_' or '-'; password must be at least 8 characters long; email must be a valid email address",
+			domain.FieldError{Field: "username", Reason: "must be 3 to 32 letters, digits, '.', '_' or '-'"},
+			domain.FieldError{Field: "password", Reason: "must be at least 8 characters long"},
+			domain.FieldError{Field: "email", Reason: "must be a valid email address"},
+		)
 
 		suite.Equal(400, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 
 	// A testcase for an error during user addition.
@@ -208,7 +227,7 @@ func (suite *UserControllerTestSuite) TestAddUser() {
 
 		userData := mocks.GetCreateUserData()
 		claims := mocks.GetClaims()
-		suite.mockUsecase.On("AddUser", userData, claims).Return(nil, &domain.Error{
+		suite.mockUsecase.On("AddUser", mock.Anything, userData, claims).Return(nil, &domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal Server Error",
@@ -219,12 +238,11 @@ func (suite *UserControllerTestSuite) TestAddUser() {
 		ctx.Request = httptest.NewRequest("POST", "/users", bytes.NewReader(body))
 		ctx.Set("claims", claims)
 
-		suite.controller.AddUser(ctx)
-		expected, err := json.Marshal(gin.H{"error": "Internal Server Error"})
-		suite.Nil(err)
+		serve(ctx, suite.controller.AddUser)
+		expected := problem(500, domain.CodeInternal, "Internal Server Error")
 
 		suite.Equal(500, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 }
 
@@ -236,19 +254,24 @@ func (suite *UserControllerTestSuite) TestGetUsers() {
 		ctx, _ := gin.CreateTestContext(w)
 
 		users := mocks.GetManyUsers()
-		suite.mockUsecase.On("GetUsers").Return(users, nil).Once()
+		users[0].Email = "user1@example.com"
+		users[0].TOTPEnabled = true
+		suite.mockUsecase.On("GetUsers", mock.Anything, mock.Anything).Return(users, nil).Once()
 
 		ctx.Request = httptest.NewRequest("GET", "/users", nil)
 
-		suite.controller.GetUsers(ctx)
+		serve(ctx, suite.controller.GetUsers)
 		expected, err := json.Marshal(gin.H{
 			"count": len(users),
-			"users": users,
+			"users": []domain.PublicUser{users[0].Public(), users[1].Public(), users[2].Public()},
 		})
 		suite.Nil(err)
 
 		suite.Equal(200, w.Code)
 		suite.Equal(string(expected), w.Body.String())
+		suite.NotContains(w.Body.String(), "email")
+		suite.NotContains(w.Body.String(), "password")
+		suite.NotContains(w.Body.String(), "totp_enabled")
 	})
 
 	// A testcase for an error during user retrieval.
@@ -256,7 +279,7 @@ func (suite *UserControllerTestSuite) TestGetUsers() {
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
 
-		suite.mockUsecase.On("GetUsers").Return(nil, &domain.Error{
+		suite.mockUsecase.On("GetUsers", mock.Anything, mock.Anything).Return(nil, &domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal Server Error",
@@ -264,12 +287,11 @@ func (suite *UserControllerTestSuite) TestGetUsers() {
 
 		ctx.Request = httptest.NewRequest("GET", "/users", nil)
 
-		suite.controller.GetUsers(ctx)
-		expected, err := json.Marshal(gin.H{"error": "Internal Server Error"})
-		suite.Nil(err)
+		serve(ctx, suite.controller.GetUsers)
+		expected := problem(500, domain.CodeInternal, "Internal Server Error")
 
 		suite.Equal(500, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 }
 
@@ -281,17 +303,21 @@ func (suite *UserControllerTestSuite) TestGetUser() {
 		ctx, _ := gin.CreateTestContext(w)
 
 		user := mocks.GetNewUser()
-		suite.mockUsecase.On("GetUserByID", user.ID).Return(user, nil).Once()
+		user.Email = "user1@example.com"
+		user.TOTPEnabled = true
+		suite.mockUsecase.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
 
 		ctx.Set("user_id", user.ID)
 		ctx.Request = httptest.NewRequest("GET", "/users/"+user.ID.Hex(), nil)
 
-		suite.controller.GetUserByID(ctx)
-		expected, err := json.Marshal(user)
+		serve(ctx, suite.controller.GetUserByID)
+		expected, err := json.Marshal(user.Public())
 		suite.Nil(err)
 
 		suite.Equal(200, w.Code)
 		suite.Equal(string(expected), w.Body.String())
+		suite.NotContains(w.Body.String(), "email")
+		suite.NotContains(w.Body.String(), "totp_enabled")
 	})
 
 	// A testcase for an error during user retrieval.
@@ -300,7 +326,7 @@ func (suite *UserControllerTestSuite) TestGetUser() {
 		ctx, _ := gin.CreateTestContext(w)
 
 		user := mocks.GetNewUser()
-		suite.mockUsecase.On("GetUserByID", user.ID).Return(nil, &domain.Error{
+		suite.mockUsecase.On("GetUserByID", mock.Anything, user.ID).Return(nil, &domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal Server Error",
@@ -309,12 +335,11 @@ func (suite *UserControllerTestSuite) TestGetUser() {
 		ctx.Set("user_id", user.ID)
 		ctx.Request = httptest.NewRequest("GET", "/users/"+user.ID.Hex(), nil)
 
-		suite.controller.GetUserByID(ctx)
-		expected, err := json.Marshal(gin.H{"error": "Internal Server Error"})
-		suite.Nil(err)
+		serve(ctx, suite.controller.GetUserByID)
+		expected := problem(500, domain.CodeInternal, "Internal Server Error")
 
 		suite.Equal(500, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 }
 
@@ -328,7 +353,7 @@ func (suite *UserControllerTestSuite) TestUpdateUserPatch() {
 		user := mocks.GetNewUser()
 		userData := mocks.GetUpdateUserData()
 		claims := mocks.GetClaims()
-		suite.mockUsecase.On("UpdateUser", user.ID, userData, claims).Return(user, nil).Once()
+		suite.mockUsecase.On("UpdateUser", mock.Anything, user.ID, userData, claims).Return(user, nil).Once()
 
 		body, err := json.Marshal(userData)
 		suite.Nil(err)
@@ -336,7 +361,7 @@ func (suite *UserControllerTestSuite) TestUpdateUserPatch() {
 		ctx.Set("user_id", user.ID)
 		ctx.Set("claims", claims)
 
-		suite.controller.UpdateUserPatch(ctx)
+		serve(ctx, suite.controller.UpdateUserPatch)
 		expected, err := json.Marshal(user)
 		suite.Nil(err)
 
@@ -355,12 +380,11 @@ func (suite *UserControllerTestSuite) TestUpdateUserPatch() {
 		ctx.Set("claims", claims)
 		ctx.Request = httptest.NewRequest("PATCH", "/users/"+user.ID.Hex(), nil)
 
-		suite.controller.UpdateUserPatch(ctx)
-		expected, err := json.Marshal(gin.H{"error": "Invalid Request"})
-		suite.Nil(err)
+		serve(ctx, suite.controller.UpdateUserPatch)
+		expected := problem(400, domain.CodeInvalidRequest, "Invalid request")
 
 		suite.Equal(400, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 
 	// A testcase for an error during user update.
@@ -371,7 +395,7 @@ func (suite *UserControllerTestSuite) TestUpdateUserPatch() {
 		user := mocks.GetNewUser()
 		userData := mocks.GetUpdateUserData()
 		claims := mocks.GetClaims()
-		suite.mockUsecase.On("UpdateUser", user.ID, userData, claims).Return(nil, &domain.Error{
+		suite.mockUsecase.On("UpdateUser", mock.Anything, user.ID, userData, claims).Return(nil, &domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal Server Error",
@@ -383,12 +407,11 @@ func (suite *UserControllerTestSuite) TestUpdateUserPatch() {
 		ctx.Set("user_id", user.ID)
 		ctx.Set("claims", claims)
 
-		suite.controller.UpdateUserPatch(ctx)
-		expected, err := json.Marshal(gin.H{"error": "Internal Server Error"})
-		suite.Nil(err)
+		serve(ctx, suite.controller.UpdateUserPatch)
+		expected := problem(500, domain.CodeInternal, "Internal Server Error")
 
 		suite.Equal(500, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 }
 
@@ -401,13 +424,13 @@ func (suite *UserControllerTestSuite) TestDeleteUser() {
 
 		user := mocks.GetNewUser()
 		claims := mocks.GetClaims()
-		suite.mockUsecase.On("DeleteUser", user.ID, claims).Return(nil).Once()
+		suite.mockUsecase.On("DeleteUser", mock.Anything, user.ID, &domain.DeleteUserQuery{}, claims).Return(nil).Once()
 
 		ctx.Set("user_id", user.ID)
 		ctx.Set("claims", claims)
 		ctx.Request = httptest.NewRequest("DELETE", "/users/"+user.ID.Hex(), nil)
 
-		suite.controller.DeleteUser(ctx)
+		serve(ctx, suite.controller.DeleteUser)
 
 		suite.Equal(204, w.Code)
 		suite.Empty(w.Body.String())
@@ -420,7 +443,7 @@ func (suite *UserControllerTestSuite) TestDeleteUser() {
 
 		user := mocks.GetNewUser()
 		claims := mocks.GetClaims()
-		suite.mockUsecase.On("DeleteUser", user.ID, claims).Return(&domain.Error{
+		suite.mockUsecase.On("DeleteUser", mock.Anything, user.ID, &domain.DeleteUserQuery{}, claims).Return(&domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal Server Error",
@@ -430,12 +453,11 @@ func (suite *UserControllerTestSuite) TestDeleteUser() {
 		ctx.Set("claims", claims)
 		ctx.Request = httptest.NewRequest("DELETE", "/users/"+user.ID.Hex(), nil)
 
-		suite.controller.DeleteUser(ctx)
-		expected, err := json.Marshal(gin.H{"error": "Internal Server Error"})
-		suite.Nil(err)
+		serve(ctx, suite.controller.DeleteUser)
+		expected := problem(500, domain.CodeInternal, "Internal Server Error")
 
 		suite.Equal(500, w.Code)
-		suite.Equal(string(expected), w.Body.String())
+		suite.Equal(expected, w.Body.String())
 	})
 }
 
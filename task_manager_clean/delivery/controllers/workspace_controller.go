@@ -0,0 +1,228 @@
+package controllers
+
+import (
+	"net/http"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A struct that handles workspace, membership and project operations by calling the usecase methods.
+type WorkspaceController struct {
+	usecase domain.WorkspaceUsecase
+}
+
+// A constructor that creates a new instance of WorkspaceController.
+func NewWorkspaceController(usecase domain.WorkspaceUsecase) *WorkspaceController {
+	return &WorkspaceController{usecase: usecase}
+}
+
+// A handler function that creates a workspace.
+func (wc *WorkspaceController) CreateWorkspace(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	// Bind the request body to the struct.
+	workspaceData := &domain.WorkspaceData{}
+	err := ctx.ShouldBindJSON(workspaceData)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	workspace, _err := wc.usecase.CreateWorkspace(ctx, workspaceData, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, workspace)
+}
+
+// A handler function that returns the workspaces of the logged in user.
+func (wc *WorkspaceController) GetWorkspaces(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+
+	workspaces, _err := wc.usecase.GetWorkspaces(ctx, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"count":      len(workspaces),
+		"workspaces": workspaces,
+	})
+}
+
+// A handler function that returns a workspace.
+func (wc *WorkspaceController) GetWorkspaceByID(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	workspaceID := ctx.MustGet("workspace_id").(primitive.ObjectID)
+
+	workspace, _err := wc.usecase.GetWorkspaceByID(ctx, workspaceID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, workspace)
+}
+
+// A handler function that updates a workspace.
+func (wc *WorkspaceController) UpdateWorkspace(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	workspaceID := ctx.MustGet("workspace_id").(primitive.ObjectID)
+
+	// Bind the request body to the struct.
+	workspaceData := &domain.WorkspaceData{}
+	err := ctx.ShouldBindJSON(workspaceData)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	workspace, _err := wc.usecase.UpdateWorkspace(ctx, workspaceID, workspaceData, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, workspace)
+}
+
+// A handler function that sets the WIP limits of the columns of the board of a workspace.
+func (wc *WorkspaceController) SetWIPLimits(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	workspaceID := ctx.MustGet("workspace_id").(primitive.ObjectID)
+
+	// Bind the request body to the struct.
+	limitsData := &domain.WIPLimitsData{}
+	err := ctx.ShouldBindJSON(limitsData)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	// Check the limits against the validation rules.
+	_err := infrastructure.Validate(limitsData)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	workspace, _err := wc.usecase.SetWIPLimits(ctx, workspaceID, limitsData, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, workspace)
+}
+
+// A handler function that deletes a workspace.
+func (wc *WorkspaceController) DeleteWorkspace(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	workspaceID := ctx.MustGet("workspace_id").(primitive.ObjectID)
+
+	_err := wc.usecase.DeleteWorkspace(ctx, workspaceID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// A handler function that adds a member to a workspace or changes their role.
+func (wc *WorkspaceController) SetMember(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	workspaceID := ctx.MustGet("workspace_id").(primitive.ObjectID)
+	userID := ctx.MustGet("user_id").(primitive.ObjectID)
+
+	// Bind the request body to the struct.
+	membershipData := &domain.MembershipData{}
+	err := ctx.ShouldBindJSON(membershipData)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	workspace, _err := wc.usecase.SetMember(ctx, workspaceID, userID, membershipData, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, workspace)
+}
+
+// A handler function that removes a member from a workspace.
+func (wc *WorkspaceController) RemoveMember(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	workspaceID := ctx.MustGet("workspace_id").(primitive.ObjectID)
+	userID := ctx.MustGet("user_id").(primitive.ObjectID)
+
+	_err := wc.usecase.RemoveMember(ctx, workspaceID, userID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// A handler function that creates a project in a workspace.
+func (wc *WorkspaceController) CreateProject(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	workspaceID := ctx.MustGet("workspace_id").(primitive.ObjectID)
+
+	// Bind the request body to the struct.
+	projectData := &domain.ProjectData{}
+	err := ctx.ShouldBindJSON(projectData)
+	if err != nil {
+		ctx.Error(invalidRequest(err))
+		return
+	}
+
+	project, _err := wc.usecase.CreateProject(ctx, workspaceID, projectData, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, project)
+}
+
+// A handler function that returns the projects of a workspace.
+func (wc *WorkspaceController) GetProjects(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	workspaceID := ctx.MustGet("workspace_id").(primitive.ObjectID)
+
+	projects, _err := wc.usecase.GetProjects(ctx, workspaceID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"count":    len(projects),
+		"projects": projects,
+	})
+}
+
+// A handler function that deletes a project.
+func (wc *WorkspaceController) DeleteProject(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	workspaceID := ctx.MustGet("workspace_id").(primitive.ObjectID)
+	projectID := ctx.MustGet("project_id").(primitive.ObjectID)
+
+	_err := wc.usecase.DeleteProject(ctx, workspaceID, projectID, claims)
+	if _err != nil {
+		ctx.Error(_err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
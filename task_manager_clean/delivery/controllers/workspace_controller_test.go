@@ -0,0 +1,179 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"task_manager/delivery/controllers"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite to test the WorkspaceController.
+type WorkspaceControllerTestSuite struct {
+	suite.Suite
+	controller  *controllers.WorkspaceController
+	mockUsecase *mocks.WorkspaceUsecase
+}
+
+// A method that initializes the WorkspaceControllerTestSuite.
+func (suite *WorkspaceControllerTestSuite) SetupSuite() {
+	suite.mockUsecase = new(mocks.WorkspaceUsecase)
+	suite.controller = controllers.NewWorkspaceController(suite.mockUsecase)
+}
+
+// A method that cleans up the WorkspaceControllerTestSuite.
+func (suite *WorkspaceControllerTestSuite) TearDownSuite() {
+	suite.mockUsecase.AssertExpectations(suite.T())
+}
+
+// A test for the WorkspaceController.CreateWorkspace method.
+func (suite *WorkspaceControllerTestSuite) TestCreateWorkspace() {
+	// A testcase for a successful creation.
+	suite.Run("CreateWorkspace_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims2()
+		workspace := mocks.GetWorkspace()
+		workspaceData := &domain.WorkspaceData{Name: workspace.Name}
+		suite.mockUsecase.On("CreateWorkspace", mock.Anything, workspaceData, claims).Return(workspace, nil).Once()
+		ctx.Set("claims", claims)
+
+		body, err := json.Marshal(workspaceData)
+		suite.Nil(err)
+		ctx.Request = httptest.NewRequest("POST", "/workspaces", bytes.NewReader(body))
+
+		serve(ctx, suite.controller.CreateWorkspace)
+		expected, err := json.Marshal(workspace)
+		suite.Nil(err)
+
+		suite.Equal(201, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+
+	// A testcase for an invalid request.
+	suite.Run("CreateWorkspace_InvalidRequest", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Set("claims", mocks.GetClaims2())
+
+		ctx.Request = httptest.NewRequest("POST", "/workspaces", nil)
+
+		serve(ctx, suite.controller.CreateWorkspace)
+
+		suite.Equal(400, w.Code)
+	})
+}
+
+// A test for the WorkspaceController.GetWorkspaceByID method.
+func (suite *WorkspaceControllerTestSuite) TestGetWorkspaceByID() {
+	// A testcase where the user is not a member of the workspace.
+	suite.Run("GetWorkspaceByID_NotFound", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims3()
+		workspaceID := mocks.GetWorkspace().ID
+		suite.mockUsecase.On("GetWorkspaceByID", mock.Anything, workspaceID, claims).Return(nil, &domain.Error{
+			Err:        errors.New("not a member"),
+			StatusCode: http.StatusNotFound,
+			Message:    "Workspace not found",
+		}).Once()
+		ctx.Set("claims", claims)
+		ctx.Set("workspace_id", workspaceID)
+
+		serve(ctx, suite.controller.GetWorkspaceByID)
+		expected := problem(404, domain.CodeNotFound, "Workspace not found")
+
+		suite.Equal(404, w.Code)
+		suite.Equal(expected, w.Body.String())
+	})
+}
+
+// A test for the WorkspaceController.SetMember method.
+func (suite *WorkspaceControllerTestSuite) TestSetMember() {
+	// A testcase where a member is added.
+	suite.Run("SetMember_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims2()
+		workspace := mocks.GetWorkspace()
+		userID := mocks.GetPrimitiveID3()
+		membershipData := &domain.MembershipData{Role: domain.WorkspaceRoleViewer}
+		suite.mockUsecase.On("SetMember", mock.Anything, workspace.ID, userID, membershipData, claims).Return(workspace, nil).Once()
+		ctx.Set("claims", claims)
+		ctx.Set("workspace_id", workspace.ID)
+		ctx.Set("user_id", userID)
+
+		body, err := json.Marshal(membershipData)
+		suite.Nil(err)
+		ctx.Request = httptest.NewRequest("PUT", "/workspaces/"+workspace.ID.Hex()+"/members/"+userID.Hex(), bytes.NewReader(body))
+
+		serve(ctx, suite.controller.SetMember)
+		expected, err := json.Marshal(workspace)
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+}
+
+// A test for the WorkspaceController.GetProjects method.
+func (suite *WorkspaceControllerTestSuite) TestGetProjects() {
+	// A testcase where the projects of a workspace are listed.
+	suite.Run("GetProjects_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims()
+		workspaceID := mocks.GetWorkspace().ID
+		projects := []domain.Project{*mocks.GetProject()}
+		suite.mockUsecase.On("GetProjects", mock.Anything, workspaceID, claims).Return(projects, nil).Once()
+		ctx.Set("claims", claims)
+		ctx.Set("workspace_id", workspaceID)
+
+		serve(ctx, suite.controller.GetProjects)
+		expected, err := json.Marshal(gin.H{
+			"count":    len(projects),
+			"projects": projects,
+		})
+		suite.Nil(err)
+
+		suite.Equal(200, w.Code)
+		suite.Equal(string(expected), w.Body.String())
+	})
+}
+
+// A test for the WorkspaceController.DeleteProject method.
+func (suite *WorkspaceControllerTestSuite) TestDeleteProject() {
+	// A testcase where a project is deleted.
+	suite.Run("DeleteProject_Success", func() {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		claims := mocks.GetClaims2()
+		project := mocks.GetProject()
+		suite.mockUsecase.On("DeleteProject", mock.Anything, project.WorkspaceID, project.ID, claims).Return(nil).Once()
+		ctx.Set("claims", claims)
+		ctx.Set("workspace_id", project.WorkspaceID)
+		ctx.Set("project_id", project.ID)
+
+		serve(ctx, suite.controller.DeleteProject)
+
+		suite.Equal(204, w.Code)
+	})
+}
+
+// A function that runs the WorkspaceControllerTestSuite.
+func Test_WorkspaceControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkspaceControllerTestSuite))
+}
@@ -0,0 +1,78 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// resolverError is the error returned to clients when an operation fails. Its extensions carry the code and the
+// status of the problem details the REST API returns for the same failure.
+type resolverError struct {
+	problem *domain.Problem
+}
+
+// A function that converts a domain error to the error of a resolver. The internal cause is logged and never
+// returned to the client.
+func newError(ctx context.Context, _err *domain.Error) error {
+	if _err.StatusCode >= http.StatusInternalServerError {
+		infrastructure.Logger(ctx).Error("graphql operation failed", "error", _err.Error())
+	}
+
+	return &resolverError{problem: _err.Problem()}
+}
+
+func (e *resolverError) Error() string {
+	if e.problem.Detail != "" {
+		return e.problem.Detail
+	}
+
+	return e.problem.Title
+}
+
+// A method that returns the extensions of the error in the GraphQL response.
+func (e *resolverError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{
+		"code":   e.problem.Code,
+		"status": e.problem.Status,
+	}
+	if len(e.problem.Errors) > 0 {
+		extensions["errors"] = e.problem.Errors
+	}
+
+	return extensions
+}
+
+// A helper function that returns an error if the access token of the claims lacks the scope.
+func requireScope(ctx context.Context, claims *domain.Claims, scope string) error {
+	if claims.HasScope(scope) {
+		return nil
+	}
+
+	return newError(ctx, &domain.Error{
+		Err:        errors.New("missing scope"),
+		StatusCode: http.StatusForbidden,
+		Code:       domain.CodeForbiddenScope,
+		Message:    "Forbidden Hint: the " + scope + " scope is required",
+	})
+}
+
+// A helper function that converts a GraphQL ID to an ObjectID.
+func parseID(ctx context.Context, id graphql.ID, idType string) (primitive.ObjectID, error) {
+	objectID, err := primitive.ObjectIDFromHex(string(id))
+	if err != nil {
+		return primitive.NilObjectID, newError(ctx, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeInvalidID,
+			Message:    "Invalid " + idType + " ID",
+		})
+	}
+
+	return objectID, nil
+}
@@ -0,0 +1,77 @@
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"task_manager/domain"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// The schema of the GraphQL API.
+//
+//go:embed schema.graphql
+var Schema string
+
+// The maximum depth of a query, which bounds the work of queries that follow the relations back and forth.
+const maxQueryDepth = 10
+
+// A struct that defines a GraphQL request, as sent in the body of a POST request.
+type Request struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Executor runs the GraphQL requests against the usecases.
+type Executor struct {
+	schema *graphql.Schema
+	tasks  domain.TaskUsecase
+	users  domain.UserUsecase
+}
+
+// A constructor that creates a new instance of Executor. The subscriptions receive the changes published on events.
+func NewExecutor(tasks domain.TaskUsecase, users domain.UserUsecase, events domain.TaskEventBroker) (*Executor, error) {
+	resolver := &Resolver{tasks: tasks, users: users, events: events}
+	schema, err := graphql.ParseSchema(Schema, resolver, graphql.MaxDepth(maxQueryDepth))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Executor{schema: schema, tasks: tasks, users: users}, nil
+}
+
+// A method that runs a query or a mutation on behalf of the user of the claims.
+func (e *Executor) Exec(ctx context.Context, claims *domain.Claims, request *Request) *graphql.Response {
+	return e.schema.Exec(e.withRequest(ctx, claims), request.Query, request.OperationName, request.Variables)
+}
+
+// A method that runs a subscription on behalf of the user of the claims. The channel receives a *graphql.Response
+// for each event, and is closed when the context is done. Queries and mutations receive a single response.
+func (e *Executor) Subscribe(ctx context.Context, claims *domain.Claims, request *Request) (<-chan interface{}, error) {
+	return e.schema.Subscribe(e.withRequest(ctx, claims), request.Query, request.OperationName, request.Variables)
+}
+
+// A helper method that returns a context that holds the claims and the loaders of a single request.
+func (e *Executor) withRequest(ctx context.Context, claims *domain.Claims) context.Context {
+	return context.WithValue(ctx, requestKey{}, &requestState{
+		claims: claims,
+		users:  newUserLoader(e.users),
+		tasks:  newOwnerTaskLoader(e.tasks, claims),
+	})
+}
+
+// The key of the state of a request in its context.
+type requestKey struct{}
+
+// A struct that holds the state shared by the resolvers of a single request.
+type requestState struct {
+	claims *domain.Claims
+	users  *userLoader
+	tasks  *ownerTaskLoader
+}
+
+// A helper function that returns the state of the request of the context.
+func stateFrom(ctx context.Context) *requestState {
+	return ctx.Value(requestKey{}).(*requestState)
+}
@@ -0,0 +1,182 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"task_manager/delivery/graphql"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"task_manager/mocks"
+	"testing"
+	"time"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A suite that contains tests for the GraphQL Executor.
+type ExecutorTestSuite struct {
+	suite.Suite
+	tasks    *mocks.TaskUsecase
+	users    *mocks.UserUsecase
+	events   *infrastructure.TaskEventBroker
+	executor *graphql.Executor
+}
+
+// A method that initializes each testcase.
+func (suite *ExecutorTestSuite) SetupSubTest() {
+	suite.tasks = new(mocks.TaskUsecase)
+	suite.users = new(mocks.UserUsecase)
+	suite.events = infrastructure.NewTaskEventBroker()
+
+	executor, err := graphql.NewExecutor(suite.tasks, suite.users, suite.events)
+	suite.Require().NoError(err)
+	suite.executor = executor
+}
+
+// A method that finalizes each testcase.
+func (suite *ExecutorTestSuite) TearDownSubTest() {
+	suite.tasks.AssertExpectations(suite.T())
+	suite.users.AssertExpectations(suite.T())
+}
+
+// A helper method that runs a request and returns its data as JSON, or its first error.
+func (suite *ExecutorTestSuite) exec(claims *domain.Claims, query string, variables map[string]interface{}) (string, map[string]interface{}) {
+	response := suite.executor.Exec(context.Background(), claims, &graphql.Request{Query: query, Variables: variables})
+	if len(response.Errors) > 0 {
+		return "", response.Errors[0].Extensions
+	}
+
+	return string(response.Data), nil
+}
+
+// A test for the tasks query.
+func (suite *ExecutorTestSuite) TestTasks() {
+	// A testcase where the owners of the tasks are read in a single batch.
+	suite.Run("Tasks_BatchedOwners", func() {
+		claims := mocks.GetClaims()
+		owner1, owner2 := mocks.GetPrimitiveID1(), mocks.GetPrimitiveID2()
+		tasks := []domain.Task{
+			{ID: primitive.NewObjectID(), Title: "A", UserID: owner1},
+			{ID: primitive.NewObjectID(), Title: "B", UserID: owner2},
+			{ID: primitive.NewObjectID(), Title: "C", UserID: owner1},
+		}
+		users := []domain.User{{ID: owner1, Username: "user1"}, {ID: owner2, Username: "user2"}}
+
+		suite.tasks.On("GetTasks", mock.Anything, &domain.TaskQuery{}, claims).Return(tasks, nil).Once()
+		suite.users.On("GetUsersByIDs", mock.Anything, mock.MatchedBy(func(ids []primitive.ObjectID) bool {
+			return len(ids) == 2
+		})).Return(users, nil).Once()
+
+		data, errs := suite.exec(claims, `{ tasks { title owner { username } } }`, nil)
+		suite.Nil(errs)
+		suite.JSONEq(`{"tasks":[
+			{"title":"A","owner":{"username":"user1"}},
+			{"title":"B","owner":{"username":"user2"}},
+			{"title":"C","owner":{"username":"user1"}}
+		]}`, data)
+	})
+
+	// A testcase where an access token without the tasks:read scope is refused.
+	suite.Run("Tasks_MissingScope", func() {
+		claims := mocks.GetClaims()
+		claims.Scopes = []string{domain.ScopeUsersWrite}
+
+		_, errs := suite.exec(claims, `{ tasks { id } }`, nil)
+		suite.Equal(domain.CodeForbiddenScope, errs["code"])
+		suite.Equal(http.StatusForbidden, errs["status"])
+	})
+}
+
+// A test for the task query.
+func (suite *ExecutorTestSuite) TestTask() {
+	// A testcase where the usecase refuses the request, and its error is returned with its code.
+	suite.Run("Task_NotFound", func() {
+		claims := mocks.GetClaims()
+		task := mocks.GetNewTask()
+		suite.tasks.On("GetTaskByID", mock.Anything, task.ID, claims).Return(nil, &domain.Error{
+			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeTaskNotFound,
+			Message:    "Task not found",
+		}).Once()
+
+		_, errs := suite.exec(claims, `query($id: ID!) { task(id: $id) { id } }`, map[string]interface{}{"id": task.ID.Hex()})
+		suite.Equal(domain.CodeTaskNotFound, errs["code"])
+	})
+
+	// A testcase where the ID is invalid.
+	suite.Run("Task_InvalidID", func() {
+		_, errs := suite.exec(mocks.GetClaims(), `{ task(id: "nope") { id } }`, nil)
+		suite.Equal(domain.CodeInvalidID, errs["code"])
+	})
+}
+
+// A test for the createTask mutation.
+func (suite *ExecutorTestSuite) TestCreateTask() {
+	// A testcase where the task is created with the default status and returned as stored.
+	suite.Run("CreateTask_Success", func() {
+		claims := mocks.GetClaims()
+		project := mocks.GetProject()
+		dueDate := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+		taskData := &domain.CreateTaskData{Title: "Write", DueDate: dueDate, Status: "Pending", ProjectID: project.ID}
+		task := &domain.Task{ID: primitive.NewObjectID(), Title: "Write", DueDate: dueDate, Status: "Pending", UserID: claims.ID, ProjectID: project.ID}
+
+		suite.tasks.On("CreateTask", mock.Anything, taskData, claims).Return(&domain.TaskView{ID: task.ID.Hex()}, nil).Once()
+		suite.tasks.On("GetTaskByID", mock.Anything, task.ID, claims).Return(task, nil).Once()
+
+		data, errs := suite.exec(claims, `mutation($input: CreateTaskInput!) { createTask(input: $input) { title status } }`, map[string]interface{}{
+			"input": map[string]interface{}{"title": "Write", "dueDate": dueDate.Format(time.RFC3339), "projectId": project.ID.Hex()},
+		})
+		suite.Nil(errs)
+		suite.JSONEq(`{"createTask":{"title":"Write","status":"Pending"}}`, data)
+	})
+
+	// A testcase where the input breaks the validation rules of the REST API.
+	suite.Run("CreateTask_Invalid", func() {
+		_, errs := suite.exec(mocks.GetClaims(), `mutation($input: CreateTaskInput!) { createTask(input: $input) { id } }`, map[string]interface{}{
+			"input": map[string]interface{}{"title": " ", "dueDate": time.Now().Add(-time.Hour).Format(time.RFC3339), "projectId": mocks.GetProject().ID.Hex()},
+		})
+		suite.Equal(domain.CodeValidationFailed, errs["code"])
+	})
+}
+
+// A test for the taskChanged subscription.
+func (suite *ExecutorTestSuite) TestTaskChanged() {
+	// A testcase where only the changes of the tasks the user can view are received.
+	suite.Run("TaskChanged_Authorized", func() {
+		claims := mocks.GetClaims()
+		hidden := domain.Task{ID: primitive.NewObjectID(), Title: "Hidden"}
+		visible := domain.Task{ID: primitive.NewObjectID(), Title: "Visible"}
+
+		suite.tasks.On("CanViewTask", mock.Anything, &hidden, claims).Return(&domain.Error{StatusCode: http.StatusForbidden}).Once()
+		suite.tasks.On("CanViewTask", mock.Anything, &visible, claims).Return(nil).Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		responses, err := suite.executor.Subscribe(ctx, claims, &graphql.Request{Query: `subscription { taskChanged { type task { title } } }`})
+		suite.Require().NoError(err)
+
+		// The subscription listens once Subscribe returns.
+		suite.events.Publish(domain.TaskEvent{Type: domain.TaskEventUpdated, Task: hidden})
+		suite.events.Publish(domain.TaskEvent{Type: domain.TaskEventDeleted, Task: visible})
+
+		response := (<-responses).(*graphqlgo.Response)
+		suite.Empty(response.Errors)
+
+		var data map[string]interface{}
+		suite.NoError(json.Unmarshal(response.Data, &data))
+		suite.Equal(map[string]interface{}{"taskChanged": map[string]interface{}{
+			"type": "DELETED",
+			"task": map[string]interface{}{"title": "Visible"},
+		}}, data)
+	})
+}
+
+// A function that runs the TestSuite.
+func Test_Executor(t *testing.T) {
+	suite.Run(t, new(ExecutorTestSuite))
+}
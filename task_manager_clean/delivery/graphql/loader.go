@@ -0,0 +1,116 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"task_manager/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// userLoader loads the users of a request in batches, so that resolving the owners of a list of tasks takes a single
+// query instead of one per task. The lists prime the IDs they will ask for, and the first load reads all of them.
+// The users are kept for the rest of the request.
+type userLoader struct {
+	users   domain.UserUsecase
+	mu      sync.Mutex
+	pending []primitive.ObjectID
+	results map[primitive.ObjectID]*userResult
+}
+
+// A struct that holds the outcome of loading a user. done is closed once the batch that contains it is read.
+type userResult struct {
+	done chan struct{}
+	user *domain.User
+	err  *domain.Error
+}
+
+// A constructor that creates a new instance of userLoader.
+func newUserLoader(users domain.UserUsecase) *userLoader {
+	return &userLoader{
+		users:   users,
+		results: map[primitive.ObjectID]*userResult{},
+	}
+}
+
+// A method that adds the IDs to the next batch, unless they are already loaded or being loaded.
+func (l *userLoader) prime(ids ...primitive.ObjectID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, id := range ids {
+		if _, ok := l.results[id]; !ok && !id.IsZero() {
+			l.results[id] = &userResult{done: make(chan struct{})}
+			l.pending = append(l.pending, id)
+		}
+	}
+}
+
+// A method that returns the user with the given ID, or nil if there is none. If the user is not loaded yet, it is
+// read together with every pending ID.
+func (l *userLoader) load(ctx context.Context, id primitive.ObjectID) (*domain.User, *domain.Error) {
+	l.prime(id)
+
+	l.mu.Lock()
+	result := l.results[id]
+	batch := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	if len(batch) > 0 {
+		l.read(ctx, batch)
+	}
+
+	// Another resolver may be reading the batch that contains the ID.
+	<-result.done
+	return result.user, result.err
+}
+
+// A helper method that reads a batch of users and completes their results.
+func (l *userLoader) read(ctx context.Context, ids []primitive.ObjectID) {
+	users, _err := l.users.GetUsersByIDs(ctx, ids)
+
+	found := map[primitive.ObjectID]*domain.User{}
+	for i := range users {
+		found[users[i].ID] = &users[i]
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, id := range ids {
+		result := l.results[id]
+		result.user, result.err = found[id], _err
+		close(result.done)
+	}
+}
+
+// ownerTaskLoader loads the tasks the user of a request can view once, and groups them by owner, so that resolving
+// the tasks of a list of users takes a single query.
+type ownerTaskLoader struct {
+	tasks  domain.TaskUsecase
+	claims *domain.Claims
+	once   sync.Once
+	owners map[primitive.ObjectID][]domain.Task
+	err    *domain.Error
+}
+
+// A constructor that creates a new instance of ownerTaskLoader.
+func newOwnerTaskLoader(tasks domain.TaskUsecase, claims *domain.Claims) *ownerTaskLoader {
+	return &ownerTaskLoader{tasks: tasks, claims: claims}
+}
+
+// A method that returns the tasks of the owner that the user of the request can view.
+func (l *ownerTaskLoader) load(ctx context.Context, ownerID primitive.ObjectID) ([]domain.Task, *domain.Error) {
+	l.once.Do(func() {
+		var tasks []domain.Task
+		tasks, l.err = l.tasks.GetTasks(ctx, &domain.TaskQuery{}, l.claims)
+
+		l.owners = map[primitive.ObjectID][]domain.Task{}
+		for _, task := range tasks {
+			l.owners[task.UserID] = append(l.owners[task.UserID], task)
+		}
+	})
+
+	return l.owners[ownerID], l.err
+}
@@ -0,0 +1,342 @@
+package graphql
+
+import (
+	"context"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"task_manager/usecase"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Resolver is the root resolver of the queries, mutations and subscriptions. It calls the same usecases as the REST
+// controllers, with the claims of the request, so that the authorization is the same.
+type Resolver struct {
+	tasks  domain.TaskUsecase
+	users  domain.UserUsecase
+	events domain.TaskEventBroker
+}
+
+// The arguments of the fields that take a single ID.
+type idArgs struct {
+	ID graphql.ID
+}
+
+// The arguments of the tasks query.
+type tasksArgs struct {
+	WorkspaceID *graphql.ID
+	ProjectID   *graphql.ID
+	Sort        *string
+}
+
+// A method that returns the tasks the user can view, optionally limited to a workspace and a project.
+func (r *Resolver) Tasks(ctx context.Context, args tasksArgs) ([]*taskResolver, error) {
+	claims := stateFrom(ctx).claims
+	err := requireScope(ctx, claims, domain.ScopeTasksRead)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &domain.TaskQuery{Sort: valueOf(args.Sort)}
+	if args.WorkspaceID != nil {
+		query.WorkspaceID = string(*args.WorkspaceID)
+	}
+	if args.ProjectID != nil {
+		query.ProjectID = string(*args.ProjectID)
+	}
+
+	tasks, _err := r.tasks.GetTasks(ctx, query, claims)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return newTaskResolvers(ctx, tasks), nil
+}
+
+// A method that returns a task with the given ID.
+func (r *Resolver) Task(ctx context.Context, args idArgs) (*taskResolver, error) {
+	claims := stateFrom(ctx).claims
+	err := requireScope(ctx, claims, domain.ScopeTasksRead)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := parseID(ctx, args.ID, "task")
+	if err != nil {
+		return nil, err
+	}
+
+	task, _err := r.tasks.GetTaskByID(ctx, id, claims)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return &taskResolver{task: *task}, nil
+}
+
+// A method that returns all the users.
+func (r *Resolver) Users(ctx context.Context) ([]*userResolver, error) {
+	users, _err := r.users.GetUsers(ctx)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	resolvers := make([]*userResolver, 0, len(users))
+	for _, user := range users {
+		resolvers = append(resolvers, &userResolver{user: user})
+	}
+
+	return resolvers, nil
+}
+
+// A method that returns a user with the given ID.
+func (r *Resolver) User(ctx context.Context, args idArgs) (*userResolver, error) {
+	id, err := parseID(ctx, args.ID, "user")
+	if err != nil {
+		return nil, err
+	}
+
+	return r.getUser(ctx, id)
+}
+
+// A method that returns the user that made the request.
+func (r *Resolver) Me(ctx context.Context) (*userResolver, error) {
+	return r.getUser(ctx, stateFrom(ctx).claims.ID)
+}
+
+// A helper method that returns the resolver of a user with the given ID.
+func (r *Resolver) getUser(ctx context.Context, id primitive.ObjectID) (*userResolver, error) {
+	user, _err := r.users.GetUserByID(ctx, id)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return &userResolver{user: *user}, nil
+}
+
+// The input of the createTask mutation.
+type createTaskInput struct {
+	Title       string
+	Description *string
+	DueDate     graphql.Time
+	Status      *string
+	Priority    *string
+	ProjectID   graphql.ID
+}
+
+// A method that creates a new task. The status is "Pending" by default.
+func (r *Resolver) CreateTask(ctx context.Context, args struct{ Input createTaskInput }) (*taskResolver, error) {
+	claims := stateFrom(ctx).claims
+	err := requireScope(ctx, claims, domain.ScopeTasksWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	projectID, err := parseID(ctx, args.Input.ProjectID, "project")
+	if err != nil {
+		return nil, err
+	}
+
+	taskData := &domain.CreateTaskData{
+		Title:       args.Input.Title,
+		Description: valueOf(args.Input.Description),
+		DueDate:     args.Input.DueDate.Time,
+		Status:      valueOf(args.Input.Status),
+		Priority:    valueOf(args.Input.Priority),
+		ProjectID:   projectID,
+	}
+	if taskData.Status == "" {
+		taskData.Status = "Pending"
+	}
+
+	_err := infrastructure.Validate(taskData)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	taskView, _err := r.tasks.CreateTask(ctx, taskData, claims)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return r.getChangedTask(ctx, taskView)
+}
+
+// The input of the replaceTask mutation.
+type replaceTaskInput struct {
+	Title       string
+	Description string
+	DueDate     graphql.Time
+	Status      string
+	Priority    *string
+}
+
+// A method that fully replaces a task with the given ID.
+func (r *Resolver) ReplaceTask(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input replaceTaskInput
+}) (*taskResolver, error) {
+	claims := stateFrom(ctx).claims
+	err := requireScope(ctx, claims, domain.ScopeTasksWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := parseID(ctx, args.ID, "task")
+	if err != nil {
+		return nil, err
+	}
+
+	taskData := &domain.ReplaceTaskData{
+		Title:       args.Input.Title,
+		Description: args.Input.Description,
+		DueDate:     args.Input.DueDate.Time,
+		Status:      args.Input.Status,
+		Priority:    valueOf(args.Input.Priority),
+	}
+
+	_err := infrastructure.Validate(taskData)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	taskView, _err := r.tasks.ReplaceTask(ctx, id, taskData, claims)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return r.getChangedTask(ctx, taskView)
+}
+
+// The input of the updateTask mutation. The fields that are not given are left unchanged.
+type updateTaskInput struct {
+	Title       *string
+	Description *string
+	DueDate     *graphql.Time
+	Status      *string
+	Priority    *string
+}
+
+// A method that partially updates a task with the given ID.
+func (r *Resolver) UpdateTask(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input updateTaskInput
+}) (*taskResolver, error) {
+	claims := stateFrom(ctx).claims
+	err := requireScope(ctx, claims, domain.ScopeTasksWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := parseID(ctx, args.ID, "task")
+	if err != nil {
+		return nil, err
+	}
+
+	taskData := &domain.UpdateTaskData{
+		Title:       valueOf(args.Input.Title),
+		Description: valueOf(args.Input.Description),
+		Status:      valueOf(args.Input.Status),
+		Priority:    valueOf(args.Input.Priority),
+	}
+	if args.Input.DueDate != nil {
+		taskData.DueDate = args.Input.DueDate.Time
+	}
+
+	_err := infrastructure.Validate(taskData)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	taskView, _err := r.tasks.UpdateTask(ctx, id, taskData, claims)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return r.getChangedTask(ctx, taskView)
+}
+
+// A method that deletes a task with the given ID.
+func (r *Resolver) DeleteTask(ctx context.Context, args idArgs) (bool, error) {
+	claims := stateFrom(ctx).claims
+	err := requireScope(ctx, claims, domain.ScopeTasksWrite)
+	if err != nil {
+		return false, err
+	}
+
+	id, err := parseID(ctx, args.ID, "task")
+	if err != nil {
+		return false, err
+	}
+
+	_err := r.tasks.DeleteTask(ctx, id, claims)
+	if _err != nil {
+		return false, newError(ctx, _err)
+	}
+
+	return true, nil
+}
+
+// A helper method that reads a task after a mutation, so that the mutation can return its relations.
+func (r *Resolver) getChangedTask(ctx context.Context, taskView *domain.TaskView) (*taskResolver, error) {
+	id, err := parseID(ctx, graphql.ID(taskView.ID), "task")
+	if err != nil {
+		return nil, err
+	}
+
+	task, _err := r.tasks.GetTaskByID(ctx, id, stateFrom(ctx).claims)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return &taskResolver{task: *task}, nil
+}
+
+// The arguments of the taskChanged subscription.
+type taskChangedArgs struct {
+	WorkspaceID *graphql.ID
+}
+
+// A method that streams the changes of the tasks the user can view, optionally limited to a workspace.
+// Each change is checked with the same authorization as reading the task.
+func (r *Resolver) TaskChanged(ctx context.Context, args taskChangedArgs) (<-chan *taskEventResolver, error) {
+	claims := stateFrom(ctx).claims
+	err := requireScope(ctx, claims, domain.ScopeTasksRead)
+	if err != nil {
+		return nil, err
+	}
+
+	var workspaceID primitive.ObjectID
+	if args.WorkspaceID != nil {
+		workspaceID, err = parseID(ctx, *args.WorkspaceID, "workspace")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	events := usecase.WatchTasks(ctx, r.tasks, r.events, workspaceID, claims)
+	changes := make(chan *taskEventResolver)
+	go func() {
+		defer close(changes)
+
+		for event := range events {
+			select {
+			case changes <- &taskEventResolver{event: event}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// A helper function that returns the value of an optional string, or the empty string.
+func valueOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,136 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+	"task_manager/domain"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A resolver of the Task type.
+type taskResolver struct {
+	task domain.Task
+}
+
+// A helper function that returns the resolvers of a list of tasks, and primes the loading of their owners so that
+// they are read in a single batch.
+func newTaskResolvers(ctx context.Context, tasks []domain.Task) []*taskResolver {
+	resolvers := make([]*taskResolver, 0, len(tasks))
+	owners := make([]primitive.ObjectID, 0, len(tasks))
+	for _, task := range tasks {
+		resolvers = append(resolvers, &taskResolver{task: task})
+		owners = append(owners, task.UserID)
+	}
+
+	stateFrom(ctx).users.prime(owners...)
+	return resolvers
+}
+
+func (r *taskResolver) ID() graphql.ID {
+	return graphql.ID(r.task.ID.Hex())
+}
+
+func (r *taskResolver) Title() string {
+	return r.task.Title
+}
+
+func (r *taskResolver) Description() string {
+	return r.task.Description
+}
+
+func (r *taskResolver) DueDate() graphql.Time {
+	return graphql.Time{Time: r.task.DueDate}
+}
+
+func (r *taskResolver) Status() string {
+	return r.task.Status
+}
+
+func (r *taskResolver) Priority() string {
+	return r.task.Priority
+}
+
+func (r *taskResolver) Urgency() float64 {
+	return r.task.Urgency(time.Now())
+}
+
+// A method that returns the owner of the task, or null if the owner no longer exists.
+func (r *taskResolver) Owner(ctx context.Context) (*userResolver, error) {
+	user, _err := stateFrom(ctx).users.load(ctx, r.task.UserID)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	if user == nil {
+		return nil, nil
+	}
+
+	return &userResolver{user: *user}, nil
+}
+
+func (r *taskResolver) WorkspaceID() *graphql.ID {
+	return optionalID(r.task.WorkspaceID)
+}
+
+func (r *taskResolver) ProjectID() *graphql.ID {
+	return optionalID(r.task.ProjectID)
+}
+
+// A resolver of the User type. The password and the two-factor secrets are not part of the type.
+type userResolver struct {
+	user domain.User
+}
+
+func (r *userResolver) ID() graphql.ID {
+	return graphql.ID(r.user.ID.Hex())
+}
+
+func (r *userResolver) Username() string {
+	return r.user.Username
+}
+
+func (r *userResolver) Role() string {
+	return r.user.Role
+}
+
+// A method that returns the tasks of the user that the user that made the request can view.
+func (r *userResolver) Tasks(ctx context.Context) ([]*taskResolver, error) {
+	state := stateFrom(ctx)
+	err := requireScope(ctx, state.claims, domain.ScopeTasksRead)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, _err := state.tasks.load(ctx, r.user.ID)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return newTaskResolvers(ctx, tasks), nil
+}
+
+// A resolver of the TaskEvent type.
+type taskEventResolver struct {
+	event domain.TaskEvent
+}
+
+func (r *taskEventResolver) Type() string {
+	return strings.ToUpper(r.event.Type)
+}
+
+func (r *taskEventResolver) Task() *taskResolver {
+	return &taskResolver{task: r.event.Task}
+}
+
+// A helper function that returns the ID, or nil if it is not set.
+func optionalID(id primitive.ObjectID) *graphql.ID {
+	if id.IsZero() {
+		return nil
+	}
+
+	gqlID := graphql.ID(id.Hex())
+	return &gqlID
+}
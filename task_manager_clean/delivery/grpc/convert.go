@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"task_manager/delivery/grpc/pb"
+	"task_manager/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The types of the task events of the API.
+var taskEventTypes = map[string]pb.TaskEvent_Type{
+	domain.TaskEventCreated: pb.TaskEvent_TYPE_CREATED,
+	domain.TaskEventUpdated: pb.TaskEvent_TYPE_UPDATED,
+	domain.TaskEventDeleted: pb.TaskEvent_TYPE_DELETED,
+}
+
+// A function that converts a task to its message.
+func toTask(task *domain.Task) *pb.Task {
+	return &pb.Task{
+		Id:          task.ID.Hex(),
+		Title:       task.Title,
+		Description: task.Description,
+		DueDate:     toTimestamp(task.DueDate),
+		Status:      task.Status,
+		OwnerId:     task.UserID.Hex(),
+		WorkspaceId: hexOrEmpty(task.WorkspaceID),
+		ProjectId:   hexOrEmpty(task.ProjectID),
+		Priority:    task.Priority,
+		Urgency:     task.Urgency(time.Now()),
+	}
+}
+
+// A function that converts a task event to its message.
+func toTaskEvent(event domain.TaskEvent) *pb.TaskEvent {
+	return &pb.TaskEvent{
+		Type: taskEventTypes[event.Type],
+		Task: toTask(&event.Task),
+	}
+}
+
+// A function that converts a user to its message. The password and the two-factor secrets are never sent.
+func toUser(user *domain.User) *pb.User {
+	return &pb.User{
+		Id:       user.ID.Hex(),
+		Username: user.Username,
+		Role:     user.Role,
+		Email:    user.Email,
+	}
+}
+
+// A function that converts a user to the message returned by the public methods, which leaves out the email.
+func toPublicUser(user *domain.User) *pb.User {
+	public := user.Public()
+	return &pb.User{
+		Id:       public.ID.Hex(),
+		Username: public.Username,
+		Role:     public.Role,
+	}
+}
+
+// A helper function that converts a time to a timestamp. The zero time has no timestamp.
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+// A helper function that converts a timestamp to a time. A missing timestamp is the zero time, as an absent
+// due_date is in the JSON of the REST API.
+func fromTimestamp(timestamp *timestamppb.Timestamp) time.Time {
+	if timestamp == nil {
+		return time.Time{}
+	}
+
+	return timestamp.AsTime()
+}
+
+// A helper function that returns the hex of an ObjectID, or the empty string for the zero ObjectID.
+func hexOrEmpty(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+
+	return id.Hex()
+}
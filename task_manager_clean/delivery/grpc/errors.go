@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// The domain of the ErrorInfo details attached to the errors of the API.
+const errorDomain = "task_manager"
+
+// The gRPC codes of the HTTP statuses the usecases return.
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// A function that returns the gRPC code of an HTTP status.
+func StatusCode(httpStatus int) codes.Code {
+	code, ok := statusCodes[httpStatus]
+	if ok {
+		return code
+	}
+
+	if httpStatus >= http.StatusInternalServerError {
+		return codes.Internal
+	}
+
+	return codes.Unknown
+}
+
+// A function that converts a domain error to a gRPC status error. The code of the problem details is attached as
+// the reason of an ErrorInfo, and the invalid fields as a BadRequest, so that clients can tell errors apart as
+// they do with the REST API. The internal cause is logged and never returned to the client.
+func newError(ctx context.Context, _err *domain.Error) error {
+	if _err.StatusCode >= http.StatusInternalServerError {
+		infrastructure.Logger(ctx).Error("grpc call failed", "error", _err.Error())
+	}
+
+	problem := _err.Problem()
+	message := problem.Detail
+	if message == "" {
+		message = problem.Title
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: problem.Code, Domain: errorDomain}}
+	if len(problem.Errors) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range problem.Errors {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Reason,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	st, err := status.New(StatusCode(problem.Status), message).WithDetails(details...)
+	if err != nil {
+		return status.Error(StatusCode(problem.Status), message)
+	}
+
+	return st.Err()
+}
+
+// A helper function that converts the ID of a request to an ObjectID.
+func parseID(ctx context.Context, id string, idType string) (primitive.ObjectID, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, newError(ctx, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeInvalidID,
+			Message:    "Invalid " + idType + " ID",
+		})
+	}
+
+	return objectID, nil
+}
+
+// A helper function that converts an optional ID of a request to an ObjectID. An empty ID is the zero ObjectID.
+func parseOptionalID(ctx context.Context, id string, idType string) (primitive.ObjectID, error) {
+	if id == "" {
+		return primitive.NilObjectID, nil
+	}
+
+	return parseID(ctx, id, idType)
+}
+
+// A helper function that returns an error if the access token of the claims lacks the scope.
+func requireScope(ctx context.Context, claims *domain.Claims, scope string) error {
+	if claims.HasScope(scope) {
+		return nil
+	}
+
+	return newError(ctx, &domain.Error{
+		Err:        errors.New("missing scope"),
+		StatusCode: http.StatusForbidden,
+		Code:       domain.CodeForbiddenScope,
+		Message:    "Forbidden Hint: the " + scope + " scope is required",
+	})
+}
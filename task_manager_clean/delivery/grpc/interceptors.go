@@ -0,0 +1,215 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"task_manager/delivery/grpc/pb"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// The metadata that carries the bearer token of a call, as the Authorization header does in the REST API.
+const authorizationKey = "authorization"
+
+// The metadata that carries the ID of a call, as the X-Request-ID header does in the REST API.
+var requestIDKey = strings.ToLower(infrastructure.RequestIDHeader)
+
+// The methods that can be called without a token, as their routes are public in the REST API.
+var publicMethods = map[string]bool{
+	pb.UserService_Register_FullMethodName: true,
+	pb.UserService_Login_FullMethodName:    true,
+	pb.UserService_GetUsers_FullMethodName: true,
+	pb.UserService_GetUser_FullMethodName:  true,
+}
+
+// The scopes that personal access tokens need to call the methods, as on the matching routes of the REST API.
+var methodScopes = map[string]string{
+	pb.TaskService_GetTasks_FullMethodName:    domain.ScopeTasksRead,
+	pb.TaskService_GetTask_FullMethodName:     domain.ScopeTasksRead,
+	pb.TaskService_WatchTasks_FullMethodName:  domain.ScopeTasksRead,
+	pb.TaskService_CreateTask_FullMethodName:  domain.ScopeTasksWrite,
+	pb.TaskService_ReplaceTask_FullMethodName: domain.ScopeTasksWrite,
+	pb.TaskService_UpdateTask_FullMethodName:  domain.ScopeTasksWrite,
+	pb.TaskService_DeleteTask_FullMethodName:  domain.ScopeTasksWrite,
+	pb.UserService_CreateUser_FullMethodName:  domain.ScopeUsersWrite,
+	pb.UserService_UpdateUser_FullMethodName:  domain.ScopeUsersWrite,
+	pb.UserService_DeleteUser_FullMethodName:  domain.ScopeUsersWrite,
+}
+
+// The key under which the claims of a call are stored in its context.
+type claimsKey struct{}
+
+// A function that returns the claims of the call, or nil for the public methods.
+func claimsFrom(ctx context.Context) *domain.Claims {
+	claims, _ := ctx.Value(claimsKey{}).(*domain.Claims)
+	return claims
+}
+
+// Authenticator checks the bearer token of every call, except those of the public methods, with the same rules as
+// the authentication middleware of the REST API.
+type Authenticator struct {
+	tokens       domain.TokenService
+	accessTokens domain.AccessTokenUsecase
+}
+
+// A constructor that creates a new instance of Authenticator.
+func NewAuthenticator(tokens domain.TokenService, accessTokens domain.AccessTokenUsecase) *Authenticator {
+	return &Authenticator{
+		tokens:       tokens,
+		accessTokens: accessTokens,
+	}
+}
+
+// A method that authenticates unary calls.
+func (a *Authenticator) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// A method that authenticates streaming calls.
+func (a *Authenticator) Stream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+}
+
+// A helper method that validates the bearer token of a call, checks the scope of its method, and returns a context
+// with the claims. Calls of the public methods are not checked.
+func (a *Authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if publicMethods[method] {
+		return ctx, nil
+	}
+
+	var authorization string
+	values := metadata.ValueFromIncomingContext(ctx, authorizationKey)
+	if len(values) > 0 {
+		authorization = values[0]
+	}
+
+	claims, _err := infrastructure.Authenticate(ctx, authorization, a.tokens, a.accessTokens)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	ctx = context.WithValue(ctx, claimsKey{}, claims)
+	ctx = infrastructure.WithLogger(ctx, infrastructure.Logger(ctx).With("user_id", claims.ID.Hex()))
+
+	scope, ok := methodScopes[method]
+	if ok {
+		err := requireScope(ctx, claims, scope)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ctx, nil
+}
+
+// A function that returns an interceptor that assigns an ID to every unary call, writes a structured access log,
+// and converts panics to internal errors. The ID is taken from the x-request-id metadata if the client sent a valid
+// one, generated otherwise, and returned in the same header.
+func UnaryLogger(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		start := time.Now()
+		ctx, requestID := withRequestLogger(ctx, logger, info.FullMethod)
+		grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, requestID))
+
+		defer func() {
+			err = recoverPanic(ctx, recover(), err)
+			logCall(ctx, start, err)
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// A function that returns an interceptor that does for streaming calls what UnaryLogger does for unary calls.
+func StreamLogger(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		start := time.Now()
+		ctx, requestID := withRequestLogger(stream.Context(), logger, info.FullMethod)
+		stream.SetHeader(metadata.Pairs(requestIDKey, requestID))
+
+		defer func() {
+			err = recoverPanic(ctx, recover(), err)
+			logCall(ctx, start, err)
+		}()
+
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// A helper function that stores a logger with the ID and the method of a call in its context.
+func withRequestLogger(ctx context.Context, logger *slog.Logger, method string) (context.Context, string) {
+	var requested string
+	values := metadata.ValueFromIncomingContext(ctx, requestIDKey)
+	if len(values) > 0 {
+		requested = values[0]
+	}
+
+	requestID := infrastructure.RequestID(requested)
+	return infrastructure.WithLogger(ctx, logger.With("request_id", requestID, "method", method)), requestID
+}
+
+// A helper function that converts a recovered panic to an internal error, or returns err if there was no panic.
+func recoverPanic(ctx context.Context, recovered any, err error) error {
+	if recovered == nil {
+		return err
+	}
+
+	return newError(ctx, &domain.Error{
+		Err:        fmt.Errorf("panic: %v", recovered),
+		StatusCode: http.StatusInternalServerError,
+		Message:    "Internal server error",
+	})
+}
+
+// A helper function that writes the access log of a call, at a level that depends on its outcome.
+func logCall(ctx context.Context, start time.Time, err error) {
+	code := status.FromContextError(err).Code()
+
+	attrs := []any{
+		"code", code.String(),
+		"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK, codes.Canceled:
+	case codes.Internal, codes.Unknown, codes.Unavailable, codes.DataLoss, codes.Unimplemented:
+		level = slog.LevelError
+		attrs = append(attrs, "error", err.Error())
+	default:
+		level = slog.LevelWarn
+		attrs = append(attrs, "error", err.Error())
+	}
+
+	infrastructure.Logger(ctx).Log(ctx, level, "call", attrs...)
+}
+
+// serverStream is a grpc.ServerStream whose context carries the values added by the interceptors.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// A method that returns the context of the stream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
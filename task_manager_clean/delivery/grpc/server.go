@@ -0,0 +1,23 @@
+package grpc
+
+import (
+	"log/slog"
+	"task_manager/delivery/grpc/pb"
+	"task_manager/domain"
+
+	"google.golang.org/grpc"
+)
+
+// A function that creates the gRPC server of the task and user services. Every call is logged and authenticated
+// like a request of the REST API, and the changes of tasks are streamed from events.
+func NewServer(tasks domain.TaskUsecase, users domain.UserUsecase, events domain.TaskEventBroker, tokens domain.TokenService, accessTokens domain.AccessTokenUsecase, logger *slog.Logger) *grpc.Server {
+	authenticator := NewAuthenticator(tokens, accessTokens)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryLogger(logger), authenticator.Unary),
+		grpc.ChainStreamInterceptor(StreamLogger(logger), authenticator.Stream),
+	)
+
+	pb.RegisterTaskServiceServer(server, NewTaskServer(tasks, events))
+	pb.RegisterUserServiceServer(server, NewUserServer(users))
+	return server
+}
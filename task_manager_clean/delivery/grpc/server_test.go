@@ -0,0 +1,318 @@
+package grpc_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"task_manager/delivery/grpc"
+	"task_manager/delivery/grpc/pb"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// A suite that contains tests for the gRPC server.
+type ServerTestSuite struct {
+	suite.Suite
+	tasks        *mocks.TaskUsecase
+	users        *mocks.UserUsecase
+	events       *mocks.TaskEventBroker
+	tokens       *mocks.TokenService
+	accessTokens *mocks.AccessTokenUsecase
+	server       *grpclib.Server
+	conn         *grpclib.ClientConn
+	taskClient   pb.TaskServiceClient
+	userClient   pb.UserServiceClient
+}
+
+// A method that starts a server on an in-memory connection for each testcase.
+func (suite *ServerTestSuite) SetupSubTest() {
+	suite.tasks = new(mocks.TaskUsecase)
+	suite.users = new(mocks.UserUsecase)
+	suite.events = new(mocks.TaskEventBroker)
+	suite.tokens = new(mocks.TokenService)
+	suite.accessTokens = new(mocks.AccessTokenUsecase)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	suite.server = grpc.NewServer(suite.tasks, suite.users, suite.events, suite.tokens, suite.accessTokens, logger)
+
+	listener := bufconn.Listen(1 << 20)
+	go suite.server.Serve(listener)
+
+	conn, err := grpclib.NewClient("passthrough:///bufconn",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	suite.Require().NoError(err)
+	suite.conn = conn
+	suite.taskClient = pb.NewTaskServiceClient(conn)
+	suite.userClient = pb.NewUserServiceClient(conn)
+}
+
+// A method that stops the server and checks the expectations of each testcase.
+func (suite *ServerTestSuite) TearDownSubTest() {
+	suite.conn.Close()
+	suite.server.Stop()
+
+	suite.tasks.AssertExpectations(suite.T())
+	suite.users.AssertExpectations(suite.T())
+	suite.events.AssertExpectations(suite.T())
+	suite.tokens.AssertExpectations(suite.T())
+	suite.accessTokens.AssertExpectations(suite.T())
+}
+
+// A helper method that returns a context that authenticates the calls with the token.
+func (suite *ServerTestSuite) withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// A helper method that expects a login token with the claims, and returns the claims the server derives from it.
+func (suite *ServerTestSuite) login(token string, claims *domain.Claims) *domain.Claims {
+	suite.tokens.On("ParseToken", token).Return(claims, nil).Once()
+	return &domain.Claims{ID: claims.ID, Username: claims.Username, Role: claims.Role}
+}
+
+// A helper method that checks the code of a status error and the reason of its ErrorInfo.
+func (suite *ServerTestSuite) assertStatus(err error, code codes.Code, reason string) *status.Status {
+	st, ok := status.FromError(err)
+	suite.Require().True(ok)
+	suite.Equal(code, st.Code())
+
+	var info *errdetails.ErrorInfo
+	for _, detail := range st.Details() {
+		if detail, ok := detail.(*errdetails.ErrorInfo); ok {
+			info = detail
+		}
+	}
+	suite.Require().NotNil(info)
+	suite.Equal(reason, info.Reason)
+	return st
+}
+
+// A test for the authentication of the calls.
+func (suite *ServerTestSuite) TestAuthentication() {
+	// A testcase where a call without a token is refused.
+	suite.Run("Authentication_MissingToken", func() {
+		_, err := suite.taskClient.GetTasks(context.Background(), &pb.GetTasksRequest{})
+		suite.assertStatus(err, codes.Unauthenticated, domain.CodeUnauthorized)
+	})
+
+	// A testcase where an invalid token is refused.
+	suite.Run("Authentication_InvalidToken", func() {
+		suite.tokens.On("ParseToken", "invalid").Return(nil, io.EOF).Once()
+
+		_, err := suite.taskClient.GetTasks(suite.withToken("invalid"), &pb.GetTasksRequest{})
+		suite.assertStatus(err, codes.Unauthenticated, domain.CodeInvalidToken)
+	})
+
+	// A testcase where an access token without the scope of the method is refused.
+	suite.Run("Authentication_MissingScope", func() {
+		token := domain.AccessTokenPrefix + "token"
+		claims := mocks.GetClaims()
+		claims.Scopes = []string{domain.ScopeTasksRead}
+		suite.accessTokens.On("AuthenticateAccessToken", mock.Anything, token).Return(claims, nil).Once()
+
+		_, err := suite.taskClient.DeleteTask(suite.withToken(token), &pb.DeleteTaskRequest{Id: mocks.GetPrimitiveID1().Hex()})
+		suite.assertStatus(err, codes.PermissionDenied, domain.CodeForbiddenScope)
+	})
+
+	// A testcase where a public method is called without a token.
+	suite.Run("Authentication_PublicMethod", func() {
+		users := mocks.GetManyUsers()
+		users[0].Email = "user1@example.com"
+		suite.users.On("GetUsers", mock.Anything).Return(users, nil).Once()
+
+		response, err := suite.userClient.GetUsers(context.Background(), &pb.GetUsersRequest{})
+		suite.Require().NoError(err)
+		suite.Len(response.Users, len(users))
+		suite.Empty(response.Users[0].Email)
+	})
+}
+
+// A test for the TaskService.
+func (suite *ServerTestSuite) TestTaskService() {
+	// A testcase where the tasks of the user are returned, with the ID of the call in the header.
+	suite.Run("GetTasks_Success", func() {
+		claims := suite.login("token", mocks.GetClaims())
+		task := mocks.GetNewTask()
+		suite.tasks.On("GetTasks", mock.Anything, &domain.TaskQuery{ProjectID: "project"}, claims).Return([]domain.Task{*task}, nil).Once()
+
+		var header metadata.MD
+		ctx := metadata.AppendToOutgoingContext(suite.withToken("token"), "x-request-id", "request-1")
+		response, err := suite.taskClient.GetTasks(ctx, &pb.GetTasksRequest{ProjectId: "project"}, grpclib.Header(&header))
+		suite.Require().NoError(err)
+		suite.Require().Len(response.Tasks, 1)
+		suite.Equal(task.ID.Hex(), response.Tasks[0].Id)
+		suite.Equal(task.UserID.Hex(), response.Tasks[0].OwnerId)
+		suite.True(task.DueDate.Equal(response.Tasks[0].DueDate.AsTime()))
+		suite.Equal([]string{"request-1"}, header.Get("x-request-id"))
+	})
+
+	// A testcase where the status of a domain error is converted to its gRPC code.
+	suite.Run("GetTask_NotFound", func() {
+		claims := suite.login("token", mocks.GetClaims())
+		id := mocks.GetPrimitiveID1()
+		suite.tasks.On("GetTaskByID", mock.Anything, id, claims).Return(nil, &domain.Error{
+			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeTaskNotFound,
+			Message:    "Task not found",
+		}).Once()
+
+		_, err := suite.taskClient.GetTask(suite.withToken("token"), &pb.GetTaskRequest{Id: id.Hex()})
+		st := suite.assertStatus(err, codes.NotFound, domain.CodeTaskNotFound)
+		suite.Equal("Task not found", st.Message())
+	})
+
+	// A testcase where an invalid ID is refused before the usecase is called.
+	suite.Run("GetTask_InvalidID", func() {
+		suite.login("token", mocks.GetClaims())
+
+		_, err := suite.taskClient.GetTask(suite.withToken("token"), &pb.GetTaskRequest{Id: "invalid"})
+		suite.assertStatus(err, codes.InvalidArgument, domain.CodeInvalidID)
+	})
+
+	// A testcase where a task is created with the default status and returned as stored.
+	suite.Run("CreateTask_Success", func() {
+		claims := suite.login("token", mocks.GetClaims())
+		task := mocks.GetNewTask()
+		task.ProjectID = mocks.GetPrimitiveID3()
+		dueDate := time.Now().Add(24 * time.Hour).Truncate(time.Second).UTC()
+		suite.tasks.On("CreateTask", mock.Anything, &domain.CreateTaskData{
+			Title:     "Title",
+			DueDate:   dueDate,
+			Status:    "Pending",
+			ProjectID: task.ProjectID,
+		}, claims).Return(mocks.GetTaskView(task), nil).Once()
+		suite.tasks.On("GetTaskByID", mock.Anything, task.ID, claims).Return(task, nil).Once()
+
+		response, err := suite.taskClient.CreateTask(suite.withToken("token"), &pb.CreateTaskRequest{
+			Title:     "Title",
+			DueDate:   timestamppb.New(dueDate),
+			ProjectId: task.ProjectID.Hex(),
+		})
+		suite.Require().NoError(err)
+		suite.Equal(task.ID.Hex(), response.Id)
+		suite.Equal(task.ProjectID.Hex(), response.ProjectId)
+	})
+
+	// A testcase where the invalid fields of a task are returned as the details of the error.
+	suite.Run("CreateTask_Invalid", func() {
+		suite.login("token", mocks.GetClaims())
+
+		_, err := suite.taskClient.CreateTask(suite.withToken("token"), &pb.CreateTaskRequest{Title: "Title"})
+		st := suite.assertStatus(err, codes.InvalidArgument, domain.CodeValidationFailed)
+
+		var fields []string
+		for _, detail := range st.Details() {
+			if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+				for _, violation := range badRequest.FieldViolations {
+					fields = append(fields, violation.Field)
+				}
+			}
+		}
+		suite.ElementsMatch([]string{"due_date", "project_id"}, fields)
+	})
+
+	// A testcase where the changes of the tasks the user can view are streamed.
+	suite.Run("WatchTasks_Filtered", func() {
+		claims := suite.login("token", mocks.GetClaims())
+		visible := mocks.GetNewTask()
+		hidden := mocks.GetNewTask2()
+
+		events := make(chan domain.TaskEvent, 2)
+		events <- domain.TaskEvent{Type: domain.TaskEventCreated, Task: *hidden}
+		events <- domain.TaskEvent{Type: domain.TaskEventDeleted, Task: *visible}
+		close(events)
+
+		suite.events.On("Subscribe", mock.Anything).Return((<-chan domain.TaskEvent)(events)).Once()
+		suite.tasks.On("CanViewTask", mock.Anything, hidden, claims).Return(&domain.Error{StatusCode: http.StatusNotFound}).Once()
+		suite.tasks.On("CanViewTask", mock.Anything, visible, claims).Return(nil).Once()
+
+		stream, err := suite.taskClient.WatchTasks(suite.withToken("token"), &pb.WatchTasksRequest{})
+		suite.Require().NoError(err)
+
+		event, err := stream.Recv()
+		suite.Require().NoError(err)
+		suite.Equal(pb.TaskEvent_TYPE_DELETED, event.Type)
+		suite.Equal(visible.ID.Hex(), event.Task.Id)
+
+		_, err = stream.Recv()
+		suite.Equal(io.EOF, err)
+	})
+}
+
+// A test for the UserService.
+func (suite *ServerTestSuite) TestUserService() {
+	// A testcase where a user registers without a token.
+	suite.Run("Register_Success", func() {
+		userData := mocks.GetAuthUserData()
+		user := mocks.GetUser3(userData)
+		suite.users.On("RegisterUser", mock.Anything, userData).Return(user, nil).Once()
+
+		response, err := suite.userClient.Register(context.Background(), &pb.Credentials{
+			Username: userData.Username,
+			Password: userData.Password,
+		})
+		suite.Require().NoError(err)
+		suite.Equal(user.ID.Hex(), response.Id)
+		suite.Equal(user.Username, response.Username)
+	})
+
+	// A testcase where the credentials are incomplete.
+	suite.Run("Register_Invalid", func() {
+		_, err := suite.userClient.Register(context.Background(), &pb.Credentials{Username: "user"})
+		suite.assertStatus(err, codes.InvalidArgument, domain.CodeValidationFailed)
+	})
+
+	// A testcase where the conflict of a taken username is converted to its gRPC code.
+	suite.Run("CreateUser_Conflict", func() {
+		claims := suite.login("token", mocks.GetClaims3())
+		userData := mocks.GetCreateUserData()
+		suite.users.On("AddUser", mock.Anything, userData, claims).Return(nil, &domain.Error{
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeUsernameTaken,
+			Message:    "Username already taken",
+		}).Once()
+
+		_, err := suite.userClient.CreateUser(suite.withToken("token"), &pb.CreateUserRequest{
+			Username: userData.Username,
+			Password: userData.Password,
+			Role:     userData.Role,
+			Email:    userData.Email,
+		})
+		suite.assertStatus(err, codes.FailedPrecondition, domain.CodeUsernameTaken)
+	})
+}
+
+// A test for the StatusCode function.
+func (suite *ServerTestSuite) TestStatusCode() {
+	suite.Equal(codes.InvalidArgument, grpc.StatusCode(http.StatusBadRequest))
+	suite.Equal(codes.Unauthenticated, grpc.StatusCode(http.StatusUnauthorized))
+	suite.Equal(codes.PermissionDenied, grpc.StatusCode(http.StatusForbidden))
+	suite.Equal(codes.NotFound, grpc.StatusCode(http.StatusNotFound))
+	suite.Equal(codes.FailedPrecondition, grpc.StatusCode(http.StatusConflict))
+	suite.Equal(codes.Unavailable, grpc.StatusCode(http.StatusServiceUnavailable))
+	suite.Equal(codes.Internal, grpc.StatusCode(http.StatusBadGateway))
+	suite.Equal(codes.Unknown, grpc.StatusCode(http.StatusTeapot))
+}
+
+// A method that runs the ServerTestSuite.
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
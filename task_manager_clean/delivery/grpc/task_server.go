@@ -0,0 +1,190 @@
+package grpc
+
+import (
+	"context"
+	"task_manager/delivery/grpc/pb"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"task_manager/usecase"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// TaskServer implements the TaskService with the same usecase as the REST controllers, so that the authorization
+// and the validation are the same.
+type TaskServer struct {
+	pb.UnimplementedTaskServiceServer
+	tasks  domain.TaskUsecase
+	events domain.TaskEventBroker
+}
+
+// A constructor that creates a new instance of TaskServer.
+func NewTaskServer(tasks domain.TaskUsecase, events domain.TaskEventBroker) *TaskServer {
+	return &TaskServer{
+		tasks:  tasks,
+		events: events,
+	}
+}
+
+// A method that returns the tasks the user can view, optionally limited to a workspace and a project.
+func (s *TaskServer) GetTasks(ctx context.Context, req *pb.GetTasksRequest) (*pb.GetTasksResponse, error) {
+	query := &domain.TaskQuery{
+		WorkspaceID: req.GetWorkspaceId(),
+		ProjectID:   req.GetProjectId(),
+		Sort:        req.GetSort(),
+	}
+
+	tasks, _err := s.tasks.GetTasks(ctx, query, claimsFrom(ctx))
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	response := &pb.GetTasksResponse{Tasks: make([]*pb.Task, 0, len(tasks))}
+	for i := range tasks {
+		response.Tasks = append(response.Tasks, toTask(&tasks[i]))
+	}
+
+	return response, nil
+}
+
+// A method that returns a task with the given ID.
+func (s *TaskServer) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.Task, error) {
+	id, err := parseID(ctx, req.GetId(), "task")
+	if err != nil {
+		return nil, err
+	}
+
+	task, _err := s.tasks.GetTaskByID(ctx, id, claimsFrom(ctx))
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return toTask(task), nil
+}
+
+// A method that creates a new task. The status is "Pending" by default.
+func (s *TaskServer) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.Task, error) {
+	projectID, err := parseOptionalID(ctx, req.GetProjectId(), "project")
+	if err != nil {
+		return nil, err
+	}
+
+	taskData := &domain.CreateTaskData{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		DueDate:     fromTimestamp(req.GetDueDate()),
+		Status:      req.GetStatus(),
+		Priority:    req.GetPriority(),
+		ProjectID:   projectID,
+	}
+	if taskData.Status == "" {
+		taskData.Status = "Pending"
+	}
+
+	_err := infrastructure.Validate(taskData)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	taskView, _err := s.tasks.CreateTask(ctx, taskData, claimsFrom(ctx))
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return s.getChangedTask(ctx, taskView)
+}
+
+// A method that fully replaces a task with the given ID.
+func (s *TaskServer) ReplaceTask(ctx context.Context, req *pb.ReplaceTaskRequest) (*pb.Task, error) {
+	id, err := parseID(ctx, req.GetId(), "task")
+	if err != nil {
+		return nil, err
+	}
+
+	taskData := &domain.ReplaceTaskData{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		DueDate:     fromTimestamp(req.GetDueDate()),
+		Status:      req.GetStatus(),
+		Priority:    req.GetPriority(),
+	}
+
+	_err := infrastructure.Validate(taskData)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	taskView, _err := s.tasks.ReplaceTask(ctx, id, taskData, claimsFrom(ctx))
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return s.getChangedTask(ctx, taskView)
+}
+
+// A method that partially updates a task with the given ID. Empty fields are left unchanged.
+func (s *TaskServer) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.Task, error) {
+	id, err := parseID(ctx, req.GetId(), "task")
+	if err != nil {
+		return nil, err
+	}
+
+	taskData := &domain.UpdateTaskData{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		DueDate:     fromTimestamp(req.GetDueDate()),
+		Status:      req.GetStatus(),
+		Priority:    req.GetPriority(),
+	}
+
+	_err := infrastructure.Validate(taskData)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	taskView, _err := s.tasks.UpdateTask(ctx, id, taskData, claimsFrom(ctx))
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return s.getChangedTask(ctx, taskView)
+}
+
+// A method that deletes a task with the given ID.
+func (s *TaskServer) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*emptypb.Empty, error) {
+	id, err := parseID(ctx, req.GetId(), "task")
+	if err != nil {
+		return nil, err
+	}
+
+	_err := s.tasks.DeleteTask(ctx, id, claimsFrom(ctx))
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// A method that streams the changes of the tasks the user can view, optionally limited to a workspace, until the
+// client cancels the call or the server shuts down.
+func (s *TaskServer) WatchTasks(req *pb.WatchTasksRequest, stream pb.TaskService_WatchTasksServer) error {
+	ctx := stream.Context()
+	workspaceID, err := parseOptionalID(ctx, req.GetWorkspaceId(), "workspace")
+	if err != nil {
+		return err
+	}
+
+	for event := range usecase.WatchTasks(ctx, s.tasks, s.events, workspaceID, claimsFrom(ctx)) {
+		err = stream.Send(toTaskEvent(event))
+		if err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+// A helper method that reads a task after a change, so that the response has every field of the stored task.
+func (s *TaskServer) getChangedTask(ctx context.Context, taskView *domain.TaskView) (*pb.Task, error) {
+	return s.GetTask(ctx, &pb.GetTaskRequest{Id: taskView.ID})
+}
@@ -0,0 +1,174 @@
+package grpc
+
+import (
+	"context"
+	"task_manager/delivery/grpc/pb"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// UserServer implements the UserService with the same usecase as the REST controllers.
+type UserServer struct {
+	pb.UnimplementedUserServiceServer
+	users domain.UserUsecase
+}
+
+// A constructor that creates a new instance of UserServer.
+func NewUserServer(users domain.UserUsecase) *UserServer {
+	return &UserServer{
+		users: users,
+	}
+}
+
+// A struct that defines the rules of the credentials, which the REST API checks when binding the request.
+type credentials struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// A method that registers a new user.
+func (s *UserServer) Register(ctx context.Context, req *pb.Credentials) (*pb.User, error) {
+	userData, err := authUserData(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	user, _err := s.users.RegisterUser(ctx, userData)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return toUser(user), nil
+}
+
+// A method that logs a user in. Users with two-factor authentication get a token for the two-factor step of the
+// REST API instead of a login token.
+func (s *UserServer) Login(ctx context.Context, req *pb.Credentials) (*pb.LoginResponse, error) {
+	userData, err := authUserData(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, _err := s.users.LoginUser(ctx, userData)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return &pb.LoginResponse{
+		Token:            result.Token,
+		MfaRequired:      result.MFARequired,
+		MfaSetupRequired: result.MFASetupRequired,
+		MfaToken:         result.MFAToken,
+	}, nil
+}
+
+// A method that returns all the users.
+func (s *UserServer) GetUsers(ctx context.Context, req *pb.GetUsersRequest) (*pb.GetUsersResponse, error) {
+	users, _err := s.users.GetUsers(ctx)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	response := &pb.GetUsersResponse{Users: make([]*pb.User, 0, len(users))}
+	for i := range users {
+		response.Users = append(response.Users, toPublicUser(&users[i]))
+	}
+
+	return response, nil
+}
+
+// A method that returns a user with the given ID.
+func (s *UserServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	id, err := parseID(ctx, req.GetId(), "user")
+	if err != nil {
+		return nil, err
+	}
+
+	user, _err := s.users.GetUserByID(ctx, id)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return toPublicUser(user), nil
+}
+
+// A method that adds a new user.
+func (s *UserServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	userData := &domain.CreateUserData{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+		Role:     req.GetRole(),
+		Email:    req.GetEmail(),
+	}
+
+	_err := infrastructure.Validate(userData)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	user, _err := s.users.AddUser(ctx, userData, claimsFrom(ctx))
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return toUser(user), nil
+}
+
+// A method that updates a user with the given ID. Empty fields are left unchanged.
+func (s *UserServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
+	id, err := parseID(ctx, req.GetId(), "user")
+	if err != nil {
+		return nil, err
+	}
+
+	userData := &domain.UpdateUserData{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+		Role:     req.GetRole(),
+		Email:    req.GetEmail(),
+	}
+
+	_err := infrastructure.Validate(userData)
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	user, _err := s.users.UpdateUser(ctx, id, userData, claimsFrom(ctx))
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return toUser(user), nil
+}
+
+// A method that deletes a user with the given ID, and handles their tasks with the requested policy.
+func (s *UserServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*emptypb.Empty, error) {
+	id, err := parseID(ctx, req.GetId(), "user")
+	if err != nil {
+		return nil, err
+	}
+
+	query := &domain.DeleteUserQuery{
+		Tasks:      req.GetTasks(),
+		ReassignTo: req.GetReassignTo(),
+	}
+
+	_err := s.users.DeleteUser(ctx, id, query, claimsFrom(ctx))
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// A helper function that checks the credentials of a request and converts them to the data of the usecase.
+func authUserData(ctx context.Context, req *pb.Credentials) (*domain.AuthUserData, error) {
+	_err := infrastructure.Validate(&credentials{Username: req.GetUsername(), Password: req.GetPassword()})
+	if _err != nil {
+		return nil, newError(ctx, _err)
+	}
+
+	return &domain.AuthUserData{Username: req.GetUsername(), Password: req.GetPassword()}, nil
+}
@@ -2,72 +2,221 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io/fs"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"task_manager/config"
 	"task_manager/database"
 	"task_manager/delivery/router"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"task_manager/repository"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 func main() {
-	log.Println("Starting server...")
-
-	// Load environment variables
+	// Load the environment variables of the .env file, if there is one
 	err := godotenv.Load(".env")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Fatal(err)
+	}
+
+	// Get the command and its subcommands, which come before the flags
+	args := os.Args[1:]
+	command := []string{}
+	for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = append(command, args[0]), args[1:]
+	}
+	if len(command) > 0 && command[0] != "serve" && command[0] != "migrate" {
+		log.Fatalf("unknown command %q, expected serve or migrate", strings.Join(command, " "))
+	}
+
+	// Load and validate the configuration from the file, the environment and the flags
+	cfg, err := config.Load(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Write structured logs, with the configured level and format
+	logger, err := infrastructure.NewLogger(os.Stdout, cfg.Log.Level, cfg.Log.Format)
 	if err != nil {
 		log.Fatal(err)
 	}
+	slog.SetDefault(logger)
 
 	// Initialize database connection
-	client, err := database.Init()
+	client, err := database.Init(cfg.Database)
 	if err != nil {
 		log.Fatal(err)
 	}
+	db := client.Database(cfg.Database.Name)
+
+	// Run the migrate command instead of the server if it is given
+	if len(command) > 0 && command[0] == "migrate" {
+		err = migrate(db, command[1:])
+		client.Disconnect(context.Background())
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	slog.Info("Starting server...")
+
+	// The workspace locks that keep the WIP limits and the dependencies consistent only hold in transactions
+	if !repository.NewMongoTransactor(client).SupportsTransactions(context.Background()) {
+		slog.Error("MongoDB does not support transactions: run it as a replica set, otherwise concurrent requests can exceed the WIP limits and store dependency cycles")
+	}
+
+	// Apply the pending migrations before serving requests
+	if cfg.Database.MigrateOnStart {
+		_, err = database.Migrate(context.Background(), db, database.Migrations)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
 
 	// Initialize router
-	router := router.InitializeRouter(client)
-	database.CreateRootUser(client)
+	metrics := infrastructure.NewMetrics()
+	health := router.GetHealthUsecase(client, cfg.Database.Name, metrics)
+	events := infrastructure.NewTaskEventBroker()
+
+	// Share the caches between all the repositories of both APIs, so that every write invalidates them
+	caches := router.NewCaches(cfg.Cache)
+
+	// Create the configured root user on the first start. It is kept as it is on the next starts, so that its
+	// password can be changed, and a root user that cannot be created stops the server.
+	if cfg.Root.Username != "" {
+		rootData := &domain.AuthUserData{Username: cfg.Root.Username, Password: cfg.Root.Password}
+		rootUser, _err := router.GetAdminUsecase(db, metrics, caches).CreateRootUser(context.Background(), rootData)
+		switch {
+		case _err == nil:
+			slog.Info("Root user created", "user_id", rootUser.ID.Hex())
+		case _err.Code != domain.CodeUsernameTaken:
+			log.Fatalf("creating the root user: %s: %s", _err.Message, _err)
+		}
+	}
+
+	// Store the contents of attachments where the configuration says, for both APIs
+	blobs, err := router.NewBlobStore(db, cfg.Attachments)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	grpcServer := router.InitializeGRPCServer(cfg, client, metrics, caches, events, blobs)
+	adminRouter := router.InitializeAdminRouter(metrics, health)
+	router, err := router.InitializeRouter(cfg, client, metrics, caches, health, events, blobs)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	server := &http.Server{
-		Addr:    ":8080",
+		Addr:    cfg.Server.Addr,
 		Handler: router,
 	}
 
+	// End the event streams when shutting down, as they would otherwise keep their connections open
+	server.RegisterOnShutdown(events.Close)
+
 	// Run server in a goroutine
 	go func() {
 		err := server.ListenAndServe()
 		if err != nil && err != http.ErrServerClosed {
-			log.Fatalf("listen: %s\n", err)
+			slog.Error("listen", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	slog.Info("Server is running", "addr", cfg.Server.Addr)
+
+	// Serve the metrics and the detailed health report on a separate admin port, so that they are not exposed with
+	// the API
+	adminServer := &http.Server{
+		Addr:    cfg.Server.MetricsAddr,
+		Handler: adminRouter,
+	}
+
+	go func() {
+		err := adminServer.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			slog.Error("listen", "error", err)
+			os.Exit(1)
 		}
 	}()
 
-	log.Println("Server is running on port 8080")
+	slog.Info("Metrics are served", "addr", cfg.Server.MetricsAddr)
+
+	// Serve the gRPC API beside the REST API, unless it is disabled
+	if cfg.Server.GRPCAddr != "" {
+		listener, err := net.Listen("tcp", cfg.Server.GRPCAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		go func() {
+			err := grpcServer.Serve(listener)
+			if err != nil {
+				slog.Error("listen", "error", err)
+				os.Exit(1)
+			}
+		}()
+
+		slog.Info("gRPC server is running", "addr", cfg.Server.GRPCAddr)
+	}
 
 	// Graceful shutdown
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
 	<-ctx.Done()
-	log.Println("Shutting down server...")
+	slog.Info("Shutting down server...")
+
+	// Report the server as not ready, and give the load balancers time to stop sending traffic
+	health.Drain()
+	time.Sleep(cfg.Server.ShutdownDrainDelay)
 
 	// Create a context with a timeout for the graceful shutdown
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
-	// Close database connection
-	if err := client.Disconnect(context.Background()); err != nil {
-		log.Println("Error closing database connection:", err)
-	} else {
-		log.Println("Database connection closed")
-	}
-
 	if err := server.Shutdown(shutdownCtx); err != nil {
 		log.Fatalf("Server forced to shutdown: %s", err)
 	}
 
-	log.Println("Server exiting")
+	if err := adminServer.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Error shutting down the admin server", "error", err)
+	}
+
+	// Let the gRPC calls in flight finish, and cancel those that are left when the timeout expires. The task streams
+	// were already ended when the events were closed.
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		slog.Error("gRPC server forced to stop")
+		grpcServer.Stop()
+	}
+
+	// Close database connection once the requests in flight are done
+	if err := client.Disconnect(context.Background()); err != nil {
+		slog.Error("Error closing database connection", "error", err)
+	} else {
+		slog.Info("Database connection closed")
+	}
+
+	slog.Info("Server exiting")
 }
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"task_manager/database"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A function that runs the migrate command. Without subcommand, it applies the pending migrations.
+// The status subcommand lists the migrations and when they were applied.
+func migrate(db *mongo.Database, subcommands []string) error {
+	ctx := context.Background()
+
+	if len(subcommands) == 0 {
+		applied, err := database.Migrate(ctx, db, database.Migrations)
+		fmt.Printf("%d migration(s) applied\n", len(applied))
+		return err
+	}
+
+	if len(subcommands) > 1 || subcommands[0] != "status" {
+		return errors.New("usage: migrate [status] [flags]")
+	}
+
+	statuses, err := database.GetMigrationStatus(ctx, db, database.Migrations)
+	if err != nil {
+		return err
+	}
+
+	return database.WriteMigrationStatus(os.Stdout, statuses)
+}
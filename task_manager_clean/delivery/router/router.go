@@ -1,15 +1,40 @@
 package router
 
 import (
-	"task_manager/database"
+	"io/fs"
+	"log/slog"
+	"task_manager/config"
 	"task_manager/delivery/controllers"
+	"task_manager/delivery/graphql"
+	grpcdelivery "task_manager/delivery/grpc"
+	"task_manager/docs"
 	"task_manager/domain"
 	"task_manager/infrastructure"
 	"task_manager/repository"
 	"task_manager/usecase"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+)
+
+const (
+	// The duration for which a password reset token is valid.
+	passwordResetTTL = time.Hour
+
+	// The number of password reset tokens that can wait for their delivery.
+	passwordResetQueueSize = 100
+
+	// The issuer shown for accounts in authenticator apps.
+	totpIssuer = "Task Manager"
+
+	// The maximum duration of the dependency checks of a readiness check.
+	healthCheckTimeout = 2 * time.Second
+
+	// The name of the GridFS bucket of the contents of attachments.
+	attachmentBucket = "attachments"
 )
 
 // Sets up the public routes
@@ -21,59 +46,465 @@ func PublicRoutes(router *gin.Engine, userController *controllers.UserController
 	router.GET("/users/:id", infrastructure.IDMiddleware("user"), userController.GetUserByID)
 }
 
+// Sets up the public routes of the API documentation
+func DocsRoutes(router *gin.Engine, docsController *controllers.DocsController) {
+	router.GET("/openapi.json", docsController.GetSpec)
+	router.GET("/docs", docsController.GetUI)
+	router.GET("/docs/assets/:name", docsController.GetAsset)
+}
+
+// Sets up the public health routes used by orchestrators and load balancers
+func HealthRoutes(router *gin.Engine, healthController *controllers.HealthController) {
+	router.GET("/healthz", healthController.Liveness)
+	router.GET("/readyz", healthController.Readiness)
+}
+
+// Sets up the routes of the admin port, which are not reachable through the public API
+func AdminRoutes(router *gin.Engine, healthController *controllers.HealthController, metrics *infrastructure.Metrics) {
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+	router.GET("/health", healthController.Report)
+}
+
+// Sets up the public routes related to password recovery
+func PasswordRoutes(router *gin.Engine, passwordController *controllers.PasswordController) {
+	router.POST("/password/forgot", passwordController.ForgotPassword)
+	router.POST("/password/reset", passwordController.ResetPassword)
+}
+
+// Sets up the two-factor routes that are available before a full login
+func TwoFactorRoutes(router *gin.Engine, twoFactorController *controllers.TwoFactorController, tokens domain.TokenService) {
+	setupAuth := infrastructure.SetupAuthMiddleware(tokens)
+
+	router.POST("/login/2fa", twoFactorController.VerifyLogin)
+
+	router.POST("/me/2fa/setup", setupAuth, twoFactorController.SetupTwoFactor)
+	router.POST("/me/2fa/activate", setupAuth, twoFactorController.ActivateTwoFactor)
+}
+
 // Protected Routes related to tasks
 func ProtectedTaskRoutes(router *gin.Engine, taskController *controllers.TaskController) {
-	router.GET("/tasks", taskController.GetTasks)
-	router.POST("/tasks", taskController.CreateTask)
+	read := infrastructure.RequireScope(domain.ScopeTasksRead)
+	write := infrastructure.RequireScope(domain.ScopeTasksWrite)
+
+	router.GET("/tasks", read, taskController.GetTasks)
+	router.POST("/tasks", write, taskController.CreateTask)
+
+	router.GET("/tasks/:id", read, infrastructure.IDMiddleware("task"), taskController.GetTaskByID)
+	router.PUT("/tasks/:id", write, infrastructure.IDMiddleware("task"), taskController.UpdateTaskPut)
+	router.PATCH("/tasks/:id", write, infrastructure.IDMiddleware("task"), taskController.UpdateTaskPatch)
+	router.DELETE("/tasks/:id", write, infrastructure.IDMiddleware("task"), taskController.DeleteTask)
+
+	router.POST("/tasks/:id/move", write, infrastructure.IDMiddleware("task"), taskController.MoveTask)
+	router.GET("/board", read, taskController.GetBoard)
+	router.GET("/stats", read, taskController.GetTaskStats)
+
+	router.GET("/tasks/:id/dependencies", read, infrastructure.IDMiddleware("task"), taskController.GetDependencies)
+	router.PUT("/tasks/:id/dependencies/:blocker_id", write, infrastructure.IDMiddleware("task"), infrastructure.ParamIDMiddleware("blocker_id", "blocker"), taskController.AddDependency)
+	router.DELETE("/tasks/:id/dependencies/:blocker_id", write, infrastructure.IDMiddleware("task"), infrastructure.ParamIDMiddleware("blocker_id", "blocker"), taskController.RemoveDependency)
+
+	router.GET("/tasks/:id/attachments", read, infrastructure.IDMiddleware("task"), taskController.GetAttachments)
+	router.POST("/tasks/:id/attachments", write, infrastructure.IDMiddleware("task"), taskController.AddAttachment)
+	router.GET("/tasks/:id/attachments/:attachment_id", read, infrastructure.IDMiddleware("task"), infrastructure.ParamIDMiddleware("attachment_id", "attachment"), taskController.GetAttachment)
+	router.DELETE("/tasks/:id/attachments/:attachment_id", write, infrastructure.IDMiddleware("task"), infrastructure.ParamIDMiddleware("attachment_id", "attachment"), taskController.DeleteAttachment)
+}
+
+// Protected route of the GraphQL API
+func ProtectedGraphQLRoutes(router *gin.Engine, graphQLController *controllers.GraphQLController) {
+	router.POST("/graphql", graphQLController.Handle)
+}
+
+// Protected Routes related to workspaces, their members, projects and tasks
+func ProtectedWorkspaceRoutes(router *gin.Engine, workspaceController *controllers.WorkspaceController, taskController *controllers.TaskController) {
+	read := infrastructure.RequireScope(domain.ScopeTasksRead)
+	write := infrastructure.RequireScope(domain.ScopeTasksWrite)
+	workspaceID := infrastructure.IDMiddleware("workspace")
+
+	router.GET("/workspaces", read, workspaceController.GetWorkspaces)
+	router.POST("/workspaces", write, workspaceController.CreateWorkspace)
+
+	router.GET("/workspaces/:id", read, workspaceID, workspaceController.GetWorkspaceByID)
+	router.PATCH("/workspaces/:id", write, workspaceID, workspaceController.UpdateWorkspace)
+	router.DELETE("/workspaces/:id", write, workspaceID, workspaceController.DeleteWorkspace)
+	router.PUT("/workspaces/:id/wip-limits", write, workspaceID, workspaceController.SetWIPLimits)
+
+	router.PUT("/workspaces/:id/members/:user_id", write, workspaceID, infrastructure.ParamIDMiddleware("user_id", "user"), workspaceController.SetMember)
+	router.DELETE("/workspaces/:id/members/:user_id", write, workspaceID, infrastructure.ParamIDMiddleware("user_id", "user"), workspaceController.RemoveMember)
 
-	router.GET("/tasks/:id", infrastructure.IDMiddleware("task"), taskController.GetTaskByID)
-	router.PUT("/tasks/:id", infrastructure.IDMiddleware("task"), taskController.UpdateTaskPut)
-	router.PATCH("/tasks/:id", infrastructure.IDMiddleware("task"), taskController.UpdateTaskPatch)
-	router.DELETE("/tasks/:id", infrastructure.IDMiddleware("task"), taskController.DeleteTask)
+	router.GET("/workspaces/:id/projects", read, workspaceID, workspaceController.GetProjects)
+	router.POST("/workspaces/:id/projects", write, workspaceID, workspaceController.CreateProject)
+	router.DELETE("/workspaces/:id/projects/:project_id", write, workspaceID, infrastructure.ParamIDMiddleware("project_id", "project"), workspaceController.DeleteProject)
+
+	router.GET("/workspaces/:id/tasks", read, workspaceID, taskController.GetTasks)
+	router.GET("/workspaces/:id/plan", read, workspaceID, taskController.GetTaskPlan)
+}
+
+// Protected Routes related to time tracking
+func ProtectedTimeRoutes(router *gin.Engine, timeEntryController *controllers.TimeEntryController) {
+	read := infrastructure.RequireScope(domain.ScopeTasksRead)
+	write := infrastructure.RequireScope(domain.ScopeTasksWrite)
+	taskID := infrastructure.IDMiddleware("task")
+
+	router.GET("/me/timer", read, timeEntryController.GetRunningTimer)
+	router.POST("/tasks/:id/timer/start", write, taskID, timeEntryController.StartTimer)
+	router.POST("/tasks/:id/timer/stop", write, taskID, timeEntryController.StopTimer)
+
+	router.GET("/tasks/:id/time-entries", read, taskID, timeEntryController.GetTimeEntries)
+	router.POST("/tasks/:id/time-entries", write, taskID, timeEntryController.AddTimeEntry)
+	router.DELETE("/tasks/:id/time-entries/:entry_id", write, taskID, infrastructure.ParamIDMiddleware("entry_id", "entry"), timeEntryController.DeleteTimeEntry)
+
+	router.GET("/reports/time", read, timeEntryController.GetTimeReport)
 }
 
 // Protected Routes related to users
 func ProtectedUserRoutes(router *gin.Engine, userController *controllers.UserController) {
-	router.POST("/users", userController.AddUser)
-	router.PATCH("/users/:id", infrastructure.IDMiddleware("user"), userController.UpdateUserPatch)
-	router.DELETE("/users/:id", infrastructure.IDMiddleware("user"), userController.DeleteUser)
+	write := infrastructure.RequireScope(domain.ScopeUsersWrite)
+
+	router.POST("/users", write, userController.AddUser)
+	router.PATCH("/users/:id", write, infrastructure.IDMiddleware("user"), userController.UpdateUserPatch)
+	router.DELETE("/users/:id", write, infrastructure.IDMiddleware("user"), userController.DeleteUser)
+}
+
+// Protected Routes related to two-factor authentication and security settings
+func ProtectedTwoFactorRoutes(router *gin.Engine, twoFactorController *controllers.TwoFactorController) {
+	router.DELETE("/me/2fa", infrastructure.RequireSession, twoFactorController.DisableTwoFactor)
+
+	router.GET("/settings/security", infrastructure.RequireSession, twoFactorController.GetSecuritySettings)
+	router.PUT("/settings/security", infrastructure.RequireSession, twoFactorController.UpdateSecuritySettings)
+}
+
+// Protected Routes related to personal access tokens
+func ProtectedAccessTokenRoutes(router *gin.Engine, accessTokenController *controllers.AccessTokenController) {
+	router.POST("/me/tokens", infrastructure.RequireSession, accessTokenController.CreateAccessToken)
+	router.GET("/me/tokens", infrastructure.RequireSession, accessTokenController.GetAccessTokens)
+	router.DELETE("/me/tokens/:id", infrastructure.RequireSession, infrastructure.IDMiddleware("token"), accessTokenController.RevokeAccessToken)
+}
+
+// Protected Routes related to roles and authorization
+func ProtectedRoleRoutes(router *gin.Engine, roleController *controllers.RoleController) {
+	router.GET("/roles", infrastructure.RequireSession, roleController.GetRoles)
+	router.POST("/roles", infrastructure.RequireSession, roleController.CreateRole)
+	router.PUT("/roles/:name", infrastructure.RequireSession, roleController.ReplaceRole)
+	router.DELETE("/roles/:name", infrastructure.RequireSession, roleController.DeleteRole)
+
+	router.GET("/authz/explain", roleController.ExplainAccess)
+}
+
+// A function that returns the collection with the given name. If metrics is set, the duration of its operations
+// is recorded.
+func GetCollection(db *mongo.Database, name string, metrics *infrastructure.Metrics) domain.Collection {
+	collection := &repository.MongoCollection{Collection: db.Collection(name)}
+	if metrics == nil {
+		return collection
+	}
+
+	return repository.NewInstrumentedCollection(collection, name, metrics)
+}
+
+// Caches holds the caches shared by every repository of the API, so that a write through any of them invalidates
+// the entries read through the others. Its fields are nil when caching is disabled.
+type Caches struct {
+	Tasks *repository.TaskCache
+	Users *repository.UserCache
+}
+
+// A function that creates the caches described by the configuration.
+func NewCaches(cfg config.CacheConfig) *Caches {
+	if !cfg.Enabled {
+		return &Caches{}
+	}
+
+	return &Caches{
+		Tasks: repository.NewLRUCache[primitive.ObjectID, domain.Task](cfg.Size, cfg.TTL),
+		Users: repository.NewUserCache(cfg.Size, cfg.TTL),
+	}
+}
+
+// A function that creates the blob store of the contents of attachments described by the configuration.
+func NewBlobStore(db *mongo.Database, cfg config.AttachmentsConfig) (domain.BlobStore, error) {
+	if cfg.Store == "gridfs" {
+		return repository.NewGridFSBlobStore(db, attachmentBucket)
+	}
+
+	return repository.NewLocalBlobStore(cfg.Dir), nil
 }
 
-func GetTaskController(db *mongo.Database) *controllers.TaskController {
-	collection := &repository.MongoCollection{Collection: db.Collection(domain.TaskCollection)}
-	taskRepository := repository.NewMongoTaskRepository(collection)
-	taskUsecase := usecase.NewTaskUsecase(taskRepository)
-	taskController := controllers.NewTaskController(taskUsecase)
+// A function that returns the limits of the files attached to tasks described by the configuration.
+func AttachmentLimits(cfg config.AttachmentsConfig) domain.AttachmentLimits {
+	return domain.AttachmentLimits{
+		MaxSize:      int64(cfg.MaxSize),
+		AllowedTypes: cfg.Types(),
+	}
+}
+
+// A function that returns the task repository, which reads through the task cache if there is one.
+func GetTaskRepository(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches) domain.TaskRepository {
+	taskRepository := repository.NewMongoTaskRepository(GetCollection(db, domain.TaskCollection, metrics))
+	if caches == nil || caches.Tasks == nil {
+		return taskRepository
+	}
+
+	return repository.NewCachedTaskRepository(taskRepository, caches.Tasks)
+}
+
+// A function that returns the user repository, which reads through the user cache if there is one.
+func GetUserRepository(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches) domain.UserRepository {
+	userRepository := repository.NewMongoUserRepository(GetCollection(db, domain.UserCollection, metrics))
+	if caches == nil || caches.Users == nil {
+		return userRepository
+	}
+
+	return repository.NewCachedUserRepository(userRepository, caches.Users)
+}
+
+func GetAuthorizer(db *mongo.Database, metrics *infrastructure.Metrics) *infrastructure.RoleAuthorizer {
+	collection := GetCollection(db, domain.RoleCollection, metrics)
+	roleRepository := repository.NewMongoRoleRepository(collection)
+	return infrastructure.NewRoleAuthorizer(roleRepository)
+}
+
+// A function that returns the task usecase, which publishes the changes of tasks to the subscribers of events and
+// stores the contents of attachments in blobs.
+func GetTaskUsecase(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches, events domain.TaskEventBroker, blobs domain.BlobStore, limits domain.AttachmentLimits) domain.TaskUsecase {
+	projectCollection := GetCollection(db, domain.ProjectCollection, metrics)
+	workspaceCollection := GetCollection(db, domain.WorkspaceCollection, metrics)
+	taskRepository := GetTaskRepository(db, metrics, caches)
+	projectRepository := repository.NewMongoProjectRepository(projectCollection)
+	workspaceRepository := repository.NewMongoWorkspaceRepository(workspaceCollection)
+	timeEntryRepository := repository.NewMongoTimeEntryRepository(GetCollection(db, domain.TimeEntryCollection, metrics))
+	transactor := repository.NewMongoTransactor(db.Client())
+	taskUsecase := usecase.NewTaskUsecase(taskRepository, projectRepository, workspaceRepository, timeEntryRepository, transactor, blobs, limits, GetAuthorizer(db, metrics))
+	return usecase.NewTaskEventUsecase(taskUsecase, taskRepository, events)
+}
+
+// A function that returns the user usecase, which deletes the contents of the attachments of the deleted tasks from
+// blobs.
+func GetUserUsecase(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches, tokens domain.TokenService, deletionPolicy string, blobs domain.BlobStore) domain.UserUsecase {
+	settingsCollection := GetCollection(db, domain.SettingsCollection, metrics)
+	userRepository := GetUserRepository(db, metrics, caches)
+	settingsRepository := repository.NewMongoSettingsRepository(settingsCollection)
+	taskRepository := GetTaskRepository(db, metrics, caches)
+	workspaceRepository := repository.NewMongoWorkspaceRepository(GetCollection(db, domain.WorkspaceCollection, metrics))
+	timeEntryRepository := repository.NewMongoTimeEntryRepository(GetCollection(db, domain.TimeEntryCollection, metrics))
+	accessTokenRepository := repository.NewMongoAccessTokenRepository(GetCollection(db, domain.AccessTokenCollection, metrics))
+	transactor := repository.NewMongoTransactor(db.Client())
+	return usecase.NewUserUsecase(userRepository, settingsRepository, taskRepository, workspaceRepository, timeEntryRepository, accessTokenRepository, blobs, transactor, GetAuthorizer(db, metrics), tokens, deletionPolicy)
+}
+
+func GetTaskController(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches, events domain.TaskEventBroker, blobs domain.BlobStore, limits domain.AttachmentLimits) *controllers.TaskController {
+	taskController := controllers.NewTaskController(GetTaskUsecase(db, metrics, caches, events, blobs, limits), limits.MaxSize)
 	return taskController
 }
 
-func GetUserController(db *mongo.Database) *controllers.UserController {
-	collection := &repository.MongoCollection{Collection: db.Collection(domain.UserCollection)}
-	userRepository := repository.NewMongoUserRepository(collection)
-	userUsecase := usecase.NewUserUsecase(userRepository)
-	userController := controllers.NewUserController(userUsecase)
+func GetUserController(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches, tokens domain.TokenService, deletionPolicy string, blobs domain.BlobStore) *controllers.UserController {
+	userController := controllers.NewUserController(GetUserUsecase(db, metrics, caches, tokens, deletionPolicy, blobs))
 	return userController
 }
 
-// InitializeRouter initializes the Gin router and sets up the routes
-func InitializeRouter(client *mongo.Client) *gin.Engine {
-	// Create a new Gin router
-	router := gin.Default()
+func GetGraphQLController(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches, tokens domain.TokenService, deletionPolicy string, events domain.TaskEventBroker, blobs domain.BlobStore, limits domain.AttachmentLimits) (*controllers.GraphQLController, error) {
+	taskUsecase := GetTaskUsecase(db, metrics, caches, events, blobs, limits)
+	userUsecase := GetUserUsecase(db, metrics, caches, tokens, deletionPolicy, blobs)
+	executor, err := graphql.NewExecutor(taskUsecase, userUsecase, events)
+	if err != nil {
+		return nil, err
+	}
+
+	graphQLController := controllers.NewGraphQLController(executor)
+	return graphQLController, nil
+}
+
+func GetTwoFactorController(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches, tokens domain.TokenService) *controllers.TwoFactorController {
+	settingsCollection := GetCollection(db, domain.SettingsCollection, metrics)
+	userRepository := GetUserRepository(db, metrics, caches)
+	settingsRepository := repository.NewMongoSettingsRepository(settingsCollection)
+	twoFactorUsecase := usecase.NewTwoFactorUsecase(userRepository, settingsRepository, GetAuthorizer(db, metrics), tokens, totpIssuer)
+	twoFactorController := controllers.NewTwoFactorController(twoFactorUsecase)
+	return twoFactorController
+}
+
+func GetRoleController(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches) *controllers.RoleController {
+	roleCollection := GetCollection(db, domain.RoleCollection, metrics)
+	workspaceCollection := GetCollection(db, domain.WorkspaceCollection, metrics)
+	roleRepository := repository.NewMongoRoleRepository(roleCollection)
+	taskRepository := GetTaskRepository(db, metrics, caches)
+	userRepository := GetUserRepository(db, metrics, caches)
+	workspaceRepository := repository.NewMongoWorkspaceRepository(workspaceCollection)
+	authorizer := infrastructure.NewRoleAuthorizer(roleRepository)
+	roleUsecase := usecase.NewRoleUsecase(roleRepository, taskRepository, userRepository, workspaceRepository, authorizer)
+	roleController := controllers.NewRoleController(roleUsecase)
+	return roleController
+}
+
+func GetWorkspaceController(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches, blobs domain.BlobStore) *controllers.WorkspaceController {
+	workspaceCollection := GetCollection(db, domain.WorkspaceCollection, metrics)
+	projectCollection := GetCollection(db, domain.ProjectCollection, metrics)
+	workspaceRepository := repository.NewMongoWorkspaceRepository(workspaceCollection)
+	projectRepository := repository.NewMongoProjectRepository(projectCollection)
+	taskRepository := GetTaskRepository(db, metrics, caches)
+	userRepository := GetUserRepository(db, metrics, caches)
+	workspaceUsecase := usecase.NewWorkspaceUsecase(workspaceRepository, projectRepository, taskRepository, userRepository, blobs, GetAuthorizer(db, metrics))
+	workspaceController := controllers.NewWorkspaceController(workspaceUsecase)
+	return workspaceController
+}
+
+func GetTimeEntryController(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches) *controllers.TimeEntryController {
+	timeEntryCollection := GetCollection(db, domain.TimeEntryCollection, metrics)
+	workspaceCollection := GetCollection(db, domain.WorkspaceCollection, metrics)
+	timeEntryRepository := repository.NewMongoTimeEntryRepository(timeEntryCollection)
+	taskRepository := GetTaskRepository(db, metrics, caches)
+	workspaceRepository := repository.NewMongoWorkspaceRepository(workspaceCollection)
+	userRepository := GetUserRepository(db, metrics, caches)
+	timeEntryUsecase := usecase.NewTimeEntryUsecase(timeEntryRepository, taskRepository, workspaceRepository, userRepository, GetAuthorizer(db, metrics))
+	timeEntryController := controllers.NewTimeEntryController(timeEntryUsecase)
+	return timeEntryController
+}
+
+func GetPasswordController(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches, resetConfig config.ResetConfig) (*controllers.PasswordController, error) {
+	sender, err := infrastructure.NewPasswordResetSender(resetConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	resetCollection := GetCollection(db, domain.PasswordResetCollection, metrics)
+	userRepository := GetUserRepository(db, metrics, caches)
+	resetRepository := repository.NewMongoPasswordResetRepository(resetCollection)
+	passwordUsecase := usecase.NewPasswordUsecase(userRepository, resetRepository, infrastructure.NewAsyncResetSender(sender, passwordResetQueueSize), passwordResetTTL, passwordResetQueueSize)
+	passwordController := controllers.NewPasswordController(passwordUsecase)
+	return passwordController, nil
+}
+
+// A function that returns the usecase of the maintenance operations, such as creating the root user.
+func GetAdminUsecase(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches) *usecase.AdminUsecase {
+	roleCollection := GetCollection(db, domain.RoleCollection, metrics)
+	resetCollection := GetCollection(db, domain.PasswordResetCollection, metrics)
+	userRepository := GetUserRepository(db, metrics, caches)
+	roleRepository := repository.NewMongoRoleRepository(roleCollection)
+	resetRepository := repository.NewMongoPasswordResetRepository(resetCollection)
+	return usecase.NewAdminUsecase(userRepository, roleRepository, resetRepository)
+}
+
+func GetHealthUsecase(client *mongo.Client, databaseName string, metrics *infrastructure.Metrics) *usecase.HealthUsecase {
+	db := client.Database(databaseName)
+	userRepository := repository.NewMongoUserRepository(GetCollection(db, domain.UserCollection, metrics))
+	return usecase.NewHealthUsecase(repository.NewMongoPinger(client), userRepository, infrastructure.GetBuildInfo(), healthCheckTimeout)
+}
+
+func GetAccessTokenUsecase(db *mongo.Database, metrics *infrastructure.Metrics, caches *Caches) *usecase.AccessTokenUsecase {
+	tokenCollection := GetCollection(db, domain.AccessTokenCollection, metrics)
+	tokenRepository := repository.NewMongoAccessTokenRepository(tokenCollection)
+	userRepository := GetUserRepository(db, metrics, caches)
+	return usecase.NewAccessTokenUsecase(tokenRepository, userRepository)
+}
+
+// InitializeRouter initializes the Gin router and sets up the routes with the given configuration.
+// If metrics is set, the requests and the database operations are recorded and the task metrics are registered.
+// The changes of tasks are published to events, whose subscribers are the GraphQL subscriptions and the gRPC streams.
+// The caches are shared with the gRPC server, so that a write through either API invalidates the entries of both.
+// The contents of attachments are stored in blobs.
+func InitializeRouter(cfg *config.Config, client *mongo.Client, metrics *infrastructure.Metrics, caches *Caches, healthUsecase domain.HealthUsecase, events domain.TaskEventBroker, blobs domain.BlobStore) (*gin.Engine, error) {
+	// Create a new Gin router whose contexts expose the values of the request context, such as its logger
+	router := gin.New()
+	router.ContextWithFallback = true
+
+	// Assign an ID to every request and write a structured access log
+	router.Use(infrastructure.RequestLogger(slog.Default()))
+
+	// Count the requests and record their latency
+	if metrics != nil {
+		router.Use(metrics.Middleware)
+	}
+
+	// Check the requests and responses against the OpenAPI specification, to catch drift in test environments
+	if cfg.Server.OpenAPIValidate {
+		validator, err := infrastructure.NewOpenAPIValidator(docs.OpenAPI, true)
+		if err != nil {
+			return nil, err
+		}
+
+		router.Use(validator.Middleware)
+	}
+
+	// Render the errors of every route as problem details
+	router.Use(infrastructure.ErrorMiddleware)
+	router.Use(infrastructure.RecoveryMiddleware())
+
+	db := client.Database(cfg.Database.Name)
+	tokens := infrastructure.NewJWTService(cfg.Auth.JWTKey, cfg.Auth.TokenTTL)
+
+	// Compute the task metrics at each scrape, and expose the activity of the caches
+	if metrics != nil {
+		metrics.RegisterTaskMetrics(repository.NewMongoTaskRepository(GetCollection(db, domain.TaskCollection, metrics)))
+		if caches.Tasks != nil {
+			metrics.RegisterCacheMetrics("tasks", caches.Tasks.Stats)
+			metrics.RegisterCacheMetrics("users", caches.Users.Stats)
+		}
+	}
 
 	// Get the task and user controllers
-	db := client.Database(database.DatabaseName)
-	taskController := GetTaskController(db)
-	userController := GetUserController(db)
+	limits := AttachmentLimits(cfg.Attachments)
+	taskController := GetTaskController(db, metrics, caches, events, blobs, limits)
+	userController := GetUserController(db, metrics, caches, tokens, cfg.Users.DeletionPolicy, blobs)
+	twoFactorController := GetTwoFactorController(db, metrics, caches, tokens)
+	roleController := GetRoleController(db, metrics, caches)
+	workspaceController := GetWorkspaceController(db, metrics, caches, blobs)
+	timeEntryController := GetTimeEntryController(db, metrics, caches)
+	accessTokenUsecase := GetAccessTokenUsecase(db, metrics, caches)
+	accessTokenController := controllers.NewAccessTokenController(accessTokenUsecase)
+	passwordController, err := GetPasswordController(db, metrics, caches, cfg.Reset)
+	if err != nil {
+		return nil, err
+	}
+
+	graphQLController, err := GetGraphQLController(db, metrics, caches, tokens, cfg.Users.DeletionPolicy, events, blobs, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	docsAssets, err := fs.Sub(docs.Assets, "swagger-ui")
+	if err != nil {
+		return nil, err
+	}
 
 	// Public routes
+	HealthRoutes(router, controllers.NewHealthController(healthUsecase))
 	PublicRoutes(router, userController)
+	DocsRoutes(router, controllers.NewDocsController(docs.OpenAPI, docs.UI, docsAssets))
+	PasswordRoutes(router, passwordController)
+	TwoFactorRoutes(router, twoFactorController, tokens)
 
 	// Protected routes
-	router.Use(infrastructure.AuthMiddleware)
+	router.Use(infrastructure.AuthMiddleware(tokens, accessTokenUsecase))
 	{
 		ProtectedTaskRoutes(router, taskController)
+		ProtectedWorkspaceRoutes(router, workspaceController, taskController)
+		ProtectedTimeRoutes(router, timeEntryController)
 		ProtectedUserRoutes(router, userController)
+		ProtectedTwoFactorRoutes(router, twoFactorController)
+		ProtectedAccessTokenRoutes(router, accessTokenController)
+		ProtectedRoleRoutes(router, roleController)
+		ProtectedGraphQLRoutes(router, graphQLController)
 	}
 
+	return router, nil
+}
+
+// InitializeGRPCServer initializes the gRPC server of the task and user services with the given configuration. It
+// uses the same usecases, caches, tokens and blob store as the router, so that both APIs serve the same data.
+func InitializeGRPCServer(cfg *config.Config, client *mongo.Client, metrics *infrastructure.Metrics, caches *Caches, events domain.TaskEventBroker, blobs domain.BlobStore) *grpc.Server {
+	db := client.Database(cfg.Database.Name)
+	tokens := infrastructure.NewJWTService(cfg.Auth.JWTKey, cfg.Auth.TokenTTL)
+
+	taskUsecase := GetTaskUsecase(db, metrics, caches, events, blobs, AttachmentLimits(cfg.Attachments))
+	userUsecase := GetUserUsecase(db, metrics, caches, tokens, cfg.Users.DeletionPolicy, blobs)
+	accessTokenUsecase := GetAccessTokenUsecase(db, metrics, caches)
+	return grpcdelivery.NewServer(taskUsecase, userUsecase, events, tokens, accessTokenUsecase, slog.Default())
+}
+
+// A function that initializes the router of the admin port, which serves the metrics and the detailed health report.
+func InitializeAdminRouter(metrics *infrastructure.Metrics, healthUsecase domain.HealthUsecase) *gin.Engine {
+	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(infrastructure.RecoveryMiddleware())
+
+	AdminRoutes(router, controllers.NewHealthController(healthUsecase), metrics)
 	return router
 }
@@ -0,0 +1,22 @@
+// Package docs embeds the OpenAPI specification of the API and the page that renders it.
+package docs
+
+import "embed"
+
+//go:generate sh update_swagger_ui.sh
+
+// The OpenAPI 3 specification of the API.
+//
+//go:embed openapi.json
+var OpenAPI []byte
+
+// A page that renders the OpenAPI specification with Swagger UI.
+//
+//go:embed index.html
+var UI []byte
+
+// The files of the pinned version of Swagger UI that the page loads, in the swagger-ui directory. They are not
+// downloaded by the build: run go generate in this package to fetch them.
+//
+//go:embed swagger-ui
+var Assets embed.FS
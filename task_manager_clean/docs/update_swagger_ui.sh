@@ -0,0 +1,22 @@
+#!/bin/sh
+# Downloads the version of Swagger UI pinned in swagger-ui/VERSION into swagger-ui, where it is embedded and served
+# at /docs/assets. The checksums of the files are kept in swagger-ui/SHA256SUMS-<version>: they are recorded by the
+# first download of a version, and later downloads must match them.
+set -eu
+
+cd "$(dirname "$0")/swagger-ui"
+version=$(cat VERSION)
+tmp=$(mktemp -d)
+trap 'rm -rf "$tmp"' EXIT
+
+curl -fsSL "https://registry.npmjs.org/swagger-ui-dist/-/swagger-ui-dist-$version.tgz" | tar -xz -C "$tmp"
+for file in swagger-ui.css swagger-ui-bundle.js LICENSE; do
+	cp "$tmp/package/$file" "$file"
+done
+
+if [ -f "SHA256SUMS-$version" ]; then
+	sha256sum -c --quiet "SHA256SUMS-$version"
+else
+	rm -f SHA256SUMS-*
+	sha256sum swagger-ui.css swagger-ui-bundle.js LICENSE > "SHA256SUMS-$version"
+fi
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	AccessTokenCollection = "access_tokens"
+)
+
+const (
+	// The prefix that identifies personal access tokens in the Authorization header.
+	AccessTokenPrefix = "tm_pat_"
+
+	// The scopes that can be granted to personal access tokens.
+	ScopeTasksRead  = "tasks:read"
+	ScopeTasksWrite = "tasks:write"
+	ScopeUsersWrite = "users:write"
+)
+
+// The list of all scopes that can be granted to personal access tokens.
+var AccessTokenScopes = []string{ScopeTasksRead, ScopeTasksWrite, ScopeUsersWrite}
+
+// A struct that defines a personal access token. Only the hash of the token is stored.
+type AccessToken struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID     primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Name       string             `json:"name" bson:"name"`
+	TokenHash  string             `json:"-" bson:"token_hash"`
+	Scopes     []string           `json:"scopes" bson:"scopes"`
+	ExpiresAt  *time.Time         `json:"expires_at" bson:"expires_at"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	LastUsedAt *time.Time         `json:"last_used_at" bson:"last_used_at"`
+}
+
+// A struct that defines the data required to create a personal access token.
+type CreateAccessTokenData struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// A struct that defines the data returned when a personal access token is created.
+// The token itself is only returned once.
+type CreatedAccessToken struct {
+	AccessToken
+	Token string `json:"token"`
+}
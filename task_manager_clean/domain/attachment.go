@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The error returned by a blob store when no blob has the given key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// A struct that defines a file attached to a task. The metadata is stored on the task, and the content is stored in
+// the blob store under the key returned by AttachmentKey.
+type Attachment struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	Filename    string             `json:"filename" bson:"filename"`
+	ContentType string             `json:"content_type" bson:"content_type"`
+	Size        int64              `json:"size" bson:"size"`
+	UserID      primitive.ObjectID `json:"user_id" bson:"user_id"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// A struct that defines a file uploaded to a task. Size is the size announced by the request, and Content is read
+// once.
+type AttachmentUpload struct {
+	Filename string
+	Size     int64
+	Content  io.Reader
+}
+
+// A struct that defines the limits of the files attached to tasks. The type of a file is detected from its content,
+// and must be one of AllowedTypes.
+type AttachmentLimits struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+// A function that returns the key of the content of an attachment in the blob store.
+func AttachmentKey(taskID, attachmentID primitive.ObjectID) string {
+	return "tasks/" + taskID.Hex() + "/" + attachmentID.Hex()
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The columns of the board, in the order they are shown.
+var BoardStatuses = []string{"Pending", "In Progress", "Completed"}
+
+// The digits of the ranks of tasks. Ranks are compared as strings, so the digits are in ascending order.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// A struct that defines the tasks of a board, in a column per status.
+type Board struct {
+	Columns []BoardColumn `json:"columns"`
+}
+
+// A struct that defines a column of a board. WIPLimit is the number of tasks of the workspace the column can hold,
+// and is only set when the board shows a single workspace with a limit for the column.
+type BoardColumn struct {
+	Status   string `json:"status"`
+	WIPLimit int    `json:"wip_limit,omitempty"`
+	Count    int    `json:"count"`
+	Tasks    []Task `json:"tasks"`
+}
+
+// A struct that defines the data required to move a task on the board. The task is placed right after the task with
+// AfterID in the column of the status, or at the top of the column if AfterID is not set.
+type MoveTaskData struct {
+	Status  string             `json:"status" validate:"required,taskstatus"`
+	AfterID primitive.ObjectID `json:"after_id"`
+}
+
+// A struct that defines the data required to set the WIP limits of the columns of a workspace. A limit of 0
+// removes the limit of the column.
+type WIPLimitsData struct {
+	Limits map[string]int `json:"limits" validate:"required,dive,keys,taskstatus,endkeys,min=0,max=1000"`
+}
+
+// A function that returns a rank that sorts between the given ranks. An empty before is below every rank, and an
+// empty after is above every rank. The ranks never end with the lowest digit, so that there is always room below
+// them.
+func RankBetween(before, after string) string {
+	// Keep the digits the ranks have in common, as far as after goes.
+	prefix := 0
+	for prefix < len(after) && rankDigit(before, prefix) == strings.IndexByte(rankDigits, after[prefix]) {
+		prefix++
+	}
+
+	if prefix > 0 {
+		rest := ""
+		if prefix < len(before) {
+			rest = before[prefix:]
+		}
+
+		return after[:prefix] + RankBetween(rest, after[prefix:])
+	}
+
+	low := rankDigit(before, 0)
+	high := len(rankDigits)
+	if after != "" {
+		high = strings.IndexByte(rankDigits, after[0])
+	}
+
+	if high-low > 1 {
+		return string(rankDigits[(low+high)/2])
+	}
+
+	// The first digits are consecutive, so the first digit of after alone sorts between the ranks if after has more
+	// digits. Otherwise, the rank continues after the first digit of before.
+	if len(after) > 1 {
+		return after[:1]
+	}
+
+	rest := ""
+	if len(before) > 1 {
+		rest = before[1:]
+	}
+
+	return string(rankDigits[low]) + RankBetween(rest, "")
+}
+
+// A function that compares the positions of two tasks in a column of the board. Ranked tasks come first, in the
+// order of their ranks, and the others follow by due date, then by ID.
+func CompareBoardTasks(a, b *Task) int {
+	if (a.Rank == "") != (b.Rank == "") {
+		if a.Rank != "" {
+			return -1
+		}
+
+		return 1
+	}
+
+	if a.Rank != b.Rank {
+		return strings.Compare(a.Rank, b.Rank)
+	}
+
+	if !a.DueDate.Equal(b.DueDate) {
+		return a.DueDate.Compare(b.DueDate)
+	}
+
+	return strings.Compare(a.ID.Hex(), b.ID.Hex())
+}
+
+// A helper function that returns the value of the digit of a rank at the given index, or 0 past its end.
+func rankDigit(rank string, index int) int {
+	if index >= len(rank) {
+		return 0
+	}
+
+	return strings.IndexByte(rankDigits, rank[index])
+}
@@ -0,0 +1,54 @@
+package domain_test
+
+import (
+	"task_manager/domain"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite that contains tests for the ranks of the board.
+type BoardTestSuite struct {
+	suite.Suite
+}
+
+// A test for the RankBetween function.
+func (suite *BoardTestSuite) TestRankBetween() {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		rank   string
+	}{
+		{"RankBetween_Empty", "", "", "i"},
+		{"RankBetween_Top", "", "i", "9"},
+		{"RankBetween_Bottom", "i", "", "r"},
+		{"RankBetween_Middle", "a", "k", "f"},
+		{"RankBetween_Adjacent", "a", "b", "ai"},
+		{"RankBetween_AdjacentLonger", "a", "b5", "b"},
+		{"RankBetween_CommonPrefix", "a1", "a3", "a2"},
+		{"RankBetween_BelowLowest", "", "1", "0i"},
+		{"RankBetween_BelowLowestPrefix", "", "01", "00i"},
+		{"RankBetween_EndOfAlphabet", "z", "", "zi"},
+		{"RankBetween_PastEndOfAlphabet", "zz", "", "zzi"},
+		{"RankBetween_BeforeEndOfAlphabet", "y", "z", "yi"},
+	}
+
+	for _, test := range tests {
+		// A testcase where the rank sorts between the given ranks and does not end with the lowest digit.
+		suite.Run(test.name, func() {
+			rank := domain.RankBetween(test.before, test.after)
+			suite.Equal(test.rank, rank)
+			suite.Less(test.before, rank)
+			if test.after != "" {
+				suite.Less(rank, test.after)
+			}
+			suite.NotEqual(byte('0'), rank[len(rank)-1])
+		})
+	}
+}
+
+// A function that runs the BoardTestSuite.
+func TestBoardTestSuite(t *testing.T) {
+	suite.Run(t, new(BoardTestSuite))
+}
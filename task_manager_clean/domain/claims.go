@@ -10,9 +10,31 @@ type Claims struct {
 	Username       string             `json:"username"`
 	Password       string             `json:"password"`
 	Role           string             `json:"role"`
+	Purpose        string             `json:"purpose,omitempty"`
+	Scopes         []string           `json:"scopes,omitempty"`
 	StandardClaims jwt.StandardClaims `json:"standard_claims"`
 }
 
 func (c *Claims) Valid() error {
 	return c.StandardClaims.Valid()
 }
+
+// A method that checks if the claims belong to a personal access token. Access tokens always carry scopes.
+func (c *Claims) IsAccessToken() bool {
+	return c.Scopes != nil
+}
+
+// A method that checks if the claims grant the given scope. Login tokens grant every scope.
+func (c *Claims) HasScope(scope string) bool {
+	if !c.IsAccessToken() {
+		return true
+	}
+
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,7 +1,135 @@
 package domain
 
+import "net/http"
+
+// The stable codes that identify errors in the problem details returned to clients.
+const (
+	CodeInvalidRequest   = "INVALID_REQUEST"
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeInvalidID        = "INVALID_ID"
+
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeInvalidCredentials   = "INVALID_CREDENTIALS"
+	CodeInvalidToken         = "INVALID_TOKEN"
+	CodeInvalidTwoFactorCode = "INVALID_TWO_FACTOR_CODE"
+
+	CodeForbidden              = "FORBIDDEN"
+	CodeForbiddenNotOwner      = "FORBIDDEN_NOT_OWNER"
+	CodeForbiddenRoleRank      = "FORBIDDEN_ROLE_RANK"
+	CodeForbiddenRoleAssign    = "FORBIDDEN_ROLE_ASSIGN"
+	CodeForbiddenPermission    = "FORBIDDEN_PERMISSION"
+	CodeForbiddenWorkspaceRole = "FORBIDDEN_WORKSPACE_ROLE"
+	CodeForbiddenScope         = "FORBIDDEN_SCOPE"
+	CodeForbiddenAccessToken   = "FORBIDDEN_ACCESS_TOKEN"
+	CodeTwoFactorRequired      = "TWO_FACTOR_REQUIRED"
+
+	CodeNotFound            = "NOT_FOUND"
+	CodeTaskNotFound        = "TASK_NOT_FOUND"
+	CodeUserNotFound        = "USER_NOT_FOUND"
+	CodeRoleNotFound        = "ROLE_NOT_FOUND"
+	CodeWorkspaceNotFound   = "WORKSPACE_NOT_FOUND"
+	CodeProjectNotFound     = "PROJECT_NOT_FOUND"
+	CodeMemberNotFound      = "MEMBER_NOT_FOUND"
+	CodeAccessTokenNotFound = "ACCESS_TOKEN_NOT_FOUND"
+	CodeTimeEntryNotFound   = "TIME_ENTRY_NOT_FOUND"
+	CodeDependencyNotFound  = "DEPENDENCY_NOT_FOUND"
+	CodeAttachmentNotFound  = "ATTACHMENT_NOT_FOUND"
+
+	CodeConflict                = "CONFLICT"
+	CodeUsernameTaken           = "USERNAME_TAKEN"
+	CodeRoleExists              = "ROLE_EXISTS"
+	CodeRoleInUse               = "ROLE_IN_USE"
+	CodeRoleBuiltin             = "ROLE_BUILTIN"
+	CodeLastOwner               = "LAST_OWNER"
+	CodeUserHasTasks            = "USER_HAS_TASKS"
+	CodeUserHasTimeEntries      = "USER_HAS_TIME_ENTRIES"
+	CodeTwoFactorNotSetUp       = "TWO_FACTOR_NOT_SET_UP"
+	CodeTwoFactorNotEnabled     = "TWO_FACTOR_NOT_ENABLED"
+	CodeTwoFactorAlreadyEnabled = "TWO_FACTOR_ALREADY_ENABLED"
+	CodeTimerAlreadyRunning     = "TIMER_ALREADY_RUNNING"
+	CodeTimerNotRunning         = "TIMER_NOT_RUNNING"
+	CodeDependencyCycle         = "DEPENDENCY_CYCLE"
+	CodeTaskBlocked             = "TASK_BLOCKED"
+	CodeWIPLimitReached         = "WIP_LIMIT_REACHED"
+
+	CodeAttachmentTooLarge       = "ATTACHMENT_TOO_LARGE"
+	CodeAttachmentTypeNotAllowed = "ATTACHMENT_TYPE_NOT_ALLOWED"
+
+	CodeTooManyAttempts = "TOO_MANY_ATTEMPTS"
+
+	CodeInternal     = "INTERNAL_ERROR"
+	CodeSpecMismatch = "SPEC_MISMATCH"
+)
+
+// The media type of the problem details defined by RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// A struct that describes an error of the application.
+// Err is the internal cause and is only logged, while Code, Message and Fields are returned to the client.
 type Error struct {
 	Err        error
 	StatusCode int
+	Code       string
 	Message    string
+	Fields     []FieldError
+}
+
+// A struct that describes why a single field of a request is invalid.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// A struct that represents the problem details of an error, as defined by RFC 7807.
+type Problem struct {
+	Type   string       `json:"type"`
+	Title  string       `json:"title"`
+	Status int          `json:"status"`
+	Detail string       `json:"detail,omitempty"`
+	Code   string       `json:"code"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// A method that implements the error interface, so that errors can be attached to the request context.
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+
+	return e.Message
+}
+
+// A method that returns the problem details of the error. Errors without a code get the generic code of their status.
+func (e *Error) Problem() *Problem {
+	code := e.Code
+	if code == "" {
+		code = DefaultCode(e.StatusCode)
+	}
+
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(e.StatusCode),
+		Status: e.StatusCode,
+		Detail: e.Message,
+		Code:   code,
+		Errors: e.Fields,
+	}
+}
+
+// A function that returns the generic code of an HTTP status.
+func DefaultCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	}
+
+	return CodeInternal
 }
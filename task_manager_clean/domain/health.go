@@ -0,0 +1,38 @@
+package domain
+
+// The statuses reported by the health endpoints and their checks.
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+	HealthStatusDraining    = "draining"
+)
+
+// A struct that describes the build of the running binary.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// A struct that describes the result of a single dependency check. The health endpoints are public, so the reason of
+// a failure is only set in the report of the admin port, and logged otherwise.
+type HealthCheck struct {
+	Status     string  `json:"status"`
+	DurationMS float64 `json:"duration_ms"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// A struct that describes the health of the API.
+// RootUserExists is only set in the report of the admin port, and does not affect the status.
+type Health struct {
+	Status         string                 `json:"status"`
+	Build          BuildInfo              `json:"build"`
+	Checks         map[string]HealthCheck `json:"checks,omitempty"`
+	RootUserExists *bool                  `json:"root_user_exists,omitempty"`
+}
+
+// A method that checks if the API can serve traffic.
+func (h *Health) Ready() bool {
+	return h.Status == HealthStatusOK
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -11,43 +13,263 @@ import (
 
 // TaskRepository defines the interface for task repository operations.
 type TaskRepository interface {
-	GetAllTasks() ([]Task, error)
-	GetTaskByID(id primitive.ObjectID) (*Task, error)
-	AddTask(task *Task) error
-	ReplaceTask(id primitive.ObjectID, taskData *Task) error
-	UpdateTask(id primitive.ObjectID, taskData bson.M) error
-	DeleteTask(id primitive.ObjectID) error
+	GetAllTasks(ctx context.Context) ([]Task, error)
+	GetTasks(ctx context.Context, filter *TaskFilter) ([]Task, error)
+	GetTaskByID(ctx context.Context, id primitive.ObjectID) (*Task, error)
+	AddTask(ctx context.Context, task *Task) error
+	ReplaceTask(ctx context.Context, id primitive.ObjectID, taskData *Task) error
+	UpdateTask(ctx context.Context, id primitive.ObjectID, taskData bson.M) error
+	DeleteTask(ctx context.Context, id primitive.ObjectID) error
+	DeleteTasks(ctx context.Context, filter *TaskFilter) error
+	ReassignTasks(ctx context.Context, filter *TaskFilter, userID primitive.ObjectID) error
+	CountTasks(ctx context.Context, filter *TaskFilter) (int64, error)
+	AddBlocker(ctx context.Context, id primitive.ObjectID, blockerID primitive.ObjectID) error
+	RemoveBlocker(ctx context.Context, filter *TaskFilter, blockerID primitive.ObjectID) error
+	AddAttachment(ctx context.Context, id primitive.ObjectID, attachment *Attachment) error
+	RemoveAttachment(ctx context.Context, id primitive.ObjectID, attachmentID primitive.ObjectID) error
+}
+
+// TaskStatsRepository defines the interface of the task repositories that can group the tasks for their statistics
+// themselves, see GetTaskStatsGroups.
+type TaskStatsRepository interface {
+	GetTaskStatsGroups(ctx context.Context, filter *TaskFilter, period *TaskStatsPeriod) (*TaskStatsGroups, error)
+}
+
+// TimeEntryRepository defines the interface for time entry repository operations.
+type TimeEntryRepository interface {
+	AddTimeEntry(ctx context.Context, entry *TimeEntry) error
+	GetTimeEntryByID(ctx context.Context, id primitive.ObjectID) (*TimeEntry, error)
+	GetRunningTimeEntry(ctx context.Context, userID primitive.ObjectID) (*TimeEntry, error)
+	GetTimeEntries(ctx context.Context, filter *TimeEntryFilter) ([]TimeEntry, error)
+	StopTimeEntry(ctx context.Context, id primitive.ObjectID, endedAt time.Time, durationSeconds int64) error
+	DeleteTimeEntry(ctx context.Context, id primitive.ObjectID) error
+	DeleteTimeEntries(ctx context.Context, filter *TimeEntryFilter) error
+	CountTimeEntries(ctx context.Context, filter *TimeEntryFilter) (int64, error)
+}
+
+// WorkspaceRepository defines the interface for workspace repository operations.
+type WorkspaceRepository interface {
+	AddWorkspace(ctx context.Context, workspace *Workspace) error
+	GetWorkspacesByUserID(ctx context.Context, userID primitive.ObjectID) ([]Workspace, error)
+	GetWorkspaceByID(ctx context.Context, id primitive.ObjectID) (*Workspace, error)
+	UpdateWorkspace(ctx context.Context, id primitive.ObjectID, workspaceData bson.M) error
+	SetMembers(ctx context.Context, id primitive.ObjectID, members []Membership) error
+	RemoveMemberships(ctx context.Context, userID primitive.ObjectID) error
+	LockWorkspace(ctx context.Context, id primitive.ObjectID) error
+	DeleteWorkspace(ctx context.Context, id primitive.ObjectID) error
+}
+
+// ProjectRepository defines the interface for project repository operations.
+type ProjectRepository interface {
+	AddProject(ctx context.Context, project *Project) error
+	GetProjectsByWorkspaceID(ctx context.Context, workspaceID primitive.ObjectID) ([]Project, error)
+	GetProjectByID(ctx context.Context, id primitive.ObjectID) (*Project, error)
+	DeleteProject(ctx context.Context, id primitive.ObjectID) error
+	DeleteProjectsByWorkspaceID(ctx context.Context, workspaceID primitive.ObjectID) error
 }
 
 // UserRepository defines the interface for user repository operations.
 type UserRepository interface {
-	AddUser(user *User) error
-	GetUsers() ([]User, error)
-	GetUserByID(objectID primitive.ObjectID) (*User, error)
-	GetUserByUsername(username string) (*User, error)
-	UpdateUser(objectID primitive.ObjectID, userData bson.M) error
-	DeleteUser(objectID primitive.ObjectID) error
+	AddUser(ctx context.Context, user *User) error
+	GetUsers(ctx context.Context) ([]User, error)
+	GetUserByID(ctx context.Context, objectID primitive.ObjectID) (*User, error)
+	GetUsersByIDs(ctx context.Context, objectIDs []primitive.ObjectID) ([]User, error)
+	GetUserByUsername(ctx context.Context, username string) (*User, error)
+	UpdateUser(ctx context.Context, objectID primitive.ObjectID, userData bson.M) error
+	DeleteUser(ctx context.Context, objectID primitive.ObjectID) error
+	CountUsersByRole(ctx context.Context, role string) (int64, error)
+	ClaimTOTPCounter(ctx context.Context, objectID primitive.ObjectID, counter int64) (bool, error)
+	ConsumeRecoveryCode(ctx context.Context, objectID primitive.ObjectID, codeHash string) (bool, error)
+	AddTwoFactorAttempt(ctx context.Context, objectID primitive.ObjectID, now time.Time, window time.Duration, limit int) (bool, error)
+}
+
+// PasswordResetRepository defines the interface for password reset token repository operations.
+type PasswordResetRepository interface {
+	AddToken(ctx context.Context, token *PasswordResetToken) error
+	GetTokenByHash(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+	MarkTokenUsed(ctx context.Context, id primitive.ObjectID) error
+	DeleteTokensByUserID(ctx context.Context, userID primitive.ObjectID) error
+}
+
+// SettingsRepository defines the interface for settings repository operations.
+type SettingsRepository interface {
+	GetSecuritySettings(ctx context.Context) (*SecuritySettings, error)
+	UpdateSecuritySettings(ctx context.Context, settings *SecuritySettings) error
+}
+
+// AccessTokenRepository defines the interface for personal access token repository operations.
+type AccessTokenRepository interface {
+	AddAccessToken(ctx context.Context, token *AccessToken) error
+	GetAccessTokensByUserID(ctx context.Context, userID primitive.ObjectID) ([]AccessToken, error)
+	GetAccessTokenByID(ctx context.Context, id primitive.ObjectID) (*AccessToken, error)
+	GetAccessTokenByHash(ctx context.Context, tokenHash string) (*AccessToken, error)
+	UpdateLastUsed(ctx context.Context, id primitive.ObjectID, lastUsedAt time.Time) error
+	DeleteAccessToken(ctx context.Context, id primitive.ObjectID) error
+	DeleteAccessTokensByUserID(ctx context.Context, userID primitive.ObjectID) error
+}
+
+// RoleRepository defines the interface for custom role repository operations.
+type RoleRepository interface {
+	GetRoles(ctx context.Context) ([]Role, error)
+	GetRoleByName(ctx context.Context, name string) (*Role, error)
+	AddRole(ctx context.Context, role *Role) error
+	ReplaceRole(ctx context.Context, name string, role *Role) error
+	DeleteRole(ctx context.Context, name string) error
+}
+
+// Transactor defines the interface for running several repository operations atomically.
+// The repositories must be called with the context passed to fn.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// BlobStore defines the interface for storing the contents of files under keys.
+// GetBlob returns ErrBlobNotFound if no blob has the key, and DeleteBlob succeeds if no blob has the key.
+type BlobStore interface {
+	PutBlob(ctx context.Context, key string, content io.Reader) error
+	GetBlob(ctx context.Context, key string) (io.ReadCloser, error)
+	DeleteBlob(ctx context.Context, key string) error
+}
+
+// Pinger defines the interface for checking that the storage backend of the repositories is reachable.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Authorizer defines the interface of the policy engine that decides which actions the caller may perform.
+type Authorizer interface {
+	Authorize(ctx context.Context, claims *Claims, request *AccessRequest) (*Decision, error)
 }
 
 // TaskUsecase defines the interface for task usecase operations.
 type TaskUsecase interface {
-	GetTasks() ([]Task, *Error)
-	GetTaskByID(objectID primitive.ObjectID) (*Task, *Error)
-	CreateTask(taskData *CreateTaskData, claims *Claims) (*TaskView, *Error)
-	ReplaceTask(objectID primitive.ObjectID, taskData *ReplaceTaskData, claims *Claims) (*TaskView, *Error)
-	UpdateTask(objectID primitive.ObjectID, taskData *UpdateTaskData, claims *Claims) (*TaskView, *Error)
-	DeleteTask(objectID primitive.ObjectID, claims *Claims) *Error
+	GetTasks(ctx context.Context, query *TaskQuery, claims *Claims) ([]Task, *Error)
+	GetTaskByID(ctx context.Context, objectID primitive.ObjectID, claims *Claims) (*Task, *Error)
+	CreateTask(ctx context.Context, taskData *CreateTaskData, claims *Claims) (*TaskView, *Error)
+	ReplaceTask(ctx context.Context, objectID primitive.ObjectID, taskData *ReplaceTaskData, claims *Claims) (*TaskView, *Error)
+	UpdateTask(ctx context.Context, objectID primitive.ObjectID, taskData *UpdateTaskData, claims *Claims) (*TaskView, *Error)
+	DeleteTask(ctx context.Context, objectID primitive.ObjectID, claims *Claims) *Error
+	CanViewTask(ctx context.Context, task *Task, claims *Claims) *Error
+	GetDependencies(ctx context.Context, objectID primitive.ObjectID, claims *Claims) (*TaskDependencies, *Error)
+	AddDependency(ctx context.Context, objectID primitive.ObjectID, blockerID primitive.ObjectID, claims *Claims) (*TaskDependencies, *Error)
+	RemoveDependency(ctx context.Context, objectID primitive.ObjectID, blockerID primitive.ObjectID, claims *Claims) *Error
+	GetTaskPlan(ctx context.Context, query *TaskQuery, claims *Claims) (*TaskPlan, *Error)
+	GetBoard(ctx context.Context, query *TaskQuery, claims *Claims) (*Board, *Error)
+	GetTaskStats(ctx context.Context, query *TaskStatsQuery, claims *Claims) (*TaskStats, *Error)
+	MoveTask(ctx context.Context, objectID primitive.ObjectID, moveData *MoveTaskData, claims *Claims) (*Task, *Error)
+	AddAttachment(ctx context.Context, objectID primitive.ObjectID, upload *AttachmentUpload, claims *Claims) (*Attachment, *Error)
+	GetAttachment(ctx context.Context, objectID primitive.ObjectID, attachmentID primitive.ObjectID, claims *Claims) (*Attachment, io.ReadCloser, *Error)
+	DeleteAttachment(ctx context.Context, objectID primitive.ObjectID, attachmentID primitive.ObjectID, claims *Claims) *Error
+}
+
+// TimeEntryUsecase defines the interface for time tracking operations.
+type TimeEntryUsecase interface {
+	StartTimer(ctx context.Context, taskID primitive.ObjectID, timerData *StartTimerData, claims *Claims) (*TimeEntry, *Error)
+	StopTimer(ctx context.Context, taskID primitive.ObjectID, claims *Claims) (*TimeEntry, *Error)
+	GetRunningTimer(ctx context.Context, claims *Claims) (*TimeEntry, *Error)
+	AddTimeEntry(ctx context.Context, taskID primitive.ObjectID, entryData *TimeEntryData, claims *Claims) (*TimeEntry, *Error)
+	GetTimeEntries(ctx context.Context, taskID primitive.ObjectID, claims *Claims) ([]TimeEntry, *Error)
+	DeleteTimeEntry(ctx context.Context, taskID primitive.ObjectID, entryID primitive.ObjectID, claims *Claims) *Error
+	GetTimeReport(ctx context.Context, query *TimeReportQuery, claims *Claims) (*TimeReport, *Error)
+}
+
+// TaskEventBroker defines the interface for delivering the changes of tasks to the subscribers of the process.
+type TaskEventBroker interface {
+	Publish(event TaskEvent)
+	Subscribe(ctx context.Context) <-chan TaskEvent
+}
+
+// WorkspaceUsecase defines the interface for workspace, membership and project operations.
+type WorkspaceUsecase interface {
+	CreateWorkspace(ctx context.Context, workspaceData *WorkspaceData, claims *Claims) (*Workspace, *Error)
+	GetWorkspaces(ctx context.Context, claims *Claims) ([]Workspace, *Error)
+	GetWorkspaceByID(ctx context.Context, id primitive.ObjectID, claims *Claims) (*Workspace, *Error)
+	UpdateWorkspace(ctx context.Context, id primitive.ObjectID, workspaceData *WorkspaceData, claims *Claims) (*Workspace, *Error)
+	SetWIPLimits(ctx context.Context, id primitive.ObjectID, limitsData *WIPLimitsData, claims *Claims) (*Workspace, *Error)
+	DeleteWorkspace(ctx context.Context, id primitive.ObjectID, claims *Claims) *Error
+	SetMember(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID, membershipData *MembershipData, claims *Claims) (*Workspace, *Error)
+	RemoveMember(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID, claims *Claims) *Error
+	CreateProject(ctx context.Context, workspaceID primitive.ObjectID, projectData *ProjectData, claims *Claims) (*Project, *Error)
+	GetProjects(ctx context.Context, workspaceID primitive.ObjectID, claims *Claims) ([]Project, *Error)
+	DeleteProject(ctx context.Context, workspaceID primitive.ObjectID, projectID primitive.ObjectID, claims *Claims) *Error
 }
 
 // UserUsecase defines the interface for user usecase operations.
 type UserUsecase interface {
-	AddUser(userData *CreateUserData, claims *Claims) (*User, *Error)
-	RegisterUser(userData *AuthUserData) (*User, *Error)
-	LoginUser(userData *AuthUserData) (string, *Error)
-	GetUsers() ([]User, *Error)
-	GetUserByID(objectID primitive.ObjectID) (*User, *Error)
-	UpdateUser(objectID primitive.ObjectID, userData *UpdateUserData, claims *Claims) (*User, *Error)
-	DeleteUser(objectID primitive.ObjectID, claims *Claims) *Error
+	AddUser(ctx context.Context, userData *CreateUserData, claims *Claims) (*User, *Error)
+	RegisterUser(ctx context.Context, userData *AuthUserData) (*User, *Error)
+	LoginUser(ctx context.Context, userData *AuthUserData) (*LoginResult, *Error)
+	GetUsers(ctx context.Context) ([]User, *Error)
+	GetUserByID(ctx context.Context, objectID primitive.ObjectID) (*User, *Error)
+	GetUsersByIDs(ctx context.Context, objectIDs []primitive.ObjectID) ([]User, *Error)
+	UpdateUser(ctx context.Context, objectID primitive.ObjectID, userData *UpdateUserData, claims *Claims) (*User, *Error)
+	DeleteUser(ctx context.Context, objectID primitive.ObjectID, query *DeleteUserQuery, claims *Claims) *Error
+}
+
+// AdminUsecase defines the interface for the maintenance operations that run directly against the store.
+type AdminUsecase interface {
+	CreateRootUser(ctx context.Context, userData *AuthUserData) (*User, *Error)
+	ResetPassword(ctx context.Context, username string, password string, disableTwoFactor bool) *Error
+	SetRole(ctx context.Context, username string, role string) (*User, *Error)
+	GetUsers(ctx context.Context) ([]User, *Error)
+}
+
+// PasswordUsecase defines the interface for password recovery operations.
+type PasswordUsecase interface {
+	ForgotPassword(ctx context.Context, data *ForgotPasswordData) *Error
+	ResetPassword(ctx context.Context, data *ResetPasswordData) *Error
+}
+
+// TwoFactorUsecase defines the interface for two-factor authentication operations.
+type TwoFactorUsecase interface {
+	SetupTwoFactor(ctx context.Context, claims *Claims) (*TwoFactorSetup, *Error)
+	ActivateTwoFactor(ctx context.Context, data *TwoFactorCodeData, claims *Claims) ([]string, *Error)
+	DisableTwoFactor(ctx context.Context, data *TwoFactorCodeData, claims *Claims) *Error
+	VerifyLogin(ctx context.Context, data *TwoFactorLoginData) (string, *Error)
+	GetSecuritySettings(ctx context.Context, claims *Claims) (*SecuritySettings, *Error)
+	UpdateSecuritySettings(ctx context.Context, settings *SecuritySettings, claims *Claims) (*SecuritySettings, *Error)
+}
+
+// AccessTokenUsecase defines the interface for personal access token operations.
+type AccessTokenUsecase interface {
+	CreateAccessToken(ctx context.Context, data *CreateAccessTokenData, claims *Claims) (*CreatedAccessToken, *Error)
+	GetAccessTokens(ctx context.Context, claims *Claims) ([]AccessToken, *Error)
+	RevokeAccessToken(ctx context.Context, id primitive.ObjectID, claims *Claims) *Error
+	AuthenticateAccessToken(ctx context.Context, token string) (*Claims, *Error)
+}
+
+// RoleUsecase defines the interface for role management and authorization explain operations.
+type RoleUsecase interface {
+	GetRoles(ctx context.Context, claims *Claims) ([]Role, *Error)
+	CreateRole(ctx context.Context, roleData *RoleData, claims *Claims) (*Role, *Error)
+	ReplaceRole(ctx context.Context, name string, roleData *RoleData, claims *Claims) (*Role, *Error)
+	DeleteRole(ctx context.Context, name string, claims *Claims) *Error
+	ExplainAccess(ctx context.Context, data *ExplainData, claims *Claims) (*Decision, *Error)
+}
+
+// HealthUsecase defines the interface for liveness and readiness operations.
+type HealthUsecase interface {
+	Liveness(ctx context.Context) *Health
+	Readiness(ctx context.Context) *Health
+	Report(ctx context.Context) *Health
+	Drain()
+}
+
+// PasswordResetSender defines the interface for delivering password reset tokens to users.
+type PasswordResetSender interface {
+	SendPasswordReset(user *User, token string, expiresAt time.Time) error
+}
+
+// TokenService defines the interface for issuing and verifying signed login tokens.
+type TokenService interface {
+	GenerateToken(user *User) (string, error)
+	GenerateMFAToken(user *User, purpose string) (string, error)
+	ParseToken(tokenString string) (*Claims, error)
+}
+
+// OperationRecorder defines the interface for recording the duration and outcome of database operations.
+type OperationRecorder interface {
+	ObserveOperation(collection, operation string, duration time.Duration, err error)
 }
 
 // Collection defines the interface for MongoDB collection operations.
@@ -56,11 +278,13 @@ type Collection interface {
 	InsertOne(context.Context, interface{}, ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
 	InsertMany(context.Context, []interface{}, ...*options.InsertManyOptions) (*mongo.InsertManyResult, error)
 	DeleteOne(context.Context, interface{}, ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+	DeleteMany(context.Context, interface{}, ...*options.DeleteOptions) (*mongo.DeleteResult, error)
 	Find(context.Context, interface{}, ...*options.FindOptions) (Cursor, error)
 	FindOneAndReplace(context.Context, interface{}, interface{}, ...*options.FindOneAndReplaceOptions) SingleResult
 	CountDocuments(context.Context, interface{}, ...*options.CountOptions) (int64, error)
 	UpdateOne(context.Context, interface{}, interface{}, ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	UpdateMany(context.Context, interface{}, interface{}, ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	Aggregate(context.Context, interface{}, ...*options.AggregateOptions) (Cursor, error)
 }
 
 // Cursor defines the interface for MongoDB cursor operations.
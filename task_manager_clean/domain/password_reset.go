@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	PasswordResetCollection = "password_resets"
+)
+
+// A struct that defines a single-use password reset token. Only the hash of the token is stored.
+type PasswordResetToken struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	TokenHash string             `json:"-" bson:"token_hash"`
+	ExpiresAt time.Time          `json:"expires_at" bson:"expires_at"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UsedAt    *time.Time         `json:"used_at" bson:"used_at"`
+}
+
+// A struct that defines the data required to request a password reset.
+type ForgotPasswordData struct {
+	Username string `json:"username" binding:"required"`
+}
+
+// A struct that defines the data required to reset a password with a reset token.
+// The new password follows the rules of CreateUserData.
+type ResetPasswordData struct {
+	Token    string `json:"token" binding:"required" validate:"required"`
+	Password string `json:"password" binding:"required" validate:"required,min=8,maxbytes=72"`
+}
@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	RoleCollection = "roles"
+)
+
+// The permissions that can be granted to roles. Permissions ending in .own only apply to resources owned by the
+// caller, the ones ending in .any apply to every resource.
+const (
+	PermissionAll = "*"
+
+	PermissionTaskReadAny   = "task.read.any"
+	PermissionTaskReadOwn   = "task.read.own"
+	PermissionTaskCreate    = "task.create"
+	PermissionTaskUpdateAny = "task.update.any"
+	PermissionTaskUpdateOwn = "task.update.own"
+	PermissionTaskDeleteAny = "task.delete.any"
+	PermissionTaskDeleteOwn = "task.delete.own"
+
+	PermissionUserCreate     = "user.create"
+	PermissionUserUpdateAny  = "user.update.any"
+	PermissionUserUpdateOwn  = "user.update.own"
+	PermissionUserDeleteAny  = "user.delete.any"
+	PermissionUserDeleteOwn  = "user.delete.own"
+	PermissionUserRoleAssign = "user.role.assign"
+
+	PermissionRoleManage     = "role.manage"
+	PermissionSettingsManage = "settings.manage"
+)
+
+// The actions that are authorized. Each action is granted by the permission of the same name, or by its .any and
+// .own variants.
+const (
+	ActionTaskRead   = "task.read"
+	ActionTaskCreate = "task.create"
+	ActionTaskUpdate = "task.update"
+	ActionTaskDelete = "task.delete"
+
+	ActionUserCreate     = "user.create"
+	ActionUserUpdate     = "user.update"
+	ActionUserDelete     = "user.delete"
+	ActionUserRoleAssign = "user.role.assign"
+
+	ActionRoleManage     = "role.manage"
+	ActionSettingsManage = "settings.manage"
+
+	// Workspace actions are only granted by the role of the user in the workspace.
+	ActionWorkspaceRead   = "workspace.read"
+	ActionWorkspaceManage = "workspace.manage"
+)
+
+// The reasons why an action can be denied.
+const (
+	DenialUnknownRole       = "unknown_role"
+	DenialUnknownTargetRole = "unknown_target_role"
+	DenialMissingPermission = "missing_permission"
+	DenialNotOwner          = "not_owner"
+	DenialRank              = "rank"
+	DenialNotMember         = "not_member"
+	DenialWorkspaceRole     = "workspace_role"
+)
+
+// The list of all permissions that can be granted to custom roles.
+var Permissions = []string{
+	PermissionTaskReadAny, PermissionTaskReadOwn, PermissionTaskCreate,
+	PermissionTaskUpdateAny, PermissionTaskUpdateOwn, PermissionTaskDeleteAny, PermissionTaskDeleteOwn,
+	PermissionUserCreate, PermissionUserUpdateAny, PermissionUserUpdateOwn,
+	PermissionUserDeleteAny, PermissionUserDeleteOwn, PermissionUserRoleAssign,
+	PermissionRoleManage, PermissionSettingsManage,
+}
+
+// The list of all actions that can be authorized.
+var Actions = []string{
+	ActionTaskRead, ActionTaskCreate, ActionTaskUpdate, ActionTaskDelete,
+	ActionUserCreate, ActionUserUpdate, ActionUserDelete, ActionUserRoleAssign,
+	ActionRoleManage, ActionSettingsManage, ActionWorkspaceRead, ActionWorkspaceManage,
+}
+
+// The roles that always exist. They cannot be changed or deleted.
+var BuiltinRoles = map[string]*Role{
+	"user": {
+		Name:        "user",
+		Description: "Manages their own tasks and account",
+		Level:       10,
+		Permissions: []string{
+			PermissionTaskReadAny, PermissionTaskCreate, PermissionTaskUpdateOwn, PermissionTaskDeleteOwn,
+			PermissionUserUpdateOwn, PermissionUserDeleteOwn,
+		},
+		BuiltIn: true,
+	},
+	"admin": {
+		Name:        "admin",
+		Description: "Manages every task and the users below them",
+		Level:       50,
+		Permissions: []string{
+			PermissionTaskReadAny, PermissionTaskCreate, PermissionTaskUpdateAny, PermissionTaskDeleteAny,
+			PermissionUserCreate, PermissionUserUpdateAny, PermissionUserUpdateOwn,
+			PermissionUserDeleteAny, PermissionUserDeleteOwn, PermissionUserRoleAssign,
+		},
+		BuiltIn: true,
+	},
+	"root": {
+		Name:        "root",
+		Description: "Has every permission",
+		Level:       100,
+		Permissions: []string{PermissionAll},
+		BuiltIn:     true,
+	},
+}
+
+// A struct that defines a role. Users can only act on users whose role has a lower level.
+type Role struct {
+	Name        string   `json:"name" bson:"_id"`
+	Description string   `json:"description" bson:"description"`
+	Level       int      `json:"level" bson:"level"`
+	Permissions []string `json:"permissions" bson:"permissions"`
+	BuiltIn     bool     `json:"built_in" bson:"-"`
+}
+
+// A method that checks if the role grants the given permission.
+func (r *Role) HasPermission(permission string) bool {
+	for _, p := range r.Permissions {
+		if p == permission || p == PermissionAll {
+			return true
+		}
+	}
+
+	return false
+}
+
+// A struct that defines the data required to create or replace a custom role.
+type RoleData struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Level       int      `json:"level" binding:"required"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// A struct that describes an action to authorize.
+// OwnerID is the owner of the resource, TargetRole is the role of the user that is acted upon, and Workspace is the
+// workspace the resource belongs to, if any.
+type AccessRequest struct {
+	Action     string
+	OwnerID    primitive.ObjectID
+	TargetRole string
+	Workspace  *Workspace
+}
+
+// A struct that defines the outcome of an authorization check and why it was reached.
+type Decision struct {
+	Allowed       bool   `json:"allowed"`
+	Action        string `json:"action"`
+	Role          string `json:"role"`
+	TargetRole    string `json:"target_role,omitempty"`
+	WorkspaceRole string `json:"workspace_role,omitempty"`
+	Grant         string `json:"grant,omitempty"`
+	Denial        string `json:"denial,omitempty"`
+	Reason        string `json:"reason"`
+}
+
+// A struct that defines the query of the authorization explain endpoint.
+type ExplainData struct {
+	Action      string `form:"action" binding:"required"`
+	TaskID      string `form:"task_id"`
+	UserID      string `form:"user_id"`
+	WorkspaceID string `form:"workspace_id"`
+	Role        string `form:"role"`
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -10,6 +11,35 @@ var (
 	TaskCollection = "tasks"
 )
 
+// The statuses a task can have.
+var TaskStatuses = []string{"Pending", "Completed", "In Progress"}
+
+// The statuses of the tasks that are not completed yet.
+var OpenTaskStatuses = []string{"Pending", "In Progress"}
+
+// The priorities a task can have, from the lowest to the highest.
+const (
+	TaskPriorityLow    = "low"
+	TaskPriorityMedium = "medium"
+	TaskPriorityHigh   = "high"
+	TaskPriorityUrgent = "urgent"
+)
+
+var TaskPriorities = []string{TaskPriorityLow, TaskPriorityMedium, TaskPriorityHigh, TaskPriorityUrgent}
+
+// The orders the task list endpoints can sort the tasks in.
+const (
+	TaskSortDueDate  = "due_date"
+	TaskSortPriority = "priority"
+	TaskSortUrgency  = "urgency"
+)
+
+var TaskSorts = []string{TaskSortDueDate, TaskSortPriority, TaskSortUrgency}
+
+// The number of days that sets how the urgency of a task grows as its due date nears: it doubles when this
+// number of days minus one is left, and reaches this number plus one times the rank when the task is due.
+const urgencyHorizonDays = 7
+
 // A struct that defines the task model.
 type Task struct {
 	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
@@ -17,38 +47,133 @@ type Task struct {
 	Description string             `json:"description" bson:"description"`
 	DueDate     time.Time          `json:"due_date" bson:"due_date"`
 	Status      string             `json:"status" bson:"status"`
+	Priority    string             `json:"priority" bson:"priority"`
+	Rank        string             `json:"rank,omitempty" bson:"rank,omitempty"`
 	UserID      primitive.ObjectID `json:"user_id" bson:"user_id"`
+	WorkspaceID primitive.ObjectID `json:"workspace_id" bson:"workspace_id,omitempty"`
+	ProjectID   primitive.ObjectID `json:"project_id" bson:"project_id,omitempty"`
+	// The tasks of the same workspace that must be completed before this one, see TaskDependencies.
+	BlockedBy []primitive.ObjectID `json:"blocked_by,omitempty" bson:"blocked_by,omitempty"`
+	// The files attached to the task, whose contents are in the blob store.
+	Attachments []Attachment `json:"attachments,omitempty" bson:"attachments,omitempty"`
+	// When the task was last completed. It is cleared when the task is reopened, and tasks completed before it was
+	// recorded have none.
+	CompletedAt *time.Time `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+}
+
+// A function that returns the rank of a priority, from 1 for low to 4 for urgent. Tasks stored before priorities
+// existed have the medium priority.
+func PriorityRank(priority string) int {
+	switch priority {
+	case TaskPriorityLow:
+		return 1
+	case TaskPriorityHigh:
+		return 3
+	case TaskPriorityUrgent:
+		return 4
+	}
+
+	return 2
+}
+
+// A method that returns how urgent the task is at the given time, from its priority and the time left until it is
+// due. The rank of the priority is multiplied by 1 + 7 / (1 + days left), so that the urgency is 1.875 times the rank
+// a week before the due date, doubles six days before, and reaches 8 times the rank when the task is due. Overdue
+// tasks count as due now, and completed tasks have no urgency. The score is rounded to two decimals.
+func (t *Task) Urgency(now time.Time) float64 {
+	if t.Status == "Completed" {
+		return 0
+	}
+
+	daysLeft := math.Max(t.DueDate.Sub(now).Hours()/24, 0)
+	urgency := float64(PriorityRank(t.Priority)) * (1 + urgencyHorizonDays/(1+daysLeft))
+	return math.Round(urgency*100) / 100
+}
+
+// The types of the changes of tasks.
+const (
+	TaskEventCreated = "created"
+	TaskEventUpdated = "updated"
+	TaskEventDeleted = "deleted"
+)
+
+// A struct that describes a change of a task. For deletions, Task holds the task as it was before the deletion.
+type TaskEvent struct {
+	Type string
+	Task Task
 }
 
 // A struct that defines the data required to create a task.
+// The rules of the validate tags are checked by infrastructure.Validate. The priority is checked by the usecase, so
+// that every API shares the same rule, and defaults to medium.
 type CreateTaskData struct {
-	Title       string    `json:"title" binding:"required"`
-	Description string    `json:"description"`
-	DueDate     time.Time `json:"due_date" binding:"required"`
-	Status      string    `json:"status"`
+	Title       string             `json:"title" validate:"notblank,max=200"`
+	Description string             `json:"description" validate:"max=5000"`
+	DueDate     time.Time          `json:"due_date" validate:"required,notpast"`
+	Status      string             `json:"status" validate:"taskstatus"`
+	Priority    string             `json:"priority"`
+	ProjectID   primitive.ObjectID `json:"project_id" validate:"required"`
 }
 
 // A struct that defines the data required to fully update a task.
 type ReplaceTaskData struct {
-	Title       string    `json:"title" binding:"required"`
-	Description string    `json:"description" binding:"required"`
-	DueDate     time.Time `json:"due_date" binding:"required"`
-	Status      string    `json:"status" binding:"required"`
+	Title       string    `json:"title" validate:"notblank,max=200"`
+	Description string    `json:"description" validate:"required,max=5000"`
+	DueDate     time.Time `json:"due_date" validate:"required,notpast"`
+	Status      string    `json:"status" validate:"required,taskstatus"`
+	Priority    string    `json:"priority"`
 }
 
 // A struct that defines the data required to partially update a task.
 type UpdateTaskData struct {
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	DueDate     time.Time `json:"due_date"`
-	Status      string    `json:"status"`
+	Title       string    `json:"title" validate:"omitempty,notblank,max=200"`
+	Description string    `json:"description" validate:"max=5000"`
+	DueDate     time.Time `json:"due_date" validate:"omitempty,notpast"`
+	Status      string    `json:"status" validate:"omitempty,taskstatus"`
+	Priority    string    `json:"priority"`
 }
 
 // A struct that defines the data that is returned when a task is manipulated.
+// Urgency is the urgency of the task when it was returned, see Task.Urgency.
 type TaskView struct {
 	ID          string    `json:"id"`
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
 	DueDate     time.Time `json:"due_date"`
 	Status      string    `json:"status"`
+	Priority    string    `json:"priority"`
+	Urgency     float64   `json:"urgency"`
+	WorkspaceID string    `json:"workspace_id,omitempty"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	// The seconds logged on the task by the stopped time entries of every user.
+	TimeSpentSeconds int64 `json:"time_spent_seconds"`
+}
+
+// A struct that defines the filters applied when listing tasks.
+// WorkspaceIDs limits the tasks to the given workspaces, and IncludeUnassigned also returns the tasks that were
+// created before workspaces existed. A nil WorkspaceIDs does not filter by workspace.
+// Statuses limits the tasks to the given statuses, and DueBefore to the tasks due before the given time.
+// IDs limits the tasks to the given IDs, and BlockedBy to the tasks blocked by the given task.
+// VisibleTo limits the tasks to those of the given user and those in OwnedWorkspaceIDs, for users who can only view
+// their own tasks.
+type TaskFilter struct {
+	UserID            primitive.ObjectID
+	WorkspaceIDs      []primitive.ObjectID
+	ProjectID         primitive.ObjectID
+	IncludeUnassigned bool
+	Statuses          []string
+	DueBefore         time.Time
+	IDs               []primitive.ObjectID
+	BlockedBy         primitive.ObjectID
+	VisibleTo         primitive.ObjectID
+	OwnedWorkspaceIDs []primitive.ObjectID
+}
+
+// A struct that defines the query parameters of the task list endpoints.
+// Sort is one of TaskSorts: by due date, by priority then due date, or by urgency. Without it, the tasks are returned
+// in the order they are stored.
+type TaskQuery struct {
+	WorkspaceID string `form:"workspace_id"`
+	ProjectID   string `form:"project_id"`
+	Sort        string `form:"sort"`
 }
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrDependencyCycle is returned when the dependencies of a set of tasks cannot be ordered, because some of the
+// tasks block each other.
+var ErrDependencyCycle = errors.New("the dependencies of the tasks form a cycle")
+
+// A struct that defines the dependencies of a task: the tasks that block it and the tasks it blocks.
+type TaskDependencies struct {
+	BlockedBy []Task `json:"blocked_by"`
+	Blocking  []Task `json:"blocking"`
+}
+
+// A struct that defines an order in which the open tasks can be completed without breaking their dependencies, and
+// the longest chain of open tasks that block each other.
+type TaskPlan struct {
+	Order        []Task `json:"order"`
+	CriticalPath []Task `json:"critical_path"`
+}
+
+// A function that returns the chain of dependencies that would become a cycle if the task was blocked by the
+// blocker, from the blocker to the task, or nil if there would be no cycle. A task cannot block itself.
+func DependencyCycle(tasks []Task, taskID, blockerID primitive.ObjectID) []primitive.ObjectID {
+	if taskID == blockerID {
+		return []primitive.ObjectID{taskID}
+	}
+
+	blockers := map[primitive.ObjectID][]primitive.ObjectID{}
+	for _, task := range tasks {
+		blockers[task.ID] = task.BlockedBy
+	}
+
+	// Walk the blockers of the blocker breadth first, remembering how each task was reached.
+	reachedFrom := map[primitive.ObjectID]primitive.ObjectID{blockerID: primitive.NilObjectID}
+	queue := []primitive.ObjectID{blockerID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range blockers[current] {
+			if _, ok := reachedFrom[next]; ok {
+				continue
+			}
+
+			reachedFrom[next] = current
+			if next == taskID {
+				path := []primitive.ObjectID{}
+				for id := taskID; !id.IsZero(); id = reachedFrom[id] {
+					path = append(path, id)
+				}
+
+				slices.Reverse(path)
+				return path
+			}
+
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+// A function that plans the open tasks in the given order of preference. A task is placed after the tasks that
+// block it, and otherwise as early as the order of preference allows. The blockers that are completed or not part
+// of the tasks are ignored. It returns ErrDependencyCycle if the tasks cannot be ordered.
+func PlanTasks(tasks []Task) (*TaskPlan, error) {
+	open := []Task{}
+	position := map[primitive.ObjectID]int{}
+	for _, task := range tasks {
+		if task.Status != "Completed" {
+			position[task.ID] = len(open)
+			open = append(open, task)
+		}
+	}
+
+	// Count the open blockers of each task, and find the tasks each of them blocks.
+	waiting := make([]int, len(open))
+	blocking := make([][]int, len(open))
+	for i, task := range open {
+		for _, blockerID := range task.BlockedBy {
+			if blocker, ok := position[blockerID]; ok {
+				waiting[i]++
+				blocking[blocker] = append(blocking[blocker], i)
+			}
+		}
+	}
+
+	ready := []int{}
+	for i := range open {
+		if waiting[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	// Take the preferred task whose blockers are all placed, and record the longest chain ending at each task.
+	order := make([]int, 0, len(open))
+	chain := make([]int, len(open))
+	previous := make([]int, len(open))
+	for i := range previous {
+		previous[i] = -1
+	}
+
+	for len(ready) > 0 {
+		current := ready[0]
+		ready = ready[1:]
+		order = append(order, current)
+		chain[current]++
+
+		for _, next := range blocking[current] {
+			if chain[current] > chain[next] {
+				chain[next] = chain[current]
+				previous[next] = current
+			}
+
+			waiting[next]--
+			if waiting[next] == 0 {
+				index, _ := slices.BinarySearch(ready, next)
+				ready = slices.Insert(ready, index, next)
+			}
+		}
+	}
+
+	if len(order) < len(open) {
+		return nil, ErrDependencyCycle
+	}
+
+	plan := &TaskPlan{Order: make([]Task, 0, len(order)), CriticalPath: []Task{}}
+	last := -1
+	for _, i := range order {
+		plan.Order = append(plan.Order, open[i])
+		if last == -1 || chain[i] > chain[last] {
+			last = i
+		}
+	}
+
+	for i := last; i != -1; i = previous[i] {
+		plan.CriticalPath = append(plan.CriticalPath, open[i])
+	}
+
+	slices.Reverse(plan.CriticalPath)
+	return plan, nil
+}
@@ -0,0 +1,108 @@
+package domain_test
+
+import (
+	"task_manager/domain"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A suite that contains tests for the dependencies of tasks.
+type TaskDependencyTestSuite struct {
+	suite.Suite
+}
+
+// A helper function that returns a task with the given status, blocked by the given tasks.
+func dependentTask(status string, blockedBy ...domain.Task) domain.Task {
+	task := domain.Task{ID: primitive.NewObjectID(), Status: status, BlockedBy: []primitive.ObjectID{}}
+	for _, blocker := range blockedBy {
+		task.BlockedBy = append(task.BlockedBy, blocker.ID)
+	}
+
+	return task
+}
+
+// A helper function that returns a diamond of tasks: a blocks b and c, which both block d.
+func diamond() (a, b, c, d domain.Task) {
+	a = dependentTask("Pending")
+	b = dependentTask("Pending", a)
+	c = dependentTask("Pending", a)
+	d = dependentTask("Pending", b, c)
+	return a, b, c, d
+}
+
+// A test for the DependencyCycle function.
+func (suite *TaskDependencyTestSuite) TestDependencyCycle() {
+	a, b, c, d := diamond()
+	tasks := []domain.Task{a, b, c, d}
+
+	tests := []struct {
+		name    string
+		taskID  primitive.ObjectID
+		blocker primitive.ObjectID
+		cycle   []primitive.ObjectID
+	}{
+		{"DependencyCycle_Self", a.ID, a.ID, []primitive.ObjectID{a.ID}},
+		{"DependencyCycle_Direct", a.ID, b.ID, []primitive.ObjectID{b.ID, a.ID}},
+		{"DependencyCycle_Diamond", a.ID, d.ID, []primitive.ObjectID{d.ID, b.ID, a.ID}},
+		{"DependencyCycle_DiamondSide", b.ID, d.ID, []primitive.ObjectID{d.ID, b.ID}},
+		{"DependencyCycle_None", d.ID, a.ID, nil},
+		{"DependencyCycle_Siblings", b.ID, c.ID, nil},
+		{"DependencyCycle_UnknownTask", primitive.NewObjectID(), d.ID, nil},
+	}
+
+	for _, test := range tests {
+		// A testcase where the chain that would become a cycle is returned, from the blocker to the task.
+		suite.Run(test.name, func() {
+			suite.Equal(test.cycle, domain.DependencyCycle(tasks, test.taskID, test.blocker))
+		})
+	}
+}
+
+// A test for the PlanTasks function.
+func (suite *TaskDependencyTestSuite) TestPlanTasks() {
+	a, b, c, d := diamond()
+	completed := dependentTask("Completed")
+	afterCompleted := dependentTask("Pending", completed, dependentTask("Pending"))
+	cycleA := dependentTask("Pending")
+	cycleB := dependentTask("Pending", cycleA)
+	cycleA.BlockedBy = []primitive.ObjectID{cycleB.ID}
+	self := dependentTask("Pending")
+	self.BlockedBy = []primitive.ObjectID{self.ID}
+
+	tests := []struct {
+		name         string
+		tasks        []domain.Task
+		order        []domain.Task
+		criticalPath []domain.Task
+		err          error
+	}{
+		{"PlanTasks_Empty", []domain.Task{}, []domain.Task{}, []domain.Task{}, nil},
+		{"PlanTasks_Diamond", []domain.Task{a, b, c, d}, []domain.Task{a, b, c, d}, []domain.Task{a, b, d}, nil},
+		{"PlanTasks_Preference", []domain.Task{d, c, b, a}, []domain.Task{a, c, b, d}, []domain.Task{a, c, d}, nil},
+		{"PlanTasks_IgnoredBlockers", []domain.Task{completed, afterCompleted}, []domain.Task{afterCompleted}, []domain.Task{afterCompleted}, nil},
+		{"PlanTasks_Cycle", []domain.Task{a, cycleA, cycleB}, nil, nil, domain.ErrDependencyCycle},
+		{"PlanTasks_SelfDependency", []domain.Task{a, self}, nil, nil, domain.ErrDependencyCycle},
+	}
+
+	for _, test := range tests {
+		// A testcase where the open tasks are ordered after their blockers, or refused if they form a cycle.
+		suite.Run(test.name, func() {
+			plan, err := domain.PlanTasks(test.tasks)
+			suite.Equal(test.err, err)
+			if test.err != nil {
+				suite.Nil(plan)
+				return
+			}
+
+			suite.Equal(test.order, plan.Order)
+			suite.Equal(test.criticalPath, plan.CriticalPath)
+		})
+	}
+}
+
+// A function that runs the TaskDependencyTestSuite.
+func TestTaskDependencyTestSuite(t *testing.T) {
+	suite.Run(t, new(TaskDependencyTestSuite))
+}
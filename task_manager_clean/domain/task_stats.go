@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A struct that defines the query parameters of the task statistics. From and To are days, and both are included.
+type TaskStatsQuery struct {
+	WorkspaceID string    `form:"workspace_id"`
+	ProjectID   string    `form:"project_id"`
+	From        time.Time `form:"from" time_format:"2006-01-02" time_utc:"1"`
+	To          time.Time `form:"to" time_format:"2006-01-02" time_utc:"1"`
+}
+
+// A struct that defines the range the statistics cover. The tasks created in the range are counted by status, and the
+// tasks completed in it make up the completion figures. A zero From or Before leaves the range open, and Before is
+// excluded. Now is the time the due dates are compared to.
+type TaskStatsPeriod struct {
+	From   time.Time
+	Before time.Time
+	Now    time.Time
+}
+
+// A method that reports whether the given time is in the range.
+func (p *TaskStatsPeriod) Includes(t time.Time) bool {
+	return (p.From.IsZero() || !t.Before(p.From)) && (p.Before.IsZero() || t.Before(p.Before))
+}
+
+// A struct that defines the statistics of a set of tasks.
+type TaskStats struct {
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+	TaskCounts
+	// The number of tasks completed per week, from the oldest week. Weeks start on Monday and have no entry if no task
+	// was completed in them.
+	CompletedPerWeek []WeeklyCompletions `json:"completed_per_week"`
+	// The statistics of each user with tasks, ordered by ID.
+	Users []UserTaskStats `json:"users"`
+}
+
+// A struct that defines the figures of the task statistics. Total, ByStatus and Overdue count the tasks created in
+// the range, by their current status. Completed counts the tasks completed in the range, and
+// AverageCompletionSeconds is the average time from their creation to their completion.
+type TaskCounts struct {
+	Total                    int            `json:"total"`
+	ByStatus                 map[string]int `json:"by_status"`
+	Overdue                  int            `json:"overdue"`
+	Completed                int            `json:"completed"`
+	AverageCompletionSeconds int64          `json:"average_completion_seconds"`
+}
+
+// A struct that defines the number of tasks completed in the week starting on the given day.
+type WeeklyCompletions struct {
+	Week  string `json:"week"`
+	Count int    `json:"count"`
+}
+
+// A struct that defines the statistics of the tasks of a user.
+type UserTaskStats struct {
+	UserID primitive.ObjectID `json:"user_id"`
+	TaskCounts
+}
+
+// A struct that defines the tasks grouped the way the statistics are computed, so that the repositories that can
+// group the tasks themselves only return the groups.
+type TaskStatsGroups struct {
+	Statuses    []TaskStatusGroup     `bson:"statuses"`
+	Completions []TaskCompletionGroup `bson:"completions"`
+}
+
+// A struct that defines the number of tasks of a user created in the range with a status, and how many of them are
+// overdue.
+type TaskStatusGroup struct {
+	UserID  primitive.ObjectID `bson:"user_id"`
+	Status  string             `bson:"status"`
+	Count   int                `bson:"count"`
+	Overdue int                `bson:"overdue"`
+}
+
+// A struct that defines the number of tasks of a user completed in a week of the range, and the seconds they took
+// from their creation to their completion.
+type TaskCompletionGroup struct {
+	UserID  primitive.ObjectID `bson:"user_id"`
+	Week    time.Time          `bson:"week"`
+	Count   int                `bson:"count"`
+	Seconds int64              `bson:"seconds"`
+}
+
+// A function that returns the groups of the statistics of the tasks that match the filter. The repositories that
+// implement TaskStatsRepository group the tasks themselves, and the tasks of the others are grouped in process.
+func GetTaskStatsGroups(ctx context.Context, repo TaskRepository, filter *TaskFilter, period *TaskStatsPeriod) (*TaskStatsGroups, error) {
+	if statsRepo, ok := repo.(TaskStatsRepository); ok {
+		return statsRepo.GetTaskStatsGroups(ctx, filter, period)
+	}
+
+	tasks, err := repo.GetTasks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return GroupTaskStats(tasks, period), nil
+}
+
+// A function that groups the tasks for their statistics. The tasks are created when their IDs were generated.
+func GroupTaskStats(tasks []Task, period *TaskStatsPeriod) *TaskStatsGroups {
+	type statusKey struct {
+		userID primitive.ObjectID
+		status string
+	}
+	type completionKey struct {
+		userID primitive.ObjectID
+		week   time.Time
+	}
+
+	groups := &TaskStatsGroups{Statuses: []TaskStatusGroup{}, Completions: []TaskCompletionGroup{}}
+	statuses := map[statusKey]int{}
+	completions := map[completionKey]int{}
+	for _, task := range tasks {
+		createdAt := task.ID.Timestamp()
+
+		if period.Includes(createdAt) {
+			key := statusKey{task.UserID, task.Status}
+			index, ok := statuses[key]
+			if !ok {
+				index = len(groups.Statuses)
+				statuses[key] = index
+				groups.Statuses = append(groups.Statuses, TaskStatusGroup{UserID: task.UserID, Status: task.Status})
+			}
+
+			groups.Statuses[index].Count++
+			if task.Status != "Completed" && task.DueDate.Before(period.Now) {
+				groups.Statuses[index].Overdue++
+			}
+		}
+
+		if task.CompletedAt != nil && period.Includes(*task.CompletedAt) {
+			key := completionKey{task.UserID, WeekOf(*task.CompletedAt)}
+			index, ok := completions[key]
+			if !ok {
+				index = len(groups.Completions)
+				completions[key] = index
+				groups.Completions = append(groups.Completions, TaskCompletionGroup{UserID: task.UserID, Week: key.week})
+			}
+
+			groups.Completions[index].Count++
+			groups.Completions[index].Seconds += int64(task.CompletedAt.Sub(createdAt) / time.Second)
+		}
+	}
+
+	return groups
+}
+
+// A function that returns the statistics of the grouped tasks.
+func NewTaskStats(groups *TaskStatsGroups) *TaskStats {
+	stats := &TaskStats{TaskCounts: newTaskCounts(), CompletedPerWeek: []WeeklyCompletions{}, Users: []UserTaskStats{}}
+
+	users := map[primitive.ObjectID]*UserTaskStats{}
+	user := func(id primitive.ObjectID) *TaskCounts {
+		if _, ok := users[id]; !ok {
+			users[id] = &UserTaskStats{UserID: id, TaskCounts: newTaskCounts()}
+		}
+
+		return &users[id].TaskCounts
+	}
+
+	for _, group := range groups.Statuses {
+		for _, counts := range []*TaskCounts{&stats.TaskCounts, user(group.UserID)} {
+			counts.Total += group.Count
+			counts.ByStatus[group.Status] += group.Count
+			counts.Overdue += group.Overdue
+		}
+	}
+
+	weeks := map[time.Time]int{}
+	seconds := map[*TaskCounts]int64{}
+	for _, group := range groups.Completions {
+		weeks[group.Week.UTC()] += group.Count
+		for _, counts := range []*TaskCounts{&stats.TaskCounts, user(group.UserID)} {
+			counts.Completed += group.Count
+			seconds[counts] += group.Seconds
+		}
+	}
+
+	for counts, total := range seconds {
+		counts.AverageCompletionSeconds = total / int64(counts.Completed)
+	}
+
+	for week, count := range weeks {
+		stats.CompletedPerWeek = append(stats.CompletedPerWeek, WeeklyCompletions{Week: week.Format("2006-01-02"), Count: count})
+	}
+	slices.SortFunc(stats.CompletedPerWeek, func(a, b WeeklyCompletions) int {
+		return strings.Compare(a.Week, b.Week)
+	})
+
+	for _, userStats := range users {
+		stats.Users = append(stats.Users, *userStats)
+	}
+	slices.SortFunc(stats.Users, func(a, b UserTaskStats) int {
+		return strings.Compare(a.UserID.Hex(), b.UserID.Hex())
+	})
+
+	return stats
+}
+
+// A function that returns the Monday that starts the week of the given time, in UTC.
+func WeekOf(t time.Time) time.Time {
+	day := t.UTC().Truncate(24 * time.Hour)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// A helper function that returns the counts of no tasks, with every status.
+func newTaskCounts() TaskCounts {
+	counts := TaskCounts{ByStatus: map[string]int{}}
+	for _, status := range TaskStatuses {
+		counts.ByStatus[status] = 0
+	}
+
+	return counts
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	TimeEntryCollection = "time_entries"
+)
+
+// The groups a time report can aggregate the time entries by.
+const (
+	TimeReportByUser = "user"
+	TimeReportByTask = "task"
+	TimeReportByTag  = "tag"
+	TimeReportByDay  = "day"
+)
+
+// A struct that defines the time a user spent on a task. A running timer is an entry without an end, and only
+// stopped entries count towards the totals. The workspace of the task is copied, so that reports can be limited to
+// the workspaces of the user without reading the tasks.
+type TimeEntry struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TaskID          primitive.ObjectID `json:"task_id" bson:"task_id"`
+	UserID          primitive.ObjectID `json:"user_id" bson:"user_id"`
+	WorkspaceID     primitive.ObjectID `json:"workspace_id" bson:"workspace_id,omitempty"`
+	StartedAt       time.Time          `json:"started_at" bson:"started_at"`
+	EndedAt         *time.Time         `json:"ended_at" bson:"ended_at"`
+	DurationSeconds int64              `json:"duration_seconds" bson:"duration_seconds"`
+	Running         bool               `json:"running" bson:"running"`
+	Note            string             `json:"note" bson:"note"`
+	Tags            []string           `json:"tags" bson:"tags"`
+}
+
+// A function that returns the number of seconds logged by the stopped time entries.
+func TimeSpent(entries []TimeEntry) int64 {
+	var seconds int64
+	for _, entry := range entries {
+		if !entry.Running {
+			seconds += entry.DurationSeconds
+		}
+	}
+
+	return seconds
+}
+
+// A struct that defines the data of a timer that is started.
+type StartTimerData struct {
+	Note string   `json:"note" validate:"max=1000"`
+	Tags []string `json:"tags" validate:"dive,notblank,max=50"`
+}
+
+// A struct that defines the data required to log a time entry manually.
+type TimeEntryData struct {
+	StartedAt time.Time `json:"started_at" validate:"required"`
+	EndedAt   time.Time `json:"ended_at" validate:"required"`
+	Note      string    `json:"note" validate:"max=1000"`
+	Tags      []string  `json:"tags" validate:"dive,notblank,max=50"`
+}
+
+// A struct that defines the filters applied when listing time entries.
+// WorkspaceIDs and IncludeUnassigned limit the entries as in TaskFilter. StartedFrom and StartedBefore limit the
+// entries to those started in the range, and ExcludeRunning leaves out the running timers.
+type TimeEntryFilter struct {
+	TaskIDs           []primitive.ObjectID
+	UserID            primitive.ObjectID
+	WorkspaceIDs      []primitive.ObjectID
+	IncludeUnassigned bool
+	Tag               string
+	StartedFrom       time.Time
+	StartedBefore     time.Time
+	ExcludeRunning    bool
+}
+
+// A struct that defines the query parameters of the time report. From and To are days, and both are included.
+type TimeReportQuery struct {
+	WorkspaceID string    `form:"workspace_id"`
+	UserID      string    `form:"user_id"`
+	TaskID      string    `form:"task_id"`
+	Tag         string    `form:"tag"`
+	From        time.Time `form:"from" time_format:"2006-01-02" time_utc:"1"`
+	To          time.Time `form:"to" time_format:"2006-01-02" time_utc:"1"`
+	GroupBy     string    `form:"group_by" validate:"omitempty,oneof=user task tag day"`
+	Format      string    `form:"format" validate:"omitempty,oneof=json csv"`
+}
+
+// A struct that defines the time spent per user, task, tag or day. An entry with several tags counts towards each
+// of them, so the rows of a report by tag can add up to more than the total.
+type TimeReport struct {
+	GroupBy      string          `json:"group_by"`
+	From         *time.Time      `json:"from,omitempty"`
+	To           *time.Time      `json:"to,omitempty"`
+	Entries      int             `json:"entries"`
+	TotalSeconds int64           `json:"total_seconds"`
+	Rows         []TimeReportRow `json:"rows"`
+}
+
+// A struct that defines a row of a time report. Key is the ID of the user or task, the tag or the day, and Label
+// is the username, the title of the task, the tag or the day.
+type TimeReportRow struct {
+	Key     string `json:"key"`
+	Label   string `json:"label"`
+	Entries int    `json:"entries"`
+	Seconds int64  `json:"seconds"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+var (
+	SettingsCollection = "settings"
+)
+
+const (
+	// The purpose of an intermediate token that can only be exchanged for a full token with a TOTP code.
+	PurposeTwoFactorLogin = "2fa_login"
+
+	// The purpose of an intermediate token that can only be used to enroll in two-factor authentication.
+	PurposeTwoFactorSetup = "2fa_setup"
+)
+
+// A struct that defines the deployment wide security settings managed by the root user.
+type SecuritySettings struct {
+	RequireAdminTwoFactor bool `json:"require_admin_2fa" bson:"require_admin_2fa"`
+}
+
+// A struct that defines the data returned when a user starts the two-factor enrollment.
+type TwoFactorSetup struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// A struct that defines the data required to confirm a two-factor operation with a TOTP or recovery code.
+type TwoFactorCodeData struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// A struct that defines the data required to complete a two-factor login.
+type TwoFactorLoginData struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -8,30 +10,90 @@ var (
 	UserCollection = "users"
 )
 
+// The policies that decide what happens to the tasks of a deleted user.
+const (
+	// Refuse to delete a user who still owns tasks.
+	UserDeletionRestrict = "restrict"
+
+	// Delete the tasks of the user with the user.
+	UserDeletionCascade = "cascade"
+
+	// Give the tasks of the user to another user.
+	UserDeletionReassign = "reassign"
+)
+
+// The list of all user deletion policies.
+var UserDeletionPolicies = []string{UserDeletionRestrict, UserDeletionCascade, UserDeletionReassign}
+
 // A struct that defines the user model.
 type User struct {
 	ID       primitive.ObjectID `json:"id" bson:"_id,omitempty"`
 	Username string             `json:"username" bson:"username"`
 	Password string             `json:"password" bson:"password"`
 	Role     string             `json:"role" bson:"role"`
+	Email    string             `json:"email,omitempty" bson:"email,omitempty"`
+
+	// Two-factor authentication state. The secret and the hashed recovery codes are never serialized to clients.
+	// TOTPLastCounter is the time step of the last accepted TOTP code, which cannot be used again.
+	TOTPEnabled     bool     `json:"totp_enabled" bson:"totp_enabled"`
+	TOTPSecret      string   `json:"-" bson:"totp_secret,omitempty"`
+	TOTPLastCounter int64    `json:"-" bson:"totp_last_counter,omitempty"`
+	RecoveryCodes   []string `json:"-" bson:"recovery_codes,omitempty"`
+
+	// The number of two-factor login attempts since the start of the current attempt window.
+	TwoFactorAttempts      int       `json:"-" bson:"two_factor_attempts,omitempty"`
+	TwoFactorAttemptsSince time.Time `json:"-" bson:"two_factor_attempts_since,omitempty"`
+}
+
+// A struct that defines the view of a user that is returned by the public user routes.
+// It leaves out the email, the password hash and the two-factor state, which only authenticated routes return.
+type PublicUser struct {
+	ID       primitive.ObjectID `json:"id"`
+	Username string             `json:"username"`
+	Role     string             `json:"role"`
+}
+
+// A method that returns the public view of the user.
+func (u *User) Public() PublicUser {
+	return PublicUser{ID: u.ID, Username: u.Username, Role: u.Role}
 }
 
 // A struct that defines the data required to register/login a user.
+// The rules of the validate tags are those of CreateUserData, and are only checked when registering.
 type AuthUserData struct {
-	Username string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	Username string `json:"username" binding:"required" validate:"required,username"`
+	Password string `json:"password" binding:"required" validate:"required,min=8,maxbytes=72"`
+}
+
+// A struct that defines the result of the first login step.
+// Token is set when the login is complete, otherwise MFAToken must be exchanged through the two-factor step.
+type LoginResult struct {
+	Token            string `json:"token,omitempty"`
+	MFARequired      bool   `json:"mfa_required,omitempty"`
+	MFASetupRequired bool   `json:"mfa_setup_required,omitempty"`
+	MFAToken         string `json:"mfa_token,omitempty"`
 }
 
 // A struct that defines the data required to create a user.
+// The rules of the validate tags are checked by infrastructure.Validate.
 type CreateUserData struct {
-	Username string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required"`
-	Role     string `json:"role" binding:"required"`
+	Username string `json:"username" validate:"required,username"`
+	Password string `json:"password" validate:"required,min=8,maxbytes=72"`
+	Role     string `json:"role" validate:"notblank"`
+	Email    string `json:"email" validate:"omitempty,email"`
 }
 
 // A struct that defines the data required to update a user.
 type UpdateUserData struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Role     string `json:"role"`
+	Username string `json:"username" validate:"omitempty,username"`
+	Password string `json:"password" validate:"omitempty,min=8,maxbytes=72"`
+	Role     string `json:"role" validate:"omitempty,notblank"`
+	Email    string `json:"email" validate:"omitempty,email"`
+}
+
+// A struct that defines the query parameters of the user deletion endpoint.
+// Tasks selects the deletion policy, and defaults to reassign when ReassignTo is set or to the configured policy.
+type DeleteUserQuery struct {
+	Tasks      string `form:"tasks"`
+	ReassignTo string `form:"reassign_to"`
 }
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	WorkspaceCollection = "workspaces"
+	ProjectCollection   = "projects"
+)
+
+// The roles of the members of a workspace. Owners manage the workspace and every task in it, members work on tasks
+// as far as their global role allows, and viewers can only read.
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleMember = "member"
+	WorkspaceRoleViewer = "viewer"
+)
+
+// The list of all workspace roles.
+var WorkspaceRoles = []string{WorkspaceRoleOwner, WorkspaceRoleMember, WorkspaceRoleViewer}
+
+// A struct that defines a workspace shared by a team.
+type Workspace struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name        string             `json:"name" bson:"name"`
+	Description string             `json:"description" bson:"description"`
+	Members     []Membership       `json:"members" bson:"members"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	// The number of tasks each column of the board can hold, by status, see WIPLimitsData.
+	WIPLimits map[string]int `json:"wip_limits,omitempty" bson:"wip_limits,omitempty"`
+}
+
+// A method that returns the role of the user in the workspace, or an empty string if they are not a member.
+func (w *Workspace) MemberRole(userID primitive.ObjectID) string {
+	for _, member := range w.Members {
+		if member.UserID == userID {
+			return member.Role
+		}
+	}
+
+	return ""
+}
+
+// A method that returns the number of owners of the workspace.
+func (w *Workspace) OwnerCount() int {
+	count := 0
+	for _, member := range w.Members {
+		if member.Role == WorkspaceRoleOwner {
+			count++
+		}
+	}
+
+	return count
+}
+
+// A struct that defines the membership of a user in a workspace.
+type Membership struct {
+	UserID primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Role   string             `json:"role" bson:"role"`
+}
+
+// A struct that defines a project inside a workspace.
+type Project struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	WorkspaceID primitive.ObjectID `json:"workspace_id" bson:"workspace_id"`
+	Name        string             `json:"name" bson:"name"`
+	Description string             `json:"description" bson:"description"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// A struct that defines the data required to create or update a workspace.
+type WorkspaceData struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// A struct that defines the data required to add a member to a workspace or change their role.
+type MembershipData struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// A struct that defines the data required to create a project.
+type ProjectData struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
@@ -1,27 +1,96 @@
 package infrastructure
 
 import (
+	"context"
+	"errors"
 	"net/http"
-	"os"
 	"strings"
 	"task_manager/domain"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// AuthMiddleware is a middleware that checks if the request is authorized
-func AuthMiddleware(ctx *gin.Context) {
-	// Get the token from the request header
-	authHeader := ctx.GetHeader("Authorization")
+// AuthMiddleware creates a middleware that checks if the request is authorized with a login token
+// or a personal access token.
+func AuthMiddleware(tokens domain.TokenService, accessTokens domain.AccessTokenUsecase) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authenticate(ctx, tokens, accessTokens)
+	}
+}
+
+// SetupAuthMiddleware creates a middleware that also accepts the intermediate token issued to users who must enroll
+// in two-factor authentication before they can log in. Personal access tokens are not accepted.
+func SetupAuthMiddleware(tokens domain.TokenService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authenticate(ctx, tokens, nil, domain.PurposeTwoFactorSetup)
+	}
+}
+
+// RequireScope creates a middleware that checks if the claims grant the given scope.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims := ctx.MustGet("claims").(*domain.Claims)
+		if !claims.HasScope(scope) {
+			abort(ctx, &domain.Error{
+				Err:        errors.New("missing scope"),
+				StatusCode: http.StatusForbidden,
+				Code:       domain.CodeForbiddenScope,
+				Message:    "Forbidden Hint: the " + scope + " scope is required",
+			})
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// RequireSession is a middleware that rejects personal access tokens, for account and security endpoints.
+func RequireSession(ctx *gin.Context) {
+	claims := ctx.MustGet("claims").(*domain.Claims)
+	if claims.IsAccessToken() {
+		abort(ctx, &domain.Error{
+			Err:        errors.New("access token not allowed"),
+			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenAccessToken,
+			Message:    "Forbidden Hint: this endpoint cannot be used with an access token",
+		})
+		return
+	}
+
+	ctx.Next()
+}
+
+// A helper function that validates the bearer token and sets the claims in the context.
+// Personal access tokens are only accepted if accessTokens is set, and tokens issued for a specific purpose are
+// only accepted if the purpose is listed.
+func authenticate(ctx *gin.Context, tokens domain.TokenService, accessTokens domain.AccessTokenUsecase, purposes ...string) {
+	claims, _err := Authenticate(ctx, ctx.GetHeader("Authorization"), tokens, accessTokens, purposes...)
+	if _err != nil {
+		abort(ctx, _err)
+		return
+	}
+
+	// Set the claims in the context
+	ctx.Set("claims", claims)
+	logClaims(ctx, claims)
 
+	ctx.Next()
+}
+
+// A function that validates the bearer token of an Authorization header and returns its claims. It is shared by the
+// REST and gRPC APIs, so that both accept the same tokens.
+// Personal access tokens are only accepted if accessTokens is set, and tokens issued for a specific purpose are
+// only accepted if the purpose is listed.
+func Authenticate(ctx context.Context, authHeader string, tokens domain.TokenService, accessTokens domain.AccessTokenUsecase, purposes ...string) (*domain.Claims, *domain.Error) {
 	// Verify that it is a Bearer Token
 	authWords := strings.Fields(authHeader)
 	if len(authWords) != 2 || authWords[0] != "Bearer" {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized Hint: Bearer Token required"})
-		ctx.Abort()
-		return
+		return nil, &domain.Error{
+			Err:        errors.New("missing bearer token"),
+			StatusCode: http.StatusUnauthorized,
+			Code:       domain.CodeUnauthorized,
+			Message:    "Unauthorized Hint: Bearer Token required",
+		}
 	}
 
 	// Get the token string
@@ -29,55 +98,58 @@ func AuthMiddleware(ctx *gin.Context) {
 
 	// If the token is empty, return an error
 	if tokenString == "" {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized Hint: Token is empty"})
-		ctx.Abort()
-		return
-	}
-
-	// Parse the token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Ensure the token method conforms to "SigningMethodHMAC"
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.NewValidationError("Unexpected signing method", jwt.ValidationErrorSignatureInvalid)
+		return nil, &domain.Error{
+			Err:        errors.New("empty token"),
+			StatusCode: http.StatusUnauthorized,
+			Code:       domain.CodeUnauthorized,
+			Message:    "Unauthorized Hint: Token is empty",
 		}
+	}
 
-		// Fetch the secret key from the environment
-		secretKeyHex, ok := os.LookupEnv("JWT_KEY")
-		if !ok {
-			return nil, jwt.NewValidationError("JWT_KEY not found", jwt.ValidationErrorSignatureInvalid)
+	// Validate personal access tokens against the stored tokens
+	if strings.HasPrefix(tokenString, domain.AccessTokenPrefix) {
+		if accessTokens == nil {
+			return nil, &domain.Error{
+				Err:        errors.New("invalid token"),
+				StatusCode: http.StatusUnauthorized,
+				Code:       domain.CodeInvalidToken,
+				Message:    "Invalid token",
+			}
 		}
 
-		return []byte(secretKeyHex), nil
-	})
-
-	// If there's an error while parsing the token, return an error
-	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-		ctx.Abort()
-		return
+		return accessTokens.AuthenticateAccessToken(ctx, tokenString)
 	}
 
-	// Extract claims and validate the token
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-		ctx.Abort()
-		return
+	// Parse and validate the token
+	claims, err := tokens.ParseToken(tokenString)
+	if err != nil || !allowsPurpose(claims.Purpose, purposes) {
+		return nil, &domain.Error{
+			Err:        errors.New("invalid token"),
+			StatusCode: http.StatusUnauthorized,
+			Code:       domain.CodeInvalidToken,
+			Message:    "Invalid token",
+		}
 	}
 
-	// Create the object ID from the claims
-	objectID, err := primitive.ObjectIDFromHex(claims["id"].(string))
-	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-		ctx.Abort()
-		return
+	return &domain.Claims{
+		ID:       claims.ID,
+		Username: claims.Username,
+		Role:     claims.Role,
+		Purpose:  claims.Purpose,
+	}, nil
+}
+
+// A helper function that checks if a token purpose is accepted. Regular tokens have no purpose.
+func allowsPurpose(purpose string, purposes []string) bool {
+	if purpose == "" {
+		return true
 	}
 
-	// Set the claims in the context
-	ctx.Set("claims", &domain.Claims{
-		ID:   objectID,
-		Role: claims["role"].(string),
-	})
+	for _, allowed := range purposes {
+		if purpose == allowed {
+			return true
+		}
+	}
 
-	ctx.Next()
+	return false
 }
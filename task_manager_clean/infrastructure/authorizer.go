@@ -0,0 +1,132 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"task_manager/domain"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RoleAuthorizer is the policy engine that maps roles to permissions. It implements the domain.Authorizer interface.
+// Built-in roles are resolved in memory, custom roles are read from the role repository.
+type RoleAuthorizer struct {
+	roleRepo domain.RoleRepository
+}
+
+// A constructor that creates a new instance of RoleAuthorizer.
+func NewRoleAuthorizer(roleRepo domain.RoleRepository) *RoleAuthorizer {
+	return &RoleAuthorizer{
+		roleRepo: roleRepo,
+	}
+}
+
+// A method that decides if the caller may perform the requested action.
+// The action is allowed if the role grants the action itself, its .any variant, or its .own variant on a resource
+// owned by the caller. Actions on other users additionally require the caller's role to outrank the target's role.
+// Resources in a workspace are only accessible to its members: viewers can only read tasks, owners can act on every
+// task, and members are limited by their global role.
+func (a *RoleAuthorizer) Authorize(ctx context.Context, claims *domain.Claims, request *domain.AccessRequest) (*domain.Decision, error) {
+	decision := &domain.Decision{
+		Action:     request.Action,
+		Role:       claims.Role,
+		TargetRole: request.TargetRole,
+	}
+
+	// Resolve the role of the caller.
+	role, err := a.GetRole(ctx, claims.Role)
+	if err == mongo.ErrNoDocuments {
+		return deny(decision, domain.DenialUnknownRole, fmt.Sprintf("role %q is not defined", claims.Role)), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the role of the user in the workspace of the resource.
+	if request.Workspace != nil {
+		workspaceRole := request.Workspace.MemberRole(claims.ID)
+		decision.WorkspaceRole = workspaceRole
+
+		switch {
+		case workspaceRole == "":
+			return deny(decision, domain.DenialNotMember, fmt.Sprintf("user is not a member of workspace %s", request.Workspace.ID.Hex())), nil
+		case request.Action == domain.ActionWorkspaceRead:
+			return allow(decision, "workspace."+workspaceRole), nil
+		case request.Action == domain.ActionWorkspaceManage && workspaceRole == domain.WorkspaceRoleOwner:
+			return allow(decision, "workspace."+workspaceRole), nil
+		case request.Action == domain.ActionWorkspaceManage:
+			return deny(decision, domain.DenialWorkspaceRole, fmt.Sprintf("workspace role %q cannot manage the workspace", workspaceRole)), nil
+		case workspaceRole == domain.WorkspaceRoleViewer && request.Action != domain.ActionTaskRead:
+			return deny(decision, domain.DenialWorkspaceRole, fmt.Sprintf("workspace role %q only grants %s", workspaceRole, domain.ActionTaskRead)), nil
+		case workspaceRole == domain.WorkspaceRoleOwner && strings.HasPrefix(request.Action, "task."):
+			return allow(decision, "workspace."+workspaceRole), nil
+		}
+	}
+
+	// Find the permission that grants the action.
+	own := !request.OwnerID.IsZero() && request.OwnerID == claims.ID
+	switch {
+	case role.HasPermission(request.Action):
+		decision.Grant = request.Action
+	case role.HasPermission(request.Action + ".any"):
+		decision.Grant = request.Action + ".any"
+	case own && role.HasPermission(request.Action+".own"):
+		decision.Grant = request.Action + ".own"
+	case role.HasPermission(request.Action + ".own"):
+		return deny(decision, domain.DenialNotOwner, fmt.Sprintf("role %q only grants %s.own and the resource belongs to another user", role.Name, request.Action)), nil
+	default:
+		return deny(decision, domain.DenialMissingPermission, fmt.Sprintf("role %q does not grant %s", role.Name, request.Action)), nil
+	}
+
+	if role.HasPermission(domain.PermissionAll) {
+		decision.Grant = domain.PermissionAll
+	}
+
+	// Check that the target user ranks below the caller. Users may act on themselves, but never on a higher rank.
+	if request.TargetRole != "" {
+		target, err := a.GetRole(ctx, request.TargetRole)
+		if err == mongo.ErrNoDocuments {
+			return deny(decision, domain.DenialUnknownTargetRole, fmt.Sprintf("role %q is not defined", request.TargetRole)), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		outranked := target.Level > role.Level || (target.Level == role.Level && !own)
+		if !role.HasPermission(domain.PermissionAll) && outranked {
+			return deny(decision, domain.DenialRank, fmt.Sprintf("role %q (level %d) cannot act on users with role %q (level %d)", role.Name, role.Level, target.Name, target.Level)), nil
+		}
+	}
+
+	decision.Allowed = true
+	decision.Reason = fmt.Sprintf("role %q grants %s", role.Name, decision.Grant)
+	return decision, nil
+}
+
+// A method that returns the built-in or custom role with the given name.
+// It returns mongo.ErrNoDocuments if the role does not exist.
+func (a *RoleAuthorizer) GetRole(ctx context.Context, name string) (*domain.Role, error) {
+	if role, ok := domain.BuiltinRoles[name]; ok {
+		return role, nil
+	}
+
+	return a.roleRepo.GetRoleByName(ctx, name)
+}
+
+// A helper function that marks a decision as allowed by a workspace role.
+func allow(decision *domain.Decision, grant string) *domain.Decision {
+	decision.Allowed = true
+	decision.Grant = grant
+	decision.Reason = fmt.Sprintf("workspace role %q grants %s", decision.WorkspaceRole, decision.Action)
+	return decision
+}
+
+// A helper function that marks a decision as denied.
+func deny(decision *domain.Decision, denial, reason string) *domain.Decision {
+	decision.Allowed = false
+	decision.Grant = ""
+	decision.Denial = denial
+	decision.Reason = reason
+	return decision
+}
@@ -0,0 +1,39 @@
+package infrastructure
+
+import (
+	"runtime"
+	"runtime/debug"
+	"task_manager/domain"
+)
+
+// The version, commit and build time of the binary. They are set at build time with
+// -ldflags "-X task_manager/infrastructure.Version=1.2.0 -X task_manager/infrastructure.Commit=..."
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// A function that returns the build information of the binary.
+// The commit and build time default to the version control information embedded by the Go toolchain.
+func GetBuildInfo() domain.BuildInfo {
+	info := domain.BuildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range buildInfo.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildTime == "":
+				info.BuildTime = setting.Value
+			}
+		}
+	}
+
+	return info
+}
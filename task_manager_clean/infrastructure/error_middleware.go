@@ -0,0 +1,58 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/debug"
+	"task_manager/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorMiddleware is a middleware that renders the errors attached to the context by the handlers and the other
+// middlewares as RFC 7807 problem details. The internal cause of an error is logged by the request logger and never
+// returned to the client.
+func ErrorMiddleware(ctx *gin.Context) {
+	ctx.Next()
+
+	// Leave the response alone if there is no error or if it has already been written.
+	if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+		return
+	}
+
+	_err := AsError(ctx.Errors.Last().Err)
+
+	ctx.Header("Content-Type", domain.ProblemContentType)
+	ctx.JSON(_err.StatusCode, _err.Problem())
+}
+
+// A function that returns a middleware that turns panics into internal server errors, so that they are rendered and
+// logged like the other errors. It must be registered after ErrorMiddleware.
+func RecoveryMiddleware() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(ctx *gin.Context, recovered any) {
+		abort(ctx, AsError(fmt.Errorf("panic: %v\n%s", recovered, debug.Stack())))
+	})
+}
+
+// A function that converts an error to a domain error. Errors that are not domain errors are internal server errors.
+func AsError(err error) *domain.Error {
+	var _err *domain.Error
+	if errors.As(err, &_err) {
+		return _err
+	}
+
+	return &domain.Error{
+		Err:        err,
+		StatusCode: http.StatusInternalServerError,
+		Code:       domain.CodeInternal,
+		Message:    "Internal server error",
+	}
+}
+
+// A helper function that attaches an error to the context and stops the handler chain.
+func abort(ctx *gin.Context, _err *domain.Error) {
+	ctx.Error(_err)
+	ctx.Abort()
+}
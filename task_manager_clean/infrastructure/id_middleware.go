@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"net/http"
+	"task_manager/domain"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -9,19 +10,28 @@ import (
 
 // A middleware that checks if the task ID is valid
 func IDMiddleware(idType string) gin.HandlerFunc {
+	return ParamIDMiddleware("id", idType)
+}
+
+// A middleware that checks if the ID in the given path parameter is valid, for nested routes
+func ParamIDMiddleware(param string, idType string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		// Get the task ID from the request
-		taskID := ctx.Param("id")
+		// Get the ID from the request
+		id := ctx.Param(param)
 
-		// Convert the task ID to an ObjectID
-		objectID, err := primitive.ObjectIDFromHex(taskID)
+		// Convert the ID to an ObjectID
+		objectID, err := primitive.ObjectIDFromHex(id)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + idType + " ID"})
-			ctx.Abort()
+			abort(ctx, &domain.Error{
+				Err:        err,
+				StatusCode: http.StatusBadRequest,
+				Code:       domain.CodeInvalidID,
+				Message:    "Invalid " + idType + " ID",
+			})
 			return
 		}
 
-		// Set the task ID in the context
+		// Set the ID in the context
 		ctx.Set(idType+"_id", objectID)
 		ctx.Next()
 	}
@@ -2,24 +2,36 @@ package infrastructure
 
 import (
 	"errors"
-	"os"
 	"task_manager/domain"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 )
 
-// A function that generates a jwt token.
-func GenerateToken(user *domain.User) (string, error) {
-	// Get the jwt key from the environment variables.
-	jwtKeyHex, ok := os.LookupEnv("JWT_KEY")
-	if !ok {
-		return "", errors.New("JWT_KEY not found")
+const (
+	// The lifetime of the intermediate tokens used during two-factor authentication.
+	mfaTokenTTL = 5 * time.Minute
+)
+
+// JWTService issues and verifies the jwt tokens of the API. It implements the domain.TokenService interface.
+type JWTService struct {
+	key      []byte
+	tokenTTL time.Duration
+}
+
+// A constructor that creates a new instance of JWTService that signs the tokens with the given key.
+// The tokenTTL is the lifetime of the login tokens.
+func NewJWTService(key string, tokenTTL time.Duration) *JWTService {
+	return &JWTService{
+		key:      []byte(key),
+		tokenTTL: tokenTTL,
 	}
-	jwtKey := []byte(jwtKeyHex)
+}
 
+// A method that generates a jwt token.
+func (s *JWTService) GenerateToken(user *domain.User) (string, error) {
 	// Setup the claims.
-	expirationTime := time.Now().Add(24 * time.Hour)
+	expirationTime := time.Now().Add(s.tokenTTL)
 	claims := &domain.Claims{
 		ID:       user.ID,
 		Username: user.Username,
@@ -30,9 +42,57 @@ func GenerateToken(user *domain.User) (string, error) {
 		},
 	}
 
+	return s.signClaims(claims)
+}
+
+// A method that generates a short-lived intermediate token that is only valid for the given purpose.
+func (s *JWTService) GenerateMFAToken(user *domain.User, purpose string) (string, error) {
+	expirationTime := time.Now().Add(mfaTokenTTL)
+	claims := &domain.Claims{
+		ID:       user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+		Purpose:  purpose,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: expirationTime.Unix(),
+		},
+	}
+
+	return s.signClaims(claims)
+}
+
+// A method that parses and validates a jwt token and returns its claims.
+func (s *JWTService) ParseToken(tokenString string) (*domain.Claims, error) {
+	claims := &domain.Claims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		// Ensure the token method conforms to "SigningMethodHMAC"
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.NewValidationError("Unexpected signing method", jwt.ValidationErrorSignatureInvalid)
+		}
+
+		return s.key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// A helper method that signs the claims with the jwt key.
+func (s *JWTService) signClaims(claims *domain.Claims) (string, error) {
+	if len(s.key) == 0 {
+		return "", errors.New("jwt key is not set")
+	}
+
 	// Create the token.
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtKey)
+	tokenString, err := token.SignedString(s.key)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// The key under which the logger of a request is stored in its context.
+type loggerKey struct{}
+
+// A function that creates a structured logger that writes to w.
+// The level is one of debug, info, warn or error and defaults to info. The format is json or text and defaults to json.
+func NewLogger(w io.Writer, level, format string) (*slog.Logger, error) {
+	var logLevel slog.Level
+	if level != "" {
+		err := logLevel.UnmarshalText([]byte(level))
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level %q", level)
+		}
+	}
+
+	options := &slog.HandlerOptions{Level: logLevel}
+	switch strings.ToLower(format) {
+	case "", "json":
+		return slog.New(slog.NewJSONHandler(w, options)), nil
+	case "text":
+		return slog.New(slog.NewTextHandler(w, options)), nil
+	}
+
+	return nil, fmt.Errorf("invalid log format %q", format)
+}
+
+// A function that returns a copy of the context that carries the logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// A function that returns the logger carried by the context, or the default logger if there is none.
+// The logger of a request is annotated with its request ID, route and user, so that the records of every layer can be
+// correlated.
+func Logger(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+			return logger
+		}
+	}
+
+	return slog.Default()
+}
@@ -0,0 +1,98 @@
+package infrastructure
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"task_manager/domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The header that carries the ID of a request.
+const RequestIDHeader = "X-Request-ID"
+
+// The request IDs that are accepted from clients. Other IDs are replaced with a generated one.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// A function that returns a middleware that assigns an ID to every request and writes a structured access log.
+// The ID is taken from the X-Request-ID header if the client sent a valid one, generated otherwise, and returned in
+// the same header. The logger of the request is stored in its context, so that the usecases and repositories log
+// with the request ID, the route and the user.
+func RequestLogger(logger *slog.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		requestID := RequestID(ctx.GetHeader(RequestIDHeader))
+		ctx.Set("request_id", requestID)
+		ctx.Header(RequestIDHeader, requestID)
+
+		route := ctx.FullPath()
+		withLogAttrs(ctx, logger, "request_id", requestID, "method", ctx.Request.Method, "route", route)
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		attrs := []any{
+			"status", status,
+			"path", ctx.Request.URL.Path,
+			"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"client_ip", ctx.ClientIP(),
+		}
+		if len(ctx.Errors) > 0 {
+			_err := AsError(ctx.Errors.Last().Err)
+			attrs = append(attrs, "code", _err.Problem().Code, "error", _err.Error())
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		Logger(ctx.Request.Context()).Log(ctx.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// A helper function that stores a logger with the given attributes in the context of the request.
+// If logger is nil, the attributes are added to the logger that is already stored.
+func withLogAttrs(ctx *gin.Context, logger *slog.Logger, args ...any) {
+	if ctx.Request == nil {
+		return
+	}
+
+	if logger == nil {
+		logger = Logger(ctx.Request.Context())
+	}
+	ctx.Request = ctx.Request.WithContext(WithLogger(ctx.Request.Context(), logger.With(args...)))
+}
+
+// A helper function that adds the user of the claims to the logger of the request.
+func logClaims(ctx *gin.Context, claims *domain.Claims) {
+	withLogAttrs(ctx, nil, "user_id", claims.ID.Hex())
+}
+
+// A function that returns the request ID sent by a client if it is valid, or a generated one otherwise.
+func RequestID(requested string) string {
+	if requestIDPattern.MatchString(requested) {
+		return requested
+	}
+
+	return newRequestID()
+}
+
+// A helper function that generates a random request ID.
+func newRequestID() string {
+	bytes := make([]byte, 16)
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "unknown"
+	}
+
+	return hex.EncodeToString(bytes)
+}
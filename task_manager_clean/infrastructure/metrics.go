@@ -0,0 +1,238 @@
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"task_manager/domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// The period during which a user that made an authenticated request is counted as active.
+const activeUserWindow = 15 * time.Minute
+
+// The maximum duration of the queries that compute the business metrics of a scrape.
+const businessMetricsTimeout = 5 * time.Second
+
+// Metrics holds the Prometheus metrics of the API. It implements the domain.OperationRecorder interface.
+// Each instance has its own registry, so that creating several instances does not cause duplicate registrations.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	errors          *prometheus.CounterVec
+	dbDuration      *prometheus.HistogramVec
+	dbErrors        *prometheus.CounterVec
+
+	mu       sync.Mutex
+	lastSeen map[string]time.Time
+}
+
+// A constructor that creates a new instance of Metrics with the HTTP, database and runtime metrics registered.
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "task_manager_http_requests_total",
+			Help: "Number of HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "task_manager_http_request_duration_seconds",
+			Help:    "Latency of HTTP requests by method and route.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "task_manager_errors_total",
+			Help: "Number of errors returned to clients by error code.",
+		}, []string{"code"}),
+		dbDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "task_manager_db_operation_duration_seconds",
+			Help:    "Latency of database operations by collection and operation.",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"collection", "operation"}),
+		dbErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "task_manager_db_operation_errors_total",
+			Help: "Number of failed database operations by collection and operation.",
+		}, []string{"collection", "operation"}),
+		lastSeen: map[string]time.Time{},
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.requestDuration,
+		m.errors,
+		m.dbDuration,
+		m.dbErrors,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "task_manager_active_users",
+			Help: "Number of distinct users that made an authenticated request in the last 15 minutes.",
+		}, m.activeUsers),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// A method that registers the business metrics computed from the tasks at each scrape.
+func (m *Metrics) RegisterTaskMetrics(taskRepo domain.TaskRepository) {
+	m.registry.MustRegister(&taskCollector{
+		taskRepo: taskRepo,
+		tasks: prometheus.NewDesc(
+			"task_manager_tasks",
+			"Number of tasks by status.",
+			[]string{"status"}, nil,
+		),
+		overdue: prometheus.NewDesc(
+			"task_manager_tasks_overdue",
+			"Number of tasks that are not completed and past their due date.",
+			nil, nil,
+		),
+	})
+}
+
+// A method that registers the metrics of a cache, which are read from its statistics at each scrape.
+func (m *Metrics) RegisterCacheMetrics(name string, stats func() domain.CacheStats) {
+	labels := prometheus.Labels{"cache": name}
+	m.registry.MustRegister(&cacheCollector{
+		stats:     stats,
+		hits:      prometheus.NewDesc("task_manager_cache_hits_total", "Number of reads served from the cache.", nil, labels),
+		misses:    prometheus.NewDesc("task_manager_cache_misses_total", "Number of reads that were not found in the cache.", nil, labels),
+		evictions: prometheus.NewDesc("task_manager_cache_evictions_total", "Number of entries evicted because the cache was full.", nil, labels),
+		size:      prometheus.NewDesc("task_manager_cache_size", "Number of entries in the cache.", nil, labels),
+	})
+}
+
+// Middleware is a middleware that counts the requests and records their latency.
+// Requests that match no route are grouped under the "unmatched" route to keep the number of series bounded.
+func (m *Metrics) Middleware(ctx *gin.Context) {
+	start := time.Now()
+	ctx.Next()
+
+	route := ctx.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	method := ctx.Request.Method
+
+	m.requests.WithLabelValues(method, route, strconv.Itoa(ctx.Writer.Status())).Inc()
+	m.requestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+
+	if len(ctx.Errors) > 0 {
+		m.errors.WithLabelValues(AsError(ctx.Errors.Last().Err).Problem().Code).Inc()
+	}
+
+	if claims, ok := ctx.Get("claims"); ok {
+		m.seen(claims.(*domain.Claims).ID.Hex())
+	}
+}
+
+// A method that returns the handler that serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// A method that records the duration and outcome of a database operation.
+func (m *Metrics) ObserveOperation(collection, operation string, duration time.Duration, err error) {
+	m.dbDuration.WithLabelValues(collection, operation).Observe(duration.Seconds())
+	if err != nil {
+		m.dbErrors.WithLabelValues(collection, operation).Inc()
+	}
+}
+
+// A helper method that records the time of the last request of a user.
+func (m *Metrics) seen(userID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastSeen[userID] = time.Now()
+}
+
+// A helper method that counts the active users and forgets the users that are no longer active.
+func (m *Metrics) activeUsers() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cutoff := time.Now().Add(-activeUserWindow)
+	for userID, lastSeen := range m.lastSeen {
+		if lastSeen.Before(cutoff) {
+			delete(m.lastSeen, userID)
+		}
+	}
+
+	return float64(len(m.lastSeen))
+}
+
+// A collector that queries the task counts when the metrics are scraped.
+type taskCollector struct {
+	taskRepo domain.TaskRepository
+	tasks    *prometheus.Desc
+	overdue  *prometheus.Desc
+}
+
+func (c *taskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.tasks
+	ch <- c.overdue
+}
+
+// A method that counts the tasks of each status and the overdue tasks. Failed queries are logged and skipped.
+func (c *taskCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), businessMetricsTimeout)
+	defer cancel()
+
+	for _, status := range domain.TaskStatuses {
+		count, err := c.taskRepo.CountTasks(ctx, &domain.TaskFilter{Statuses: []string{status}})
+		if err != nil {
+			Logger(ctx).Error("counting tasks for metrics", "status", status, "error", err)
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(count), status)
+	}
+
+	open := []string{}
+	for _, status := range domain.TaskStatuses {
+		if status != "Completed" {
+			open = append(open, status)
+		}
+	}
+
+	count, err := c.taskRepo.CountTasks(ctx, &domain.TaskFilter{Statuses: open, DueBefore: time.Now()})
+	if err != nil {
+		Logger(ctx).Error("counting overdue tasks for metrics", "error", err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.overdue, prometheus.GaugeValue, float64(count))
+}
+
+// A collector that reads the statistics of a cache when the metrics are scraped.
+type cacheCollector struct {
+	stats     func() domain.CacheStats
+	hits      *prometheus.Desc
+	misses    *prometheus.Desc
+	evictions *prometheus.Desc
+	size      *prometheus.Desc
+}
+
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.evictions
+	ch <- c.size
+}
+
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(stats.Evictions))
+	ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(stats.Size))
+}
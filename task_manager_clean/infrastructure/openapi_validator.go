@@ -0,0 +1,199 @@
+package infrastructure
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"task_manager/domain"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gin-gonic/gin"
+)
+
+// The event streams are checked as plain text.
+func init() {
+	openapi3filter.RegisterBodyDecoder("text/event-stream", openapi3filter.RegisteredBodyDecoder("text/plain"))
+}
+
+// A struct that checks requests and responses against an OpenAPI specification, to detect drift between the
+// handlers and the documented API. It is meant for tests. In strict mode, a request to a route that is not documented
+// is a mismatch too, otherwise it is not checked.
+type OpenAPIValidator struct {
+	router routers.Router
+	strict bool
+}
+
+// A constructor that loads the specification and creates a new instance of OpenAPIValidator.
+func NewOpenAPIValidator(spec []byte, strict bool) (*OpenAPIValidator, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenAPIValidator{router: router, strict: strict}, nil
+}
+
+// Middleware is a middleware that replaces the responses that do not match the specification with errors.
+func (v *OpenAPIValidator) Middleware(ctx *gin.Context) {
+	v.Handle(ctx, ctx.Next)
+}
+
+// Handle runs next and checks the request and the response against the specification.
+// Requests that do not match are only reported if they were accepted, since rejecting them is the job of the handlers.
+// A mismatch is logged and the response is replaced with a 500 error.
+func (v *OpenAPIValidator) Handle(ctx *gin.Context, next func()) {
+	if ctx.Request == nil {
+		next()
+		return
+	}
+
+	route, pathParams, err := v.router.FindRoute(ctx.Request)
+	if err != nil && !v.strict {
+		next()
+		return
+	}
+	if err != nil {
+		writer := hold(ctx, next)
+
+		// Requests that match no gin route are answered with a 404 that is not part of the API.
+		if ctx.FullPath() != "" || writer.status != http.StatusNotFound {
+			v.mismatch(ctx, errors.New("is not documented in the specification"))
+			return
+		}
+
+		writer.release(ctx)
+		return
+	}
+
+	// The handlers read request bodies as JSON whatever their content type.
+	if ctx.Request.Header.Get("Content-Type") == "" && ctx.Request.Body != nil && ctx.Request.Body != http.NoBody {
+		ctx.Request.Header.Set("Content-Type", "application/json")
+	}
+
+	// The parts of the uploaded files have the types of the files, which the filter cannot decode.
+	options := &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+	if strings.HasPrefix(ctx.Request.Header.Get("Content-Type"), "multipart/form-data") {
+		options.ExcludeRequestBody = true
+	}
+
+	requestInput := &openapi3filter.RequestValidationInput{
+		Request:    ctx.Request,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    options,
+	}
+	requestErr := openapi3filter.ValidateRequest(ctx.Request.Context(), requestInput)
+
+	// Hold the response until it has been checked.
+	writer := hold(ctx, next)
+
+	if requestErr != nil && writer.status < http.StatusBadRequest {
+		v.mismatch(ctx, errors.New("accepted a request that does not match the specification: "+requestErr.Error()))
+		return
+	}
+
+	err = openapi3filter.ValidateResponse(ctx.Request.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: requestInput,
+		Status:                 writer.status,
+		Header:                 writer.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(writer.body.Bytes())),
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+	})
+	if err != nil {
+		v.mismatch(ctx, errors.New("returned a response that does not match the specification: "+err.Error()))
+		return
+	}
+
+	writer.release(ctx)
+}
+
+// A helper method that replaces the response of a request that does not match the specification.
+func (v *OpenAPIValidator) mismatch(ctx *gin.Context, err error) {
+	_err := &domain.Error{
+		Err:        errors.New(ctx.Request.Method + " " + ctx.Request.URL.Path + " " + err.Error()),
+		StatusCode: http.StatusInternalServerError,
+		Code:       domain.CodeSpecMismatch,
+		Message:    strings.SplitN(err.Error(), "\n", 2)[0],
+	}
+	Logger(ctx.Request.Context()).Error("specification mismatch", "code", _err.Code, "error", _err.Err.Error())
+
+	ctx.Header("Content-Type", domain.ProblemContentType)
+	ctx.JSON(_err.StatusCode, _err.Problem())
+}
+
+// A response writer that keeps the status and the body of a response instead of writing them.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+// A helper function that runs next with a writer that holds the response instead of writing it.
+func hold(ctx *gin.Context, next func()) *bufferedWriter {
+	writer := &bufferedWriter{ResponseWriter: ctx.Writer, status: http.StatusOK}
+	ctx.Writer = writer
+	next()
+	ctx.Writer = writer.ResponseWriter
+
+	return writer
+}
+
+// A helper method that writes the held response.
+func (w *bufferedWriter) release(ctx *gin.Context) {
+	ctx.Writer.WriteHeader(w.status)
+	if w.written {
+		ctx.Writer.WriteHeaderNow()
+		ctx.Writer.Write(w.body.Bytes())
+	}
+}
+
+// WriteHeader ignores the codes that are not positive, as gin does for the renders that keep the status.
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+// Flush does nothing, since the response is only written once it has been checked.
+func (w *bufferedWriter) Flush() {}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
@@ -0,0 +1,170 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/smtp"
+	"os"
+	"sync"
+	"task_manager/config"
+	"task_manager/domain"
+	"time"
+)
+
+// SMTPResetSender delivers password reset tokens by email through an SMTP server.
+type SMTPResetSender struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// A method that emails the reset token to the user.
+func (s *SMTPResetSender) SendPasswordReset(user *domain.User, token string, expiresAt time.Time) error {
+	if user.Email == "" {
+		return errors.New("user " + user.Username + " has no email address")
+	}
+
+	// Build the message.
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: Password reset\r\n\r\n"+
+		"Hello %s,\r\n\r\nUse the following token to reset your password: %s\r\n"+
+		"The token expires at %s and can only be used once.\r\n",
+		s.From, user.Email, user.Username, token, expiresAt.UTC().Format(time.RFC1123))
+
+	// Authenticate only when credentials are configured.
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+
+	return smtp.SendMail(s.Host+":"+s.Port, auth, s.From, []string{user.Email}, []byte(message))
+}
+
+// FileResetSender writes password reset tokens to a file, or to the standard logger when no path is set.
+// It is meant for local development and testing, since anyone who can read the file or the log can take over the
+// accounts.
+type FileResetSender struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// A method that records the reset token in the file or log.
+func (s *FileResetSender) SendPasswordReset(user *domain.User, token string, expiresAt time.Time) error {
+	line := fmt.Sprintf("password reset for %s: token=%s expires_at=%s\n", user.Username, token, expiresAt.UTC().Format(time.RFC3339))
+
+	if s.Path == "" {
+		log.Print(line)
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(line)
+	return err
+}
+
+// NoResetSender is the password reset sender used when none is configured. It delivers no tokens, so that they are
+// never written anywhere by default, and users must reset their passwords with taskadmin.
+type NoResetSender struct{}
+
+// A method that fails to deliver the reset token.
+func (NoResetSender) SendPasswordReset(user *domain.User, token string, expiresAt time.Time) error {
+	return errors.New("no password reset sender is configured")
+}
+
+// AsyncResetSender is a decorator around a domain.PasswordResetSender that delivers the tokens from a queue in the
+// background. Tokens are only delivered to existing users, so waiting for the delivery would let callers tell the
+// existing usernames by the time the requests take. Failed deliveries are logged.
+type AsyncResetSender struct {
+	sender domain.PasswordResetSender
+	queue  chan resetDelivery
+}
+
+// A struct that defines a token waiting in the queue of an AsyncResetSender.
+type resetDelivery struct {
+	user      domain.User
+	token     string
+	expiresAt time.Time
+}
+
+// A constructor that creates a new instance of AsyncResetSender with a queue of the given size, and starts
+// delivering the queued tokens.
+func NewAsyncResetSender(sender domain.PasswordResetSender, size int) *AsyncResetSender {
+	s := &AsyncResetSender{
+		sender: sender,
+		queue:  make(chan resetDelivery, size),
+	}
+	go s.run()
+
+	return s
+}
+
+// A method that queues the reset token for delivery. It fails if the queue is full.
+func (s *AsyncResetSender) SendPasswordReset(user *domain.User, token string, expiresAt time.Time) error {
+	select {
+	case s.queue <- resetDelivery{user: *user, token: token, expiresAt: expiresAt}:
+		return nil
+	default:
+		return errors.New("the queue of password reset tokens is full")
+	}
+}
+
+// A helper method that delivers the queued tokens one at a time.
+func (s *AsyncResetSender) run() {
+	for delivery := range s.queue {
+		err := s.sender.SendPasswordReset(&delivery.user, delivery.token, delivery.expiresAt)
+		if err != nil {
+			slog.Default().Error("delivering a password reset token", "user_id", delivery.user.ID.Hex(), "error", err)
+		}
+	}
+}
+
+// A function that creates the password reset sender selected by the configuration.
+func NewPasswordResetSender(cfg config.ResetConfig) (domain.PasswordResetSender, error) {
+	switch cfg.Sender {
+	case "smtp":
+		if cfg.SMTP.Host == "" {
+			return nil, errors.New("the SMTP host is not set")
+		}
+
+		if cfg.SMTP.From == "" {
+			return nil, errors.New("the SMTP sender address is not set")
+		}
+
+		port := cfg.SMTP.Port
+		if port == "" {
+			port = "587"
+		}
+
+		return &SMTPResetSender{
+			Host:     cfg.SMTP.Host,
+			Port:     port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+		}, nil
+	case "", "none":
+		return NoResetSender{}, nil
+	case "file":
+		if cfg.TokenFile == "" {
+			return nil, errors.New("the file of the reset tokens is not set")
+		}
+
+		return &FileResetSender{Path: cfg.TokenFile}, nil
+	case "log":
+		slog.Default().Warn("password reset tokens are written to the log, which must only be done in development")
+		return &FileResetSender{}, nil
+	default:
+		return nil, errors.New("unknown password reset sender: " + cfg.Sender)
+	}
+}
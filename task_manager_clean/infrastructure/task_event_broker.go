@@ -0,0 +1,85 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"task_manager/domain"
+)
+
+// The number of events buffered for each subscriber before the events are dropped.
+const taskEventBuffer = 64
+
+// TaskEventBroker delivers the changes of tasks to the subscribers of the process. It implements the
+// domain.TaskEventBroker interface. Publishing never blocks: a subscriber that falls behind loses the events that do
+// not fit in its buffer.
+type TaskEventBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan domain.TaskEvent]struct{}
+	closed      bool
+}
+
+// A constructor that creates a new instance of TaskEventBroker.
+func NewTaskEventBroker() *TaskEventBroker {
+	return &TaskEventBroker{
+		subscribers: map[chan domain.TaskEvent]struct{}{},
+	}
+}
+
+// A method that sends the event to every subscriber.
+func (b *TaskEventBroker) Publish(event domain.TaskEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			Logger(context.Background()).Warn("dropping task event for a slow subscriber", "type", event.Type, "task_id", event.Task.ID.Hex())
+		}
+	}
+}
+
+// A method that returns a channel that receives the events published until the context is done or the broker is
+// closed. The channel is then closed.
+func (b *TaskEventBroker) Subscribe(ctx context.Context) <-chan domain.TaskEvent {
+	ch := make(chan domain.TaskEvent, taskEventBuffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(ch)
+		return ch
+	}
+
+	b.subscribers[ch] = struct{}{}
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(ch)
+	}()
+
+	return ch
+}
+
+// A method that ends every subscription, so that the streams of the subscribers finish before the server shuts down.
+func (b *TaskEventBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// A helper method that removes a subscriber and closes its channel, unless the broker already did.
+func (b *TaskEventBroker) unsubscribe(ch chan domain.TaskEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,86 @@
+package infrastructure_test
+
+import (
+	"task_manager/infrastructure"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// The base32 encoding of the SHA1 secret of the RFC 6238 test vectors, "12345678901234567890".
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// A suite that contains tests for the TOTP codes.
+type TOTPServiceTestSuite struct {
+	suite.Suite
+}
+
+// A test for the GenerateTOTPCode function.
+func (suite *TOTPServiceTestSuite) TestGenerateTOTPCode() {
+	// The codes of the SHA1 vectors of RFC 6238, Appendix B, cut to six digits.
+	tests := []struct {
+		name string
+		unix int64
+		code string
+	}{
+		{"GenerateTOTPCode_59", 59, "287082"},
+		{"GenerateTOTPCode_1111111109", 1111111109, "081804"},
+		{"GenerateTOTPCode_1111111111", 1111111111, "050471"},
+		{"GenerateTOTPCode_1234567890", 1234567890, "005924"},
+		{"GenerateTOTPCode_2000000000", 2000000000, "279037"},
+		{"GenerateTOTPCode_20000000000", 20000000000, "353130"},
+	}
+
+	for _, test := range tests {
+		// A testcase where the code matches the test vector of RFC 6238.
+		suite.Run(test.name, func() {
+			code, err := infrastructure.GenerateTOTPCode(rfc6238Secret, time.Unix(test.unix, 0))
+			suite.Nil(err)
+			suite.Equal(test.code, code)
+		})
+	}
+
+	// A testcase where the secret is not valid base32.
+	suite.Run("GenerateTOTPCode_InvalidSecret", func() {
+		_, err := infrastructure.GenerateTOTPCode("not base32!", time.Unix(59, 0))
+		suite.Error(err)
+	})
+}
+
+// A test for the ValidateTOTP function.
+func (suite *TOTPServiceTestSuite) TestValidateTOTP() {
+	// The code of the time step 37037037, from 1111111110 to 1111111139.
+	const code = "050471"
+
+	tests := []struct {
+		name  string
+		code  string
+		unix  int64
+		step  int64
+		valid bool
+	}{
+		{"ValidateTOTP_Current", code, 1111111111, 37037037, true},
+		{"ValidateTOTP_PreviousStep", code, 1111111111 + 30, 37037037, true},
+		{"ValidateTOTP_NextStep", code, 1111111111 - 30, 37037037, true},
+		{"ValidateTOTP_TooLate", code, 1111111111 + 60, 0, false},
+		{"ValidateTOTP_TooEarly", code, 1111111111 - 60, 0, false},
+		{"ValidateTOTP_Spaces", " " + code + " ", 1111111111, 37037037, true},
+		{"ValidateTOTP_WrongLength", "50471", 1111111111, 0, false},
+		{"ValidateTOTP_WrongCode", "050472", 1111111111, 0, false},
+	}
+
+	for _, test := range tests {
+		// A testcase where the code is accepted within one time step of the current one, and refused otherwise.
+		suite.Run(test.name, func() {
+			step, valid := infrastructure.ValidateTOTP(rfc6238Secret, test.code, time.Unix(test.unix, 0))
+			suite.Equal(test.valid, valid)
+			suite.Equal(test.step, step)
+		})
+	}
+}
+
+// A function that runs the TOTPServiceTestSuite.
+func TestTOTPServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TOTPServiceTestSuite))
+}
@@ -0,0 +1,161 @@
+package infrastructure
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"task_manager/domain"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// The validator that checks the rules declared in the validate tags of the domain structs.
+var validate = newValidator()
+
+// The characters a username may contain.
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{3,32}$`)
+
+// A function that creates the validator with the rules of the application.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(FieldName)
+
+	// A rule for strings that must contain something other than whitespace.
+	v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+
+	// A rule for the status of a task.
+	v.RegisterValidation("taskstatus", func(fl validator.FieldLevel) bool {
+		for _, status := range domain.TaskStatuses {
+			if fl.Field().String() == status {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	// A rule for dates that must not be before the current day.
+	v.RegisterValidation("notpast", func(fl validator.FieldLevel) bool {
+		date, ok := fl.Field().Interface().(time.Time)
+		return ok && !date.Before(time.Now().UTC().Truncate(24*time.Hour))
+	})
+
+	// A rule for strings whose length in bytes is limited, such as passwords that bcrypt truncates after 72 bytes.
+	v.RegisterValidation("maxbytes", func(fl validator.FieldLevel) bool {
+		limit, err := strconv.Atoi(fl.Param())
+		return err == nil && len(fl.Field().String()) <= limit
+	})
+
+	// A rule for usernames.
+	v.RegisterValidation("username", func(fl validator.FieldLevel) bool {
+		return usernamePattern.MatchString(fl.Field().String())
+	})
+
+	return v
+}
+
+// Validate checks the data against every rule of its validate tags, and reports all the invalid fields at once.
+// It is shared by every path that accepts tasks or users, so that they all enforce the same rules.
+func Validate(data any) *domain.Error {
+	err := validate.Struct(data)
+	if err == nil {
+		return nil
+	}
+
+	fields := FieldErrors(err)
+	if len(fields) == 0 {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field.Field + " " + field.Reason
+	}
+
+	return &domain.Error{
+		Err:        err,
+		StatusCode: http.StatusBadRequest,
+		Code:       domain.CodeValidationFailed,
+		Message:    strings.Join(messages, "; "),
+		Fields:     fields,
+	}
+}
+
+// FieldErrors converts the errors of a validator to the invalid fields, with a reason for each field.
+// Errors that are not validation errors have no fields.
+func FieldErrors(err error) []domain.FieldError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	fields := make([]domain.FieldError, len(validationErrors))
+	for i, fieldError := range validationErrors {
+		fields[i] = domain.FieldError{Field: fieldError.Field(), Reason: reason(fieldError)}
+	}
+
+	return fields
+}
+
+// FieldName returns the name of a struct field in requests, which is its JSON or query name.
+func FieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// A helper function that describes why a field failed a rule.
+func reason(fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required":
+		return "is required"
+	case "notblank":
+		return "must not be blank"
+	case "min":
+		if isNumber(fieldError.Kind()) {
+			return "must be at least " + fieldError.Param()
+		}
+
+		return "must be at least " + fieldError.Param() + " characters long"
+	case "max":
+		if isNumber(fieldError.Kind()) {
+			return "must be at most " + fieldError.Param()
+		}
+
+		return "must be at most " + fieldError.Param() + " characters long"
+	case "maxbytes":
+		return "must be at most " + fieldError.Param() + " bytes long"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fieldError.Param(), " ", ", ")
+	case "taskstatus":
+		return "must be one of: " + strings.Join(domain.TaskStatuses, ", ")
+	case "notpast":
+		return "must not be in the past"
+	case "email":
+		return "must be a valid email address"
+	case "username":
+		return "must be 3 to 32 letters, digits, '.', '_' or '-'"
+	}
+
+	return "must satisfy the " + fieldError.Tag() + " rule"
+}
+
+// A helper function that reports whether a kind of field is a number, whose limits are values instead of lengths.
+func isNumber(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
@@ -0,0 +1,147 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AccessTokenUsecase is an autogenerated mock type for the AccessTokenUsecase type
+type AccessTokenUsecase struct {
+	mock.Mock
+}
+
+// AuthenticateAccessToken provides a mock function with given fields: ctx, token
+func (_m *AccessTokenUsecase) AuthenticateAccessToken(ctx context.Context, token string) (*domain.Claims, *domain.Error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthenticateAccessToken")
+	}
+
+	var r0 *domain.Claims
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Claims, *domain.Error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Claims); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Claims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) *domain.Error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateAccessToken provides a mock function with given fields: ctx, data, claims
+func (_m *AccessTokenUsecase) CreateAccessToken(ctx context.Context, data *domain.CreateAccessTokenData, claims *domain.Claims) (*domain.CreatedAccessToken, *domain.Error) {
+	ret := _m.Called(ctx, data, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAccessToken")
+	}
+
+	var r0 *domain.CreatedAccessToken
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateAccessTokenData, *domain.Claims) (*domain.CreatedAccessToken, *domain.Error)); ok {
+		return rf(ctx, data, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateAccessTokenData, *domain.Claims) *domain.CreatedAccessToken); ok {
+		r0 = rf(ctx, data, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CreatedAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.CreateAccessTokenData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, data, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetAccessTokens provides a mock function with given fields: ctx, claims
+func (_m *AccessTokenUsecase) GetAccessTokens(ctx context.Context, claims *domain.Claims) ([]domain.AccessToken, *domain.Error) {
+	ret := _m.Called(ctx, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccessTokens")
+	}
+
+	var r0 []domain.AccessToken
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims) ([]domain.AccessToken, *domain.Error)); ok {
+		return rf(ctx, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims) []domain.AccessToken); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// RevokeAccessToken provides a mock function with given fields: ctx, id, claims
+func (_m *AccessTokenUsecase) RevokeAccessToken(ctx context.Context, id primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	ret := _m.Called(ctx, id, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAccessToken")
+	}
+
+	var r0 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r0 = rf(ctx, id, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Error)
+		}
+	}
+
+	return r0
+}
+
+// NewAccessTokenUsecase creates a new instance of AccessTokenUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccessTokenUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccessTokenUsecase {
+	mock := &AccessTokenUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,145 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdminUsecase is an autogenerated mock type for the AdminUsecase type
+type AdminUsecase struct {
+	mock.Mock
+}
+
+// CreateRootUser provides a mock function with given fields: ctx, userData
+func (_m *AdminUsecase) CreateRootUser(ctx context.Context, userData *domain.AuthUserData) (*domain.User, *domain.Error) {
+	ret := _m.Called(ctx, userData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRootUser")
+	}
+
+	var r0 *domain.User
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AuthUserData) (*domain.User, *domain.Error)); ok {
+		return rf(ctx, userData)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AuthUserData) *domain.User); ok {
+		r0 = rf(ctx, userData)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.AuthUserData) *domain.Error); ok {
+		r1 = rf(ctx, userData)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetUsers provides a mock function with given fields: ctx
+func (_m *AdminUsecase) GetUsers(ctx context.Context) ([]domain.User, *domain.Error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 []domain.User
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.User, *domain.Error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) *domain.Error); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// ResetPassword provides a mock function with given fields: ctx, username, password, disableTwoFactor
+func (_m *AdminUsecase) ResetPassword(ctx context.Context, username string, password string, disableTwoFactor bool) *domain.Error {
+	ret := _m.Called(ctx, username, password, disableTwoFactor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) *domain.Error); ok {
+		r0 = rf(ctx, username, password, disableTwoFactor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Error)
+		}
+	}
+
+	return r0
+}
+
+// SetRole provides a mock function with given fields: ctx, username, role
+func (_m *AdminUsecase) SetRole(ctx context.Context, username string, role string) (*domain.User, *domain.Error) {
+	ret := _m.Called(ctx, username, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRole")
+	}
+
+	var r0 *domain.User
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, *domain.Error)); ok {
+		return rf(ctx, username, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
+		r0 = rf(ctx, username, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *domain.Error); ok {
+		r1 = rf(ctx, username, role)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// NewAdminUsecase creates a new instance of AdminUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminUsecase {
+	mock := &AdminUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
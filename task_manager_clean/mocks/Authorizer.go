@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Authorizer is an autogenerated mock type for the Authorizer type
+type Authorizer struct {
+	mock.Mock
+}
+
+// Authorize provides a mock function with given fields: ctx, claims, request
+func (_m *Authorizer) Authorize(ctx context.Context, claims *domain.Claims, request *domain.AccessRequest) (*domain.Decision, error) {
+	ret := _m.Called(ctx, claims, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authorize")
+	}
+
+	var r0 *domain.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims, *domain.AccessRequest) (*domain.Decision, error)); ok {
+		return rf(ctx, claims, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims, *domain.AccessRequest) *domain.Decision); ok {
+		r0 = rf(ctx, claims, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Claims, *domain.AccessRequest) error); ok {
+		r1 = rf(ctx, claims, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAuthorizer creates a new instance of Authorizer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthorizer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Authorizer {
+	mock := &Authorizer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
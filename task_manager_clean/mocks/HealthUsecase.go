@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthUsecase is an autogenerated mock type for the HealthUsecase type
+type HealthUsecase struct {
+	mock.Mock
+}
+
+// Drain provides a mock function with no fields
+func (_m *HealthUsecase) Drain() {
+	_m.Called()
+}
+
+// Liveness provides a mock function with given fields: ctx
+func (_m *HealthUsecase) Liveness(ctx context.Context) *domain.Health {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Liveness")
+	}
+
+	var r0 *domain.Health
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.Health); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Health)
+		}
+	}
+
+	return r0
+}
+
+// Readiness provides a mock function with given fields: ctx
+func (_m *HealthUsecase) Readiness(ctx context.Context) *domain.Health {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Readiness")
+	}
+
+	var r0 *domain.Health
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.Health); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Health)
+		}
+	}
+
+	return r0
+}
+
+// Report provides a mock function with given fields: ctx
+func (_m *HealthUsecase) Report(ctx context.Context) *domain.Health {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Report")
+	}
+
+	var r0 *domain.Health
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.Health); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Health)
+		}
+	}
+
+	return r0
+}
+
+// NewHealthUsecase creates a new instance of HealthUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthUsecase {
+	mock := &HealthUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,33 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OperationRecorder is an autogenerated mock type for the OperationRecorder type
+type OperationRecorder struct {
+	mock.Mock
+}
+
+// ObserveOperation provides a mock function with given fields: collection, operation, duration, err
+func (_m *OperationRecorder) ObserveOperation(collection string, operation string, duration time.Duration, err error) {
+	_m.Called(collection, operation, duration, err)
+}
+
+// NewOperationRecorder creates a new instance of OperationRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOperationRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OperationRecorder {
+	mock := &OperationRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,48 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// PasswordResetSender is an autogenerated mock type for the PasswordResetSender type
+type PasswordResetSender struct {
+	mock.Mock
+}
+
+// SendPasswordReset provides a mock function with given fields: user, token, expiresAt
+func (_m *PasswordResetSender) SendPasswordReset(user *domain.User, token string, expiresAt time.Time) error {
+	ret := _m.Called(user, token, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendPasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.User, string, time.Time) error); ok {
+		r0 = rf(user, token, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPasswordResetSender creates a new instance of PasswordResetSender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordResetSender(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordResetSender {
+	mock := &PasswordResetSender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
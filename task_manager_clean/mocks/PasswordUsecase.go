@@ -0,0 +1,69 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasswordUsecase is an autogenerated mock type for the PasswordUsecase type
+type PasswordUsecase struct {
+	mock.Mock
+}
+
+// ForgotPassword provides a mock function with given fields: ctx, data
+func (_m *PasswordUsecase) ForgotPassword(ctx context.Context, data *domain.ForgotPasswordData) *domain.Error {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgotPassword")
+	}
+
+	var r0 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ForgotPasswordData) *domain.Error); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Error)
+		}
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: ctx, data
+func (_m *PasswordUsecase) ResetPassword(ctx context.Context, data *domain.ResetPasswordData) *domain.Error {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ResetPasswordData) *domain.Error); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Error)
+		}
+	}
+
+	return r0
+}
+
+// NewPasswordUsecase creates a new instance of PasswordUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordUsecase {
+	mock := &PasswordUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,177 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RoleUsecase is an autogenerated mock type for the RoleUsecase type
+type RoleUsecase struct {
+	mock.Mock
+}
+
+// CreateRole provides a mock function with given fields: ctx, roleData, claims
+func (_m *RoleUsecase) CreateRole(ctx context.Context, roleData *domain.RoleData, claims *domain.Claims) (*domain.Role, *domain.Error) {
+	ret := _m.Called(ctx, roleData, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRole")
+	}
+
+	var r0 *domain.Role
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.RoleData, *domain.Claims) (*domain.Role, *domain.Error)); ok {
+		return rf(ctx, roleData, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.RoleData, *domain.Claims) *domain.Role); ok {
+		r0 = rf(ctx, roleData, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.RoleData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, roleData, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteRole provides a mock function with given fields: ctx, name, claims
+func (_m *RoleUsecase) DeleteRole(ctx context.Context, name string, claims *domain.Claims) *domain.Error {
+	ret := _m.Called(ctx, name, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRole")
+	}
+
+	var r0 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.Claims) *domain.Error); ok {
+		r0 = rf(ctx, name, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Error)
+		}
+	}
+
+	return r0
+}
+
+// ExplainAccess provides a mock function with given fields: ctx, data, claims
+func (_m *RoleUsecase) ExplainAccess(ctx context.Context, data *domain.ExplainData, claims *domain.Claims) (*domain.Decision, *domain.Error) {
+	ret := _m.Called(ctx, data, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExplainAccess")
+	}
+
+	var r0 *domain.Decision
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExplainData, *domain.Claims) (*domain.Decision, *domain.Error)); ok {
+		return rf(ctx, data, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExplainData, *domain.Claims) *domain.Decision); ok {
+		r0 = rf(ctx, data, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.ExplainData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, data, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetRoles provides a mock function with given fields: ctx, claims
+func (_m *RoleUsecase) GetRoles(ctx context.Context, claims *domain.Claims) ([]domain.Role, *domain.Error) {
+	ret := _m.Called(ctx, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoles")
+	}
+
+	var r0 []domain.Role
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims) ([]domain.Role, *domain.Error)); ok {
+		return rf(ctx, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims) []domain.Role); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// ReplaceRole provides a mock function with given fields: ctx, name, roleData, claims
+func (_m *RoleUsecase) ReplaceRole(ctx context.Context, name string, roleData *domain.RoleData, claims *domain.Claims) (*domain.Role, *domain.Error) {
+	ret := _m.Called(ctx, name, roleData, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceRole")
+	}
+
+	var r0 *domain.Role
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.RoleData, *domain.Claims) (*domain.Role, *domain.Error)); ok {
+		return rf(ctx, name, roleData, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.RoleData, *domain.Claims) *domain.Role); ok {
+		r0 = rf(ctx, name, roleData, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.RoleData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, name, roleData, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// NewRoleUsecase creates a new instance of RoleUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRoleUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RoleUsecase {
+	mock := &RoleUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,54 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TaskEventBroker is an autogenerated mock type for the TaskEventBroker type
+type TaskEventBroker struct {
+	mock.Mock
+}
+
+// Publish provides a mock function with given fields: event
+func (_m *TaskEventBroker) Publish(event domain.TaskEvent) {
+	_m.Called(event)
+}
+
+// Subscribe provides a mock function with given fields: ctx
+func (_m *TaskEventBroker) Subscribe(ctx context.Context) <-chan domain.TaskEvent {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan domain.TaskEvent
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan domain.TaskEvent); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan domain.TaskEvent)
+		}
+	}
+
+	return r0
+}
+
+// NewTaskEventBroker creates a new instance of TaskEventBroker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaskEventBroker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaskEventBroker {
+	mock := &TaskEventBroker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
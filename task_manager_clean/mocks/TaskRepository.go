@@ -1,8 +1,9 @@
-// Code generated by mockery v2.44.1. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
 import (
+	context "context"
 	domain "task_manager/domain"
 
 	mock "github.com/stretchr/testify/mock"
@@ -15,17 +16,53 @@ type TaskRepository struct {
 	mock.Mock
 }
 
-// AddTask provides a mock function with given fields: task
-func (_m *TaskRepository) AddTask(task *domain.Task) error {
-	ret := _m.Called(task)
+// AddAttachment provides a mock function with given fields: ctx, id, attachment
+func (_m *TaskRepository) AddAttachment(ctx context.Context, id primitive.ObjectID, attachment *domain.Attachment) error {
+	ret := _m.Called(ctx, id, attachment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Attachment) error); ok {
+		r0 = rf(ctx, id, attachment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddBlocker provides a mock function with given fields: ctx, id, blockerID
+func (_m *TaskRepository) AddBlocker(ctx context.Context, id primitive.ObjectID, blockerID primitive.ObjectID) error {
+	ret := _m.Called(ctx, id, blockerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBlocker")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, id, blockerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddTask provides a mock function with given fields: ctx, task
+func (_m *TaskRepository) AddTask(ctx context.Context, task *domain.Task) error {
+	ret := _m.Called(ctx, task)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddTask")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*domain.Task) error); ok {
-		r0 = rf(task)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Task) error); ok {
+		r0 = rf(ctx, task)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -33,17 +70,45 @@ func (_m *TaskRepository) AddTask(task *domain.Task) error {
 	return r0
 }
 
-// DeleteTask provides a mock function with given fields: id
-func (_m *TaskRepository) DeleteTask(id primitive.ObjectID) error {
-	ret := _m.Called(id)
+// CountTasks provides a mock function with given fields: ctx, filter
+func (_m *TaskRepository) CountTasks(ctx context.Context, filter *domain.TaskFilter) (int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountTasks")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskFilter) (int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskFilter) int64); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TaskFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTask provides a mock function with given fields: ctx, id
+func (_m *TaskRepository) DeleteTask(ctx context.Context, id primitive.ObjectID) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteTask")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -51,9 +116,27 @@ func (_m *TaskRepository) DeleteTask(id primitive.ObjectID) error {
 	return r0
 }
 
-// GetAllTasks provides a mock function with given fields:
-func (_m *TaskRepository) GetAllTasks() ([]domain.Task, error) {
-	ret := _m.Called()
+// DeleteTasks provides a mock function with given fields: ctx, filter
+func (_m *TaskRepository) DeleteTasks(ctx context.Context, filter *domain.TaskFilter) error {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskFilter) error); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAllTasks provides a mock function with given fields: ctx
+func (_m *TaskRepository) GetAllTasks(ctx context.Context) ([]domain.Task, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllTasks")
@@ -61,19 +144,19 @@ func (_m *TaskRepository) GetAllTasks() ([]domain.Task, error) {
 
 	var r0 []domain.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]domain.Task, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Task, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []domain.Task); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Task); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -81,9 +164,9 @@ func (_m *TaskRepository) GetAllTasks() ([]domain.Task, error) {
 	return r0, r1
 }
 
-// GetTaskByID provides a mock function with given fields: id
-func (_m *TaskRepository) GetTaskByID(id primitive.ObjectID) (*domain.Task, error) {
-	ret := _m.Called(id)
+// GetTaskByID provides a mock function with given fields: ctx, id
+func (_m *TaskRepository) GetTaskByID(ctx context.Context, id primitive.ObjectID) (*domain.Task, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTaskByID")
@@ -91,19 +174,49 @@ func (_m *TaskRepository) GetTaskByID(id primitive.ObjectID) (*domain.Task, erro
 
 	var r0 *domain.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID) (*domain.Task, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) (*domain.Task, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID) *domain.Task); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) *domain.Task); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTasks provides a mock function with given fields: ctx, filter
+func (_m *TaskRepository) GetTasks(ctx context.Context, filter *domain.TaskFilter) ([]domain.Task, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTasks")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskFilter) ([]domain.Task, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskFilter) []domain.Task); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TaskFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -111,17 +224,71 @@ func (_m *TaskRepository) GetTaskByID(id primitive.ObjectID) (*domain.Task, erro
 	return r0, r1
 }
 
-// ReplaceTask provides a mock function with given fields: id, taskData
-func (_m *TaskRepository) ReplaceTask(id primitive.ObjectID, taskData *domain.Task) error {
-	ret := _m.Called(id, taskData)
+// ReassignTasks provides a mock function with given fields: ctx, filter, userID
+func (_m *TaskRepository) ReassignTasks(ctx context.Context, filter *domain.TaskFilter, userID primitive.ObjectID) error {
+	ret := _m.Called(ctx, filter, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskFilter, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, filter, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveAttachment provides a mock function with given fields: ctx, id, attachmentID
+func (_m *TaskRepository) RemoveAttachment(ctx context.Context, id primitive.ObjectID, attachmentID primitive.ObjectID) error {
+	ret := _m.Called(ctx, id, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, id, attachmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveBlocker provides a mock function with given fields: ctx, filter, blockerID
+func (_m *TaskRepository) RemoveBlocker(ctx context.Context, filter *domain.TaskFilter, blockerID primitive.ObjectID) error {
+	ret := _m.Called(ctx, filter, blockerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveBlocker")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskFilter, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, filter, blockerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplaceTask provides a mock function with given fields: ctx, id, taskData
+func (_m *TaskRepository) ReplaceTask(ctx context.Context, id primitive.ObjectID, taskData *domain.Task) error {
+	ret := _m.Called(ctx, id, taskData)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ReplaceTask")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID, *domain.Task) error); ok {
-		r0 = rf(id, taskData)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Task) error); ok {
+		r0 = rf(ctx, id, taskData)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -129,17 +296,17 @@ func (_m *TaskRepository) ReplaceTask(id primitive.ObjectID, taskData *domain.Ta
 	return r0
 }
 
-// UpdateTask provides a mock function with given fields: id, taskData
-func (_m *TaskRepository) UpdateTask(id primitive.ObjectID, taskData primitive.M) error {
-	ret := _m.Called(id, taskData)
+// UpdateTask provides a mock function with given fields: ctx, id, taskData
+func (_m *TaskRepository) UpdateTask(ctx context.Context, id primitive.ObjectID, taskData primitive.M) error {
+	ret := _m.Called(ctx, id, taskData)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateTask")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.M) error); ok {
-		r0 = rf(id, taskData)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.M) error); ok {
+		r0 = rf(ctx, id, taskData)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1,10 +1,13 @@
-// Code generated by mockery v2.44.1. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
 import (
+	context "context"
 	domain "task_manager/domain"
 
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,9 +18,93 @@ type TaskUsecase struct {
 	mock.Mock
 }
 
-// CreateTask provides a mock function with given fields: taskData, claims
-func (_m *TaskUsecase) CreateTask(taskData *domain.CreateTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
-	ret := _m.Called(taskData, claims)
+// AddAttachment provides a mock function with given fields: ctx, objectID, upload, claims
+func (_m *TaskUsecase) AddAttachment(ctx context.Context, objectID primitive.ObjectID, upload *domain.AttachmentUpload, claims *domain.Claims) (*domain.Attachment, *domain.Error) {
+	ret := _m.Called(ctx, objectID, upload, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAttachment")
+	}
+
+	var r0 *domain.Attachment
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.AttachmentUpload, *domain.Claims) (*domain.Attachment, *domain.Error)); ok {
+		return rf(ctx, objectID, upload, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.AttachmentUpload, *domain.Claims) *domain.Attachment); ok {
+		r0 = rf(ctx, objectID, upload, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.AttachmentUpload, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, objectID, upload, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// AddDependency provides a mock function with given fields: ctx, objectID, blockerID, claims
+func (_m *TaskUsecase) AddDependency(ctx context.Context, objectID primitive.ObjectID, blockerID primitive.ObjectID, claims *domain.Claims) (*domain.TaskDependencies, *domain.Error) {
+	ret := _m.Called(ctx, objectID, blockerID, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDependency")
+	}
+
+	var r0 *domain.TaskDependencies
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.Claims) (*domain.TaskDependencies, *domain.Error)); ok {
+		return rf(ctx, objectID, blockerID, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.Claims) *domain.TaskDependencies); ok {
+		r0 = rf(ctx, objectID, blockerID, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskDependencies)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, objectID, blockerID, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// CanViewTask provides a mock function with given fields: ctx, task, claims
+func (_m *TaskUsecase) CanViewTask(ctx context.Context, task *domain.Task, claims *domain.Claims) *domain.Error {
+	ret := _m.Called(ctx, task, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CanViewTask")
+	}
+
+	var r0 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Task, *domain.Claims) *domain.Error); ok {
+		r0 = rf(ctx, task, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Error)
+		}
+	}
+
+	return r0
+}
+
+// CreateTask provides a mock function with given fields: ctx, taskData, claims
+func (_m *TaskUsecase) CreateTask(ctx context.Context, taskData *domain.CreateTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
+	ret := _m.Called(ctx, taskData, claims)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateTask")
@@ -25,19 +112,19 @@ func (_m *TaskUsecase) CreateTask(taskData *domain.CreateTaskData, claims *domai
 
 	var r0 *domain.TaskView
 	var r1 *domain.Error
-	if rf, ok := ret.Get(0).(func(*domain.CreateTaskData, *domain.Claims) (*domain.TaskView, *domain.Error)); ok {
-		return rf(taskData, claims)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateTaskData, *domain.Claims) (*domain.TaskView, *domain.Error)); ok {
+		return rf(ctx, taskData, claims)
 	}
-	if rf, ok := ret.Get(0).(func(*domain.CreateTaskData, *domain.Claims) *domain.TaskView); ok {
-		r0 = rf(taskData, claims)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateTaskData, *domain.Claims) *domain.TaskView); ok {
+		r0 = rf(ctx, taskData, claims)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.TaskView)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*domain.CreateTaskData, *domain.Claims) *domain.Error); ok {
-		r1 = rf(taskData, claims)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.CreateTaskData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, taskData, claims)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.Error)
@@ -47,17 +134,37 @@ func (_m *TaskUsecase) CreateTask(taskData *domain.CreateTaskData, claims *domai
 	return r0, r1
 }
 
-// DeleteTask provides a mock function with given fields: objectID, claims
-func (_m *TaskUsecase) DeleteTask(objectID primitive.ObjectID, claims *domain.Claims) *domain.Error {
-	ret := _m.Called(objectID, claims)
+// DeleteAttachment provides a mock function with given fields: ctx, objectID, attachmentID, claims
+func (_m *TaskUsecase) DeleteAttachment(ctx context.Context, objectID primitive.ObjectID, attachmentID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	ret := _m.Called(ctx, objectID, attachmentID, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAttachment")
+	}
+
+	var r0 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r0 = rf(ctx, objectID, attachmentID, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Error)
+		}
+	}
+
+	return r0
+}
+
+// DeleteTask provides a mock function with given fields: ctx, objectID, claims
+func (_m *TaskUsecase) DeleteTask(ctx context.Context, objectID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	ret := _m.Called(ctx, objectID, claims)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteTask")
 	}
 
 	var r0 *domain.Error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID, *domain.Claims) *domain.Error); ok {
-		r0 = rf(objectID, claims)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r0 = rf(ctx, objectID, claims)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Error)
@@ -67,9 +174,114 @@ func (_m *TaskUsecase) DeleteTask(objectID primitive.ObjectID, claims *domain.Cl
 	return r0
 }
 
-// GetTaskByID provides a mock function with given fields: objectID
-func (_m *TaskUsecase) GetTaskByID(objectID primitive.ObjectID) (*domain.Task, *domain.Error) {
-	ret := _m.Called(objectID)
+// GetAttachment provides a mock function with given fields: ctx, objectID, attachmentID, claims
+func (_m *TaskUsecase) GetAttachment(ctx context.Context, objectID primitive.ObjectID, attachmentID primitive.ObjectID, claims *domain.Claims) (*domain.Attachment, io.ReadCloser, *domain.Error) {
+	ret := _m.Called(ctx, objectID, attachmentID, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttachment")
+	}
+
+	var r0 *domain.Attachment
+	var r1 io.ReadCloser
+	var r2 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.Claims) (*domain.Attachment, io.ReadCloser, *domain.Error)); ok {
+		return rf(ctx, objectID, attachmentID, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.Claims) *domain.Attachment); ok {
+		r0 = rf(ctx, objectID, attachmentID, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.Claims) io.ReadCloser); ok {
+		r1 = rf(ctx, objectID, attachmentID, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r2 = rf(ctx, objectID, attachmentID, claims)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*domain.Error)
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// GetBoard provides a mock function with given fields: ctx, query, claims
+func (_m *TaskUsecase) GetBoard(ctx context.Context, query *domain.TaskQuery, claims *domain.Claims) (*domain.Board, *domain.Error) {
+	ret := _m.Called(ctx, query, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBoard")
+	}
+
+	var r0 *domain.Board
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskQuery, *domain.Claims) (*domain.Board, *domain.Error)); ok {
+		return rf(ctx, query, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskQuery, *domain.Claims) *domain.Board); ok {
+		r0 = rf(ctx, query, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Board)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TaskQuery, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, query, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetDependencies provides a mock function with given fields: ctx, objectID, claims
+func (_m *TaskUsecase) GetDependencies(ctx context.Context, objectID primitive.ObjectID, claims *domain.Claims) (*domain.TaskDependencies, *domain.Error) {
+	ret := _m.Called(ctx, objectID, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDependencies")
+	}
+
+	var r0 *domain.TaskDependencies
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) (*domain.TaskDependencies, *domain.Error)); ok {
+		return rf(ctx, objectID, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) *domain.TaskDependencies); ok {
+		r0 = rf(ctx, objectID, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskDependencies)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, objectID, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetTaskByID provides a mock function with given fields: ctx, objectID, claims
+func (_m *TaskUsecase) GetTaskByID(ctx context.Context, objectID primitive.ObjectID, claims *domain.Claims) (*domain.Task, *domain.Error) {
+	ret := _m.Called(ctx, objectID, claims)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTaskByID")
@@ -77,19 +289,51 @@ func (_m *TaskUsecase) GetTaskByID(objectID primitive.ObjectID) (*domain.Task, *
 
 	var r0 *domain.Task
 	var r1 *domain.Error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID) (*domain.Task, *domain.Error)); ok {
-		return rf(objectID)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) (*domain.Task, *domain.Error)); ok {
+		return rf(ctx, objectID, claims)
 	}
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID) *domain.Task); ok {
-		r0 = rf(objectID)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) *domain.Task); ok {
+		r0 = rf(ctx, objectID, claims)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(primitive.ObjectID) *domain.Error); ok {
-		r1 = rf(objectID)
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, objectID, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetTaskPlan provides a mock function with given fields: ctx, query, claims
+func (_m *TaskUsecase) GetTaskPlan(ctx context.Context, query *domain.TaskQuery, claims *domain.Claims) (*domain.TaskPlan, *domain.Error) {
+	ret := _m.Called(ctx, query, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskPlan")
+	}
+
+	var r0 *domain.TaskPlan
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskQuery, *domain.Claims) (*domain.TaskPlan, *domain.Error)); ok {
+		return rf(ctx, query, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskQuery, *domain.Claims) *domain.TaskPlan); ok {
+		r0 = rf(ctx, query, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TaskQuery, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, query, claims)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.Error)
@@ -99,9 +343,41 @@ func (_m *TaskUsecase) GetTaskByID(objectID primitive.ObjectID) (*domain.Task, *
 	return r0, r1
 }
 
-// GetTasks provides a mock function with given fields:
-func (_m *TaskUsecase) GetTasks() ([]domain.Task, *domain.Error) {
-	ret := _m.Called()
+// GetTaskStats provides a mock function with given fields: ctx, query, claims
+func (_m *TaskUsecase) GetTaskStats(ctx context.Context, query *domain.TaskStatsQuery, claims *domain.Claims) (*domain.TaskStats, *domain.Error) {
+	ret := _m.Called(ctx, query, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskStats")
+	}
+
+	var r0 *domain.TaskStats
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskStatsQuery, *domain.Claims) (*domain.TaskStats, *domain.Error)); ok {
+		return rf(ctx, query, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskStatsQuery, *domain.Claims) *domain.TaskStats); ok {
+		r0 = rf(ctx, query, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TaskStatsQuery, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, query, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetTasks provides a mock function with given fields: ctx, query, claims
+func (_m *TaskUsecase) GetTasks(ctx context.Context, query *domain.TaskQuery, claims *domain.Claims) ([]domain.Task, *domain.Error) {
+	ret := _m.Called(ctx, query, claims)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTasks")
@@ -109,19 +385,19 @@ func (_m *TaskUsecase) GetTasks() ([]domain.Task, *domain.Error) {
 
 	var r0 []domain.Task
 	var r1 *domain.Error
-	if rf, ok := ret.Get(0).(func() ([]domain.Task, *domain.Error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskQuery, *domain.Claims) ([]domain.Task, *domain.Error)); ok {
+		return rf(ctx, query, claims)
 	}
-	if rf, ok := ret.Get(0).(func() []domain.Task); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskQuery, *domain.Claims) []domain.Task); ok {
+		r0 = rf(ctx, query, claims)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() *domain.Error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TaskQuery, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, query, claims)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.Error)
@@ -131,9 +407,61 @@ func (_m *TaskUsecase) GetTasks() ([]domain.Task, *domain.Error) {
 	return r0, r1
 }
 
-// ReplaceTask provides a mock function with given fields: objectID, taskData, claims
-func (_m *TaskUsecase) ReplaceTask(objectID primitive.ObjectID, taskData *domain.ReplaceTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
-	ret := _m.Called(objectID, taskData, claims)
+// MoveTask provides a mock function with given fields: ctx, objectID, moveData, claims
+func (_m *TaskUsecase) MoveTask(ctx context.Context, objectID primitive.ObjectID, moveData *domain.MoveTaskData, claims *domain.Claims) (*domain.Task, *domain.Error) {
+	ret := _m.Called(ctx, objectID, moveData, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveTask")
+	}
+
+	var r0 *domain.Task
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.MoveTaskData, *domain.Claims) (*domain.Task, *domain.Error)); ok {
+		return rf(ctx, objectID, moveData, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.MoveTaskData, *domain.Claims) *domain.Task); ok {
+		r0 = rf(ctx, objectID, moveData, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.MoveTaskData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, objectID, moveData, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// RemoveDependency provides a mock function with given fields: ctx, objectID, blockerID, claims
+func (_m *TaskUsecase) RemoveDependency(ctx context.Context, objectID primitive.ObjectID, blockerID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	ret := _m.Called(ctx, objectID, blockerID, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDependency")
+	}
+
+	var r0 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r0 = rf(ctx, objectID, blockerID, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Error)
+		}
+	}
+
+	return r0
+}
+
+// ReplaceTask provides a mock function with given fields: ctx, objectID, taskData, claims
+func (_m *TaskUsecase) ReplaceTask(ctx context.Context, objectID primitive.ObjectID, taskData *domain.ReplaceTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
+	ret := _m.Called(ctx, objectID, taskData, claims)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ReplaceTask")
@@ -141,19 +469,19 @@ func (_m *TaskUsecase) ReplaceTask(objectID primitive.ObjectID, taskData *domain
 
 	var r0 *domain.TaskView
 	var r1 *domain.Error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID, *domain.ReplaceTaskData, *domain.Claims) (*domain.TaskView, *domain.Error)); ok {
-		return rf(objectID, taskData, claims)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.ReplaceTaskData, *domain.Claims) (*domain.TaskView, *domain.Error)); ok {
+		return rf(ctx, objectID, taskData, claims)
 	}
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID, *domain.ReplaceTaskData, *domain.Claims) *domain.TaskView); ok {
-		r0 = rf(objectID, taskData, claims)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.ReplaceTaskData, *domain.Claims) *domain.TaskView); ok {
+		r0 = rf(ctx, objectID, taskData, claims)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.TaskView)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(primitive.ObjectID, *domain.ReplaceTaskData, *domain.Claims) *domain.Error); ok {
-		r1 = rf(objectID, taskData, claims)
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.ReplaceTaskData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, objectID, taskData, claims)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.Error)
@@ -163,9 +491,9 @@ func (_m *TaskUsecase) ReplaceTask(objectID primitive.ObjectID, taskData *domain
 	return r0, r1
 }
 
-// UpdateTask provides a mock function with given fields: objectID, taskData, claims
-func (_m *TaskUsecase) UpdateTask(objectID primitive.ObjectID, taskData *domain.UpdateTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
-	ret := _m.Called(objectID, taskData, claims)
+// UpdateTask provides a mock function with given fields: ctx, objectID, taskData, claims
+func (_m *TaskUsecase) UpdateTask(ctx context.Context, objectID primitive.ObjectID, taskData *domain.UpdateTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
+	ret := _m.Called(ctx, objectID, taskData, claims)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateTask")
@@ -173,19 +501,19 @@ func (_m *TaskUsecase) UpdateTask(objectID primitive.ObjectID, taskData *domain.
 
 	var r0 *domain.TaskView
 	var r1 *domain.Error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID, *domain.UpdateTaskData, *domain.Claims) (*domain.TaskView, *domain.Error)); ok {
-		return rf(objectID, taskData, claims)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.UpdateTaskData, *domain.Claims) (*domain.TaskView, *domain.Error)); ok {
+		return rf(ctx, objectID, taskData, claims)
 	}
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID, *domain.UpdateTaskData, *domain.Claims) *domain.TaskView); ok {
-		r0 = rf(objectID, taskData, claims)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.UpdateTaskData, *domain.Claims) *domain.TaskView); ok {
+		r0 = rf(ctx, objectID, taskData, claims)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.TaskView)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(primitive.ObjectID, *domain.UpdateTaskData, *domain.Claims) *domain.Error); ok {
-		r1 = rf(objectID, taskData, claims)
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.UpdateTaskData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, objectID, taskData, claims)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.Error)
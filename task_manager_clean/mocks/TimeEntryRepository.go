@@ -0,0 +1,223 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+
+	time "time"
+)
+
+// TimeEntryRepository is an autogenerated mock type for the TimeEntryRepository type
+type TimeEntryRepository struct {
+	mock.Mock
+}
+
+// AddTimeEntry provides a mock function with given fields: ctx, entry
+func (_m *TimeEntryRepository) AddTimeEntry(ctx context.Context, entry *domain.TimeEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTimeEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TimeEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountTimeEntries provides a mock function with given fields: ctx, filter
+func (_m *TimeEntryRepository) CountTimeEntries(ctx context.Context, filter *domain.TimeEntryFilter) (int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountTimeEntries")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TimeEntryFilter) (int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TimeEntryFilter) int64); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TimeEntryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTimeEntries provides a mock function with given fields: ctx, filter
+func (_m *TimeEntryRepository) DeleteTimeEntries(ctx context.Context, filter *domain.TimeEntryFilter) error {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTimeEntries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TimeEntryFilter) error); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTimeEntry provides a mock function with given fields: ctx, id
+func (_m *TimeEntryRepository) DeleteTimeEntry(ctx context.Context, id primitive.ObjectID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTimeEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRunningTimeEntry provides a mock function with given fields: ctx, userID
+func (_m *TimeEntryRepository) GetRunningTimeEntry(ctx context.Context, userID primitive.ObjectID) (*domain.TimeEntry, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRunningTimeEntry")
+	}
+
+	var r0 *domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) (*domain.TimeEntry, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) *domain.TimeEntry); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTimeEntries provides a mock function with given fields: ctx, filter
+func (_m *TimeEntryRepository) GetTimeEntries(ctx context.Context, filter *domain.TimeEntryFilter) ([]domain.TimeEntry, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimeEntries")
+	}
+
+	var r0 []domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TimeEntryFilter) ([]domain.TimeEntry, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TimeEntryFilter) []domain.TimeEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TimeEntryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTimeEntryByID provides a mock function with given fields: ctx, id
+func (_m *TimeEntryRepository) GetTimeEntryByID(ctx context.Context, id primitive.ObjectID) (*domain.TimeEntry, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimeEntryByID")
+	}
+
+	var r0 *domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) (*domain.TimeEntry, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) *domain.TimeEntry); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StopTimeEntry provides a mock function with given fields: ctx, id, endedAt, durationSeconds
+func (_m *TimeEntryRepository) StopTimeEntry(ctx context.Context, id primitive.ObjectID, endedAt time.Time, durationSeconds int64) error {
+	ret := _m.Called(ctx, id, endedAt, durationSeconds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopTimeEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, time.Time, int64) error); ok {
+		r0 = rf(ctx, id, endedAt, durationSeconds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTimeEntryRepository creates a new instance of TimeEntryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTimeEntryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TimeEntryRepository {
+	mock := &TimeEntryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,243 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TimeEntryUsecase is an autogenerated mock type for the TimeEntryUsecase type
+type TimeEntryUsecase struct {
+	mock.Mock
+}
+
+// AddTimeEntry provides a mock function with given fields: ctx, taskID, entryData, claims
+func (_m *TimeEntryUsecase) AddTimeEntry(ctx context.Context, taskID primitive.ObjectID, entryData *domain.TimeEntryData, claims *domain.Claims) (*domain.TimeEntry, *domain.Error) {
+	ret := _m.Called(ctx, taskID, entryData, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTimeEntry")
+	}
+
+	var r0 *domain.TimeEntry
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.TimeEntryData, *domain.Claims) (*domain.TimeEntry, *domain.Error)); ok {
+		return rf(ctx, taskID, entryData, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.TimeEntryData, *domain.Claims) *domain.TimeEntry); ok {
+		r0 = rf(ctx, taskID, entryData, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.TimeEntryData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, taskID, entryData, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteTimeEntry provides a mock function with given fields: ctx, taskID, entryID, claims
+func (_m *TimeEntryUsecase) DeleteTimeEntry(ctx context.Context, taskID primitive.ObjectID, entryID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	ret := _m.Called(ctx, taskID, entryID, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTimeEntry")
+	}
+
+	var r0 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r0 = rf(ctx, taskID, entryID, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Error)
+		}
+	}
+
+	return r0
+}
+
+// GetRunningTimer provides a mock function with given fields: ctx, claims
+func (_m *TimeEntryUsecase) GetRunningTimer(ctx context.Context, claims *domain.Claims) (*domain.TimeEntry, *domain.Error) {
+	ret := _m.Called(ctx, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRunningTimer")
+	}
+
+	var r0 *domain.TimeEntry
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims) (*domain.TimeEntry, *domain.Error)); ok {
+		return rf(ctx, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims) *domain.TimeEntry); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetTimeEntries provides a mock function with given fields: ctx, taskID, claims
+func (_m *TimeEntryUsecase) GetTimeEntries(ctx context.Context, taskID primitive.ObjectID, claims *domain.Claims) ([]domain.TimeEntry, *domain.Error) {
+	ret := _m.Called(ctx, taskID, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimeEntries")
+	}
+
+	var r0 []domain.TimeEntry
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) ([]domain.TimeEntry, *domain.Error)); ok {
+		return rf(ctx, taskID, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) []domain.TimeEntry); ok {
+		r0 = rf(ctx, taskID, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, taskID, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetTimeReport provides a mock function with given fields: ctx, query, claims
+func (_m *TimeEntryUsecase) GetTimeReport(ctx context.Context, query *domain.TimeReportQuery, claims *domain.Claims) (*domain.TimeReport, *domain.Error) {
+	ret := _m.Called(ctx, query, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimeReport")
+	}
+
+	var r0 *domain.TimeReport
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TimeReportQuery, *domain.Claims) (*domain.TimeReport, *domain.Error)); ok {
+		return rf(ctx, query, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TimeReportQuery, *domain.Claims) *domain.TimeReport); ok {
+		r0 = rf(ctx, query, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TimeReportQuery, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, query, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// StartTimer provides a mock function with given fields: ctx, taskID, timerData, claims
+func (_m *TimeEntryUsecase) StartTimer(ctx context.Context, taskID primitive.ObjectID, timerData *domain.StartTimerData, claims *domain.Claims) (*domain.TimeEntry, *domain.Error) {
+	ret := _m.Called(ctx, taskID, timerData, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartTimer")
+	}
+
+	var r0 *domain.TimeEntry
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.StartTimerData, *domain.Claims) (*domain.TimeEntry, *domain.Error)); ok {
+		return rf(ctx, taskID, timerData, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.StartTimerData, *domain.Claims) *domain.TimeEntry); ok {
+		r0 = rf(ctx, taskID, timerData, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.StartTimerData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, taskID, timerData, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// StopTimer provides a mock function with given fields: ctx, taskID, claims
+func (_m *TimeEntryUsecase) StopTimer(ctx context.Context, taskID primitive.ObjectID, claims *domain.Claims) (*domain.TimeEntry, *domain.Error) {
+	ret := _m.Called(ctx, taskID, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopTimer")
+	}
+
+	var r0 *domain.TimeEntry
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) (*domain.TimeEntry, *domain.Error)); ok {
+		return rf(ctx, taskID, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) *domain.TimeEntry); ok {
+		r0 = rf(ctx, taskID, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, taskID, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// NewTimeEntryUsecase creates a new instance of TimeEntryUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTimeEntryUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TimeEntryUsecase {
+	mock := &TimeEntryUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,114 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TokenService is an autogenerated mock type for the TokenService type
+type TokenService struct {
+	mock.Mock
+}
+
+// GenerateMFAToken provides a mock function with given fields: user, purpose
+func (_m *TokenService) GenerateMFAToken(user *domain.User, purpose string) (string, error) {
+	ret := _m.Called(user, purpose)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateMFAToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.User, string) (string, error)); ok {
+		return rf(user, purpose)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.User, string) string); ok {
+		r0 = rf(user, purpose)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.User, string) error); ok {
+		r1 = rf(user, purpose)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateToken provides a mock function with given fields: user
+func (_m *TokenService) GenerateToken(user *domain.User) (string, error) {
+	ret := _m.Called(user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.User) (string, error)); ok {
+		return rf(user)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.User) string); ok {
+		r0 = rf(user)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.User) error); ok {
+		r1 = rf(user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParseToken provides a mock function with given fields: tokenString
+func (_m *TokenService) ParseToken(tokenString string) (*domain.Claims, error) {
+	ret := _m.Called(tokenString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseToken")
+	}
+
+	var r0 *domain.Claims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Claims, error)); ok {
+		return rf(tokenString)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Claims); ok {
+		r0 = rf(tokenString)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Claims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenString)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTokenService creates a new instance of TokenService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTokenService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TokenService {
+	mock := &TokenService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,207 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TwoFactorUsecase is an autogenerated mock type for the TwoFactorUsecase type
+type TwoFactorUsecase struct {
+	mock.Mock
+}
+
+// ActivateTwoFactor provides a mock function with given fields: ctx, data, claims
+func (_m *TwoFactorUsecase) ActivateTwoFactor(ctx context.Context, data *domain.TwoFactorCodeData, claims *domain.Claims) ([]string, *domain.Error) {
+	ret := _m.Called(ctx, data, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivateTwoFactor")
+	}
+
+	var r0 []string
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TwoFactorCodeData, *domain.Claims) ([]string, *domain.Error)); ok {
+		return rf(ctx, data, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TwoFactorCodeData, *domain.Claims) []string); ok {
+		r0 = rf(ctx, data, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TwoFactorCodeData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, data, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// DisableTwoFactor provides a mock function with given fields: ctx, data, claims
+func (_m *TwoFactorUsecase) DisableTwoFactor(ctx context.Context, data *domain.TwoFactorCodeData, claims *domain.Claims) *domain.Error {
+	ret := _m.Called(ctx, data, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableTwoFactor")
+	}
+
+	var r0 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TwoFactorCodeData, *domain.Claims) *domain.Error); ok {
+		r0 = rf(ctx, data, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Error)
+		}
+	}
+
+	return r0
+}
+
+// GetSecuritySettings provides a mock function with given fields: ctx, claims
+func (_m *TwoFactorUsecase) GetSecuritySettings(ctx context.Context, claims *domain.Claims) (*domain.SecuritySettings, *domain.Error) {
+	ret := _m.Called(ctx, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSecuritySettings")
+	}
+
+	var r0 *domain.SecuritySettings
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims) (*domain.SecuritySettings, *domain.Error)); ok {
+		return rf(ctx, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims) *domain.SecuritySettings); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SecuritySettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// SetupTwoFactor provides a mock function with given fields: ctx, claims
+func (_m *TwoFactorUsecase) SetupTwoFactor(ctx context.Context, claims *domain.Claims) (*domain.TwoFactorSetup, *domain.Error) {
+	ret := _m.Called(ctx, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetupTwoFactor")
+	}
+
+	var r0 *domain.TwoFactorSetup
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims) (*domain.TwoFactorSetup, *domain.Error)); ok {
+		return rf(ctx, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims) *domain.TwoFactorSetup); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TwoFactorSetup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateSecuritySettings provides a mock function with given fields: ctx, settings, claims
+func (_m *TwoFactorUsecase) UpdateSecuritySettings(ctx context.Context, settings *domain.SecuritySettings, claims *domain.Claims) (*domain.SecuritySettings, *domain.Error) {
+	ret := _m.Called(ctx, settings, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSecuritySettings")
+	}
+
+	var r0 *domain.SecuritySettings
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.SecuritySettings, *domain.Claims) (*domain.SecuritySettings, *domain.Error)); ok {
+		return rf(ctx, settings, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.SecuritySettings, *domain.Claims) *domain.SecuritySettings); ok {
+		r0 = rf(ctx, settings, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SecuritySettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.SecuritySettings, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, settings, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// VerifyLogin provides a mock function with given fields: ctx, data
+func (_m *TwoFactorUsecase) VerifyLogin(ctx context.Context, data *domain.TwoFactorLoginData) (string, *domain.Error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyLogin")
+	}
+
+	var r0 string
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TwoFactorLoginData) (string, *domain.Error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TwoFactorLoginData) string); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TwoFactorLoginData) *domain.Error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// NewTwoFactorUsecase creates a new instance of TwoFactorUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTwoFactorUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TwoFactorUsecase {
+	mock := &TwoFactorUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,13 +1,16 @@
-// Code generated by mockery v2.44.1. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
 import (
+	context "context"
 	domain "task_manager/domain"
 
 	mock "github.com/stretchr/testify/mock"
 
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+
+	time "time"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -15,17 +18,45 @@ type UserRepository struct {
 	mock.Mock
 }
 
-// AddUser provides a mock function with given fields: user
-func (_m *UserRepository) AddUser(user *domain.User) error {
-	ret := _m.Called(user)
+// AddTwoFactorAttempt provides a mock function with given fields: ctx, objectID, now, window, limit
+func (_m *UserRepository) AddTwoFactorAttempt(ctx context.Context, objectID primitive.ObjectID, now time.Time, window time.Duration, limit int) (bool, error) {
+	ret := _m.Called(ctx, objectID, now, window, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTwoFactorAttempt")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, time.Time, time.Duration, int) (bool, error)); ok {
+		return rf(ctx, objectID, now, window, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, time.Time, time.Duration, int) bool); ok {
+		r0 = rf(ctx, objectID, now, window, limit)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, time.Time, time.Duration, int) error); ok {
+		r1 = rf(ctx, objectID, now, window, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddUser provides a mock function with given fields: ctx, user
+func (_m *UserRepository) AddUser(ctx context.Context, user *domain.User) error {
+	ret := _m.Called(ctx, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*domain.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -33,17 +64,101 @@ func (_m *UserRepository) AddUser(user *domain.User) error {
 	return r0
 }
 
-// DeleteUser provides a mock function with given fields: objectID
-func (_m *UserRepository) DeleteUser(objectID primitive.ObjectID) error {
-	ret := _m.Called(objectID)
+// ClaimTOTPCounter provides a mock function with given fields: ctx, objectID, counter
+func (_m *UserRepository) ClaimTOTPCounter(ctx context.Context, objectID primitive.ObjectID, counter int64) (bool, error) {
+	ret := _m.Called(ctx, objectID, counter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimTOTPCounter")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, int64) (bool, error)); ok {
+		return rf(ctx, objectID, counter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, int64) bool); ok {
+		r0 = rf(ctx, objectID, counter)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, int64) error); ok {
+		r1 = rf(ctx, objectID, counter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConsumeRecoveryCode provides a mock function with given fields: ctx, objectID, codeHash
+func (_m *UserRepository) ConsumeRecoveryCode(ctx context.Context, objectID primitive.ObjectID, codeHash string) (bool, error) {
+	ret := _m.Called(ctx, objectID, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeRecoveryCode")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string) (bool, error)); ok {
+		return rf(ctx, objectID, codeHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, string) bool); ok {
+		r0 = rf(ctx, objectID, codeHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, string) error); ok {
+		r1 = rf(ctx, objectID, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountUsersByRole provides a mock function with given fields: ctx, role
+func (_m *UserRepository) CountUsersByRole(ctx context.Context, role string) (int64, error) {
+	ret := _m.Called(ctx, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUsersByRole")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUser provides a mock function with given fields: ctx, objectID
+func (_m *UserRepository) DeleteUser(ctx context.Context, objectID primitive.ObjectID) error {
+	ret := _m.Called(ctx, objectID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID) error); ok {
-		r0 = rf(objectID)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, objectID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -51,9 +166,9 @@ func (_m *UserRepository) DeleteUser(objectID primitive.ObjectID) error {
 	return r0
 }
 
-// GetUserByID provides a mock function with given fields: objectID
-func (_m *UserRepository) GetUserByID(objectID primitive.ObjectID) (*domain.User, error) {
-	ret := _m.Called(objectID)
+// GetUserByID provides a mock function with given fields: ctx, objectID
+func (_m *UserRepository) GetUserByID(ctx context.Context, objectID primitive.ObjectID) (*domain.User, error) {
+	ret := _m.Called(ctx, objectID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUserByID")
@@ -61,19 +176,19 @@ func (_m *UserRepository) GetUserByID(objectID primitive.ObjectID) (*domain.User
 
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID) (*domain.User, error)); ok {
-		return rf(objectID)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) (*domain.User, error)); ok {
+		return rf(ctx, objectID)
 	}
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID) *domain.User); ok {
-		r0 = rf(objectID)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) *domain.User); ok {
+		r0 = rf(ctx, objectID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
-		r1 = rf(objectID)
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(ctx, objectID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -81,9 +196,9 @@ func (_m *UserRepository) GetUserByID(objectID primitive.ObjectID) (*domain.User
 	return r0, r1
 }
 
-// GetUserByUsername provides a mock function with given fields: username
-func (_m *UserRepository) GetUserByUsername(username string) (*domain.User, error) {
-	ret := _m.Called(username)
+// GetUserByUsername provides a mock function with given fields: ctx, username
+func (_m *UserRepository) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
+	ret := _m.Called(ctx, username)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUserByUsername")
@@ -91,19 +206,19 @@ func (_m *UserRepository) GetUserByUsername(username string) (*domain.User, erro
 
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*domain.User, error)); ok {
-		return rf(username)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return rf(ctx, username)
 	}
-	if rf, ok := ret.Get(0).(func(string) *domain.User); ok {
-		r0 = rf(username)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = rf(ctx, username)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(username)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -111,9 +226,9 @@ func (_m *UserRepository) GetUserByUsername(username string) (*domain.User, erro
 	return r0, r1
 }
 
-// GetUsers provides a mock function with given fields:
-func (_m *UserRepository) GetUsers() ([]domain.User, error) {
-	ret := _m.Called()
+// GetUsers provides a mock function with given fields: ctx
+func (_m *UserRepository) GetUsers(ctx context.Context) ([]domain.User, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUsers")
@@ -121,19 +236,49 @@ func (_m *UserRepository) GetUsers() ([]domain.User, error) {
 
 	var r0 []domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]domain.User, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsersByIDs provides a mock function with given fields: ctx, objectIDs
+func (_m *UserRepository) GetUsersByIDs(ctx context.Context, objectIDs []primitive.ObjectID) ([]domain.User, error) {
+	ret := _m.Called(ctx, objectIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersByIDs")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []primitive.ObjectID) ([]domain.User, error)); ok {
+		return rf(ctx, objectIDs)
 	}
-	if rf, ok := ret.Get(0).(func() []domain.User); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, []primitive.ObjectID) []domain.User); ok {
+		r0 = rf(ctx, objectIDs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, []primitive.ObjectID) error); ok {
+		r1 = rf(ctx, objectIDs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -141,17 +286,17 @@ func (_m *UserRepository) GetUsers() ([]domain.User, error) {
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: objectID, userData
-func (_m *UserRepository) UpdateUser(objectID primitive.ObjectID, userData primitive.M) error {
-	ret := _m.Called(objectID, userData)
+// UpdateUser provides a mock function with given fields: ctx, objectID, userData
+func (_m *UserRepository) UpdateUser(ctx context.Context, objectID primitive.ObjectID, userData primitive.M) error {
+	ret := _m.Called(ctx, objectID, userData)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.M) error); ok {
-		r0 = rf(objectID, userData)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.M) error); ok {
+		r0 = rf(ctx, objectID, userData)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1,8 +1,9 @@
-// Code generated by mockery v2.44.1. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
 import (
+	context "context"
 	domain "task_manager/domain"
 
 	mock "github.com/stretchr/testify/mock"
@@ -15,9 +16,9 @@ type UserUsecase struct {
 	mock.Mock
 }
 
-// AddUser provides a mock function with given fields: userData, claims
-func (_m *UserUsecase) AddUser(userData *domain.CreateUserData, claims *domain.Claims) (*domain.User, *domain.Error) {
-	ret := _m.Called(userData, claims)
+// AddUser provides a mock function with given fields: ctx, userData, claims
+func (_m *UserUsecase) AddUser(ctx context.Context, userData *domain.CreateUserData, claims *domain.Claims) (*domain.User, *domain.Error) {
+	ret := _m.Called(ctx, userData, claims)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddUser")
@@ -25,19 +26,19 @@ func (_m *UserUsecase) AddUser(userData *domain.CreateUserData, claims *domain.C
 
 	var r0 *domain.User
 	var r1 *domain.Error
-	if rf, ok := ret.Get(0).(func(*domain.CreateUserData, *domain.Claims) (*domain.User, *domain.Error)); ok {
-		return rf(userData, claims)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateUserData, *domain.Claims) (*domain.User, *domain.Error)); ok {
+		return rf(ctx, userData, claims)
 	}
-	if rf, ok := ret.Get(0).(func(*domain.CreateUserData, *domain.Claims) *domain.User); ok {
-		r0 = rf(userData, claims)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateUserData, *domain.Claims) *domain.User); ok {
+		r0 = rf(ctx, userData, claims)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*domain.CreateUserData, *domain.Claims) *domain.Error); ok {
-		r1 = rf(userData, claims)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.CreateUserData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, userData, claims)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.Error)
@@ -47,17 +48,17 @@ func (_m *UserUsecase) AddUser(userData *domain.CreateUserData, claims *domain.C
 	return r0, r1
 }
 
-// DeleteUser provides a mock function with given fields: objectID, claims
-func (_m *UserUsecase) DeleteUser(objectID primitive.ObjectID, claims *domain.Claims) *domain.Error {
-	ret := _m.Called(objectID, claims)
+// DeleteUser provides a mock function with given fields: ctx, objectID, query, claims
+func (_m *UserUsecase) DeleteUser(ctx context.Context, objectID primitive.ObjectID, query *domain.DeleteUserQuery, claims *domain.Claims) *domain.Error {
+	ret := _m.Called(ctx, objectID, query, claims)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteUser")
 	}
 
 	var r0 *domain.Error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID, *domain.Claims) *domain.Error); ok {
-		r0 = rf(objectID, claims)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.DeleteUserQuery, *domain.Claims) *domain.Error); ok {
+		r0 = rf(ctx, objectID, query, claims)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Error)
@@ -67,9 +68,9 @@ func (_m *UserUsecase) DeleteUser(objectID primitive.ObjectID, claims *domain.Cl
 	return r0
 }
 
-// GetUserByID provides a mock function with given fields: objectID
-func (_m *UserUsecase) GetUserByID(objectID primitive.ObjectID) (*domain.User, *domain.Error) {
-	ret := _m.Called(objectID)
+// GetUserByID provides a mock function with given fields: ctx, objectID
+func (_m *UserUsecase) GetUserByID(ctx context.Context, objectID primitive.ObjectID) (*domain.User, *domain.Error) {
+	ret := _m.Called(ctx, objectID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUserByID")
@@ -77,19 +78,19 @@ func (_m *UserUsecase) GetUserByID(objectID primitive.ObjectID) (*domain.User, *
 
 	var r0 *domain.User
 	var r1 *domain.Error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID) (*domain.User, *domain.Error)); ok {
-		return rf(objectID)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) (*domain.User, *domain.Error)); ok {
+		return rf(ctx, objectID)
 	}
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID) *domain.User); ok {
-		r0 = rf(objectID)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) *domain.User); ok {
+		r0 = rf(ctx, objectID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(primitive.ObjectID) *domain.Error); ok {
-		r1 = rf(objectID)
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) *domain.Error); ok {
+		r1 = rf(ctx, objectID)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.Error)
@@ -99,9 +100,9 @@ func (_m *UserUsecase) GetUserByID(objectID primitive.ObjectID) (*domain.User, *
 	return r0, r1
 }
 
-// GetUsers provides a mock function with given fields:
-func (_m *UserUsecase) GetUsers() ([]domain.User, *domain.Error) {
-	ret := _m.Called()
+// GetUsers provides a mock function with given fields: ctx
+func (_m *UserUsecase) GetUsers(ctx context.Context) ([]domain.User, *domain.Error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUsers")
@@ -109,19 +110,19 @@ func (_m *UserUsecase) GetUsers() ([]domain.User, *domain.Error) {
 
 	var r0 []domain.User
 	var r1 *domain.Error
-	if rf, ok := ret.Get(0).(func() ([]domain.User, *domain.Error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.User, *domain.Error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []domain.User); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.User); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() *domain.Error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) *domain.Error); ok {
+		r1 = rf(ctx)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.Error)
@@ -131,27 +132,61 @@ func (_m *UserUsecase) GetUsers() ([]domain.User, *domain.Error) {
 	return r0, r1
 }
 
-// LoginUser provides a mock function with given fields: userData
-func (_m *UserUsecase) LoginUser(userData *domain.AuthUserData) (string, *domain.Error) {
-	ret := _m.Called(userData)
+// GetUsersByIDs provides a mock function with given fields: ctx, objectIDs
+func (_m *UserUsecase) GetUsersByIDs(ctx context.Context, objectIDs []primitive.ObjectID) ([]domain.User, *domain.Error) {
+	ret := _m.Called(ctx, objectIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersByIDs")
+	}
+
+	var r0 []domain.User
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, []primitive.ObjectID) ([]domain.User, *domain.Error)); ok {
+		return rf(ctx, objectIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []primitive.ObjectID) []domain.User); ok {
+		r0 = rf(ctx, objectIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []primitive.ObjectID) *domain.Error); ok {
+		r1 = rf(ctx, objectIDs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// LoginUser provides a mock function with given fields: ctx, userData
+func (_m *UserUsecase) LoginUser(ctx context.Context, userData *domain.AuthUserData) (*domain.LoginResult, *domain.Error) {
+	ret := _m.Called(ctx, userData)
 
 	if len(ret) == 0 {
 		panic("no return value specified for LoginUser")
 	}
 
-	var r0 string
+	var r0 *domain.LoginResult
 	var r1 *domain.Error
-	if rf, ok := ret.Get(0).(func(*domain.AuthUserData) (string, *domain.Error)); ok {
-		return rf(userData)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AuthUserData) (*domain.LoginResult, *domain.Error)); ok {
+		return rf(ctx, userData)
 	}
-	if rf, ok := ret.Get(0).(func(*domain.AuthUserData) string); ok {
-		r0 = rf(userData)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AuthUserData) *domain.LoginResult); ok {
+		r0 = rf(ctx, userData)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LoginResult)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*domain.AuthUserData) *domain.Error); ok {
-		r1 = rf(userData)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.AuthUserData) *domain.Error); ok {
+		r1 = rf(ctx, userData)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.Error)
@@ -161,9 +196,9 @@ func (_m *UserUsecase) LoginUser(userData *domain.AuthUserData) (string, *domain
 	return r0, r1
 }
 
-// RegisterUser provides a mock function with given fields: userData
-func (_m *UserUsecase) RegisterUser(userData *domain.AuthUserData) (*domain.User, *domain.Error) {
-	ret := _m.Called(userData)
+// RegisterUser provides a mock function with given fields: ctx, userData
+func (_m *UserUsecase) RegisterUser(ctx context.Context, userData *domain.AuthUserData) (*domain.User, *domain.Error) {
+	ret := _m.Called(ctx, userData)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RegisterUser")
@@ -171,19 +206,19 @@ func (_m *UserUsecase) RegisterUser(userData *domain.AuthUserData) (*domain.User
 
 	var r0 *domain.User
 	var r1 *domain.Error
-	if rf, ok := ret.Get(0).(func(*domain.AuthUserData) (*domain.User, *domain.Error)); ok {
-		return rf(userData)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AuthUserData) (*domain.User, *domain.Error)); ok {
+		return rf(ctx, userData)
 	}
-	if rf, ok := ret.Get(0).(func(*domain.AuthUserData) *domain.User); ok {
-		r0 = rf(userData)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AuthUserData) *domain.User); ok {
+		r0 = rf(ctx, userData)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*domain.AuthUserData) *domain.Error); ok {
-		r1 = rf(userData)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.AuthUserData) *domain.Error); ok {
+		r1 = rf(ctx, userData)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.Error)
@@ -193,9 +228,9 @@ func (_m *UserUsecase) RegisterUser(userData *domain.AuthUserData) (*domain.User
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: objectID, userData, claims
-func (_m *UserUsecase) UpdateUser(objectID primitive.ObjectID, userData *domain.UpdateUserData, claims *domain.Claims) (*domain.User, *domain.Error) {
-	ret := _m.Called(objectID, userData, claims)
+// UpdateUser provides a mock function with given fields: ctx, objectID, userData, claims
+func (_m *UserUsecase) UpdateUser(ctx context.Context, objectID primitive.ObjectID, userData *domain.UpdateUserData, claims *domain.Claims) (*domain.User, *domain.Error) {
+	ret := _m.Called(ctx, objectID, userData, claims)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateUser")
@@ -203,19 +238,19 @@ func (_m *UserUsecase) UpdateUser(objectID primitive.ObjectID, userData *domain.
 
 	var r0 *domain.User
 	var r1 *domain.Error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID, *domain.UpdateUserData, *domain.Claims) (*domain.User, *domain.Error)); ok {
-		return rf(objectID, userData, claims)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.UpdateUserData, *domain.Claims) (*domain.User, *domain.Error)); ok {
+		return rf(ctx, objectID, userData, claims)
 	}
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID, *domain.UpdateUserData, *domain.Claims) *domain.User); ok {
-		r0 = rf(objectID, userData, claims)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.UpdateUserData, *domain.Claims) *domain.User); ok {
+		r0 = rf(ctx, objectID, userData, claims)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(primitive.ObjectID, *domain.UpdateUserData, *domain.Claims) *domain.Error); ok {
-		r1 = rf(objectID, userData, claims)
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.UpdateUserData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, objectID, userData, claims)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*domain.Error)
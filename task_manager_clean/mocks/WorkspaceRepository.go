@@ -0,0 +1,199 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WorkspaceRepository is an autogenerated mock type for the WorkspaceRepository type
+type WorkspaceRepository struct {
+	mock.Mock
+}
+
+// AddWorkspace provides a mock function with given fields: ctx, workspace
+func (_m *WorkspaceRepository) AddWorkspace(ctx context.Context, workspace *domain.Workspace) error {
+	ret := _m.Called(ctx, workspace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddWorkspace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Workspace) error); ok {
+		r0 = rf(ctx, workspace)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteWorkspace provides a mock function with given fields: ctx, id
+func (_m *WorkspaceRepository) DeleteWorkspace(ctx context.Context, id primitive.ObjectID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetWorkspaceByID provides a mock function with given fields: ctx, id
+func (_m *WorkspaceRepository) GetWorkspaceByID(ctx context.Context, id primitive.ObjectID) (*domain.Workspace, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceByID")
+	}
+
+	var r0 *domain.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) (*domain.Workspace, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) *domain.Workspace); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkspacesByUserID provides a mock function with given fields: ctx, userID
+func (_m *WorkspaceRepository) GetWorkspacesByUserID(ctx context.Context, userID primitive.ObjectID) ([]domain.Workspace, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspacesByUserID")
+	}
+
+	var r0 []domain.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) ([]domain.Workspace, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) []domain.Workspace); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockWorkspace provides a mock function with given fields: ctx, id
+func (_m *WorkspaceRepository) LockWorkspace(ctx context.Context, id primitive.ObjectID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockWorkspace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveMemberships provides a mock function with given fields: ctx, userID
+func (_m *WorkspaceRepository) RemoveMemberships(ctx context.Context, userID primitive.ObjectID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMemberships")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetMembers provides a mock function with given fields: ctx, id, members
+func (_m *WorkspaceRepository) SetMembers(ctx context.Context, id primitive.ObjectID, members []domain.Membership) error {
+	ret := _m.Called(ctx, id, members)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMembers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, []domain.Membership) error); ok {
+		r0 = rf(ctx, id, members)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateWorkspace provides a mock function with given fields: ctx, id, workspaceData
+func (_m *WorkspaceRepository) UpdateWorkspace(ctx context.Context, id primitive.ObjectID, workspaceData primitive.M) error {
+	ret := _m.Called(ctx, id, workspaceData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWorkspace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.M) error); ok {
+		r0 = rf(ctx, id, workspaceData)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewWorkspaceRepository creates a new instance of WorkspaceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWorkspaceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WorkspaceRepository {
+	mock := &WorkspaceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,347 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WorkspaceUsecase is an autogenerated mock type for the WorkspaceUsecase type
+type WorkspaceUsecase struct {
+	mock.Mock
+}
+
+// CreateProject provides a mock function with given fields: ctx, workspaceID, projectData, claims
+func (_m *WorkspaceUsecase) CreateProject(ctx context.Context, workspaceID primitive.ObjectID, projectData *domain.ProjectData, claims *domain.Claims) (*domain.Project, *domain.Error) {
+	ret := _m.Called(ctx, workspaceID, projectData, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateProject")
+	}
+
+	var r0 *domain.Project
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.ProjectData, *domain.Claims) (*domain.Project, *domain.Error)); ok {
+		return rf(ctx, workspaceID, projectData, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.ProjectData, *domain.Claims) *domain.Project); ok {
+		r0 = rf(ctx, workspaceID, projectData, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.ProjectData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, workspaceID, projectData, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateWorkspace provides a mock function with given fields: ctx, workspaceData, claims
+func (_m *WorkspaceUsecase) CreateWorkspace(ctx context.Context, workspaceData *domain.WorkspaceData, claims *domain.Claims) (*domain.Workspace, *domain.Error) {
+	ret := _m.Called(ctx, workspaceData, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspace")
+	}
+
+	var r0 *domain.Workspace
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.WorkspaceData, *domain.Claims) (*domain.Workspace, *domain.Error)); ok {
+		return rf(ctx, workspaceData, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.WorkspaceData, *domain.Claims) *domain.Workspace); ok {
+		r0 = rf(ctx, workspaceData, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.WorkspaceData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, workspaceData, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteProject provides a mock function with given fields: ctx, workspaceID, projectID, claims
+func (_m *WorkspaceUsecase) DeleteProject(ctx context.Context, workspaceID primitive.ObjectID, projectID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	ret := _m.Called(ctx, workspaceID, projectID, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProject")
+	}
+
+	var r0 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r0 = rf(ctx, workspaceID, projectID, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Error)
+		}
+	}
+
+	return r0
+}
+
+// DeleteWorkspace provides a mock function with given fields: ctx, id, claims
+func (_m *WorkspaceUsecase) DeleteWorkspace(ctx context.Context, id primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	ret := _m.Called(ctx, id, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspace")
+	}
+
+	var r0 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r0 = rf(ctx, id, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Error)
+		}
+	}
+
+	return r0
+}
+
+// GetProjects provides a mock function with given fields: ctx, workspaceID, claims
+func (_m *WorkspaceUsecase) GetProjects(ctx context.Context, workspaceID primitive.ObjectID, claims *domain.Claims) ([]domain.Project, *domain.Error) {
+	ret := _m.Called(ctx, workspaceID, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjects")
+	}
+
+	var r0 []domain.Project
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) ([]domain.Project, *domain.Error)); ok {
+		return rf(ctx, workspaceID, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) []domain.Project); ok {
+		r0 = rf(ctx, workspaceID, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, workspaceID, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetWorkspaceByID provides a mock function with given fields: ctx, id, claims
+func (_m *WorkspaceUsecase) GetWorkspaceByID(ctx context.Context, id primitive.ObjectID, claims *domain.Claims) (*domain.Workspace, *domain.Error) {
+	ret := _m.Called(ctx, id, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceByID")
+	}
+
+	var r0 *domain.Workspace
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) (*domain.Workspace, *domain.Error)); ok {
+		return rf(ctx, id, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.Claims) *domain.Workspace); ok {
+		r0 = rf(ctx, id, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, id, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetWorkspaces provides a mock function with given fields: ctx, claims
+func (_m *WorkspaceUsecase) GetWorkspaces(ctx context.Context, claims *domain.Claims) ([]domain.Workspace, *domain.Error) {
+	ret := _m.Called(ctx, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaces")
+	}
+
+	var r0 []domain.Workspace
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims) ([]domain.Workspace, *domain.Error)); ok {
+		return rf(ctx, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Claims) []domain.Workspace); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// RemoveMember provides a mock function with given fields: ctx, id, userID, claims
+func (_m *WorkspaceUsecase) RemoveMember(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	ret := _m.Called(ctx, id, userID, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.Claims) *domain.Error); ok {
+		r0 = rf(ctx, id, userID, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Error)
+		}
+	}
+
+	return r0
+}
+
+// SetMember provides a mock function with given fields: ctx, id, userID, membershipData, claims
+func (_m *WorkspaceUsecase) SetMember(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID, membershipData *domain.MembershipData, claims *domain.Claims) (*domain.Workspace, *domain.Error) {
+	ret := _m.Called(ctx, id, userID, membershipData, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMember")
+	}
+
+	var r0 *domain.Workspace
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.MembershipData, *domain.Claims) (*domain.Workspace, *domain.Error)); ok {
+		return rf(ctx, id, userID, membershipData, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.MembershipData, *domain.Claims) *domain.Workspace); ok {
+		r0 = rf(ctx, id, userID, membershipData, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, primitive.ObjectID, *domain.MembershipData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, id, userID, membershipData, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// SetWIPLimits provides a mock function with given fields: ctx, id, limitsData, claims
+func (_m *WorkspaceUsecase) SetWIPLimits(ctx context.Context, id primitive.ObjectID, limitsData *domain.WIPLimitsData, claims *domain.Claims) (*domain.Workspace, *domain.Error) {
+	ret := _m.Called(ctx, id, limitsData, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWIPLimits")
+	}
+
+	var r0 *domain.Workspace
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.WIPLimitsData, *domain.Claims) (*domain.Workspace, *domain.Error)); ok {
+		return rf(ctx, id, limitsData, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.WIPLimitsData, *domain.Claims) *domain.Workspace); ok {
+		r0 = rf(ctx, id, limitsData, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.WIPLimitsData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, id, limitsData, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateWorkspace provides a mock function with given fields: ctx, id, workspaceData, claims
+func (_m *WorkspaceUsecase) UpdateWorkspace(ctx context.Context, id primitive.ObjectID, workspaceData *domain.WorkspaceData, claims *domain.Claims) (*domain.Workspace, *domain.Error) {
+	ret := _m.Called(ctx, id, workspaceData, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWorkspace")
+	}
+
+	var r0 *domain.Workspace
+	var r1 *domain.Error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.WorkspaceData, *domain.Claims) (*domain.Workspace, *domain.Error)); ok {
+		return rf(ctx, id, workspaceData, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *domain.WorkspaceData, *domain.Claims) *domain.Workspace); ok {
+		r0 = rf(ctx, id, workspaceData, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, *domain.WorkspaceData, *domain.Claims) *domain.Error); ok {
+		r1 = rf(ctx, id, workspaceData, claims)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// NewWorkspaceUsecase creates a new instance of WorkspaceUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWorkspaceUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WorkspaceUsecase {
+	mock := &WorkspaceUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
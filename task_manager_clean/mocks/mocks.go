@@ -14,6 +14,7 @@ func GetNewTask() *domain.Task {
 		Description: "This is some example description for the first task.",
 		DueDate:     format(time.Now().AddDate(0, 0, 3)),
 		Status:      "In Progress",
+		Priority:    domain.TaskPriorityMedium,
 		UserID:      GetPrimitiveID2(),
 	}
 }
@@ -24,6 +25,7 @@ func GetCreateTaskData() *domain.CreateTaskData {
 		Description: "This is some example description for the first task.",
 		DueDate:     format(time.Now().AddDate(0, 0, 3)),
 		Status:      "In Progress",
+		ProjectID:   GetProject().ID,
 	}
 }
 
@@ -46,13 +48,17 @@ func GetUpdateTaskData() *domain.UpdateTaskData {
 }
 
 func GetTaskView(task *domain.Task) *domain.TaskView {
-	return &domain.TaskView{
+	view := &domain.TaskView{
 		ID:          task.ID.Hex(),
 		Title:       task.Title,
 		Description: task.Description,
 		DueDate:     task.DueDate,
 		Status:      task.Status,
+		Priority:    task.Priority,
 	}
+	view.Urgency = urgency(view)
+
+	return view
 }
 
 func GetNewTask2() *domain.Task {
@@ -62,6 +68,7 @@ func GetNewTask2() *domain.Task {
 		Description: "This is some example description for the second task.",
 		DueDate:     format(time.Now().AddDate(0, 0, 1)),
 		Status:      "Completed",
+		Priority:    domain.TaskPriorityMedium,
 		UserID:      GetPrimitiveID1(),
 	}
 }
@@ -104,6 +111,11 @@ func GetAuthData(user *domain.User) *domain.AuthUserData {
 	}
 }
 
+func urgency(view *domain.TaskView) float64 {
+	task := &domain.Task{DueDate: view.DueDate, Status: view.Status, Priority: view.Priority}
+	return task.Urgency(time.Now())
+}
+
 func format(d time.Time) time.Time {
 	return d.UTC().Truncate(time.Millisecond)
 }
@@ -168,6 +180,7 @@ func GetTask(taskData *domain.CreateTaskData, claims *domain.Claims) *domain.Tas
 		Description: taskData.Description,
 		DueDate:     taskData.DueDate,
 		Status:      taskData.Status,
+		Priority:    domain.TaskPriorityMedium,
 		UserID:      claims.ID,
 	}
 }
@@ -179,6 +192,7 @@ func GetTask2(taskData *domain.ReplaceTaskData, claims *domain.Claims) *domain.T
 		Description: taskData.Description,
 		DueDate:     taskData.DueDate,
 		Status:      taskData.Status,
+		Priority:    domain.TaskPriorityMedium,
 		UserID:      claims.ID,
 	}
 }
@@ -190,6 +204,7 @@ func GetTask3(taskData *domain.UpdateTaskData, claims *domain.Claims) *domain.Ta
 		Description: taskData.Description,
 		DueDate:     taskData.DueDate,
 		Status:      taskData.Status,
+		Priority:    domain.TaskPriorityMedium,
 		UserID:      claims.ID,
 	}
 }
@@ -295,31 +310,131 @@ func GetUser4(userData *domain.UpdateUserData) *domain.User {
 }
 
 func GetView(taskData *domain.CreateTaskData, claims *domain.Claims) *domain.TaskView {
-	return &domain.TaskView{
+	view := &domain.TaskView{
 		ID:          claims.ID.Hex(),
 		Title:       taskData.Title,
 		Description: taskData.Description,
 		DueDate:     taskData.DueDate,
 		Status:      taskData.Status,
+		Priority:    domain.TaskPriorityMedium,
 	}
+	view.Urgency = urgency(view)
+
+	return view
 }
 
 func GetView2(taskData *domain.ReplaceTaskData, claims *domain.Claims) *domain.TaskView {
-	return &domain.TaskView{
+	view := &domain.TaskView{
 		ID:          claims.ID.Hex(),
 		Title:       taskData.Title,
 		Description: taskData.Description,
 		DueDate:     taskData.DueDate,
 		Status:      taskData.Status,
+		Priority:    domain.TaskPriorityMedium,
 	}
+	view.Urgency = urgency(view)
+
+	return view
 }
 
 func GetView3(taskData *domain.UpdateTaskData, claims *domain.Claims) *domain.TaskView {
-	return &domain.TaskView{
+	view := &domain.TaskView{
 		ID:          claims.ID.Hex(),
 		Title:       taskData.Title,
 		Description: taskData.Description,
 		DueDate:     taskData.DueDate,
 		Status:      taskData.Status,
+		Priority:    domain.TaskPriorityMedium,
+	}
+	view.Urgency = urgency(view)
+
+	return view
+}
+
+func GetPasswordResetToken(tokenHash string) *domain.PasswordResetToken {
+	return &domain.PasswordResetToken{
+		ID:        primitive.NewObjectID(),
+		UserID:    GetPrimitiveID1(),
+		TokenHash: tokenHash,
+		ExpiresAt: format(time.Now().Add(time.Hour)),
+		CreatedAt: format(time.Now()),
+	}
+}
+
+func GetAccessToken(tokenHash string) *domain.AccessToken {
+	return &domain.AccessToken{
+		ID:        primitive.NewObjectID(),
+		UserID:    GetPrimitiveID1(),
+		Name:      "ci",
+		TokenHash: tokenHash,
+		Scopes:    []string{domain.ScopeTasksRead},
+		CreatedAt: format(time.Now()),
+	}
+}
+
+func GetRole() *domain.Role {
+	return &domain.Role{
+		Name:        "editor",
+		Description: "Edits every task",
+		Level:       30,
+		Permissions: []string{domain.PermissionTaskReadAny, domain.PermissionTaskUpdateAny},
+	}
+}
+
+func GetWorkspace() *domain.Workspace {
+	id, err := primitive.ObjectIDFromHex("60f1b3b3b3f3b3f3b3f3b3f6")
+	if err != nil {
+		panic(err)
+	}
+
+	return &domain.Workspace{
+		ID:   id,
+		Name: "Team",
+		Members: []domain.Membership{
+			{UserID: GetPrimitiveID1(), Role: domain.WorkspaceRoleMember},
+			{UserID: GetPrimitiveID2(), Role: domain.WorkspaceRoleOwner},
+		},
+		CreatedAt: format(time.Now()),
+	}
+}
+
+func GetProject() *domain.Project {
+	id, err := primitive.ObjectIDFromHex("60f1b3b3b3f3b3f3b3f3b3f7")
+	if err != nil {
+		panic(err)
+	}
+
+	return &domain.Project{
+		ID:          id,
+		WorkspaceID: GetWorkspace().ID,
+		Name:        "Backend",
+		CreatedAt:   format(time.Now()),
+	}
+}
+
+func GetRunningTimeEntry() *domain.TimeEntry {
+	return &domain.TimeEntry{
+		ID:          primitive.NewObjectID(),
+		TaskID:      GetPrimitiveID1(),
+		UserID:      GetPrimitiveID1(),
+		WorkspaceID: GetWorkspace().ID,
+		StartedAt:   format(time.Now().Add(-time.Hour)),
+		Running:     true,
+		Tags:        []string{},
+	}
+}
+
+func GetTimeEntry(userID primitive.ObjectID, tags []string, durationSeconds int64) *domain.TimeEntry {
+	startedAt := format(time.Now().Add(-2 * time.Hour))
+	endedAt := startedAt.Add(time.Duration(durationSeconds) * time.Second)
+	return &domain.TimeEntry{
+		ID:              primitive.NewObjectID(),
+		TaskID:          GetPrimitiveID1(),
+		UserID:          userID,
+		WorkspaceID:     GetWorkspace().ID,
+		StartedAt:       startedAt,
+		EndedAt:         &endedAt,
+		DurationSeconds: durationSeconds,
+		Tags:            tags,
 	}
 }
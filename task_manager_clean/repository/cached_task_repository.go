@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"task_manager/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskCache is the cache of the tasks by ID. It can be shared by several CachedTaskRepository instances, so that
+// the writes of each of them invalidate the entries read by the others.
+type TaskCache = LRUCache[primitive.ObjectID, domain.Task]
+
+// CachedTaskRepository is a decorator around a domain.TaskRepository that caches the tasks read by ID.
+// Every write invalidates the entries it may change, and the writes by filter invalidate the whole cache. The writes
+// of a transaction invalidate them again once it ends, since the tasks read meanwhile are the ones before the writes.
+type CachedTaskRepository struct {
+	repo  domain.TaskRepository
+	cache *TaskCache
+}
+
+// A constructor that creates a new instance of CachedTaskRepository.
+func NewCachedTaskRepository(repo domain.TaskRepository, cache *TaskCache) *CachedTaskRepository {
+	return &CachedTaskRepository{
+		repo:  repo,
+		cache: cache,
+	}
+}
+
+// A method that returns the task with the given id from the cache, or from the backend on a miss. A transaction reads
+// the backend, since the checks made in it must see the task as it is stored.
+func (r *CachedTaskRepository) GetTaskByID(ctx context.Context, id primitive.ObjectID) (*domain.Task, error) {
+	if InTransaction(ctx) {
+		return r.repo.GetTaskByID(ctx, id)
+	}
+
+	if task, ok := r.cache.Get(id); ok {
+		return cloneTask(&task), nil
+	}
+
+	generation := r.cache.Generation()
+	task, err := r.repo.GetTaskByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.SetIfUnchanged(id, *cloneTask(task), generation)
+	return task, nil
+}
+
+func (r *CachedTaskRepository) GetAllTasks(ctx context.Context) ([]domain.Task, error) {
+	return r.repo.GetAllTasks(ctx)
+}
+
+func (r *CachedTaskRepository) GetTasks(ctx context.Context, filter *domain.TaskFilter) ([]domain.Task, error) {
+	return r.repo.GetTasks(ctx, filter)
+}
+
+// A method that groups the tasks that match the filter for their statistics, with the backend when it can.
+func (r *CachedTaskRepository) GetTaskStatsGroups(ctx context.Context, filter *domain.TaskFilter, period *domain.TaskStatsPeriod) (*domain.TaskStatsGroups, error) {
+	return domain.GetTaskStatsGroups(ctx, r.repo, filter, period)
+}
+
+func (r *CachedTaskRepository) CountTasks(ctx context.Context, filter *domain.TaskFilter) (int64, error) {
+	return r.repo.CountTasks(ctx, filter)
+}
+
+func (r *CachedTaskRepository) AddTask(ctx context.Context, task *domain.Task) error {
+	defer r.invalidate(ctx, task.ID)
+	return r.repo.AddTask(ctx, task)
+}
+
+func (r *CachedTaskRepository) ReplaceTask(ctx context.Context, id primitive.ObjectID, taskData *domain.Task) error {
+	defer r.invalidate(ctx, id)
+	return r.repo.ReplaceTask(ctx, id, taskData)
+}
+
+func (r *CachedTaskRepository) UpdateTask(ctx context.Context, id primitive.ObjectID, taskData bson.M) error {
+	defer r.invalidate(ctx, id)
+	return r.repo.UpdateTask(ctx, id, taskData)
+}
+
+func (r *CachedTaskRepository) DeleteTask(ctx context.Context, id primitive.ObjectID) error {
+	defer r.invalidate(ctx, id)
+	return r.repo.DeleteTask(ctx, id)
+}
+
+func (r *CachedTaskRepository) DeleteTasks(ctx context.Context, filter *domain.TaskFilter) error {
+	defer r.purge(ctx)
+	return r.repo.DeleteTasks(ctx, filter)
+}
+
+func (r *CachedTaskRepository) ReassignTasks(ctx context.Context, filter *domain.TaskFilter, userID primitive.ObjectID) error {
+	defer r.purge(ctx)
+	return r.repo.ReassignTasks(ctx, filter, userID)
+}
+
+func (r *CachedTaskRepository) AddBlocker(ctx context.Context, id primitive.ObjectID, blockerID primitive.ObjectID) error {
+	defer r.invalidate(ctx, id)
+	return r.repo.AddBlocker(ctx, id, blockerID)
+}
+
+func (r *CachedTaskRepository) RemoveBlocker(ctx context.Context, filter *domain.TaskFilter, blockerID primitive.ObjectID) error {
+	defer r.purge(ctx)
+	return r.repo.RemoveBlocker(ctx, filter, blockerID)
+}
+
+func (r *CachedTaskRepository) AddAttachment(ctx context.Context, id primitive.ObjectID, attachment *domain.Attachment) error {
+	defer r.invalidate(ctx, id)
+	return r.repo.AddAttachment(ctx, id, attachment)
+}
+
+func (r *CachedTaskRepository) RemoveAttachment(ctx context.Context, id primitive.ObjectID, attachmentID primitive.ObjectID) error {
+	defer r.invalidate(ctx, id)
+	return r.repo.RemoveAttachment(ctx, id, attachmentID)
+}
+
+// A helper function that copies a task, so that the callers cannot change the cached blockers, attachments and
+// completion time.
+func cloneTask(task *domain.Task) *domain.Task {
+	clone := *task
+	clone.BlockedBy = slices.Clone(task.BlockedBy)
+	clone.Attachments = slices.Clone(task.Attachments)
+	if task.CompletedAt != nil {
+		completedAt := *task.CompletedAt
+		clone.CompletedAt = &completedAt
+	}
+
+	return &clone
+}
+
+// A helper method that removes a task from the cache, now and once the transaction of the write ends.
+func (r *CachedTaskRepository) invalidate(ctx context.Context, id primitive.ObjectID) {
+	r.cache.Delete(id)
+	AfterTransaction(ctx, func() { r.cache.Delete(id) })
+}
+
+// A helper method that empties the cache, now and once the transaction of the write ends.
+func (r *CachedTaskRepository) purge(ctx context.Context) {
+	r.cache.Purge()
+	AfterTransaction(ctx, r.cache.Purge)
+}
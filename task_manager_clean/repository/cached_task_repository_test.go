@@ -0,0 +1,177 @@
+package repository_test
+
+import (
+	"context"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"task_manager/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A suite that contains tests for the CachedTaskRepository.
+type CachedTaskRepositoryTestSuite struct {
+	suite.Suite
+	backend *mocks.TaskRepository
+	cache   *repository.TaskCache
+	repo    *repository.CachedTaskRepository
+}
+
+// A method that initializes each testcase.
+func (suite *CachedTaskRepositoryTestSuite) SetupSubTest() {
+	suite.backend = new(mocks.TaskRepository)
+	suite.cache = repository.NewLRUCache[primitive.ObjectID, domain.Task](10, time.Minute)
+	suite.repo = repository.NewCachedTaskRepository(suite.backend, suite.cache)
+}
+
+// A method that finalizes each testcase.
+func (suite *CachedTaskRepositoryTestSuite) TearDownSubTest() {
+	suite.backend.AssertExpectations(suite.T())
+}
+
+// A test for the CachedTaskRepository.GetTaskByID method.
+func (suite *CachedTaskRepositoryTestSuite) TestGetTaskByID() {
+	// A testcase where the second read is served from the cache.
+	suite.Run("GetTaskByID_Cached", func() {
+		task := mocks.GetNewTask()
+		suite.backend.On("GetTaskByID", mock.Anything, task.ID).Return(task, nil).Once()
+
+		first, err := suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.NoError(err)
+		second, err := suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.NoError(err)
+
+		suite.Equal(first, second)
+		suite.Equal(domain.CacheStats{Hits: 1, Misses: 1, Size: 1}, suite.cache.Stats())
+	})
+
+	// A testcase where changing a task served from the cache does not change the cached task.
+	suite.Run("GetTaskByID_Copy", func() {
+		task := mocks.GetNewTask()
+		blockerID := primitive.NewObjectID()
+		completedAt := time.Now()
+		task.BlockedBy = []primitive.ObjectID{blockerID}
+		task.Attachments = []domain.Attachment{{Filename: "a.txt"}}
+		task.CompletedAt = new(time.Time)
+		*task.CompletedAt = completedAt
+		suite.backend.On("GetTaskByID", mock.Anything, task.ID).Return(task, nil).Once()
+
+		_, err := suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.NoError(err)
+		cached, err := suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.NoError(err)
+		cached.BlockedBy[0] = primitive.NewObjectID()
+		cached.Attachments[0].Filename = "b.txt"
+		*cached.CompletedAt = completedAt.Add(time.Hour)
+
+		again, err := suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.NoError(err)
+		suite.Equal([]primitive.ObjectID{blockerID}, again.BlockedBy)
+		suite.Equal([]domain.Attachment{{Filename: "a.txt"}}, again.Attachments)
+		suite.Equal(completedAt, *again.CompletedAt)
+	})
+
+	// A testcase where a read in a transaction skips the cache, so that the checks made in it see the stored task.
+	suite.Run("GetTaskByID_Transaction", func() {
+		task := mocks.GetNewTask()
+		suite.backend.On("GetTaskByID", mock.Anything, task.ID).Return(task, nil).Twice()
+
+		_, err := suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.NoError(err)
+		ctx, _ := repository.WithTransactionHooks(context.Background())
+		_, err = suite.repo.GetTaskByID(ctx, task.ID)
+		suite.NoError(err)
+
+		suite.Equal(domain.CacheStats{Misses: 1, Size: 1}, suite.cache.Stats())
+	})
+
+	// A testcase where a missing task is not cached.
+	suite.Run("GetTaskByID_NotFound", func() {
+		task := mocks.GetNewTask()
+		suite.backend.On("GetTaskByID", mock.Anything, task.ID).Return(nil, mongo.ErrNoDocuments).Twice()
+
+		_, err := suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.Equal(mongo.ErrNoDocuments, err)
+		_, err = suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.Equal(mongo.ErrNoDocuments, err)
+	})
+
+	// A testcase where the cached task cannot be changed through the returned pointer.
+	suite.Run("GetTaskByID_Copy", func() {
+		task := mocks.GetNewTask()
+		suite.backend.On("GetTaskByID", mock.Anything, task.ID).Return(task, nil).Once()
+
+		first, _ := suite.repo.GetTaskByID(context.Background(), task.ID)
+		first.Title = "changed"
+		second, _ := suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.NotEqual("changed", second.Title)
+	})
+}
+
+// A test for the invalidation of the cache by the writes.
+func (suite *CachedTaskRepositoryTestSuite) TestInvalidation() {
+	// A testcase where an update invalidates the task, so that the next read gets the new version.
+	suite.Run("UpdateTask_Invalidates", func() {
+		task := mocks.GetNewTask()
+		updated := *task
+		updated.Title = "updated"
+		update := bson.M{"title": "updated"}
+
+		suite.backend.On("GetTaskByID", mock.Anything, task.ID).Return(task, nil).Once()
+		suite.backend.On("UpdateTask", mock.Anything, task.ID, update).Return(nil).Once()
+		suite.backend.On("GetTaskByID", mock.Anything, task.ID).Return(&updated, nil).Once()
+
+		suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.NoError(suite.repo.UpdateTask(context.Background(), task.ID, update))
+		result, err := suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.NoError(err)
+		suite.Equal("updated", result.Title)
+	})
+
+	// A testcase where a write by filter clears the cache.
+	suite.Run("DeleteTasks_Purges", func() {
+		task := mocks.GetNewTask()
+		filter := &domain.TaskFilter{UserID: task.UserID}
+
+		suite.backend.On("GetTaskByID", mock.Anything, task.ID).Return(task, nil).Twice()
+		suite.backend.On("DeleteTasks", mock.Anything, filter).Return(nil).Once()
+
+		suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.NoError(suite.repo.DeleteTasks(context.Background(), filter))
+		suite.Equal(0, suite.cache.Stats().Size)
+		suite.repo.GetTaskByID(context.Background(), task.ID)
+	})
+
+	// A testcase where the task read before the commit of an update is invalidated once the transaction ends.
+	suite.Run("UpdateTask_Transaction", func() {
+		task := mocks.GetNewTask()
+		updated := *task
+		updated.Title = "updated"
+		update := bson.M{"title": "updated"}
+
+		suite.backend.On("UpdateTask", mock.Anything, task.ID, update).Return(nil).Once()
+		suite.backend.On("GetTaskByID", mock.Anything, task.ID).Return(task, nil).Once()
+		suite.backend.On("GetTaskByID", mock.Anything, task.ID).Return(&updated, nil).Once()
+
+		ctx, hooks := repository.WithTransactionHooks(context.Background())
+		suite.NoError(suite.repo.UpdateTask(ctx, task.ID, update))
+		suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.Equal(1, suite.cache.Stats().Size)
+
+		hooks.Run()
+		result, err := suite.repo.GetTaskByID(context.Background(), task.ID)
+		suite.NoError(err)
+		suite.Equal("updated", result.Title)
+	})
+}
+
+// A function that runs the TestSuite.
+func Test_CachedTaskRepository(t *testing.T) {
+	suite.Run(t, new(CachedTaskRepositoryTestSuite))
+}
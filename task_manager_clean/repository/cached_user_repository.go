@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"task_manager/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserCache is the cache of the users by ID, with an index of their IDs by username. It can be shared by several
+// CachedUserRepository instances, so that the writes of each of them invalidate the entries read by the others.
+type UserCache struct {
+	users *LRUCache[primitive.ObjectID, domain.User]
+	ids   *LRUCache[string, primitive.ObjectID]
+}
+
+// A constructor that creates a new instance of UserCache.
+func NewUserCache(size int, ttl time.Duration) *UserCache {
+	return &UserCache{
+		users: NewLRUCache[primitive.ObjectID, domain.User](size, ttl),
+		ids:   NewLRUCache[string, primitive.ObjectID](size, ttl),
+	}
+}
+
+// A method that returns the hits, misses and evictions of the users and the current number of cached users.
+// A lookup by username is a hit only if both the ID and the user are cached.
+func (c *UserCache) Stats() domain.CacheStats {
+	stats := c.users.Stats()
+	stats.Misses += c.ids.Stats().Misses
+	return stats
+}
+
+// CachedUserRepository is a decorator around a domain.UserRepository that caches the users read by ID and by
+// username. Every write invalidates the user it changes, and again once the transaction of the write ends.
+type CachedUserRepository struct {
+	repo  domain.UserRepository
+	cache *UserCache
+}
+
+// A constructor that creates a new instance of CachedUserRepository.
+func NewCachedUserRepository(repo domain.UserRepository, cache *UserCache) *CachedUserRepository {
+	return &CachedUserRepository{
+		repo:  repo,
+		cache: cache,
+	}
+}
+
+// A method that returns the user with the given id from the cache, or from the backend on a miss.
+func (r *CachedUserRepository) GetUserByID(ctx context.Context, objectID primitive.ObjectID) (*domain.User, error) {
+	if user, ok := r.cache.users.Get(objectID); ok {
+		return cloneUser(&user), nil
+	}
+
+	generation := r.cache.users.Generation()
+	user, err := r.repo.GetUserByID(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.users.SetIfUnchanged(objectID, *cloneUser(user), generation)
+	return user, nil
+}
+
+// A method that returns the users with the given ids, reading only the users that are not cached from the backend.
+func (r *CachedUserRepository) GetUsersByIDs(ctx context.Context, objectIDs []primitive.ObjectID) ([]domain.User, error) {
+	users := []domain.User{}
+	missing := []primitive.ObjectID{}
+	for _, id := range objectIDs {
+		if user, ok := r.cache.users.Get(id); ok {
+			users = append(users, *cloneUser(&user))
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) == 0 {
+		return users, nil
+	}
+
+	generation := r.cache.users.Generation()
+	found, err := r.repo.GetUsersByIDs(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range found {
+		r.cache.users.SetIfUnchanged(found[i].ID, *cloneUser(&found[i]), generation)
+	}
+
+	return append(users, found...), nil
+}
+
+// A method that returns the user with the given username from the cache, or from the backend on a miss.
+// The username index may be stale after a rename, so the username of the cached user is checked.
+func (r *CachedUserRepository) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
+	if id, ok := r.cache.ids.Get(username); ok {
+		if user, ok := r.cache.users.Get(id); ok && user.Username == username {
+			return cloneUser(&user), nil
+		}
+	}
+
+	generation := r.cache.users.Generation()
+	user, err := r.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.users.SetIfUnchanged(user.ID, *cloneUser(user), generation)
+	r.cache.ids.Set(username, user.ID)
+	return user, nil
+}
+
+func (r *CachedUserRepository) GetUsers(ctx context.Context) ([]domain.User, error) {
+	return r.repo.GetUsers(ctx)
+}
+
+func (r *CachedUserRepository) CountUsersByRole(ctx context.Context, role string) (int64, error) {
+	return r.repo.CountUsersByRole(ctx, role)
+}
+
+func (r *CachedUserRepository) AddUser(ctx context.Context, user *domain.User) error {
+	defer r.invalidate(ctx, user.ID)
+	return r.repo.AddUser(ctx, user)
+}
+
+func (r *CachedUserRepository) UpdateUser(ctx context.Context, objectID primitive.ObjectID, userData bson.M) error {
+	defer r.invalidate(ctx, objectID)
+	return r.repo.UpdateUser(ctx, objectID, userData)
+}
+
+func (r *CachedUserRepository) DeleteUser(ctx context.Context, objectID primitive.ObjectID) error {
+	defer r.invalidate(ctx, objectID)
+	return r.repo.DeleteUser(ctx, objectID)
+}
+
+func (r *CachedUserRepository) ClaimTOTPCounter(ctx context.Context, objectID primitive.ObjectID, counter int64) (bool, error) {
+	defer r.invalidate(ctx, objectID)
+	return r.repo.ClaimTOTPCounter(ctx, objectID, counter)
+}
+
+func (r *CachedUserRepository) ConsumeRecoveryCode(ctx context.Context, objectID primitive.ObjectID, codeHash string) (bool, error) {
+	defer r.invalidate(ctx, objectID)
+	return r.repo.ConsumeRecoveryCode(ctx, objectID, codeHash)
+}
+
+func (r *CachedUserRepository) AddTwoFactorAttempt(ctx context.Context, objectID primitive.ObjectID, now time.Time, window time.Duration, limit int) (bool, error) {
+	defer r.invalidate(ctx, objectID)
+	return r.repo.AddTwoFactorAttempt(ctx, objectID, now, window, limit)
+}
+
+// A helper function that copies a user, so that the callers cannot change the cached recovery codes.
+func cloneUser(user *domain.User) *domain.User {
+	clone := *user
+	clone.RecoveryCodes = append([]string(nil), user.RecoveryCodes...)
+	return &clone
+}
+
+// A helper method that removes a user from the cache, now and once the transaction of the write ends.
+func (r *CachedUserRepository) invalidate(ctx context.Context, id primitive.ObjectID) {
+	r.cache.users.Delete(id)
+	AfterTransaction(ctx, func() { r.cache.users.Delete(id) })
+}
@@ -0,0 +1,129 @@
+package repository_test
+
+import (
+	"context"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"task_manager/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A suite that contains tests for the CachedUserRepository.
+type CachedUserRepositoryTestSuite struct {
+	suite.Suite
+	backend *mocks.UserRepository
+	cache   *repository.UserCache
+	repo    *repository.CachedUserRepository
+}
+
+// A method that initializes each testcase.
+func (suite *CachedUserRepositoryTestSuite) SetupSubTest() {
+	suite.backend = new(mocks.UserRepository)
+	suite.cache = repository.NewUserCache(10, time.Minute)
+	suite.repo = repository.NewCachedUserRepository(suite.backend, suite.cache)
+}
+
+// A method that finalizes each testcase.
+func (suite *CachedUserRepositoryTestSuite) TearDownSubTest() {
+	suite.backend.AssertExpectations(suite.T())
+}
+
+// A test for the CachedUserRepository.GetUserByUsername method.
+func (suite *CachedUserRepositoryTestSuite) TestGetUserByUsername() {
+	// A testcase where the user read by username is then served from the cache by username and by ID.
+	suite.Run("GetUserByUsername_Cached", func() {
+		user := mocks.GetNewUser()
+		suite.backend.On("GetUserByUsername", mock.Anything, user.Username).Return(user, nil).Once()
+
+		_, err := suite.repo.GetUserByUsername(context.Background(), user.Username)
+		suite.NoError(err)
+		result, err := suite.repo.GetUserByUsername(context.Background(), user.Username)
+		suite.NoError(err)
+		suite.Equal(user, result)
+		result, err = suite.repo.GetUserByID(context.Background(), user.ID)
+		suite.NoError(err)
+		suite.Equal(user, result)
+		suite.Equal(domain.CacheStats{Hits: 2, Misses: 1, Size: 1}, suite.cache.Stats())
+	})
+
+	// A testcase where a renamed user is no longer found by the old username.
+	suite.Run("GetUserByUsername_Renamed", func() {
+		user := mocks.GetNewUser()
+		renamed := *user
+		renamed.Username = "renamed"
+		update := bson.M{"username": "renamed"}
+
+		suite.backend.On("GetUserByUsername", mock.Anything, user.Username).Return(user, nil).Once()
+		suite.backend.On("UpdateUser", mock.Anything, user.ID, update).Return(nil).Once()
+		suite.backend.On("GetUserByID", mock.Anything, user.ID).Return(&renamed, nil).Once()
+		suite.backend.On("GetUserByUsername", mock.Anything, user.Username).Return(nil, mongo.ErrNoDocuments).Once()
+
+		suite.repo.GetUserByUsername(context.Background(), user.Username)
+		suite.NoError(suite.repo.UpdateUser(context.Background(), user.ID, update))
+		suite.repo.GetUserByID(context.Background(), user.ID)
+
+		_, err := suite.repo.GetUserByUsername(context.Background(), user.Username)
+		suite.Equal(mongo.ErrNoDocuments, err)
+	})
+}
+
+// A test for the CachedUserRepository.GetUsersByIDs method.
+func (suite *CachedUserRepositoryTestSuite) TestGetUsersByIDs() {
+	// A testcase where only the users that are not cached are read from the backend.
+	suite.Run("GetUsersByIDs_Partial", func() {
+		cached := mocks.GetNewUser()
+		missing := mocks.GetNewUser2()
+		suite.backend.On("GetUserByID", mock.Anything, cached.ID).Return(cached, nil).Once()
+		suite.backend.On("GetUsersByIDs", mock.Anything, []primitive.ObjectID{missing.ID}).Return([]domain.User{*missing}, nil).Once()
+
+		suite.repo.GetUserByID(context.Background(), cached.ID)
+		result, err := suite.repo.GetUsersByIDs(context.Background(), []primitive.ObjectID{cached.ID, missing.ID})
+		suite.NoError(err)
+		suite.Equal([]domain.User{*cached, *missing}, result)
+	})
+}
+
+// A test for the CachedUserRepository.DeleteUser method.
+func (suite *CachedUserRepositoryTestSuite) TestDeleteUser() {
+	// A testcase where a deleted user is no longer served from the cache.
+	suite.Run("DeleteUser_Invalidates", func() {
+		user := mocks.GetNewUser()
+		suite.backend.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		suite.backend.On("DeleteUser", mock.Anything, user.ID).Return(nil).Once()
+		suite.backend.On("GetUserByID", mock.Anything, user.ID).Return(nil, mongo.ErrNoDocuments).Once()
+
+		suite.repo.GetUserByID(context.Background(), user.ID)
+		suite.NoError(suite.repo.DeleteUser(context.Background(), user.ID))
+
+		_, err := suite.repo.GetUserByID(context.Background(), user.ID)
+		suite.Equal(mongo.ErrNoDocuments, err)
+	})
+
+	// A testcase where the user read before the commit of the deletion is invalidated once the transaction ends.
+	suite.Run("DeleteUser_Transaction", func() {
+		user := mocks.GetNewUser()
+		suite.backend.On("DeleteUser", mock.Anything, user.ID).Return(nil).Once()
+		suite.backend.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		suite.backend.On("GetUserByID", mock.Anything, user.ID).Return(nil, mongo.ErrNoDocuments).Once()
+
+		ctx, hooks := repository.WithTransactionHooks(context.Background())
+		suite.NoError(suite.repo.DeleteUser(ctx, user.ID))
+		suite.repo.GetUserByID(context.Background(), user.ID)
+
+		hooks.Run()
+		_, err := suite.repo.GetUserByID(context.Background(), user.ID)
+		suite.Equal(mongo.ErrNoDocuments, err)
+	})
+}
+
+// A function that runs the TestSuite.
+func Test_CachedUserRepository(t *testing.T) {
+	suite.Run(t, new(CachedUserRepositoryTestSuite))
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"task_manager/domain"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// This struct is a MongoDB GridFS implementation of the BlobStore interface.
+// Each blob is a GridFS file whose ID and name are its key, so that the blobs are stored with the rest of the data
+// and are included in its backups.
+type GridFSBlobStore struct {
+	bucket *gridfs.Bucket
+}
+
+// A constructor that creates a new instance of GridFSBlobStore, which stores the blobs in the bucket with the given
+// name.
+func NewGridFSBlobStore(db *mongo.Database, bucketName string) (*GridFSBlobStore, error) {
+	bucket, err := gridfs.NewBucket(db, options.GridFSBucket().SetName(bucketName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &GridFSBlobStore{
+		bucket: bucket,
+	}, nil
+}
+
+// A method that stores the content under the given key, which must not be used by another blob.
+func (s *GridFSBlobStore) PutBlob(ctx context.Context, key string, content io.Reader) error {
+	stream, err := s.bucket.OpenUploadStreamWithID(key, key)
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		stream.SetWriteDeadline(deadline)
+	}
+
+	// An aborted upload deletes the chunks that were already written.
+	_, err = io.Copy(stream, content)
+	if err != nil {
+		stream.Abort()
+		return err
+	}
+
+	return stream.Close()
+}
+
+// A method that opens the blob with the given key.
+func (s *GridFSBlobStore) GetBlob(ctx context.Context, key string) (io.ReadCloser, error) {
+	stream, err := s.bucket.OpenDownloadStream(key)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		stream.SetReadDeadline(deadline)
+	}
+
+	return stream, nil
+}
+
+// A method that deletes the blob with the given key.
+func (s *GridFSBlobStore) DeleteBlob(ctx context.Context, key string) error {
+	err := s.bucket.DeleteContext(ctx, key)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return nil
+	}
+
+	return err
+}
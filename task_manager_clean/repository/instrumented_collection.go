@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"task_manager/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InstrumentedCollection is a decorator around a domain.Collection that records the duration and the outcome of
+// every operation. It works with any implementation of domain.Collection, including the mocks.
+type InstrumentedCollection struct {
+	collection domain.Collection
+	name       string
+	recorder   domain.OperationRecorder
+}
+
+// A constructor that creates a new instance of InstrumentedCollection.
+func NewInstrumentedCollection(collection domain.Collection, name string, recorder domain.OperationRecorder) *InstrumentedCollection {
+	return &InstrumentedCollection{
+		collection: collection,
+		name:       name,
+		recorder:   recorder,
+	}
+}
+
+func (c *InstrumentedCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) domain.SingleResult {
+	start := time.Now()
+	result := c.collection.FindOne(ctx, filter, opts...)
+	c.observe("FindOne", start, result.Err())
+	return result
+}
+
+func (c *InstrumentedCollection) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	start := time.Now()
+	result, err := c.collection.InsertOne(ctx, document, opts...)
+	c.observe("InsertOne", start, err)
+	return result, err
+}
+
+func (c *InstrumentedCollection) InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	start := time.Now()
+	result, err := c.collection.InsertMany(ctx, documents, opts...)
+	c.observe("InsertMany", start, err)
+	return result, err
+}
+
+func (c *InstrumentedCollection) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	start := time.Now()
+	result, err := c.collection.DeleteOne(ctx, filter, opts...)
+	c.observe("DeleteOne", start, err)
+	return result, err
+}
+
+func (c *InstrumentedCollection) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	start := time.Now()
+	result, err := c.collection.DeleteMany(ctx, filter, opts...)
+	c.observe("DeleteMany", start, err)
+	return result, err
+}
+
+func (c *InstrumentedCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (domain.Cursor, error) {
+	start := time.Now()
+	cursor, err := c.collection.Find(ctx, filter, opts...)
+	c.observe("Find", start, err)
+	return cursor, err
+}
+
+func (c *InstrumentedCollection) FindOneAndReplace(ctx context.Context, filter, replacement interface{}, opts ...*options.FindOneAndReplaceOptions) domain.SingleResult {
+	start := time.Now()
+	result := c.collection.FindOneAndReplace(ctx, filter, replacement, opts...)
+	c.observe("FindOneAndReplace", start, result.Err())
+	return result
+}
+
+func (c *InstrumentedCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	start := time.Now()
+	count, err := c.collection.CountDocuments(ctx, filter, opts...)
+	c.observe("CountDocuments", start, err)
+	return count, err
+}
+
+func (c *InstrumentedCollection) UpdateOne(ctx context.Context, filter, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	start := time.Now()
+	result, err := c.collection.UpdateOne(ctx, filter, update, opts...)
+	c.observe("UpdateOne", start, err)
+	return result, err
+}
+
+func (c *InstrumentedCollection) UpdateMany(ctx context.Context, filter, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	start := time.Now()
+	result, err := c.collection.UpdateMany(ctx, filter, update, opts...)
+	c.observe("UpdateMany", start, err)
+	return result, err
+}
+
+func (c *InstrumentedCollection) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (domain.Cursor, error) {
+	start := time.Now()
+	cursor, err := c.collection.Aggregate(ctx, pipeline, opts...)
+	c.observe("Aggregate", start, err)
+	return cursor, err
+}
+
+// A helper method that records an operation. Missing documents are an expected outcome and not counted as errors.
+func (c *InstrumentedCollection) observe(operation string, start time.Time, err error) {
+	if err == mongo.ErrNoDocuments {
+		err = nil
+	}
+
+	c.recorder.ObserveOperation(c.name, operation, time.Since(start), err)
+}
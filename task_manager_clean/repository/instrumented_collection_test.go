@@ -0,0 +1,74 @@
+package repository_test
+
+import (
+	"context"
+	"task_manager/mocks"
+	"task_manager/repository"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A suite that contains tests for the InstrumentedCollection.
+type InstrumentedCollectionTestSuite struct {
+	suite.Suite
+	collection   *mocks.Collection
+	recorder     *mocks.OperationRecorder
+	instrumented *repository.InstrumentedCollection
+}
+
+// A method that initializes the test suite.
+func (suite *InstrumentedCollectionTestSuite) SetupTest() {
+	suite.collection = new(mocks.Collection)
+	suite.recorder = new(mocks.OperationRecorder)
+	suite.instrumented = repository.NewInstrumentedCollection(suite.collection, "tasks", suite.recorder)
+}
+
+// A method that finalizes each test.
+func (suite *InstrumentedCollectionTestSuite) TearDownTest() {
+	suite.collection.AssertExpectations(suite.T())
+	suite.recorder.AssertExpectations(suite.T())
+}
+
+// A test for the InstrumentedCollection.CountDocuments method.
+func (suite *InstrumentedCollectionTestSuite) TestCountDocuments() {
+	// A testcase where the operation succeeds.
+	suite.Run("CountDocuments_Success", func() {
+		suite.collection.On("CountDocuments", mock.Anything, bson.M{}).Return(int64(2), nil).Once()
+		suite.recorder.On("ObserveOperation", "tasks", "CountDocuments", mock.Anything, nil).Once()
+
+		count, err := suite.instrumented.CountDocuments(context.Background(), bson.M{})
+		suite.NoError(err)
+		suite.Equal(int64(2), count)
+	})
+
+	// A testcase where the operation fails and the error is recorded.
+	suite.Run("CountDocuments_Failure", func() {
+		suite.collection.On("CountDocuments", mock.Anything, bson.M{}).Return(int64(0), mongo.ErrClientDisconnected).Once()
+		suite.recorder.On("ObserveOperation", "tasks", "CountDocuments", mock.Anything, mongo.ErrClientDisconnected).Once()
+
+		_, err := suite.instrumented.CountDocuments(context.Background(), bson.M{})
+		suite.Equal(mongo.ErrClientDisconnected, err)
+	})
+}
+
+// A test for the InstrumentedCollection.FindOne method.
+func (suite *InstrumentedCollectionTestSuite) TestFindOne() {
+	// A testcase where no document is found, which is not recorded as an error.
+	suite.Run("FindOne_NoDocuments", func() {
+		result := new(mocks.SingleResult)
+		result.On("Err").Return(mongo.ErrNoDocuments).Once()
+		suite.collection.On("FindOne", mock.Anything, bson.M{}).Return(result).Once()
+		suite.recorder.On("ObserveOperation", "tasks", "FindOne", mock.Anything, nil).Once()
+
+		suite.Equal(result, suite.instrumented.FindOne(context.Background(), bson.M{}))
+	})
+}
+
+// A function that runs the TestSuite.
+func Test_InstrumentedCollection(t *testing.T) {
+	suite.Run(t, new(InstrumentedCollectionTestSuite))
+}
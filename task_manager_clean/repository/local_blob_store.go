@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"task_manager/domain"
+)
+
+// This struct is a local filesystem implementation of the BlobStore interface.
+// Each blob is a file under the root directory, at the path given by its key. A blob is written to a temporary file
+// that is renamed when it is complete, so that a failed upload never leaves a partial blob under its key.
+type LocalBlobStore struct {
+	root string
+}
+
+// A constructor that creates a new instance of LocalBlobStore.
+func NewLocalBlobStore(root string) *LocalBlobStore {
+	return &LocalBlobStore{
+		root: root,
+	}
+}
+
+// A method that stores the content under the given key, replacing the blob that had the key.
+func (s *LocalBlobStore) PutBlob(ctx context.Context, key string, content io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = io.Copy(file, content)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+// A method that opens the blob with the given key.
+func (s *LocalBlobStore) GetBlob(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// A method that deletes the blob with the given key, and its directory once it is empty.
+func (s *LocalBlobStore) DeleteBlob(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	// The directory is kept if other blobs are still in it.
+	os.Remove(filepath.Dir(path))
+	return nil
+}
+
+// A helper method that returns the path of the file of a blob. Keys that would leave the root directory are refused.
+func (s *LocalBlobStore) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", errors.New("invalid blob key: " + key)
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"task_manager/domain"
+	"task_manager/repository"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite that contains tests for the LocalBlobStore.
+type LocalBlobStoreTestSuite struct {
+	suite.Suite
+}
+
+// A test for the LocalBlobStore.PutBlob and LocalBlobStore.GetBlob methods.
+func (suite *LocalBlobStoreTestSuite) TestPutBlob() {
+	// A testcase where a stored blob is read back.
+	suite.Run("PutBlob_Success", func() {
+		store := repository.NewLocalBlobStore(suite.T().TempDir())
+		err := store.PutBlob(context.Background(), "tasks/1/2", strings.NewReader("content"))
+		suite.NoError(err)
+
+		blob, err := store.GetBlob(context.Background(), "tasks/1/2")
+		suite.NoError(err)
+		defer blob.Close()
+
+		content, err := io.ReadAll(blob)
+		suite.NoError(err)
+		suite.Equal("content", string(content))
+	})
+
+	// A testcase where a key would leave the root directory.
+	suite.Run("PutBlob_InvalidKey", func() {
+		root := suite.T().TempDir()
+		store := repository.NewLocalBlobStore(filepath.Join(root, "blobs"))
+
+		err := store.PutBlob(context.Background(), "../outside", strings.NewReader("content"))
+		suite.Error(err)
+		suite.NoFileExists(filepath.Join(root, "outside"))
+	})
+
+	// A testcase where no blob has the key.
+	suite.Run("GetBlob_NotFound", func() {
+		store := repository.NewLocalBlobStore(suite.T().TempDir())
+
+		_, err := store.GetBlob(context.Background(), "tasks/1/2")
+		suite.ErrorIs(err, domain.ErrBlobNotFound)
+	})
+}
+
+// A test for the LocalBlobStore.DeleteBlob method.
+func (suite *LocalBlobStoreTestSuite) TestDeleteBlob() {
+	// A testcase where the blob and its empty directory are deleted, and deleting it again succeeds.
+	suite.Run("DeleteBlob_Success", func() {
+		root := suite.T().TempDir()
+		store := repository.NewLocalBlobStore(root)
+		suite.NoError(store.PutBlob(context.Background(), "tasks/1/2", strings.NewReader("content")))
+
+		suite.NoError(store.DeleteBlob(context.Background(), "tasks/1/2"))
+		suite.NoError(store.DeleteBlob(context.Background(), "tasks/1/2"))
+
+		_, err := os.Stat(filepath.Join(root, "tasks", "1"))
+		suite.True(os.IsNotExist(err))
+	})
+}
+
+// A function that runs the TestSuite.
+func Test_LocalBlobStore(t *testing.T) {
+	suite.Run(t, new(LocalBlobStoreTestSuite))
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"container/list"
+	"sync"
+	"task_manager/domain"
+	"time"
+)
+
+// LRUCache is a cache that holds at most size entries, each for at most ttl. When the cache is full, the least
+// recently used entry is evicted. It is safe for concurrent use.
+type LRUCache[K comparable, V any] struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu         sync.Mutex
+	order      *list.List
+	entries    map[K]*list.Element
+	stats      domain.CacheStats
+	generation uint64
+}
+
+// A struct that holds a cached value and its expiry.
+type cacheEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// A constructor that creates a new instance of LRUCache.
+func NewLRUCache[K comparable, V any](size int, ttl time.Duration) *LRUCache[K, V] {
+	return &LRUCache[K, V]{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: map[K]*list.Element{},
+	}
+}
+
+// A method that returns the value of the key, if it is cached and has not expired.
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if ok && c.now().After(element.Value.(*cacheEntry[K, V]).expiresAt) {
+		c.remove(element)
+		ok = false
+	}
+
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+
+	c.stats.Hits++
+	c.order.MoveToFront(element)
+	return element.Value.(*cacheEntry[K, V]).value, true
+}
+
+// A method that returns the current generation of the cache, which changes whenever entries are invalidated.
+func (c *LRUCache[K, V]) Generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generation
+}
+
+// A method that caches the value of the key, and evicts the least recently used entry if the cache is full.
+func (c *LRUCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, value)
+}
+
+// A method that caches the value of the key only if no entry was invalidated since the generation was read.
+// This keeps a value read from the backend before a concurrent write from being cached after the write.
+func (c *LRUCache[K, V]) SetIfUnchanged(key K, value V, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation == generation {
+		c.set(key, value)
+	}
+}
+
+// A helper method that caches the value of the key. The caller must hold the lock.
+func (c *LRUCache[K, V]) set(key K, value V) {
+	expiresAt := c.now().Add(c.ttl)
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*cacheEntry[K, V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry[K, V]{key: key, value: value, expiresAt: expiresAt})
+
+	if c.order.Len() > c.size {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// A method that removes the key from the cache.
+func (c *LRUCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// A method that removes every entry from the cache.
+func (c *LRUCache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.order.Init()
+	c.entries = map[K]*list.Element{}
+}
+
+// A method that returns the hits, misses and evictions of the cache and its current size.
+func (c *LRUCache[K, V]) Stats() domain.CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Size = c.order.Len()
+	return stats
+}
+
+// A helper method that removes an element. The caller must hold the lock.
+func (c *LRUCache[K, V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cacheEntry[K, V]).key)
+}
@@ -0,0 +1,83 @@
+package repository_test
+
+import (
+	"task_manager/domain"
+	"task_manager/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite that contains tests for the LRUCache.
+type LRUCacheTestSuite struct {
+	suite.Suite
+}
+
+// A test for the LRUCache.Get and LRUCache.Set methods.
+func (suite *LRUCacheTestSuite) TestGet() {
+	// A testcase where a cached value is found and a missing one is not.
+	suite.Run("Get_HitAndMiss", func() {
+		cache := repository.NewLRUCache[string, int](2, time.Minute)
+		cache.Set("a", 1)
+
+		value, ok := cache.Get("a")
+		suite.True(ok)
+		suite.Equal(1, value)
+
+		_, ok = cache.Get("b")
+		suite.False(ok)
+		suite.Equal(domain.CacheStats{Hits: 1, Misses: 1, Size: 1}, cache.Stats())
+	})
+
+	// A testcase where the least recently used entry is evicted when the cache is full.
+	suite.Run("Get_Eviction", func() {
+		cache := repository.NewLRUCache[string, int](2, time.Minute)
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		cache.Get("a")
+		cache.Set("c", 3)
+
+		_, ok := cache.Get("b")
+		suite.False(ok)
+		_, ok = cache.Get("a")
+		suite.True(ok)
+		suite.Equal(uint64(1), cache.Stats().Evictions)
+		suite.Equal(2, cache.Stats().Size)
+	})
+
+	// A testcase where an entry expires.
+	suite.Run("Get_Expired", func() {
+		cache := repository.NewLRUCache[string, int](2, 10*time.Millisecond)
+		cache.Set("a", 1)
+		time.Sleep(20 * time.Millisecond)
+
+		_, ok := cache.Get("a")
+		suite.False(ok)
+		suite.Equal(0, cache.Stats().Size)
+	})
+}
+
+// A test for the LRUCache.SetIfUnchanged method.
+func (suite *LRUCacheTestSuite) TestSetIfUnchanged() {
+	// A testcase where an invalidation between the read and the write keeps the value out of the cache.
+	suite.Run("SetIfUnchanged_Invalidated", func() {
+		cache := repository.NewLRUCache[string, int](2, time.Minute)
+		generation := cache.Generation()
+		cache.Delete("a")
+		cache.SetIfUnchanged("a", 1, generation)
+
+		_, ok := cache.Get("a")
+		suite.False(ok)
+
+		cache.SetIfUnchanged("a", 2, cache.Generation())
+		value, ok := cache.Get("a")
+		suite.True(ok)
+		suite.Equal(2, value)
+	})
+}
+
+// A function that runs the TestSuite.
+func Test_LRUCache(t *testing.T) {
+	suite.Run(t, new(LRUCacheTestSuite))
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"task_manager/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// This struct is a MongoDB implementation of the AccessTokenRepository interface.
+type MongoAccessTokenRepository struct {
+	collection domain.Collection
+}
+
+// A constructor that creates a new instance of MongoAccessTokenRepository.
+func NewMongoAccessTokenRepository(collection domain.Collection) *MongoAccessTokenRepository {
+	return &MongoAccessTokenRepository{
+		collection: collection,
+	}
+}
+
+// A method that adds a new access token.
+func (r *MongoAccessTokenRepository) AddAccessToken(ctx context.Context, token *domain.AccessToken) error {
+	// Insert the token into the database.
+	_, err := r.collection.InsertOne(ctx, token)
+	return err
+}
+
+// A method that returns all access tokens of the user with the given ID.
+func (r *MongoAccessTokenRepository) GetAccessTokensByUserID(ctx context.Context, userID primitive.ObjectID) ([]domain.AccessToken, error) {
+	tokens := []domain.AccessToken{}
+
+	// Query the database for the tokens of the user.
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterate over the cursor and decode each token into an AccessToken struct.
+	err = cursor.All(ctx, &tokens)
+	return tokens, err
+}
+
+// A method that returns the access token with the given ID.
+func (r *MongoAccessTokenRepository) GetAccessTokenByID(ctx context.Context, id primitive.ObjectID) (*domain.AccessToken, error) {
+	token := &domain.AccessToken{}
+
+	// Query the database for a token with the given ID.
+	result := r.collection.FindOne(ctx, bson.M{"_id": id})
+	if err := result.Decode(token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// A method that returns the access token with the given hash.
+func (r *MongoAccessTokenRepository) GetAccessTokenByHash(ctx context.Context, tokenHash string) (*domain.AccessToken, error) {
+	token := &domain.AccessToken{}
+
+	// Query the database for a token with the given hash.
+	result := r.collection.FindOne(ctx, bson.M{"token_hash": tokenHash})
+	if err := result.Decode(token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// A method that records when the access token with the given ID was last used.
+func (r *MongoAccessTokenRepository) UpdateLastUsed(ctx context.Context, id primitive.ObjectID, lastUsedAt time.Time) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_used_at": lastUsedAt}})
+	return err
+}
+
+// A method that deletes the access token with the given ID.
+func (r *MongoAccessTokenRepository) DeleteAccessToken(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// A method that deletes the access tokens of the user with the given ID.
+func (r *MongoAccessTokenRepository) DeleteAccessTokensByUserID(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
@@ -0,0 +1,124 @@
+package repository_test
+
+import (
+	"context"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"task_manager/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A suite that contains tests for the MongoAccessTokenRepository.
+type MongoAccessTokenRepositoryTestSuite struct {
+	suite.Suite
+	repo       *repository.MongoAccessTokenRepository
+	collection *mocks.Collection
+}
+
+// A method that initializes the test suite.
+func (suite *MongoAccessTokenRepositoryTestSuite) SetupSuite() {
+	suite.collection = new(mocks.Collection)
+	suite.repo = repository.NewMongoAccessTokenRepository(suite.collection)
+}
+
+// A method that finalizes the test suite.
+func (suite *MongoAccessTokenRepositoryTestSuite) TearDownSuite() {
+	suite.collection.AssertExpectations(suite.T())
+}
+
+// A test for the MongoAccessTokenRepository.GetAccessTokensByUserID method.
+func (suite *MongoAccessTokenRepositoryTestSuite) TestGetAccessTokensByUserID() {
+	// A testcase for the successful retrieval of the tokens.
+	suite.Run("GetAccessTokensByUserID_Success", func() {
+		tokens := []domain.AccessToken{*mocks.GetAccessToken("hash")}
+
+		cursor := new(mocks.Cursor)
+		cursor.On("All", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			tokenPtr := args.Get(1).(*[]domain.AccessToken)
+			*tokenPtr = append(*tokenPtr, tokens...)
+		})
+
+		suite.collection.On("Find", mock.Anything, mock.Anything).Return(cursor, nil).Once()
+
+		result, err := suite.repo.GetAccessTokensByUserID(context.Background(), mocks.GetPrimitiveID1())
+		suite.NoError(err)
+		suite.Equal(tokens, result)
+	})
+}
+
+// A test for the MongoAccessTokenRepository.GetAccessTokenByHash method.
+func (suite *MongoAccessTokenRepositoryTestSuite) TestGetAccessTokenByHash() {
+	// A testcase for the successful retrieval of a token.
+	suite.Run("GetAccessTokenByHash_Success", func() {
+		token := mocks.GetAccessToken("hash")
+
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			tokenPtr := args.Get(0).(*domain.AccessToken)
+			*tokenPtr = *token
+		})
+
+		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
+
+		result, err := suite.repo.GetAccessTokenByHash(context.Background(), "hash")
+		suite.NoError(err)
+		suite.Equal(token, result)
+	})
+
+	// A testcase for a token that does not exist.
+	suite.Run("GetAccessTokenByHash_NotFound", func() {
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+
+		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
+
+		result, err := suite.repo.GetAccessTokenByHash(context.Background(), "hash")
+		suite.Equal(mongo.ErrNoDocuments, err)
+		suite.Nil(result)
+	})
+}
+
+// A test for the MongoAccessTokenRepository.UpdateLastUsed method.
+func (suite *MongoAccessTokenRepositoryTestSuite) TestUpdateLastUsed() {
+	// A testcase for the successful update of a token.
+	suite.Run("UpdateLastUsed_Success", func() {
+		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{ModifiedCount: 1}, nil).Once()
+
+		err := suite.repo.UpdateLastUsed(context.Background(), mocks.GetPrimitiveID1(), time.Now())
+		suite.NoError(err)
+	})
+}
+
+// A test for the MongoAccessTokenRepository.DeleteAccessToken method.
+func (suite *MongoAccessTokenRepositoryTestSuite) TestDeleteAccessToken() {
+	// A testcase for the failure of deleting a token.
+	suite.Run("DeleteAccessToken_Failure", func() {
+		suite.collection.On("DeleteOne", mock.Anything, mock.Anything).Return(&mongo.DeleteResult{}, mongo.ErrClientDisconnected).Once()
+
+		err := suite.repo.DeleteAccessToken(context.Background(), mocks.GetPrimitiveID1())
+		suite.Error(err)
+	})
+}
+
+// A test for the MongoAccessTokenRepository.DeleteAccessTokensByUserID method.
+func (suite *MongoAccessTokenRepositoryTestSuite) TestDeleteAccessTokensByUserID() {
+	// A testcase where the tokens of the user are deleted.
+	suite.Run("DeleteAccessTokensByUserID_Success", func() {
+		userID := mocks.GetPrimitiveID1()
+		suite.collection.On("DeleteMany", mock.Anything, bson.M{"user_id": userID}).Return(&mongo.DeleteResult{DeletedCount: 2}, nil).Once()
+
+		err := suite.repo.DeleteAccessTokensByUserID(context.Background(), userID)
+		suite.NoError(err)
+	})
+}
+
+// A function that runs the MongoAccessTokenRepositoryTestSuite.
+func TestMongoAccessTokenRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MongoAccessTokenRepositoryTestSuite))
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"task_manager/domain"
+	"task_manager/infrastructure"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -14,23 +16,33 @@ type MongoCollection struct {
 }
 
 func (m *MongoCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) domain.SingleResult {
+	defer m.log(ctx, "FindOne", time.Now())
 	result := m.Collection.FindOne(ctx, filter, opts...)
 	return &MongoSingleResult{SingleResult: result}
 }
 
 func (m *MongoCollection) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	defer m.log(ctx, "InsertOne", time.Now())
 	return m.Collection.InsertOne(ctx, document, opts...)
 }
 
 func (m *MongoCollection) InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	defer m.log(ctx, "InsertMany", time.Now())
 	return m.Collection.InsertMany(ctx, documents, opts...)
 }
 
 func (m *MongoCollection) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	defer m.log(ctx, "DeleteOne", time.Now())
 	return m.Collection.DeleteOne(ctx, filter, opts...)
 }
 
+func (m *MongoCollection) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	defer m.log(ctx, "DeleteMany", time.Now())
+	return m.Collection.DeleteMany(ctx, filter, opts...)
+}
+
 func (m *MongoCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (domain.Cursor, error) {
+	defer m.log(ctx, "Find", time.Now())
 	cursor, err := m.Collection.Find(ctx, filter, opts...)
 	if err != nil {
 		return nil, err
@@ -39,18 +51,36 @@ func (m *MongoCollection) Find(ctx context.Context, filter interface{}, opts ...
 }
 
 func (m *MongoCollection) FindOneAndReplace(ctx context.Context, filter, replacement interface{}, opts ...*options.FindOneAndReplaceOptions) domain.SingleResult {
+	defer m.log(ctx, "FindOneAndReplace", time.Now())
 	result := m.Collection.FindOneAndReplace(ctx, filter, replacement, opts...)
 	return &MongoSingleResult{SingleResult: result}
 }
 
 func (m *MongoCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	defer m.log(ctx, "CountDocuments", time.Now())
 	return m.Collection.CountDocuments(ctx, filter, opts...)
 }
 
 func (m *MongoCollection) UpdateOne(ctx context.Context, filter, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	defer m.log(ctx, "UpdateOne", time.Now())
 	return m.Collection.UpdateOne(ctx, filter, update, opts...)
 }
 
 func (m *MongoCollection) UpdateMany(ctx context.Context, filter, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	defer m.log(ctx, "UpdateMany", time.Now())
 	return m.Collection.UpdateMany(ctx, filter, update, opts...)
 }
+
+func (m *MongoCollection) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (domain.Cursor, error) {
+	defer m.log(ctx, "Aggregate", time.Now())
+	cursor, err := m.Collection.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &MongoCursor{Cursor: cursor}, nil
+}
+
+// A helper method that logs the duration of an operation at the debug level, with the logger of the request.
+func (m *MongoCollection) log(ctx context.Context, operation string, start time.Time) {
+	infrastructure.Logger(ctx).Debug("mongo operation", "collection", m.Name(), "operation", operation, "duration_ms", float64(time.Since(start).Microseconds())/1000)
+}
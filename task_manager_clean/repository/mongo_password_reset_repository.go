@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"task_manager/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// This struct is a MongoDB implementation of the PasswordResetRepository interface.
+type MongoPasswordResetRepository struct {
+	collection domain.Collection
+}
+
+// A constructor that creates a new instance of MongoPasswordResetRepository.
+func NewMongoPasswordResetRepository(collection domain.Collection) *MongoPasswordResetRepository {
+	return &MongoPasswordResetRepository{
+		collection: collection,
+	}
+}
+
+// A method that adds a new reset token.
+func (r *MongoPasswordResetRepository) AddToken(ctx context.Context, token *domain.PasswordResetToken) error {
+	// Insert the token into the database.
+	_, err := r.collection.InsertOne(ctx, token)
+	return err
+}
+
+// A method that returns the reset token with the given hash.
+func (r *MongoPasswordResetRepository) GetTokenByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	token := &domain.PasswordResetToken{}
+
+	// Query the database for a token with the given hash.
+	result := r.collection.FindOne(ctx, bson.M{"token_hash": tokenHash})
+	if err := result.Decode(token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// A method that marks the reset token with the given ID as used.
+// It returns mongo.ErrNoDocuments if the token was already used, so that a token can only be consumed once.
+func (r *MongoPasswordResetRepository) MarkTokenUsed(ctx context.Context, id primitive.ObjectID) error {
+	// Only update the token if it has not been used yet.
+	filter := bson.M{"_id": id, "used_at": nil}
+	update := bson.M{"$set": bson.M{"used_at": time.Now()}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.ModifiedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// A method that deletes all reset tokens of the user with the given ID.
+func (r *MongoPasswordResetRepository) DeleteTokensByUserID(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
@@ -0,0 +1,112 @@
+package repository_test
+
+import (
+	"context"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"task_manager/repository"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A suite that contains tests for the MongoPasswordResetRepository.
+type MongoPasswordResetRepositoryTestSuite struct {
+	suite.Suite
+	repo       *repository.MongoPasswordResetRepository
+	collection *mocks.Collection
+}
+
+// A method that initializes the test suite.
+func (suite *MongoPasswordResetRepositoryTestSuite) SetupSuite() {
+	suite.collection = new(mocks.Collection)
+	suite.repo = repository.NewMongoPasswordResetRepository(suite.collection)
+}
+
+// A method that finalizes the test suite.
+func (suite *MongoPasswordResetRepositoryTestSuite) TearDownSuite() {
+	suite.collection.AssertExpectations(suite.T())
+}
+
+// A test for the MongoPasswordResetRepository.AddToken method.
+func (suite *MongoPasswordResetRepositoryTestSuite) TestAddToken() {
+	// A testcase for the successful addition of a token.
+	suite.Run("AddToken_Success", func() {
+		token := mocks.GetPasswordResetToken("hash")
+		suite.collection.On("InsertOne", mock.Anything, token).Return(&mongo.InsertOneResult{}, nil).Once()
+
+		err := suite.repo.AddToken(context.Background(), token)
+		suite.NoError(err)
+	})
+}
+
+// A test for the MongoPasswordResetRepository.GetTokenByHash method.
+func (suite *MongoPasswordResetRepositoryTestSuite) TestGetTokenByHash() {
+	// A testcase for the successful retrieval of a token.
+	suite.Run("GetTokenByHash_Success", func() {
+		token := mocks.GetPasswordResetToken("hash")
+
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			tokenPtr := args.Get(0).(*domain.PasswordResetToken)
+			*tokenPtr = *token
+		})
+
+		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
+
+		result, err := suite.repo.GetTokenByHash(context.Background(), "hash")
+		suite.NoError(err)
+		suite.Equal(token, result)
+	})
+
+	// A testcase for a token that does not exist.
+	suite.Run("GetTokenByHash_NotFound", func() {
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+
+		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
+
+		result, err := suite.repo.GetTokenByHash(context.Background(), "hash")
+		suite.Equal(mongo.ErrNoDocuments, err)
+		suite.Nil(result)
+	})
+}
+
+// A test for the MongoPasswordResetRepository.MarkTokenUsed method.
+func (suite *MongoPasswordResetRepositoryTestSuite) TestMarkTokenUsed() {
+	// A testcase where an unused token is marked as used.
+	suite.Run("MarkTokenUsed_Success", func() {
+		token := mocks.GetPasswordResetToken("hash")
+		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{ModifiedCount: 1}, nil).Once()
+
+		err := suite.repo.MarkTokenUsed(context.Background(), token.ID)
+		suite.NoError(err)
+	})
+
+	// A testcase where the token was already used.
+	suite.Run("MarkTokenUsed_AlreadyUsed", func() {
+		token := mocks.GetPasswordResetToken("hash")
+		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{ModifiedCount: 0}, nil).Once()
+
+		err := suite.repo.MarkTokenUsed(context.Background(), token.ID)
+		suite.Equal(mongo.ErrNoDocuments, err)
+	})
+}
+
+// A test for the MongoPasswordResetRepository.DeleteTokensByUserID method.
+func (suite *MongoPasswordResetRepositoryTestSuite) TestDeleteTokensByUserID() {
+	// A testcase for the successful deletion of the tokens.
+	suite.Run("DeleteTokensByUserID_Success", func() {
+		suite.collection.On("DeleteMany", mock.Anything, mock.Anything).Return(&mongo.DeleteResult{DeletedCount: 2}, nil).Once()
+
+		err := suite.repo.DeleteTokensByUserID(context.Background(), mocks.GetPrimitiveID1())
+		suite.NoError(err)
+	})
+}
+
+// A function that runs the MongoPasswordResetRepositoryTestSuite.
+func TestMongoPasswordResetRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MongoPasswordResetRepositoryTestSuite))
+}
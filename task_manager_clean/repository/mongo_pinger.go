@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// This struct is a MongoDB implementation of the Pinger interface.
+type MongoPinger struct {
+	client *mongo.Client
+}
+
+// A constructor that creates a new instance of MongoPinger.
+func NewMongoPinger(client *mongo.Client) *MongoPinger {
+	return &MongoPinger{
+		client: client,
+	}
+}
+
+// A method that checks that the primary of the MongoDB deployment is reachable.
+func (p *MongoPinger) Ping(ctx context.Context) error {
+	return p.client.Ping(ctx, readpref.Primary())
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"task_manager/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// This struct is a MongoDB implementation of the ProjectRepository interface.
+type MongoProjectRepository struct {
+	collection domain.Collection
+}
+
+// A constructor that creates a new instance of MongoProjectRepository.
+func NewMongoProjectRepository(collection domain.Collection) *MongoProjectRepository {
+	return &MongoProjectRepository{
+		collection: collection,
+	}
+}
+
+// A method that adds a new project.
+func (r *MongoProjectRepository) AddProject(ctx context.Context, project *domain.Project) error {
+	// Insert the project into the database.
+	_, err := r.collection.InsertOne(ctx, project)
+	return err
+}
+
+// A method that returns the projects of the workspace with the given ID.
+func (r *MongoProjectRepository) GetProjectsByWorkspaceID(ctx context.Context, workspaceID primitive.ObjectID) ([]domain.Project, error) {
+	projects := []domain.Project{}
+
+	// Query the database for the projects of the workspace.
+	cursor, err := r.collection.Find(ctx, bson.M{"workspace_id": workspaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterate over the cursor and decode each project into a Project struct.
+	err = cursor.All(ctx, &projects)
+	return projects, err
+}
+
+// A method that returns the project with the given ID.
+func (r *MongoProjectRepository) GetProjectByID(ctx context.Context, id primitive.ObjectID) (*domain.Project, error) {
+	project := &domain.Project{}
+
+	// Query the database for a project with the given ID.
+	result := r.collection.FindOne(ctx, bson.M{"_id": id})
+	if err := result.Decode(project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// A method that deletes the project with the given ID.
+func (r *MongoProjectRepository) DeleteProject(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// A method that deletes the projects of the workspace with the given ID.
+func (r *MongoProjectRepository) DeleteProjectsByWorkspaceID(ctx context.Context, workspaceID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"workspace_id": workspaceID})
+	return err
+}
@@ -0,0 +1,84 @@
+package repository_test
+
+import (
+	"context"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"task_manager/repository"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A suite that contains tests for the MongoProjectRepository.
+type MongoProjectRepositoryTestSuite struct {
+	suite.Suite
+	repo       *repository.MongoProjectRepository
+	collection *mocks.Collection
+}
+
+// A method that initializes the test suite.
+func (suite *MongoProjectRepositoryTestSuite) SetupSuite() {
+	suite.collection = new(mocks.Collection)
+	suite.repo = repository.NewMongoProjectRepository(suite.collection)
+}
+
+// A method that finalizes the test suite.
+func (suite *MongoProjectRepositoryTestSuite) TearDownSuite() {
+	suite.collection.AssertExpectations(suite.T())
+}
+
+// A test for the MongoProjectRepository.GetProjectsByWorkspaceID method.
+func (suite *MongoProjectRepositoryTestSuite) TestGetProjectsByWorkspaceID() {
+	// A testcase for the successful retrieval of the projects of a workspace.
+	suite.Run("GetProjectsByWorkspaceID_Success", func() {
+		projects := []domain.Project{*mocks.GetProject()}
+
+		cursor := new(mocks.Cursor)
+		cursor.On("All", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			projectPtr := args.Get(1).(*[]domain.Project)
+			*projectPtr = append(*projectPtr, projects...)
+		})
+
+		suite.collection.On("Find", mock.Anything, bson.M{"workspace_id": projects[0].WorkspaceID}).Return(cursor, nil).Once()
+
+		result, err := suite.repo.GetProjectsByWorkspaceID(context.Background(), projects[0].WorkspaceID)
+		suite.NoError(err)
+		suite.Equal(projects, result)
+	})
+}
+
+// A test for the MongoProjectRepository.GetProjectByID method.
+func (suite *MongoProjectRepositoryTestSuite) TestGetProjectByID() {
+	// A testcase for a project that does not exist.
+	suite.Run("GetProjectByID_NotFound", func() {
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+
+		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
+
+		result, err := suite.repo.GetProjectByID(context.Background(), mocks.GetProject().ID)
+		suite.Equal(mongo.ErrNoDocuments, err)
+		suite.Nil(result)
+	})
+}
+
+// A test for the MongoProjectRepository.DeleteProjectsByWorkspaceID method.
+func (suite *MongoProjectRepositoryTestSuite) TestDeleteProjectsByWorkspaceID() {
+	// A testcase for the successful deletion of the projects of a workspace.
+	suite.Run("DeleteProjectsByWorkspaceID_Success", func() {
+		workspaceID := mocks.GetWorkspace().ID
+		suite.collection.On("DeleteMany", mock.Anything, bson.M{"workspace_id": workspaceID}).Return(&mongo.DeleteResult{DeletedCount: 1}, nil).Once()
+
+		err := suite.repo.DeleteProjectsByWorkspaceID(context.Background(), workspaceID)
+		suite.NoError(err)
+	})
+}
+
+// A function that runs the MongoProjectRepositoryTestSuite.
+func TestMongoProjectRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MongoProjectRepositoryTestSuite))
+}
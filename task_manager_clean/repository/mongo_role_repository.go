@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"task_manager/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// This struct is a MongoDB implementation of the RoleRepository interface.
+type MongoRoleRepository struct {
+	collection domain.Collection
+}
+
+// A constructor that creates a new instance of MongoRoleRepository.
+func NewMongoRoleRepository(collection domain.Collection) *MongoRoleRepository {
+	return &MongoRoleRepository{
+		collection: collection,
+	}
+}
+
+// A method that returns all custom roles.
+func (r *MongoRoleRepository) GetRoles(ctx context.Context) ([]domain.Role, error) {
+	roles := []domain.Role{}
+
+	// Query the database for all roles.
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterate over the cursor and decode each role into a Role struct.
+	err = cursor.All(ctx, &roles)
+	return roles, err
+}
+
+// A method that returns the custom role with the given name.
+func (r *MongoRoleRepository) GetRoleByName(ctx context.Context, name string) (*domain.Role, error) {
+	role := &domain.Role{}
+
+	// Query the database for a role with the given name.
+	result := r.collection.FindOne(ctx, bson.M{"_id": name})
+	if err := result.Decode(role); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// A method that adds a new custom role.
+func (r *MongoRoleRepository) AddRole(ctx context.Context, role *domain.Role) error {
+	// Insert the role into the database.
+	_, err := r.collection.InsertOne(ctx, role)
+	return err
+}
+
+// A method that replaces the custom role with the given name, with the new role.
+func (r *MongoRoleRepository) ReplaceRole(ctx context.Context, name string, role *domain.Role) error {
+	// Replace the role with the given name.
+	result := r.collection.FindOneAndReplace(ctx, bson.M{"_id": name}, role)
+	return result.Err()
+}
+
+// A method that deletes the custom role with the given name.
+func (r *MongoRoleRepository) DeleteRole(ctx context.Context, name string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": name})
+	return err
+}
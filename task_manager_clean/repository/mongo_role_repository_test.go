@@ -0,0 +1,115 @@
+package repository_test
+
+import (
+	"context"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"task_manager/repository"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A suite that contains tests for the MongoRoleRepository.
+type MongoRoleRepositoryTestSuite struct {
+	suite.Suite
+	repo       *repository.MongoRoleRepository
+	collection *mocks.Collection
+}
+
+// A method that initializes the test suite.
+func (suite *MongoRoleRepositoryTestSuite) SetupSuite() {
+	suite.collection = new(mocks.Collection)
+	suite.repo = repository.NewMongoRoleRepository(suite.collection)
+}
+
+// A method that finalizes the test suite.
+func (suite *MongoRoleRepositoryTestSuite) TearDownSuite() {
+	suite.collection.AssertExpectations(suite.T())
+}
+
+// A test for the MongoRoleRepository.GetRoles method.
+func (suite *MongoRoleRepositoryTestSuite) TestGetRoles() {
+	// A testcase for the successful retrieval of the roles.
+	suite.Run("GetRoles_Success", func() {
+		roles := []domain.Role{*mocks.GetRole()}
+
+		cursor := new(mocks.Cursor)
+		cursor.On("All", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			rolePtr := args.Get(1).(*[]domain.Role)
+			*rolePtr = append(*rolePtr, roles...)
+		})
+
+		suite.collection.On("Find", mock.Anything, mock.Anything).Return(cursor, nil).Once()
+
+		result, err := suite.repo.GetRoles(context.Background())
+		suite.NoError(err)
+		suite.Equal(roles, result)
+	})
+}
+
+// A test for the MongoRoleRepository.GetRoleByName method.
+func (suite *MongoRoleRepositoryTestSuite) TestGetRoleByName() {
+	// A testcase for the successful retrieval of a role.
+	suite.Run("GetRoleByName_Success", func() {
+		role := mocks.GetRole()
+
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			rolePtr := args.Get(0).(*domain.Role)
+			*rolePtr = *role
+		})
+
+		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
+
+		result, err := suite.repo.GetRoleByName(context.Background(), role.Name)
+		suite.NoError(err)
+		suite.Equal(role, result)
+	})
+
+	// A testcase for a role that does not exist.
+	suite.Run("GetRoleByName_NotFound", func() {
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+
+		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
+
+		result, err := suite.repo.GetRoleByName(context.Background(), "missing")
+		suite.Equal(mongo.ErrNoDocuments, err)
+		suite.Nil(result)
+	})
+}
+
+// A test for the MongoRoleRepository.ReplaceRole method.
+func (suite *MongoRoleRepositoryTestSuite) TestReplaceRole() {
+	// A testcase for the successful replacement of a role.
+	suite.Run("ReplaceRole_Success", func() {
+		role := mocks.GetRole()
+
+		res := new(mocks.SingleResult)
+		res.On("Err").Return(nil)
+
+		suite.collection.On("FindOneAndReplace", mock.Anything, mock.Anything, role).Return(res).Once()
+
+		err := suite.repo.ReplaceRole(context.Background(), role.Name, role)
+		suite.NoError(err)
+	})
+}
+
+// A test for the MongoRoleRepository.DeleteRole method.
+func (suite *MongoRoleRepositoryTestSuite) TestDeleteRole() {
+	// A testcase for the failure of deleting a role.
+	suite.Run("DeleteRole_Failure", func() {
+		suite.collection.On("DeleteOne", mock.Anything, mock.Anything).Return(&mongo.DeleteResult{}, mongo.ErrClientDisconnected).Once()
+
+		err := suite.repo.DeleteRole(context.Background(), "editor")
+		suite.Error(err)
+	})
+}
+
+// A function that runs the MongoRoleRepositoryTestSuite.
+func TestMongoRoleRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MongoRoleRepositoryTestSuite))
+}
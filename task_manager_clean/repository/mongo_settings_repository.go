@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"task_manager/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// The ID of the document that holds the security settings.
+	securitySettingsID = "security"
+)
+
+// This struct is a MongoDB implementation of the SettingsRepository interface.
+type MongoSettingsRepository struct {
+	collection domain.Collection
+}
+
+// A constructor that creates a new instance of MongoSettingsRepository.
+func NewMongoSettingsRepository(collection domain.Collection) *MongoSettingsRepository {
+	return &MongoSettingsRepository{
+		collection: collection,
+	}
+}
+
+// A method that returns the security settings, or the defaults if they were never saved.
+func (r *MongoSettingsRepository) GetSecuritySettings(ctx context.Context) (*domain.SecuritySettings, error) {
+	settings := &domain.SecuritySettings{}
+
+	// Query the database for the security settings.
+	result := r.collection.FindOne(ctx, bson.M{"_id": securitySettingsID})
+	err := result.Decode(settings)
+	if err == mongo.ErrNoDocuments {
+		return &domain.SecuritySettings{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// A method that saves the security settings.
+func (r *MongoSettingsRepository) UpdateSecuritySettings(ctx context.Context, settings *domain.SecuritySettings) error {
+	// Create the settings document if it doesn't exist.
+	opts := options.Update().SetUpsert(true)
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": securitySettingsID}, bson.M{"$set": settings}, opts)
+	return err
+}
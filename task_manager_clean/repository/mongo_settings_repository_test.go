@@ -0,0 +1,88 @@
+package repository_test
+
+import (
+	"context"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"task_manager/repository"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A suite that contains tests for the MongoSettingsRepository.
+type MongoSettingsRepositoryTestSuite struct {
+	suite.Suite
+	repo       *repository.MongoSettingsRepository
+	collection *mocks.Collection
+}
+
+// A method that initializes the test suite.
+func (suite *MongoSettingsRepositoryTestSuite) SetupSuite() {
+	suite.collection = new(mocks.Collection)
+	suite.repo = repository.NewMongoSettingsRepository(suite.collection)
+}
+
+// A method that finalizes the test suite.
+func (suite *MongoSettingsRepositoryTestSuite) TearDownSuite() {
+	suite.collection.AssertExpectations(suite.T())
+}
+
+// A test for the MongoSettingsRepository.GetSecuritySettings method.
+func (suite *MongoSettingsRepositoryTestSuite) TestGetSecuritySettings() {
+	// A testcase for stored settings.
+	suite.Run("GetSecuritySettings_Success", func() {
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(0).(*domain.SecuritySettings).RequireAdminTwoFactor = true
+		})
+
+		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
+
+		result, err := suite.repo.GetSecuritySettings(context.Background())
+		suite.NoError(err)
+		suite.True(result.RequireAdminTwoFactor)
+	})
+
+	// A testcase where the settings were never saved.
+	suite.Run("GetSecuritySettings_Default", func() {
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+
+		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
+
+		result, err := suite.repo.GetSecuritySettings(context.Background())
+		suite.NoError(err)
+		suite.Equal(&domain.SecuritySettings{}, result)
+	})
+
+	// A testcase for the failure of retrieving the settings.
+	suite.Run("GetSecuritySettings_Failure", func() {
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(mongo.ErrClientDisconnected)
+
+		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
+
+		result, err := suite.repo.GetSecuritySettings(context.Background())
+		suite.Error(err)
+		suite.Nil(result)
+	})
+}
+
+// A test for the MongoSettingsRepository.UpdateSecuritySettings method.
+func (suite *MongoSettingsRepositoryTestSuite) TestUpdateSecuritySettings() {
+	// A testcase for the successful update of the settings.
+	suite.Run("UpdateSecuritySettings_Success", func() {
+		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{UpsertedCount: 1}, nil).Once()
+
+		err := suite.repo.UpdateSecuritySettings(context.Background(), &domain.SecuritySettings{RequireAdminTwoFactor: true})
+		suite.NoError(err)
+	})
+}
+
+// A function that runs the MongoSettingsRepositoryTestSuite.
+func TestMongoSettingsRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MongoSettingsRepositoryTestSuite))
+}
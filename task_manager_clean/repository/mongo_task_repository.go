@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"encoding/binary"
 	"task_manager/domain"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -21,53 +23,248 @@ func NewMongoTaskRepository(collection domain.Collection) *MongoTaskRepository {
 }
 
 // A method that returns all tasks.
-func (r *MongoTaskRepository) GetAllTasks() ([]domain.Task, error) {
+func (r *MongoTaskRepository) GetAllTasks(ctx context.Context) ([]domain.Task, error) {
 	tasks := []domain.Task{}
 
 	// Query the database for all tasks.
-	cursor, err := r.collection.Find(context.Background(), bson.M{})
+	cursor, err := r.collection.Find(ctx, bson.M{})
 	if err != nil {
 		return nil, err
 	}
 
 	// Iterate over the cursor and decode each task into a Task struct.
-	err = cursor.All(context.Background(), &tasks)
+	err = cursor.All(ctx, &tasks)
+	return tasks, err
+}
+
+// A method that returns the tasks that match the filter.
+func (r *MongoTaskRepository) GetTasks(ctx context.Context, filter *domain.TaskFilter) ([]domain.Task, error) {
+	tasks := []domain.Task{}
+
+	// Query the database for the matching tasks.
+	cursor, err := r.collection.Find(ctx, taskFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterate over the cursor and decode each task into a Task struct.
+	err = cursor.All(ctx, &tasks)
 	return tasks, err
 }
 
 // A method that returns a task with the given ID.
-func (r *MongoTaskRepository) GetTaskByID(id primitive.ObjectID) (*domain.Task, error) {
+func (r *MongoTaskRepository) GetTaskByID(ctx context.Context, id primitive.ObjectID) (*domain.Task, error) {
 	task := &domain.Task{}
 
 	// Query the database for a task with the given ID.
-	result := r.collection.FindOne(context.Background(), bson.M{"_id": id})
+	result := r.collection.FindOne(ctx, bson.M{"_id": id})
 	err := result.Decode(task)
 	return task, err
 }
 
 // A method that adds a new task.
-func (r *MongoTaskRepository) AddTask(task *domain.Task) error {
+func (r *MongoTaskRepository) AddTask(ctx context.Context, task *domain.Task) error {
 	// Insert the task into the database.
-	_, err := r.collection.InsertOne(context.Background(), task)
+	_, err := r.collection.InsertOne(ctx, task)
 	return err
 }
 
 // A method that replaces a task with the given ID, with the new task.
-func (r *MongoTaskRepository) ReplaceTask(id primitive.ObjectID, newTask *domain.Task) error {
+func (r *MongoTaskRepository) ReplaceTask(ctx context.Context, id primitive.ObjectID, newTask *domain.Task) error {
 	// Replace the task with the given ID.
-	result := r.collection.FindOneAndReplace(context.Background(), bson.M{"_id": id}, newTask)
+	result := r.collection.FindOneAndReplace(ctx, bson.M{"_id": id}, newTask)
 	return result.Err()
 }
 
 // A method that updates a task with the given ID.
-func (r *MongoTaskRepository) UpdateTask(id primitive.ObjectID, taskData bson.M) error {
+func (r *MongoTaskRepository) UpdateTask(ctx context.Context, id primitive.ObjectID, taskData bson.M) error {
 	// Update the task with the given ID.
-	_, err := r.collection.UpdateOne(context.Background(), bson.M{"_id": id}, bson.M{"$set": taskData})
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": taskData})
 	return err
 }
 
 // A method that deletes a task with the given ID.
-func (r *MongoTaskRepository) DeleteTask(id primitive.ObjectID) error {
-	_, err := r.collection.DeleteOne(context.Background(), bson.M{"_id": id})
+func (r *MongoTaskRepository) DeleteTask(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// A method that deletes the tasks that match the filter.
+func (r *MongoTaskRepository) DeleteTasks(ctx context.Context, filter *domain.TaskFilter) error {
+	_, err := r.collection.DeleteMany(ctx, taskFilter(filter))
+	return err
+}
+
+// A method that gives the tasks that match the filter to the given user.
+func (r *MongoTaskRepository) ReassignTasks(ctx context.Context, filter *domain.TaskFilter, userID primitive.ObjectID) error {
+	_, err := r.collection.UpdateMany(ctx, taskFilter(filter), bson.M{"$set": bson.M{"user_id": userID}})
+	return err
+}
+
+// A method that counts the tasks that match the filter.
+func (r *MongoTaskRepository) CountTasks(ctx context.Context, filter *domain.TaskFilter) (int64, error) {
+	return r.collection.CountDocuments(ctx, taskFilter(filter))
+}
+
+// A method that adds a task to the blockers of the task with the given ID, unless it is already one of them.
+func (r *MongoTaskRepository) AddBlocker(ctx context.Context, id primitive.ObjectID, blockerID primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$addToSet": bson.M{"blocked_by": blockerID}})
+	return err
+}
+
+// A method that removes a task from the blockers of the tasks that match the filter.
+func (r *MongoTaskRepository) RemoveBlocker(ctx context.Context, filter *domain.TaskFilter, blockerID primitive.ObjectID) error {
+	_, err := r.collection.UpdateMany(ctx, taskFilter(filter), bson.M{"$pull": bson.M{"blocked_by": blockerID}})
 	return err
 }
+
+// A method that adds an attachment to the task with the given ID.
+func (r *MongoTaskRepository) AddAttachment(ctx context.Context, id primitive.ObjectID, attachment *domain.Attachment) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$push": bson.M{"attachments": attachment}})
+	return err
+}
+
+// A method that removes an attachment from the task with the given ID.
+func (r *MongoTaskRepository) RemoveAttachment(ctx context.Context, id primitive.ObjectID, attachmentID primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$pull": bson.M{"attachments": bson.M{"_id": attachmentID}}})
+	return err
+}
+
+// A method that groups the tasks that match the filter for their statistics, with an aggregation pipeline that
+// computes the groups of the created and the completed tasks side by side. The tasks are created when their IDs
+// were generated.
+func (r *MongoTaskRepository) GetTaskStatsGroups(ctx context.Context, filter *domain.TaskFilter, period *domain.TaskStatsPeriod) (*domain.TaskStatsGroups, error) {
+	created := bson.M{}
+	if !period.From.IsZero() {
+		created["$gte"] = firstObjectIDAt(period.From)
+	}
+	if !period.Before.IsZero() {
+		created["$lt"] = firstObjectIDAt(period.Before)
+	}
+
+	completed := bson.M{"$type": "date"}
+	if !period.From.IsZero() {
+		completed["$gte"] = period.From
+	}
+	if !period.Before.IsZero() {
+		completed["$lt"] = period.Before
+	}
+
+	statuses := bson.A{}
+	if len(created) > 0 {
+		statuses = append(statuses, bson.M{"$match": bson.M{"_id": created}})
+	}
+	statuses = append(statuses,
+		bson.M{"$group": bson.M{
+			"_id":   bson.M{"user_id": "$user_id", "status": "$status"},
+			"count": bson.M{"$sum": 1},
+			"overdue": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$and": bson.A{
+					bson.M{"$ne": bson.A{"$status", "Completed"}},
+					bson.M{"$lt": bson.A{"$due_date", period.Now}},
+				}},
+				1,
+				0,
+			}}},
+		}},
+		bson.M{"$project": bson.M{"_id": 0, "user_id": "$_id.user_id", "status": "$_id.status", "count": 1, "overdue": 1}},
+	)
+
+	completions := bson.A{
+		bson.M{"$match": bson.M{"completed_at": completed}},
+		bson.M{"$group": bson.M{
+			"_id": bson.M{
+				"user_id": "$user_id",
+				"week":    bson.M{"$dateTrunc": bson.M{"date": "$completed_at", "unit": "week", "startOfWeek": "monday"}},
+			},
+			"count": bson.M{"$sum": 1},
+			"seconds": bson.M{"$sum": bson.M{"$dateDiff": bson.M{
+				"startDate": bson.M{"$toDate": "$_id"},
+				"endDate":   "$completed_at",
+				"unit":      "second",
+			}}},
+		}},
+		bson.M{"$project": bson.M{"_id": 0, "user_id": "$_id.user_id", "week": "$_id.week", "count": 1, "seconds": 1}},
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": taskFilter(filter)},
+		bson.M{"$facet": bson.M{"statuses": statuses, "completions": completions}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	// The facets are returned in a single document.
+	results := []domain.TaskStatsGroups{}
+	err = cursor.All(ctx, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := &domain.TaskStatsGroups{Statuses: []domain.TaskStatusGroup{}, Completions: []domain.TaskCompletionGroup{}}
+	if len(results) > 0 {
+		groups.Statuses = append(groups.Statuses, results[0].Statuses...)
+		groups.Completions = append(groups.Completions, results[0].Completions...)
+	}
+
+	return groups, nil
+}
+
+// A helper function that returns the smallest ID generated at the given time, to compare the creation times of
+// documents. The IDs of primitive.NewObjectIDFromTimestamp end with a counter, so they cannot be used as bounds.
+func firstObjectIDAt(t time.Time) primitive.ObjectID {
+	var id primitive.ObjectID
+	binary.BigEndian.PutUint32(id[0:4], uint32(t.Unix()))
+	return id
+}
+
+// A helper function that converts a task filter into a MongoDB query.
+func taskFilter(filter *domain.TaskFilter) bson.M {
+	query := bson.M{}
+
+	if !filter.UserID.IsZero() {
+		query["user_id"] = filter.UserID
+	}
+
+	if filter.WorkspaceIDs != nil {
+		inWorkspaces := bson.M{"workspace_id": bson.M{"$in": filter.WorkspaceIDs}}
+		if filter.IncludeUnassigned {
+			query["$or"] = bson.A{inWorkspaces, bson.M{"workspace_id": bson.M{"$exists": false}}}
+		} else {
+			query["workspace_id"] = inWorkspaces["workspace_id"]
+		}
+	}
+
+	if !filter.ProjectID.IsZero() {
+		query["project_id"] = filter.ProjectID
+	}
+
+	if filter.Statuses != nil {
+		query["status"] = bson.M{"$in": filter.Statuses}
+	}
+
+	if !filter.DueBefore.IsZero() {
+		query["due_date"] = bson.M{"$lt": filter.DueBefore}
+	}
+
+	if filter.IDs != nil {
+		query["_id"] = bson.M{"$in": filter.IDs}
+	}
+
+	if !filter.BlockedBy.IsZero() {
+		query["blocked_by"] = filter.BlockedBy
+	}
+
+	// The condition is nested, since the filter of the workspaces may already use $or.
+	if !filter.VisibleTo.IsZero() {
+		query["$and"] = bson.A{bson.M{"$or": bson.A{
+			bson.M{"user_id": filter.VisibleTo},
+			bson.M{"workspace_id": bson.M{"$in": filter.OwnedWorkspaceIDs}},
+		}}}
+	}
+
+	return query
+}
@@ -1,10 +1,12 @@
 package repository_test
 
 import (
+	"context"
 	"task_manager/domain"
 	"task_manager/mocks"
 	"task_manager/repository"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -45,7 +47,7 @@ func (suite *MongoTaskRepositoryTestSuite) TestGetAllTasks() {
 
 		suite.collection.On("Find", mock.Anything, mock.Anything).Return(cursor, nil).Once()
 
-		result, err := suite.repo.GetAllTasks()
+		result, err := suite.repo.GetAllTasks(context.Background())
 		suite.NoError(err)
 		suite.Equal(tasks, result)
 	})
@@ -54,7 +56,7 @@ func (suite *MongoTaskRepositoryTestSuite) TestGetAllTasks() {
 	suite.Run("GetAllTasks_Failure", func() {
 		suite.collection.On("Find", mock.Anything, mock.Anything).Return(new(mocks.Cursor), mongo.ErrNoDocuments).Once()
 
-		result, err := suite.repo.GetAllTasks()
+		result, err := suite.repo.GetAllTasks(context.Background())
 		suite.Error(err)
 		suite.Nil(result)
 	})
@@ -74,7 +76,7 @@ func (suite *MongoTaskRepositoryTestSuite) TestGetTaskByID() {
 
 		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res, nil).Once()
 
-		result, err := suite.repo.GetTaskByID(task.ID)
+		result, err := suite.repo.GetTaskByID(context.Background(), task.ID)
 		suite.NoError(err)
 		suite.Equal(task, result)
 	})
@@ -86,7 +88,7 @@ func (suite *MongoTaskRepositoryTestSuite) TestGetTaskByID() {
 		res.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments).Once()
 		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res, nil).Once()
 
-		result, err := suite.repo.GetTaskByID(id)
+		result, err := suite.repo.GetTaskByID(context.Background(), id)
 		suite.Error(err)
 		suite.Equal(domain.Task{}, *result)
 	})
@@ -99,7 +101,7 @@ func (suite *MongoTaskRepositoryTestSuite) TestAddTask() {
 		task := mocks.GetNewTask()
 		suite.collection.On("InsertOne", mock.Anything, task).Return(&mongo.InsertOneResult{}, nil).Once()
 
-		err := suite.repo.AddTask(task)
+		err := suite.repo.AddTask(context.Background(), task)
 		suite.NoError(err)
 	})
 
@@ -108,7 +110,7 @@ func (suite *MongoTaskRepositoryTestSuite) TestAddTask() {
 		task := mocks.GetNewTask()
 		suite.collection.On("InsertOne", mock.Anything, task).Return(nil, mongo.ErrNoDocuments).Once()
 
-		err := suite.repo.AddTask(task)
+		err := suite.repo.AddTask(context.Background(), task)
 		suite.Error(err)
 	})
 }
@@ -124,7 +126,7 @@ func (suite *MongoTaskRepositoryTestSuite) TestReplaceTask() {
 		res.On("Err").Return(nil)
 		suite.collection.On("FindOneAndReplace", mock.Anything, mock.Anything, task).Return(res, nil).Once()
 
-		err := suite.repo.ReplaceTask(id, task)
+		err := suite.repo.ReplaceTask(context.Background(), id, task)
 		suite.NoError(err)
 	})
 
@@ -137,7 +139,7 @@ func (suite *MongoTaskRepositoryTestSuite) TestReplaceTask() {
 		res.On("Err").Return(mongo.ErrNoDocuments)
 		suite.collection.On("FindOneAndReplace", mock.Anything, mock.Anything, task).Return(res, nil).Once()
 
-		err := suite.repo.ReplaceTask(id, task)
+		err := suite.repo.ReplaceTask(context.Background(), id, task)
 		suite.Error(err)
 	})
 }
@@ -152,7 +154,7 @@ func (suite *MongoTaskRepositoryTestSuite) TestUpdateTask() {
 
 		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{}, nil).Once()
 
-		err := suite.repo.UpdateTask(id, taskData)
+		err := suite.repo.UpdateTask(context.Background(), id, taskData)
 		suite.NoError(err)
 	})
 
@@ -164,11 +166,25 @@ func (suite *MongoTaskRepositoryTestSuite) TestUpdateTask() {
 
 		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{}, mongo.ErrClientDisconnected).Once()
 
-		err := suite.repo.UpdateTask(id, taskData)
+		err := suite.repo.UpdateTask(context.Background(), id, taskData)
 		suite.Error(err)
 	})
 }
 
+// A test for the MongoTaskRepository.RemoveAttachment method.
+func (suite *MongoTaskRepositoryTestSuite) TestRemoveAttachment() {
+	// A testcase where the attachment is pulled from the attachments of the task.
+	suite.Run("RemoveAttachment_Success", func() {
+		task := mocks.GetNewTask()
+		attachmentID := primitive.NewObjectID()
+
+		suite.collection.On("UpdateOne", mock.Anything, bson.M{"_id": task.ID}, bson.M{"$pull": bson.M{"attachments": bson.M{"_id": attachmentID}}}).Return(&mongo.UpdateResult{}, nil).Once()
+
+		err := suite.repo.RemoveAttachment(context.Background(), task.ID, attachmentID)
+		suite.NoError(err)
+	})
+}
+
 // A test for the MongoUserRepository.DeleteTask method.
 func (suite *MongoTaskRepositoryTestSuite) TestDeleteTask() {
 	// A testcase for the successful deletion of a task.
@@ -178,7 +194,7 @@ func (suite *MongoTaskRepositoryTestSuite) TestDeleteTask() {
 
 		suite.collection.On("DeleteOne", mock.Anything, mock.Anything).Return(&mongo.DeleteResult{}, nil).Once()
 
-		err := suite.repo.DeleteTask(id)
+		err := suite.repo.DeleteTask(context.Background(), id)
 		suite.NoError(err)
 	})
 
@@ -189,7 +205,156 @@ func (suite *MongoTaskRepositoryTestSuite) TestDeleteTask() {
 
 		suite.collection.On("DeleteOne", mock.Anything, mock.Anything).Return(&mongo.DeleteResult{}, mongo.ErrClientDisconnected).Once()
 
-		err := suite.repo.DeleteTask(id)
+		err := suite.repo.DeleteTask(context.Background(), id)
+		suite.Error(err)
+	})
+}
+
+// A test for the MongoTaskRepository.GetTasks method.
+func (suite *MongoTaskRepositoryTestSuite) TestGetTasks() {
+	// A testcase where the tasks of the workspaces and the unassigned tasks are queried.
+	suite.Run("GetTasks_Workspaces", func() {
+		workspaceIDs := []primitive.ObjectID{mocks.GetWorkspace().ID}
+		query := bson.M{"$or": bson.A{
+			bson.M{"workspace_id": bson.M{"$in": workspaceIDs}},
+			bson.M{"workspace_id": bson.M{"$exists": false}},
+		}}
+
+		cursor := new(mocks.Cursor)
+		cursor.On("All", mock.Anything, mock.Anything).Return(nil)
+		suite.collection.On("Find", mock.Anything, query).Return(cursor, nil).Once()
+
+		_, err := suite.repo.GetTasks(context.Background(), &domain.TaskFilter{WorkspaceIDs: workspaceIDs, IncludeUnassigned: true})
+		suite.NoError(err)
+	})
+
+	// A testcase where the tasks of a project are queried.
+	suite.Run("GetTasks_Project", func() {
+		project := mocks.GetProject()
+		query := bson.M{"workspace_id": bson.M{"$in": []primitive.ObjectID{project.WorkspaceID}}, "project_id": project.ID}
+
+		cursor := new(mocks.Cursor)
+		cursor.On("All", mock.Anything, mock.Anything).Return(nil)
+		suite.collection.On("Find", mock.Anything, query).Return(cursor, nil).Once()
+
+		_, err := suite.repo.GetTasks(context.Background(), &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{project.WorkspaceID}, ProjectID: project.ID})
+		suite.NoError(err)
+	})
+
+	// A testcase where only the tasks of the user and of the workspaces they own are queried.
+	suite.Run("GetTasks_VisibleTo", func() {
+		workspaceIDs := []primitive.ObjectID{mocks.GetWorkspace().ID}
+		userID := mocks.GetPrimitiveID1()
+		query := bson.M{
+			"$or": bson.A{
+				bson.M{"workspace_id": bson.M{"$in": workspaceIDs}},
+				bson.M{"workspace_id": bson.M{"$exists": false}},
+			},
+			"$and": bson.A{bson.M{"$or": bson.A{
+				bson.M{"user_id": userID},
+				bson.M{"workspace_id": bson.M{"$in": workspaceIDs}},
+			}}},
+		}
+
+		cursor := new(mocks.Cursor)
+		cursor.On("All", mock.Anything, mock.Anything).Return(nil)
+		suite.collection.On("Find", mock.Anything, query).Return(cursor, nil).Once()
+
+		filter := &domain.TaskFilter{WorkspaceIDs: workspaceIDs, IncludeUnassigned: true, VisibleTo: userID, OwnedWorkspaceIDs: workspaceIDs}
+		_, err := suite.repo.GetTasks(context.Background(), filter)
+		suite.NoError(err)
+	})
+}
+
+// A helper function that returns the ID with the given timestamp and no other bytes.
+func objectIDAt(timestamp uint32) primitive.ObjectID {
+	return primitive.ObjectID{byte(timestamp >> 24), byte(timestamp >> 16), byte(timestamp >> 8), byte(timestamp)}
+}
+
+// A test for the MongoTaskRepository.GetTaskStatsGroups method.
+func (suite *MongoTaskRepositoryTestSuite) TestGetTaskStatsGroups() {
+	// A testcase where the groups of the tasks of a workspace created and completed in a range are aggregated.
+	suite.Run("GetTaskStatsGroups_Success", func() {
+		workspaceIDs := []primitive.ObjectID{mocks.GetWorkspace().ID}
+		period := &domain.TaskStatsPeriod{
+			From:   time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC),
+			Before: time.Date(2024, 9, 16, 0, 0, 0, 0, time.UTC),
+			Now:    time.Date(2024, 9, 20, 0, 0, 0, 0, time.UTC),
+		}
+		pipeline := mock.MatchedBy(func(pipeline bson.A) bool {
+			facets := pipeline[1].(bson.M)["$facet"].(bson.M)
+			statuses := facets["statuses"].(bson.A)
+			completions := facets["completions"].(bson.A)
+			return suite.Equal(bson.M{"$match": bson.M{"workspace_id": bson.M{"$in": workspaceIDs}}}, pipeline[0]) &&
+				suite.Equal(bson.M{"$match": bson.M{"_id": bson.M{
+					"$gte": objectIDAt(0x66d50000),
+					"$lt":  objectIDAt(0x66e77500),
+				}}}, statuses[0]) &&
+				suite.Equal(bson.M{"$match": bson.M{"completed_at": bson.M{"$type": "date", "$gte": period.From, "$lt": period.Before}}}, completions[0])
+		})
+		groups := domain.TaskStatsGroups{
+			Statuses:    []domain.TaskStatusGroup{{UserID: mocks.GetPrimitiveID1(), Status: "Pending", Count: 2, Overdue: 1}},
+			Completions: []domain.TaskCompletionGroup{{UserID: mocks.GetPrimitiveID1(), Week: period.From, Count: 1, Seconds: 3600}},
+		}
+
+		cursor := new(mocks.Cursor)
+		cursor.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(1).(*[]domain.TaskStatsGroups) = []domain.TaskStatsGroups{groups}
+		}).Return(nil)
+		suite.collection.On("Aggregate", mock.Anything, pipeline).Return(cursor, nil).Once()
+
+		result, err := suite.repo.GetTaskStatsGroups(context.Background(), &domain.TaskFilter{WorkspaceIDs: workspaceIDs}, period)
+		suite.NoError(err)
+		suite.Equal(&groups, result)
+	})
+}
+
+// A test for the MongoTaskRepository.DeleteTasks method.
+func (suite *MongoTaskRepositoryTestSuite) TestDeleteTasks() {
+	// A testcase for the successful deletion of the tasks of a workspace.
+	suite.Run("DeleteTasks_Success", func() {
+		workspaceID := mocks.GetWorkspace().ID
+		query := bson.M{"workspace_id": bson.M{"$in": []primitive.ObjectID{workspaceID}}}
+		suite.collection.On("DeleteMany", mock.Anything, query).Return(&mongo.DeleteResult{DeletedCount: 2}, nil).Once()
+
+		err := suite.repo.DeleteTasks(context.Background(), &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{workspaceID}})
+		suite.NoError(err)
+	})
+}
+
+// A test for the MongoTaskRepository.ReassignTasks method.
+func (suite *MongoTaskRepositoryTestSuite) TestReassignTasks() {
+	// A testcase where the tasks of a user are given to another user.
+	suite.Run("ReassignTasks_Success", func() {
+		from := mocks.GetPrimitiveID1()
+		to := mocks.GetPrimitiveID2()
+		update := bson.M{"$set": bson.M{"user_id": to}}
+		suite.collection.On("UpdateMany", mock.Anything, bson.M{"user_id": from}, update).Return(&mongo.UpdateResult{ModifiedCount: 2}, nil).Once()
+
+		err := suite.repo.ReassignTasks(context.Background(), &domain.TaskFilter{UserID: from}, to)
+		suite.NoError(err)
+	})
+}
+
+// A test for the MongoTaskRepository.CountTasks method.
+func (suite *MongoTaskRepositoryTestSuite) TestCountTasks() {
+	// A testcase where the overdue tasks are counted.
+	suite.Run("CountTasks_Overdue", func() {
+		now := time.Now()
+		statuses := []string{"Pending", "In Progress"}
+		query := bson.M{"status": bson.M{"$in": statuses}, "due_date": bson.M{"$lt": now}}
+		suite.collection.On("CountDocuments", mock.Anything, query).Return(int64(3), nil).Once()
+
+		count, err := suite.repo.CountTasks(context.Background(), &domain.TaskFilter{Statuses: statuses, DueBefore: now})
+		suite.NoError(err)
+		suite.Equal(int64(3), count)
+	})
+
+	// A testcase for the failure of counting the tasks.
+	suite.Run("CountTasks_Failure", func() {
+		suite.collection.On("CountDocuments", mock.Anything, bson.M{}).Return(int64(0), mongo.ErrClientDisconnected).Once()
+
+		_, err := suite.repo.CountTasks(context.Background(), &domain.TaskFilter{})
 		suite.Error(err)
 	})
 }
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"task_manager/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// This struct is a MongoDB implementation of the TimeEntryRepository interface.
+type MongoTimeEntryRepository struct {
+	collection domain.Collection
+}
+
+// A constructor that creates a new instance of MongoTimeEntryRepository.
+func NewMongoTimeEntryRepository(collection domain.Collection) *MongoTimeEntryRepository {
+	return &MongoTimeEntryRepository{
+		collection: collection,
+	}
+}
+
+// A method that adds a new time entry. Adding a second running timer for a user fails with a duplicate key error.
+func (r *MongoTimeEntryRepository) AddTimeEntry(ctx context.Context, entry *domain.TimeEntry) error {
+	// Insert the time entry into the database.
+	_, err := r.collection.InsertOne(ctx, entry)
+	return err
+}
+
+// A method that returns the time entry with the given ID.
+func (r *MongoTimeEntryRepository) GetTimeEntryByID(ctx context.Context, id primitive.ObjectID) (*domain.TimeEntry, error) {
+	entry := &domain.TimeEntry{}
+
+	// Query the database for a time entry with the given ID.
+	result := r.collection.FindOne(ctx, bson.M{"_id": id})
+	if err := result.Decode(entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// A method that returns the running timer of the user with the given ID.
+func (r *MongoTimeEntryRepository) GetRunningTimeEntry(ctx context.Context, userID primitive.ObjectID) (*domain.TimeEntry, error) {
+	entry := &domain.TimeEntry{}
+
+	// Query the database for the running time entry of the user.
+	result := r.collection.FindOne(ctx, bson.M{"user_id": userID, "running": true})
+	if err := result.Decode(entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// A method that returns the time entries that match the filter, in the order they were started.
+func (r *MongoTimeEntryRepository) GetTimeEntries(ctx context.Context, filter *domain.TimeEntryFilter) ([]domain.TimeEntry, error) {
+	entries := []domain.TimeEntry{}
+
+	// Query the database for the matching time entries.
+	cursor, err := r.collection.Find(ctx, timeEntryFilter(filter), options.Find().SetSort(bson.D{{Key: "started_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterate over the cursor and decode each entry into a TimeEntry struct.
+	err = cursor.All(ctx, &entries)
+	return entries, err
+}
+
+// A method that stops the running timer with the given ID. It returns mongo.ErrNoDocuments if the timer is not
+// running anymore, so that a timer stopped twice at the same time is only stopped once.
+func (r *MongoTimeEntryRepository) StopTimeEntry(ctx context.Context, id primitive.ObjectID, endedAt time.Time, durationSeconds int64) error {
+	update := bson.M{"$set": bson.M{"running": false, "ended_at": endedAt, "duration_seconds": durationSeconds}}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "running": true}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// A method that deletes the time entry with the given ID.
+func (r *MongoTimeEntryRepository) DeleteTimeEntry(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// A method that deletes the time entries that match the filter.
+func (r *MongoTimeEntryRepository) DeleteTimeEntries(ctx context.Context, filter *domain.TimeEntryFilter) error {
+	_, err := r.collection.DeleteMany(ctx, timeEntryFilter(filter))
+	return err
+}
+
+// A method that counts the time entries that match the filter.
+func (r *MongoTimeEntryRepository) CountTimeEntries(ctx context.Context, filter *domain.TimeEntryFilter) (int64, error) {
+	return r.collection.CountDocuments(ctx, timeEntryFilter(filter))
+}
+
+// A helper function that converts a time entry filter into a MongoDB query.
+func timeEntryFilter(filter *domain.TimeEntryFilter) bson.M {
+	query := bson.M{}
+
+	if filter.TaskIDs != nil {
+		query["task_id"] = bson.M{"$in": filter.TaskIDs}
+	}
+
+	if !filter.UserID.IsZero() {
+		query["user_id"] = filter.UserID
+	}
+
+	if filter.WorkspaceIDs != nil {
+		inWorkspaces := bson.M{"workspace_id": bson.M{"$in": filter.WorkspaceIDs}}
+		if filter.IncludeUnassigned {
+			query["$or"] = bson.A{inWorkspaces, bson.M{"workspace_id": bson.M{"$exists": false}}}
+		} else {
+			query["workspace_id"] = inWorkspaces["workspace_id"]
+		}
+	}
+
+	if filter.Tag != "" {
+		query["tags"] = filter.Tag
+	}
+
+	startedAt := bson.M{}
+	if !filter.StartedFrom.IsZero() {
+		startedAt["$gte"] = filter.StartedFrom
+	}
+	if !filter.StartedBefore.IsZero() {
+		startedAt["$lt"] = filter.StartedBefore
+	}
+	if len(startedAt) > 0 {
+		query["started_at"] = startedAt
+	}
+
+	if filter.ExcludeRunning {
+		query["running"] = false
+	}
+
+	return query
+}
@@ -0,0 +1,133 @@
+package repository_test
+
+import (
+	"context"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"task_manager/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A suite that contains tests for the MongoTimeEntryRepository.
+type MongoTimeEntryRepositoryTestSuite struct {
+	suite.Suite
+	repo       *repository.MongoTimeEntryRepository
+	collection *mocks.Collection
+}
+
+// A method that initializes the test suite.
+func (suite *MongoTimeEntryRepositoryTestSuite) SetupSuite() {
+	suite.collection = new(mocks.Collection)
+	suite.repo = repository.NewMongoTimeEntryRepository(suite.collection)
+}
+
+// A method that finalizes the test suite.
+func (suite *MongoTimeEntryRepositoryTestSuite) TearDownSuite() {
+	suite.collection.AssertExpectations(suite.T())
+}
+
+// A test for the MongoTimeEntryRepository.GetRunningTimeEntry method.
+func (suite *MongoTimeEntryRepositoryTestSuite) TestGetRunningTimeEntry() {
+	// A testcase for the successful retrieval of the running timer of a user.
+	suite.Run("GetRunningTimeEntry_Success", func() {
+		entry := mocks.GetRunningTimeEntry()
+
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			entryPtr := args.Get(0).(*domain.TimeEntry)
+			*entryPtr = *entry
+		})
+
+		suite.collection.On("FindOne", mock.Anything, bson.M{"user_id": entry.UserID, "running": true}).Return(res).Once()
+
+		result, err := suite.repo.GetRunningTimeEntry(context.Background(), entry.UserID)
+		suite.NoError(err)
+		suite.Equal(entry, result)
+	})
+
+	// A testcase for a user without a running timer.
+	suite.Run("GetRunningTimeEntry_NotFound", func() {
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+
+		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
+
+		result, err := suite.repo.GetRunningTimeEntry(context.Background(), mocks.GetPrimitiveID1())
+		suite.Equal(mongo.ErrNoDocuments, err)
+		suite.Nil(result)
+	})
+}
+
+// A test for the MongoTimeEntryRepository.GetTimeEntries method.
+func (suite *MongoTimeEntryRepositoryTestSuite) TestGetTimeEntries() {
+	// A testcase where the stopped entries of a tag in a date range are queried in the workspaces of the user.
+	suite.Run("GetTimeEntries_Filter", func() {
+		workspaceIDs := []primitive.ObjectID{mocks.GetWorkspace().ID}
+		from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+		before := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+		query := bson.M{
+			"workspace_id": bson.M{"$in": workspaceIDs},
+			"tags":         "billable",
+			"started_at":   bson.M{"$gte": from, "$lt": before},
+			"running":      false,
+		}
+
+		cursor := new(mocks.Cursor)
+		cursor.On("All", mock.Anything, mock.Anything).Return(nil)
+		suite.collection.On("Find", mock.Anything, query, mock.Anything).Return(cursor, nil).Once()
+
+		_, err := suite.repo.GetTimeEntries(context.Background(), &domain.TimeEntryFilter{
+			WorkspaceIDs:   workspaceIDs,
+			Tag:            "billable",
+			StartedFrom:    from,
+			StartedBefore:  before,
+			ExcludeRunning: true,
+		})
+		suite.NoError(err)
+	})
+}
+
+// A test for the MongoTimeEntryRepository.StopTimeEntry method.
+func (suite *MongoTimeEntryRepositoryTestSuite) TestStopTimeEntry() {
+	// A testcase where the running timer is stopped.
+	suite.Run("StopTimeEntry_Success", func() {
+		entry := mocks.GetRunningTimeEntry()
+		suite.collection.On("UpdateOne", mock.Anything, bson.M{"_id": entry.ID, "running": true}, mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+
+		err := suite.repo.StopTimeEntry(context.Background(), entry.ID, time.Now(), 60)
+		suite.NoError(err)
+	})
+
+	// A testcase where the timer was already stopped.
+	suite.Run("StopTimeEntry_NotRunning", func() {
+		entry := mocks.GetRunningTimeEntry()
+		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{}, nil).Once()
+
+		err := suite.repo.StopTimeEntry(context.Background(), entry.ID, time.Now(), 60)
+		suite.Equal(mongo.ErrNoDocuments, err)
+	})
+}
+
+// A test for the MongoTimeEntryRepository.DeleteTimeEntries method.
+func (suite *MongoTimeEntryRepositoryTestSuite) TestDeleteTimeEntries() {
+	// A testcase where the time entries of a user are deleted.
+	suite.Run("DeleteTimeEntries_Success", func() {
+		userID := mocks.GetPrimitiveID1()
+		suite.collection.On("DeleteMany", mock.Anything, bson.M{"user_id": userID}).Return(&mongo.DeleteResult{DeletedCount: 3}, nil).Once()
+
+		err := suite.repo.DeleteTimeEntries(context.Background(), &domain.TimeEntryFilter{UserID: userID})
+		suite.NoError(err)
+	})
+}
+
+// A function that runs the MongoTimeEntryRepositoryTestSuite.
+func TestMongoTimeEntryRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MongoTimeEntryRepositoryTestSuite))
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// This struct is a MongoDB implementation of the Transactor interface.
+// Transactions need a replica set or a sharded cluster. On a standalone server the operations run one after the
+// other without a transaction, and the workspace locks taken in them do not serialize anything, which is why the
+// server reports such a deployment when it starts.
+type MongoTransactor struct {
+	client    *mongo.Client
+	mu        sync.Mutex
+	checked   bool
+	supported bool
+}
+
+// A constructor that creates a new instance of MongoTransactor.
+func NewMongoTransactor(client *mongo.Client) *MongoTransactor {
+	return &MongoTransactor{
+		client: client,
+	}
+}
+
+// A method that runs fn in a transaction, which is committed if fn succeeds and aborted otherwise.
+// The transaction is retried on transient errors, so fn may run more than once. The functions given to
+// AfterTransaction during fn run once the transaction ends.
+func (t *MongoTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !t.SupportsTransactions(ctx) {
+		return fn(ctx)
+	}
+
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	ctx, hooks := WithTransactionHooks(ctx)
+	defer hooks.Run()
+
+	_, err = session.WithTransaction(ctx, func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessionCtx)
+	})
+	return err
+}
+
+// A method that checks whether the deployment is a replica set or a sharded cluster, which support transactions.
+// The answer is remembered once the server has replied.
+func (t *MongoTransactor) SupportsTransactions(ctx context.Context) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.checked {
+		return t.supported
+	}
+
+	hello := struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}{}
+
+	err := t.client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		return false
+	}
+
+	t.checked = true
+	t.supported = hello.SetName != "" || hello.Msg == "isdbgrid"
+	return t.supported
+}
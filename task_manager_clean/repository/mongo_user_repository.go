@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"task_manager/domain"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -21,33 +22,33 @@ func NewMongoUserRepository(collection domain.Collection) *MongoUserRepository {
 }
 
 // A method that adds a new user.
-func (r *MongoUserRepository) AddUser(user *domain.User) error {
+func (r *MongoUserRepository) AddUser(ctx context.Context, user *domain.User) error {
 	// Insert the user into the database.
-	_, err := r.collection.InsertOne(context.Background(), user)
+	_, err := r.collection.InsertOne(ctx, user)
 	return err
 }
 
 // A method that returns all users.
-func (r *MongoUserRepository) GetUsers() ([]domain.User, error) {
+func (r *MongoUserRepository) GetUsers(ctx context.Context) ([]domain.User, error) {
 	users := []domain.User{}
 
 	// Query the database for all users.
-	cursor, err := r.collection.Find(context.Background(), bson.M{})
+	cursor, err := r.collection.Find(ctx, bson.M{})
 	if err != nil {
 		return nil, err
 	}
 
 	// Iterate over the cursor and decode each user into a User struct.
-	err = cursor.All(context.Background(), &users)
+	err = cursor.All(ctx, &users)
 	return users, err
 }
 
 // A method that returns a user with the given id.
-func (r *MongoUserRepository) GetUserByID(id primitive.ObjectID) (*domain.User, error) {
+func (r *MongoUserRepository) GetUserByID(ctx context.Context, id primitive.ObjectID) (*domain.User, error) {
 	user := &domain.User{}
 
 	// Query the database for a user with the given ID.
-	result := r.collection.FindOne(context.Background(), bson.M{"_id": id})
+	result := r.collection.FindOne(ctx, bson.M{"_id": id})
 	if err := result.Decode(user); err != nil {
 		return nil, err
 	}
@@ -55,12 +56,25 @@ func (r *MongoUserRepository) GetUserByID(id primitive.ObjectID) (*domain.User,
 	return user, nil
 }
 
+// A method that returns the users with the given ids, in no particular order. Missing users are skipped.
+func (r *MongoUserRepository) GetUsersByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.User, error) {
+	users := []domain.User{}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(ctx, &users)
+	return users, err
+}
+
 // A method that returns a user with the given username.
-func (r *MongoUserRepository) GetUserByUsername(username string) (*domain.User, error) {
+func (r *MongoUserRepository) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
 	user := &domain.User{}
 
 	// Query the database for a user with the given username.
-	result := r.collection.FindOne(context.Background(), bson.M{"username": username})
+	result := r.collection.FindOne(ctx, bson.M{"username": username})
 	if err := result.Decode(user); err != nil {
 		return nil, err
 	}
@@ -69,15 +83,84 @@ func (r *MongoUserRepository) GetUserByUsername(username string) (*domain.User,
 }
 
 // A method that updates a user with the given ID.
-func (r *MongoUserRepository) UpdateUser(id primitive.ObjectID, userData bson.M) error {
+func (r *MongoUserRepository) UpdateUser(ctx context.Context, id primitive.ObjectID, userData bson.M) error {
 	// Update the user in the database.
-	_, err := r.collection.UpdateOne(context.Background(), bson.M{"_id": id}, bson.M{"$set": userData})
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": userData})
 	return err
 }
 
 // A method that deletes a user with the given ID.
-func (r *MongoUserRepository) DeleteUser(id primitive.ObjectID) error {
+func (r *MongoUserRepository) DeleteUser(ctx context.Context, id primitive.ObjectID) error {
 	// Delete the user from the database.
-	_, err := r.collection.DeleteOne(context.Background(), bson.M{"_id": id})
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
 	return err
 }
+
+// A method that counts the users with the given role.
+func (r *MongoUserRepository) CountUsersByRole(ctx context.Context, role string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"role": role})
+}
+
+// A method that records the time step of an accepted TOTP code of a user. It reports false if a code of the same or a
+// later time step was already accepted, so that every code can only be used once.
+func (r *MongoUserRepository) ClaimTOTPCounter(ctx context.Context, id primitive.ObjectID, counter int64) (bool, error) {
+	filter := bson.M{
+		"_id": id,
+		"$or": bson.A{
+			bson.M{"totp_last_counter": bson.M{"$lt": counter}},
+			bson.M{"totp_last_counter": bson.M{"$exists": false}},
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"totp_last_counter": counter}})
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// A method that removes a hashed recovery code from a user. It reports false if the user does not have the code, so
+// that a code used by concurrent requests is only accepted once.
+func (r *MongoUserRepository) ConsumeRecoveryCode(ctx context.Context, id primitive.ObjectID, codeHash string) (bool, error) {
+	filter := bson.M{"_id": id, "recovery_codes": codeHash}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$pull": bson.M{"recovery_codes": codeHash}})
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// A method that records a two-factor login attempt of a user. The attempts are counted in windows that start with the
+// first attempt after the previous window ended, and it reports false without recording the attempt if the limit of
+// the current window was reached.
+func (r *MongoUserRepository) AddTwoFactorAttempt(ctx context.Context, id primitive.ObjectID, now time.Time, window time.Duration, limit int) (bool, error) {
+	since := now.Add(-window)
+
+	// Count the attempt in the current window, unless it is full.
+	filter := bson.M{"_id": id, "two_factor_attempts_since": bson.M{"$gt": since}, "two_factor_attempts": bson.M{"$lt": limit}}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"two_factor_attempts": 1}})
+	if err != nil {
+		return false, err
+	}
+
+	if result.ModifiedCount == 1 {
+		return true, nil
+	}
+
+	// Start a new window if the current one has ended.
+	filter = bson.M{
+		"_id": id,
+		"$or": bson.A{
+			bson.M{"two_factor_attempts_since": bson.M{"$lte": since}},
+			bson.M{"two_factor_attempts_since": bson.M{"$exists": false}},
+		},
+	}
+	result, err = r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"two_factor_attempts": 1, "two_factor_attempts_since": now}})
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
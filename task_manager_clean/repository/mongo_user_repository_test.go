@@ -1,10 +1,12 @@
 package repository_test
 
 import (
+	"context"
 	"task_manager/domain"
 	"task_manager/mocks"
 	"task_manager/repository"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -40,7 +42,7 @@ func (suite *MongoUserRepositoryTestSuite) TestAddUser() {
 		user := mocks.GetNewUser()
 		suite.collection.On("InsertOne", mock.Anything, user).Return(&mongo.InsertOneResult{}, nil).Once()
 
-		err := suite.repo.AddUser(user)
+		err := suite.repo.AddUser(context.Background(), user)
 		suite.NoError(err)
 	})
 
@@ -49,7 +51,7 @@ func (suite *MongoUserRepositoryTestSuite) TestAddUser() {
 		user := mocks.GetNewUser()
 		suite.collection.On("InsertOne", mock.Anything, user).Return(&mongo.InsertOneResult{}, mongo.ErrClientDisconnected).Once()
 
-		err := suite.repo.AddUser(user)
+		err := suite.repo.AddUser(context.Background(), user)
 		suite.Error(err)
 	})
 }
@@ -67,7 +69,7 @@ func (suite *MongoUserRepositoryTestSuite) TestGetUsers() {
 			*usersPtr = append(*usersPtr, users...)
 		})
 
-		result, err := suite.repo.GetUsers()
+		result, err := suite.repo.GetUsers(context.Background())
 
 		suite.NoError(err)
 		suite.Equal(users, result)
@@ -77,12 +79,32 @@ func (suite *MongoUserRepositoryTestSuite) TestGetUsers() {
 	suite.Run("GetUsers_Failure", func() {
 		suite.collection.On("Find", mock.Anything, mock.Anything).Return(nil, mongo.ErrClientDisconnected).Once()
 
-		result, err := suite.repo.GetUsers()
+		result, err := suite.repo.GetUsers(context.Background())
 		suite.Error(err)
 		suite.Nil(result)
 	})
 }
 
+// A test for the MongoUserRepository.GetUsersByIDs method.
+func (suite *MongoUserRepositoryTestSuite) TestGetUsersByIDs() {
+	// A testcase where the users are read with a single query.
+	suite.Run("GetUsersByIDs_Success", func() {
+		users := mocks.GetManyUsers()
+		ids := []primitive.ObjectID{users[0].ID, users[1].ID}
+		cursor := new(mocks.Cursor)
+
+		suite.collection.On("Find", mock.Anything, bson.M{"_id": bson.M{"$in": ids}}).Return(cursor, nil).Once()
+		cursor.On("All", mock.Anything, &[]domain.User{}).Return(nil).Once().Run(func(args mock.Arguments) {
+			usersPtr := args.Get(1).(*[]domain.User)
+			*usersPtr = append(*usersPtr, users[:2]...)
+		})
+
+		result, err := suite.repo.GetUsersByIDs(context.Background(), ids)
+		suite.NoError(err)
+		suite.Equal(users[:2], result)
+	})
+}
+
 // A test for the MongoUserRepository.GetUserByID method.
 func (suite *MongoUserRepositoryTestSuite) TestGetUserByID() {
 	// A testcase for the successful retrieval of a user by ID.
@@ -98,7 +120,7 @@ func (suite *MongoUserRepositoryTestSuite) TestGetUserByID() {
 
 		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
 
-		result, err := suite.repo.GetUserByID(id)
+		result, err := suite.repo.GetUserByID(context.Background(), id)
 
 		suite.NoError(err)
 		suite.Equal(user, result)
@@ -112,7 +134,7 @@ func (suite *MongoUserRepositoryTestSuite) TestGetUserByID() {
 		res.On("Decode", mockUser).Return(mongo.ErrNoDocuments).Once()
 		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
 
-		result, err := suite.repo.GetUserByID(id)
+		result, err := suite.repo.GetUserByID(context.Background(), id)
 		suite.Error(err)
 		suite.Nil(result)
 	})
@@ -133,7 +155,7 @@ func (suite *MongoUserRepositoryTestSuite) TestGetUserByUsername() {
 
 		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
 
-		result, err := suite.repo.GetUserByUsername(username)
+		result, err := suite.repo.GetUserByUsername(context.Background(), username)
 
 		suite.NoError(err)
 		suite.Equal(user, result)
@@ -147,7 +169,7 @@ func (suite *MongoUserRepositoryTestSuite) TestGetUserByUsername() {
 		res.On("Decode", mockUser).Return(mongo.ErrNoDocuments).Once()
 		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
 
-		result, err := suite.repo.GetUserByUsername(username)
+		result, err := suite.repo.GetUserByUsername(context.Background(), username)
 		suite.Error(err)
 		suite.Nil(result)
 	})
@@ -163,7 +185,7 @@ func (suite *MongoUserRepositoryTestSuite) TestUpdateUser() {
 
 		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{}, nil).Once()
 
-		err := suite.repo.UpdateUser(id, userData)
+		err := suite.repo.UpdateUser(context.Background(), id, userData)
 		suite.NoError(err)
 	})
 
@@ -175,7 +197,7 @@ func (suite *MongoUserRepositoryTestSuite) TestUpdateUser() {
 
 		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{}, mongo.ErrClientDisconnected).Once()
 
-		err := suite.repo.UpdateUser(id, userData)
+		err := suite.repo.UpdateUser(context.Background(), id, userData)
 		suite.Error(err)
 	})
 }
@@ -189,7 +211,7 @@ func (suite *MongoUserRepositoryTestSuite) TestDeleteUser() {
 
 		suite.collection.On("DeleteOne", mock.Anything, mock.Anything).Return(&mongo.DeleteResult{}, nil).Once()
 
-		err := suite.repo.DeleteUser(id)
+		err := suite.repo.DeleteUser(context.Background(), id)
 		suite.NoError(err)
 	})
 
@@ -200,11 +222,108 @@ func (suite *MongoUserRepositoryTestSuite) TestDeleteUser() {
 
 		suite.collection.On("DeleteOne", mock.Anything, mock.Anything).Return(&mongo.DeleteResult{}, mongo.ErrClientDisconnected).Once()
 
-		err := suite.repo.DeleteUser(id)
+		err := suite.repo.DeleteUser(context.Background(), id)
 		suite.Error(err)
 	})
 }
 
+// A test for the MongoUserRepository.CountUsersByRole method.
+func (suite *MongoUserRepositoryTestSuite) TestCountUsersByRole() {
+	// A testcase where the root users are counted.
+	suite.Run("CountUsersByRole_Success", func() {
+		suite.collection.On("CountDocuments", mock.Anything, bson.M{"role": "root"}).Return(int64(1), nil).Once()
+
+		count, err := suite.repo.CountUsersByRole(context.Background(), "root")
+		suite.NoError(err)
+		suite.Equal(int64(1), count)
+	})
+}
+
+// A test for the MongoUserRepository.ClaimTOTPCounter method.
+func (suite *MongoUserRepositoryTestSuite) TestClaimTOTPCounter() {
+	// A testcase where the time step is later than the last accepted one.
+	suite.Run("ClaimTOTPCounter_Success", func() {
+		id := mocks.GetPrimitiveID1()
+		update := bson.M{"$set": bson.M{"totp_last_counter": int64(100)}}
+		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, update).Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil).Once()
+
+		claimed, err := suite.repo.ClaimTOTPCounter(context.Background(), id, 100)
+		suite.NoError(err)
+		suite.True(claimed)
+	})
+
+	// A testcase where the time step was already used.
+	suite.Run("ClaimTOTPCounter_Used", func() {
+		id := mocks.GetPrimitiveID1()
+		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{}, nil).Once()
+
+		claimed, err := suite.repo.ClaimTOTPCounter(context.Background(), id, 100)
+		suite.NoError(err)
+		suite.False(claimed)
+	})
+}
+
+// A test for the MongoUserRepository.ConsumeRecoveryCode method.
+func (suite *MongoUserRepositoryTestSuite) TestConsumeRecoveryCode() {
+	// A testcase where the user has the recovery code.
+	suite.Run("ConsumeRecoveryCode_Success", func() {
+		id := mocks.GetPrimitiveID1()
+		filter := bson.M{"_id": id, "recovery_codes": "hash"}
+		update := bson.M{"$pull": bson.M{"recovery_codes": "hash"}}
+		suite.collection.On("UpdateOne", mock.Anything, filter, update).Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil).Once()
+
+		consumed, err := suite.repo.ConsumeRecoveryCode(context.Background(), id, "hash")
+		suite.NoError(err)
+		suite.True(consumed)
+	})
+
+	// A testcase where the recovery code was already used.
+	suite.Run("ConsumeRecoveryCode_Used", func() {
+		id := mocks.GetPrimitiveID1()
+		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{}, nil).Once()
+
+		consumed, err := suite.repo.ConsumeRecoveryCode(context.Background(), id, "hash")
+		suite.NoError(err)
+		suite.False(consumed)
+	})
+}
+
+// A test for the MongoUserRepository.AddTwoFactorAttempt method.
+func (suite *MongoUserRepositoryTestSuite) TestAddTwoFactorAttempt() {
+	now := time.Now()
+	increment := bson.M{"$inc": bson.M{"two_factor_attempts": 1}}
+	reset := bson.M{"$set": bson.M{"two_factor_attempts": 1, "two_factor_attempts_since": now}}
+
+	// A testcase where the attempt is counted in the current window.
+	suite.Run("AddTwoFactorAttempt_Counted", func() {
+		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, increment).Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil).Once()
+
+		allowed, err := suite.repo.AddTwoFactorAttempt(context.Background(), mocks.GetPrimitiveID1(), now, time.Minute, 5)
+		suite.NoError(err)
+		suite.True(allowed)
+	})
+
+	// A testcase where the previous window has ended and a new one starts.
+	suite.Run("AddTwoFactorAttempt_NewWindow", func() {
+		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, increment).Return(&mongo.UpdateResult{}, nil).Once()
+		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, reset).Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil).Once()
+
+		allowed, err := suite.repo.AddTwoFactorAttempt(context.Background(), mocks.GetPrimitiveID1(), now, time.Minute, 5)
+		suite.NoError(err)
+		suite.True(allowed)
+	})
+
+	// A testcase where the current window is full.
+	suite.Run("AddTwoFactorAttempt_Limit", func() {
+		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, increment).Return(&mongo.UpdateResult{}, nil).Once()
+		suite.collection.On("UpdateOne", mock.Anything, mock.Anything, reset).Return(&mongo.UpdateResult{}, nil).Once()
+
+		allowed, err := suite.repo.AddTwoFactorAttempt(context.Background(), mocks.GetPrimitiveID1(), now, time.Minute, 5)
+		suite.NoError(err)
+		suite.False(allowed)
+	})
+}
+
 // A function that runs the MongoUserRepositoryTestSuite.
 func TestMongoUserRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(MongoUserRepositoryTestSuite))
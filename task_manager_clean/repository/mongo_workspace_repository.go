@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"task_manager/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// This struct is a MongoDB implementation of the WorkspaceRepository interface.
+type MongoWorkspaceRepository struct {
+	collection domain.Collection
+}
+
+// A constructor that creates a new instance of MongoWorkspaceRepository.
+func NewMongoWorkspaceRepository(collection domain.Collection) *MongoWorkspaceRepository {
+	return &MongoWorkspaceRepository{
+		collection: collection,
+	}
+}
+
+// A method that adds a new workspace.
+func (r *MongoWorkspaceRepository) AddWorkspace(ctx context.Context, workspace *domain.Workspace) error {
+	// Insert the workspace into the database.
+	_, err := r.collection.InsertOne(ctx, workspace)
+	return err
+}
+
+// A method that returns the workspaces the user with the given ID is a member of.
+func (r *MongoWorkspaceRepository) GetWorkspacesByUserID(ctx context.Context, userID primitive.ObjectID) ([]domain.Workspace, error) {
+	workspaces := []domain.Workspace{}
+
+	// Query the database for the workspaces of the user.
+	cursor, err := r.collection.Find(ctx, bson.M{"members.user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterate over the cursor and decode each workspace into a Workspace struct.
+	err = cursor.All(ctx, &workspaces)
+	return workspaces, err
+}
+
+// A method that returns the workspace with the given ID.
+func (r *MongoWorkspaceRepository) GetWorkspaceByID(ctx context.Context, id primitive.ObjectID) (*domain.Workspace, error) {
+	workspace := &domain.Workspace{}
+
+	// Query the database for a workspace with the given ID.
+	result := r.collection.FindOne(ctx, bson.M{"_id": id})
+	if err := result.Decode(workspace); err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
+
+// A method that updates the workspace with the given ID.
+func (r *MongoWorkspaceRepository) UpdateWorkspace(ctx context.Context, id primitive.ObjectID, workspaceData bson.M) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": workspaceData})
+	return err
+}
+
+// A method that replaces the members of the workspace with the given ID.
+func (r *MongoWorkspaceRepository) SetMembers(ctx context.Context, id primitive.ObjectID, members []domain.Membership) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"members": members}})
+	return err
+}
+
+// A method that removes the user with the given ID from the members of every workspace.
+func (r *MongoWorkspaceRepository) RemoveMemberships(ctx context.Context, userID primitive.ObjectID) error {
+	update := bson.M{"$pull": bson.M{"members": bson.M{"user_id": userID}}}
+	_, err := r.collection.UpdateMany(ctx, bson.M{"members.user_id": userID}, update)
+	return err
+}
+
+// A method that increments the version of the workspace with the given ID. Two transactions that lock the same
+// workspace write the same document, so one of them is aborted and retried after the other commits.
+func (r *MongoWorkspaceRepository) LockWorkspace(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"version": 1}})
+	return err
+}
+
+// A method that deletes the workspace with the given ID.
+func (r *MongoWorkspaceRepository) DeleteWorkspace(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
@@ -0,0 +1,127 @@
+package repository_test
+
+import (
+	"context"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"task_manager/repository"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A suite that contains tests for the MongoWorkspaceRepository.
+type MongoWorkspaceRepositoryTestSuite struct {
+	suite.Suite
+	repo       *repository.MongoWorkspaceRepository
+	collection *mocks.Collection
+}
+
+// A method that initializes the test suite.
+func (suite *MongoWorkspaceRepositoryTestSuite) SetupSuite() {
+	suite.collection = new(mocks.Collection)
+	suite.repo = repository.NewMongoWorkspaceRepository(suite.collection)
+}
+
+// A method that finalizes the test suite.
+func (suite *MongoWorkspaceRepositoryTestSuite) TearDownSuite() {
+	suite.collection.AssertExpectations(suite.T())
+}
+
+// A test for the MongoWorkspaceRepository.GetWorkspacesByUserID method.
+func (suite *MongoWorkspaceRepositoryTestSuite) TestGetWorkspacesByUserID() {
+	// A testcase for the successful retrieval of the workspaces of a member.
+	suite.Run("GetWorkspacesByUserID_Success", func() {
+		workspaces := []domain.Workspace{*mocks.GetWorkspace()}
+
+		cursor := new(mocks.Cursor)
+		cursor.On("All", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			workspacePtr := args.Get(1).(*[]domain.Workspace)
+			*workspacePtr = append(*workspacePtr, workspaces...)
+		})
+
+		suite.collection.On("Find", mock.Anything, bson.M{"members.user_id": mocks.GetPrimitiveID1()}).Return(cursor, nil).Once()
+
+		result, err := suite.repo.GetWorkspacesByUserID(context.Background(), mocks.GetPrimitiveID1())
+		suite.NoError(err)
+		suite.Equal(workspaces, result)
+	})
+}
+
+// A test for the MongoWorkspaceRepository.GetWorkspaceByID method.
+func (suite *MongoWorkspaceRepositoryTestSuite) TestGetWorkspaceByID() {
+	// A testcase for the successful retrieval of a workspace.
+	suite.Run("GetWorkspaceByID_Success", func() {
+		workspace := mocks.GetWorkspace()
+
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			workspacePtr := args.Get(0).(*domain.Workspace)
+			*workspacePtr = *workspace
+		})
+
+		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
+
+		result, err := suite.repo.GetWorkspaceByID(context.Background(), workspace.ID)
+		suite.NoError(err)
+		suite.Equal(workspace, result)
+	})
+
+	// A testcase for a workspace that does not exist.
+	suite.Run("GetWorkspaceByID_NotFound", func() {
+		res := new(mocks.SingleResult)
+		res.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+
+		suite.collection.On("FindOne", mock.Anything, mock.Anything).Return(res).Once()
+
+		result, err := suite.repo.GetWorkspaceByID(context.Background(), mocks.GetWorkspace().ID)
+		suite.Equal(mongo.ErrNoDocuments, err)
+		suite.Nil(result)
+	})
+}
+
+// A test for the MongoWorkspaceRepository.SetMembers method.
+func (suite *MongoWorkspaceRepositoryTestSuite) TestSetMembers() {
+	// A testcase for the successful update of the members.
+	suite.Run("SetMembers_Success", func() {
+		workspace := mocks.GetWorkspace()
+		update := bson.M{"$set": bson.M{"members": workspace.Members}}
+		suite.collection.On("UpdateOne", mock.Anything, bson.M{"_id": workspace.ID}, update).Return(&mongo.UpdateResult{ModifiedCount: 1}, nil).Once()
+
+		err := suite.repo.SetMembers(context.Background(), workspace.ID, workspace.Members)
+		suite.NoError(err)
+	})
+}
+
+// A test for the MongoWorkspaceRepository.RemoveMemberships method.
+func (suite *MongoWorkspaceRepositoryTestSuite) TestRemoveMemberships() {
+	// A testcase where the user is removed from the workspaces they are a member of.
+	suite.Run("RemoveMemberships_Success", func() {
+		userID := mocks.GetPrimitiveID1()
+		update := bson.M{"$pull": bson.M{"members": bson.M{"user_id": userID}}}
+		suite.collection.On("UpdateMany", mock.Anything, bson.M{"members.user_id": userID}, update).Return(&mongo.UpdateResult{ModifiedCount: 2}, nil).Once()
+
+		err := suite.repo.RemoveMemberships(context.Background(), userID)
+		suite.NoError(err)
+	})
+}
+
+// A test for the MongoWorkspaceRepository.LockWorkspace method.
+func (suite *MongoWorkspaceRepositoryTestSuite) TestLockWorkspace() {
+	// A testcase where the version of the workspace is incremented.
+	suite.Run("LockWorkspace_Success", func() {
+		id := mocks.GetPrimitiveID1()
+		suite.collection.On("UpdateOne", mock.Anything, bson.M{"_id": id}, bson.M{"$inc": bson.M{"version": 1}}).Return(&mongo.UpdateResult{ModifiedCount: 1}, nil).Once()
+
+		err := suite.repo.LockWorkspace(context.Background(), id)
+		suite.NoError(err)
+	})
+}
+
+// A function that runs the MongoWorkspaceRepositoryTestSuite.
+func TestMongoWorkspaceRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MongoWorkspaceRepositoryTestSuite))
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+// A key of the context values that hold the hooks of a transaction.
+type transactionHooksKey struct{}
+
+// TransactionHooks collects the functions to run once a transaction ends, when its writes are visible to the
+// readers outside of it or were aborted.
+type TransactionHooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+// A function that returns a context whose transaction collects the functions given to AfterTransaction, and the
+// hooks to run once the transaction ends.
+func WithTransactionHooks(ctx context.Context) (context.Context, *TransactionHooks) {
+	hooks := &TransactionHooks{}
+	return context.WithValue(ctx, transactionHooksKey{}, hooks), hooks
+}
+
+// A function that runs fn once the transaction of the context ends, or right away outside of a transaction.
+func AfterTransaction(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(transactionHooksKey{}).(*TransactionHooks)
+	if !ok {
+		fn()
+		return
+	}
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.fns = append(hooks.fns, fn)
+}
+
+// A function that reports whether the context belongs to a transaction.
+func InTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(transactionHooksKey{}).(*TransactionHooks)
+	return ok
+}
+
+// A method that runs the collected functions in the order they were given, and forgets them.
+func (h *TransactionHooks) Run() {
+	h.mu.Lock()
+	fns := h.fns
+	h.fns = nil
+	h.mu.Unlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A struct that defines the services for personal access tokens.
+type AccessTokenUsecase struct {
+	tokenRepo domain.AccessTokenRepository
+	userRepo  domain.UserRepository
+}
+
+// A constructor that creates a new instance of AccessTokenUsecase.
+func NewAccessTokenUsecase(tokenRepo domain.AccessTokenRepository, userRepo domain.UserRepository) *AccessTokenUsecase {
+	return &AccessTokenUsecase{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// A method that creates a personal access token for the logged in user.
+func (au *AccessTokenUsecase) CreateAccessToken(ctx context.Context, data *domain.CreateAccessTokenData, claims *domain.Claims) (*domain.CreatedAccessToken, *domain.Error) {
+	// Check that at least one known scope is requested.
+	if len(data.Scopes) == 0 {
+		return nil, &domain.Error{
+			Err:        errors.New("no scopes"),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "scopes must contain at least one of: " + strings.Join(domain.AccessTokenScopes, ", "),
+			Fields:     []domain.FieldError{{Field: "scopes", Reason: "must contain at least one of: " + strings.Join(domain.AccessTokenScopes, ", ")}},
+		}
+	}
+
+	for _, scope := range data.Scopes {
+		if !isAccessTokenScope(scope) {
+			return nil, &domain.Error{
+				Err:        errors.New("unknown scope " + scope),
+				StatusCode: http.StatusBadRequest,
+				Code:       domain.CodeValidationFailed,
+				Message:    "scopes must be any of: " + strings.Join(domain.AccessTokenScopes, ", "),
+				Fields:     []domain.FieldError{{Field: "scopes", Reason: "must be any of: " + strings.Join(domain.AccessTokenScopes, ", ")}},
+			}
+		}
+	}
+
+	// Check that the expiry is in the future.
+	now := time.Now()
+	if data.ExpiresAt != nil && !data.ExpiresAt.After(now) {
+		return nil, &domain.Error{
+			Err:        errors.New("expiry in the past"),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "expires_at must be in the future",
+			Fields:     []domain.FieldError{{Field: "expires_at", Reason: "must be in the future"}},
+		}
+	}
+
+	// Generate the token.
+	secret, err := infrastructure.GenerateRandomToken()
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+	token := domain.AccessTokenPrefix + secret
+
+	// Store only the hash of the token.
+	accessToken := domain.AccessToken{
+		ID:        primitive.NewObjectID(),
+		UserID:    claims.ID,
+		Name:      data.Name,
+		TokenHash: infrastructure.HashToken(token),
+		Scopes:    data.Scopes,
+		ExpiresAt: data.ExpiresAt,
+		CreatedAt: now,
+	}
+
+	err = au.tokenRepo.AddAccessToken(ctx, &accessToken)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return &domain.CreatedAccessToken{
+		AccessToken: accessToken,
+		Token:       token,
+	}, nil
+}
+
+// A method that returns the personal access tokens of the logged in user.
+func (au *AccessTokenUsecase) GetAccessTokens(ctx context.Context, claims *domain.Claims) ([]domain.AccessToken, *domain.Error) {
+	tokens, err := au.tokenRepo.GetAccessTokensByUserID(ctx, claims.ID)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return tokens, nil
+}
+
+// A method that revokes a personal access token of the logged in user.
+func (au *AccessTokenUsecase) RevokeAccessToken(ctx context.Context, id primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	token, err := au.tokenRepo.GetAccessTokenByID(ctx, id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return &domain.Error{
+				Err:        err,
+				StatusCode: http.StatusNotFound,
+				Code:       domain.CodeAccessTokenNotFound,
+				Message:    "Access token not found",
+			}
+		}
+
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	// Users can only revoke their own tokens. Other tokens are reported as missing.
+	if token.UserID != claims.ID {
+		return &domain.Error{
+			Err:        errors.New("trying to revoke another user's access token"),
+			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeAccessTokenNotFound,
+			Message:    "Access token not found",
+		}
+	}
+
+	err = au.tokenRepo.DeleteAccessToken(ctx, id)
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return nil
+}
+
+// A method that validates a personal access token and returns the claims of its user, limited to the token's scopes.
+func (au *AccessTokenUsecase) AuthenticateAccessToken(ctx context.Context, token string) (*domain.Claims, *domain.Error) {
+	invalidToken := &domain.Error{
+		Err:        errors.New("invalid access token"),
+		StatusCode: http.StatusUnauthorized,
+		Code:       domain.CodeInvalidToken,
+		Message:    "Invalid token",
+	}
+
+	accessToken, err := au.tokenRepo.GetAccessTokenByHash(ctx, infrastructure.HashToken(token))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, invalidToken
+		}
+
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	now := time.Now()
+	if accessToken.ExpiresAt != nil && now.After(*accessToken.ExpiresAt) {
+		return nil, invalidToken
+	}
+
+	// Load the user so that role changes and deletions apply to existing tokens.
+	user, err := au.userRepo.GetUserByID(ctx, accessToken.UserID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, invalidToken
+		}
+
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	// Recording the last use is best effort and must not fail the request.
+	_ = au.tokenRepo.UpdateLastUsed(ctx, accessToken.ID, now)
+
+	return &domain.Claims{
+		ID:       user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+		Scopes:   append([]string{}, accessToken.Scopes...),
+	}, nil
+}
+
+// A helper function that checks if a scope can be granted to access tokens.
+func isAccessTokenScope(scope string) bool {
+	for _, s := range domain.AccessTokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,162 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"task_manager/mocks"
+	"task_manager/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	mockAccessToken = mock.AnythingOfType("*domain.AccessToken")
+)
+
+// A suite that tests the access token usecase.
+type AccessTokenUsecaseSuite struct {
+	suite.Suite
+	tokenRepo *mocks.AccessTokenRepository
+	userRepo  *mocks.UserRepository
+	usecase   *usecase.AccessTokenUsecase
+}
+
+// A method that sets up the test suite.
+func (suite *AccessTokenUsecaseSuite) SetupTest() {
+	suite.tokenRepo = new(mocks.AccessTokenRepository)
+	suite.userRepo = new(mocks.UserRepository)
+	suite.usecase = usecase.NewAccessTokenUsecase(suite.tokenRepo, suite.userRepo)
+}
+
+// A method that tears down the test suite.
+func (suite *AccessTokenUsecaseSuite) TearDownTest() {
+	suite.tokenRepo.AssertExpectations(suite.T())
+	suite.userRepo.AssertExpectations(suite.T())
+}
+
+// A test for the AccessTokenUsecase.CreateAccessToken method.
+func (suite *AccessTokenUsecaseSuite) Test_CreateAccessToken() {
+	// A testcase where a token is created and stored hashed.
+	suite.Run("CreateAccessToken_Success", func() {
+		claims := mocks.GetClaims()
+		data := &domain.CreateAccessTokenData{Name: "ci", Scopes: []string{domain.ScopeTasksRead, domain.ScopeTasksWrite}}
+		var stored *domain.AccessToken
+
+		suite.tokenRepo.On("AddAccessToken", mock.Anything, mockAccessToken).Return(nil).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*domain.AccessToken)
+		}).Once()
+
+		result, err := suite.usecase.CreateAccessToken(context.Background(), data, claims)
+		suite.Nil(err)
+		suite.True(strings.HasPrefix(result.Token, domain.AccessTokenPrefix))
+		suite.Equal(infrastructure.HashToken(result.Token), stored.TokenHash)
+		suite.Equal(claims.ID, stored.UserID)
+		suite.Equal(data.Scopes, stored.Scopes)
+	})
+
+	// A testcase where an unknown scope is requested.
+	suite.Run("CreateAccessToken_UnknownScope", func() {
+		data := &domain.CreateAccessTokenData{Name: "ci", Scopes: []string{"everything"}}
+
+		result, err := suite.usecase.CreateAccessToken(context.Background(), data, mocks.GetClaims())
+		suite.Nil(result)
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+	})
+
+	// A testcase where the expiry is in the past.
+	suite.Run("CreateAccessToken_ExpiredAlready", func() {
+		expiresAt := time.Now().Add(-time.Hour)
+		data := &domain.CreateAccessTokenData{Name: "ci", Scopes: []string{domain.ScopeTasksRead}, ExpiresAt: &expiresAt}
+
+		result, err := suite.usecase.CreateAccessToken(context.Background(), data, mocks.GetClaims())
+		suite.Nil(result)
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+	})
+}
+
+// A test for the AccessTokenUsecase.RevokeAccessToken method.
+func (suite *AccessTokenUsecaseSuite) Test_RevokeAccessToken() {
+	// A testcase where the owner revokes a token.
+	suite.Run("RevokeAccessToken_Success", func() {
+		token := mocks.GetAccessToken("hash")
+		suite.tokenRepo.On("GetAccessTokenByID", mock.Anything, token.ID).Return(token, nil).Once()
+		suite.tokenRepo.On("DeleteAccessToken", mock.Anything, token.ID).Return(nil).Once()
+
+		err := suite.usecase.RevokeAccessToken(context.Background(), token.ID, mocks.GetClaims())
+		suite.Nil(err)
+	})
+
+	// A testcase where a user tries to revoke another user's token.
+	suite.Run("RevokeAccessToken_NotOwner", func() {
+		token := mocks.GetAccessToken("hash")
+		suite.tokenRepo.On("GetAccessTokenByID", mock.Anything, token.ID).Return(token, nil).Once()
+
+		err := suite.usecase.RevokeAccessToken(context.Background(), token.ID, mocks.GetClaims2())
+		suite.Equal(http.StatusNotFound, err.StatusCode)
+	})
+}
+
+// A test for the AccessTokenUsecase.AuthenticateAccessToken method.
+func (suite *AccessTokenUsecaseSuite) Test_AuthenticateAccessToken() {
+	// A testcase where a valid token yields the claims of its user.
+	suite.Run("AuthenticateAccessToken_Success", func() {
+		token := mocks.GetAccessToken(infrastructure.HashToken("tm_pat_secret"))
+		user := mocks.GetUser2(mocks.GetClaims())
+
+		suite.tokenRepo.On("GetAccessTokenByHash", mock.Anything, token.TokenHash).Return(token, nil).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, token.UserID).Return(user, nil).Once()
+		suite.tokenRepo.On("UpdateLastUsed", mock.Anything, token.ID, mockTime).Return(nil).Once()
+
+		claims, err := suite.usecase.AuthenticateAccessToken(context.Background(), "tm_pat_secret")
+		suite.Nil(err)
+		suite.Equal(user.ID, claims.ID)
+		suite.Equal(user.Role, claims.Role)
+		suite.True(claims.IsAccessToken())
+		suite.True(claims.HasScope(domain.ScopeTasksRead))
+		suite.False(claims.HasScope(domain.ScopeTasksWrite))
+	})
+
+	// A testcase where the token does not exist.
+	suite.Run("AuthenticateAccessToken_Unknown", func() {
+		suite.tokenRepo.On("GetAccessTokenByHash", mock.Anything, mockString).Return(nil, mongo.ErrNoDocuments).Once()
+
+		claims, err := suite.usecase.AuthenticateAccessToken(context.Background(), "tm_pat_unknown")
+		suite.Nil(claims)
+		suite.Equal(http.StatusUnauthorized, err.StatusCode)
+	})
+
+	// A testcase where the token has expired.
+	suite.Run("AuthenticateAccessToken_Expired", func() {
+		token := mocks.GetAccessToken("hash")
+		expiresAt := time.Now().Add(-time.Minute)
+		token.ExpiresAt = &expiresAt
+
+		suite.tokenRepo.On("GetAccessTokenByHash", mock.Anything, mockString).Return(token, nil).Once()
+
+		claims, err := suite.usecase.AuthenticateAccessToken(context.Background(), "tm_pat_secret")
+		suite.Nil(claims)
+		suite.Equal(http.StatusUnauthorized, err.StatusCode)
+	})
+
+	// A testcase where the repository fails.
+	suite.Run("AuthenticateAccessToken_Error", func() {
+		suite.tokenRepo.On("GetAccessTokenByHash", mock.Anything, mockString).Return(nil, errors.New("some error")).Once()
+
+		claims, err := suite.usecase.AuthenticateAccessToken(context.Background(), "tm_pat_secret")
+		suite.Nil(claims)
+		suite.Equal(http.StatusInternalServerError, err.StatusCode)
+	})
+}
+
+// A function that runs the AccessTokenUsecaseSuite.
+func TestAccessTokenUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(AccessTokenUsecaseSuite))
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A struct that defines the maintenance operations of the admin command. They run directly against the store, so
+// they are not authorized by a role, and they recover the accounts the API cannot, such as a root user who forgot
+// their password.
+type AdminUsecase struct {
+	userRepo  domain.UserRepository
+	roleRepo  domain.RoleRepository
+	resetRepo domain.PasswordResetRepository
+}
+
+// A constructor that creates a new instance of AdminUsecase.
+func NewAdminUsecase(userRepo domain.UserRepository, roleRepo domain.RoleRepository, resetRepo domain.PasswordResetRepository) *AdminUsecase {
+	return &AdminUsecase{
+		userRepo:  userRepo,
+		roleRepo:  roleRepo,
+		resetRepo: resetRepo,
+	}
+}
+
+// A method that creates a user with the root role. The username and the password follow the rules of the API.
+func (au *AdminUsecase) CreateRootUser(ctx context.Context, userData *domain.AuthUserData) (*domain.User, *domain.Error) {
+	// Check if the username is already taken first, so that an existing user is reported as such whatever the given
+	// password, as the server does on every start with the configured root user.
+	_, err := au.userRepo.GetUserByUsername(ctx, userData.Username)
+	if err == nil {
+		return nil, &domain.Error{
+			Err:        errors.New("conflict"),
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeUsernameTaken,
+			Message:    "Username already exists",
+		}
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, internalError(err)
+	}
+
+	_err := infrastructure.Validate(&domain.CreateUserData{Username: userData.Username, Password: userData.Password, Role: "root"})
+	if _err != nil {
+		return nil, _err
+	}
+
+	user := &domain.User{
+		ID:       primitive.NewObjectID(),
+		Username: userData.Username,
+		Role:     "root",
+	}
+
+	user.Password, err = infrastructure.HashPassword(userData.Password)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	err = au.userRepo.AddUser(ctx, user)
+	if err != nil {
+		return nil, writeUserError(err)
+	}
+
+	return user, nil
+}
+
+// A method that sets the password of a user and revokes their pending password reset tokens. With disableTwoFactor,
+// the two-factor authentication of the user is also disabled, for users who lost their authenticator.
+func (au *AdminUsecase) ResetPassword(ctx context.Context, username string, password string, disableTwoFactor bool) *domain.Error {
+	_err := infrastructure.Validate(&domain.UpdateUserData{Password: password})
+	if _err != nil {
+		return _err
+	}
+	if password == "" {
+		return &domain.Error{
+			Err:        errors.New("empty password"),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "password is required",
+			Fields:     []domain.FieldError{{Field: "password", Reason: "is required"}},
+		}
+	}
+
+	user, _err := au.getUser(ctx, username)
+	if _err != nil {
+		return _err
+	}
+
+	hashedPassword, err := infrastructure.HashPassword(password)
+	if err != nil {
+		return internalError(err)
+	}
+
+	userData := bson.M{"password": hashedPassword}
+	if disableTwoFactor {
+		userData["totp_enabled"] = false
+		userData["totp_secret"] = ""
+		userData["recovery_codes"] = []string{}
+	}
+
+	err = au.userRepo.UpdateUser(ctx, user.ID, userData)
+	if err != nil {
+		return internalError(err)
+	}
+
+	err = au.resetRepo.DeleteTokensByUserID(ctx, user.ID)
+	if err != nil {
+		return internalError(err)
+	}
+
+	return nil
+}
+
+// A method that changes the role of a user to a built-in or custom role.
+func (au *AdminUsecase) SetRole(ctx context.Context, username string, role string) (*domain.User, *domain.Error) {
+	user, _err := au.getUser(ctx, username)
+	if _err != nil {
+		return nil, _err
+	}
+
+	if _, ok := domain.BuiltinRoles[role]; !ok {
+		_, err := au.roleRepo.GetRoleByName(ctx, role)
+		if err != nil {
+			return nil, notFoundOrInternal(err, domain.CodeRoleNotFound, "Role not found")
+		}
+	}
+
+	err := au.userRepo.UpdateUser(ctx, user.ID, bson.M{"role": role})
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	user.Role = role
+	return user, nil
+}
+
+// A method that returns all the users.
+func (au *AdminUsecase) GetUsers(ctx context.Context) ([]domain.User, *domain.Error) {
+	users, err := au.userRepo.GetUsers(ctx)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	return users, nil
+}
+
+// A helper method that returns the user with the given username.
+func (au *AdminUsecase) getUser(ctx context.Context, username string) (*domain.User, *domain.Error) {
+	user, err := au.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, notFoundOrInternal(err, domain.CodeUserNotFound, "User not found")
+	}
+
+	return user, nil
+}
+
+// A helper function that converts an unexpected error of the store into a domain error.
+func internalError(err error) *domain.Error {
+	return &domain.Error{
+		Err:        err,
+		StatusCode: http.StatusInternalServerError,
+		Message:    "Internal server error",
+	}
+}
@@ -0,0 +1,137 @@
+package usecase_test
+
+import (
+	"context"
+	"net/http"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"task_manager/mocks"
+	"task_manager/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A suite that tests the admin usecase.
+type AdminUsecaseSuite struct {
+	suite.Suite
+	userRepo  *mocks.UserRepository
+	roleRepo  *mocks.RoleRepository
+	resetRepo *mocks.PasswordResetRepository
+	usecase   *usecase.AdminUsecase
+}
+
+// A method that sets up each testcase.
+func (suite *AdminUsecaseSuite) SetupSubTest() {
+	suite.userRepo = new(mocks.UserRepository)
+	suite.roleRepo = new(mocks.RoleRepository)
+	suite.resetRepo = new(mocks.PasswordResetRepository)
+	suite.usecase = usecase.NewAdminUsecase(suite.userRepo, suite.roleRepo, suite.resetRepo)
+}
+
+// A method that tears down each testcase.
+func (suite *AdminUsecaseSuite) TearDownSubTest() {
+	suite.userRepo.AssertExpectations(suite.T())
+	suite.roleRepo.AssertExpectations(suite.T())
+	suite.resetRepo.AssertExpectations(suite.T())
+}
+
+// A test for the AdminUsecase.CreateRootUser method.
+func (suite *AdminUsecaseSuite) Test_CreateRootUser() {
+	// A testcase where the root user is created with a hashed password.
+	suite.Run("CreateRootUser_Success", func() {
+		var added *domain.User
+		suite.userRepo.On("GetUserByUsername", mock.Anything, "admin").Return(nil, mongo.ErrNoDocuments).Once()
+		suite.userRepo.On("AddUser", mock.Anything, mockUser).Return(nil).Run(func(args mock.Arguments) {
+			added = args.Get(1).(*domain.User)
+		}).Once()
+
+		user, err := suite.usecase.CreateRootUser(context.Background(), &domain.AuthUserData{Username: "admin", Password: "password123"})
+		suite.Nil(err)
+		suite.Equal("root", user.Role)
+		suite.Equal(added, user)
+		suite.NoError(infrastructure.ComparePasswords(added.Password, "password123"))
+	})
+
+	// A testcase where the username is taken.
+	suite.Run("CreateRootUser_Taken", func() {
+		suite.userRepo.On("GetUserByUsername", mock.Anything, "admin").Return(mocks.GetNewUser(), nil).Once()
+
+		_, err := suite.usecase.CreateRootUser(context.Background(), &domain.AuthUserData{Username: "admin", Password: "password123"})
+		suite.Equal(domain.CodeUsernameTaken, err.Code)
+	})
+
+	// A testcase where the password is too short.
+	suite.Run("CreateRootUser_ShortPassword", func() {
+		suite.userRepo.On("GetUserByUsername", mock.Anything, "admin").Return(nil, mongo.ErrNoDocuments).Once()
+
+		_, err := suite.usecase.CreateRootUser(context.Background(), &domain.AuthUserData{Username: "admin", Password: "short"})
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+		suite.Equal("password", err.Fields[0].Field)
+	})
+}
+
+// A test for the AdminUsecase.ResetPassword method.
+func (suite *AdminUsecaseSuite) Test_ResetPassword() {
+	// A testcase where the password is reset and the two-factor authentication disabled.
+	suite.Run("ResetPassword_DisableTwoFactor", func() {
+		user := mocks.GetNewUser()
+		var userData bson.M
+		suite.userRepo.On("GetUserByUsername", mock.Anything, user.Username).Return(user, nil).Once()
+		suite.userRepo.On("UpdateUser", mock.Anything, user.ID, mock.AnythingOfType("primitive.M")).Return(nil).Run(func(args mock.Arguments) {
+			userData = args.Get(2).(bson.M)
+		}).Once()
+		suite.resetRepo.On("DeleteTokensByUserID", mock.Anything, user.ID).Return(nil).Once()
+
+		err := suite.usecase.ResetPassword(context.Background(), user.Username, "newpassword", true)
+		suite.Nil(err)
+		suite.NoError(infrastructure.ComparePasswords(userData["password"].(string), "newpassword"))
+		suite.Equal(false, userData["totp_enabled"])
+	})
+
+	// A testcase where the user does not exist.
+	suite.Run("ResetPassword_UnknownUser", func() {
+		suite.userRepo.On("GetUserByUsername", mock.Anything, "unknown").Return(nil, mongo.ErrNoDocuments).Once()
+
+		err := suite.usecase.ResetPassword(context.Background(), "unknown", "newpassword", false)
+		suite.Equal(domain.CodeUserNotFound, err.Code)
+	})
+
+	// A testcase where the password is empty.
+	suite.Run("ResetPassword_Empty", func() {
+		err := suite.usecase.ResetPassword(context.Background(), "user", "", false)
+		suite.Equal(domain.CodeValidationFailed, err.Code)
+	})
+}
+
+// A test for the AdminUsecase.SetRole method.
+func (suite *AdminUsecaseSuite) Test_SetRole() {
+	// A testcase where a user is promoted to a built-in role.
+	suite.Run("SetRole_Builtin", func() {
+		user := mocks.GetNewUser()
+		suite.userRepo.On("GetUserByUsername", mock.Anything, user.Username).Return(user, nil).Once()
+		suite.userRepo.On("UpdateUser", mock.Anything, user.ID, bson.M{"role": "root"}).Return(nil).Once()
+
+		updated, err := suite.usecase.SetRole(context.Background(), user.Username, "root")
+		suite.Nil(err)
+		suite.Equal("root", updated.Role)
+	})
+
+	// A testcase where the role does not exist.
+	suite.Run("SetRole_UnknownRole", func() {
+		user := mocks.GetNewUser()
+		suite.userRepo.On("GetUserByUsername", mock.Anything, user.Username).Return(user, nil).Once()
+		suite.roleRepo.On("GetRoleByName", mock.Anything, "unknown").Return(nil, mongo.ErrNoDocuments).Once()
+
+		_, err := suite.usecase.SetRole(context.Background(), user.Username, "unknown")
+		suite.Equal(domain.CodeRoleNotFound, err.Code)
+	})
+}
+
+// A method that runs the AdminUsecaseSuite.
+func TestAdminUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(AdminUsecaseSuite))
+}
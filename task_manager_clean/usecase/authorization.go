@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+)
+
+// A helper function that asks the authorizer for a decision and converts its failures into a domain error.
+// Denied requests are logged with the reason of the denial.
+func authorize(ctx context.Context, authorizer domain.Authorizer, claims *domain.Claims, request *domain.AccessRequest) (*domain.Decision, *domain.Error) {
+	decision, err := authorizer.Authorize(ctx, claims, request)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	if !decision.Allowed {
+		infrastructure.Logger(ctx).Info("access denied", "action", decision.Action, "denial", decision.Denial, "reason", decision.Reason)
+	}
+
+	return decision, nil
+}
+
+// A helper function that capitalizes a role name for use in messages.
+func titleRole(role string) string {
+	if role == "" {
+		return role
+	}
+
+	return strings.ToUpper(role[:1]) + role[1:]
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"sync/atomic"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"time"
+)
+
+// A struct that reports the liveness and readiness of the API.
+type HealthUsecase struct {
+	pinger   domain.Pinger
+	userRepo domain.UserRepository
+	build    domain.BuildInfo
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+// A constructor that creates a new instance of HealthUsecase.
+// The timeout bounds the duration of the dependency checks of a readiness check.
+func NewHealthUsecase(pinger domain.Pinger, userRepo domain.UserRepository, build domain.BuildInfo, timeout time.Duration) *HealthUsecase {
+	return &HealthUsecase{
+		pinger:   pinger,
+		userRepo: userRepo,
+		build:    build,
+		timeout:  timeout,
+	}
+}
+
+// A method that reports that the process is up. It does not check the dependencies.
+func (hu *HealthUsecase) Liveness(ctx context.Context) *domain.Health {
+	return &domain.Health{
+		Status: domain.HealthStatusOK,
+		Build:  hu.build,
+	}
+}
+
+// A method that reports if the API can serve traffic.
+// The API is ready if the storage backend answers within the timeout and the API is not shutting down.
+func (hu *HealthUsecase) Readiness(ctx context.Context) *domain.Health {
+	return hu.readiness(ctx, false)
+}
+
+// A method that reports if the API can serve traffic like Readiness, with the reason of the failed checks and
+// whether a root user exists. The report is meant for the operators, and is not served by the public endpoints.
+func (hu *HealthUsecase) Report(ctx context.Context) *domain.Health {
+	return hu.readiness(ctx, true)
+}
+
+// A helper method that checks if the API can serve traffic. The detailed report adds the reason of the failed checks
+// and whether a root user exists, which does not affect the readiness.
+func (hu *HealthUsecase) readiness(ctx context.Context, detailed bool) *domain.Health {
+	health := &domain.Health{
+		Status: domain.HealthStatusOK,
+		Build:  hu.build,
+		Checks: map[string]domain.HealthCheck{},
+	}
+
+	// Report the shutdown without checking the dependencies, so that load balancers stop sending traffic.
+	if hu.draining.Load() {
+		health.Status = domain.HealthStatusDraining
+		return health
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hu.timeout)
+	defer cancel()
+
+	// Check the storage backend.
+	start := time.Now()
+	err := hu.pinger.Ping(ctx)
+	health.Checks["database"] = healthCheck(start, err, detailed)
+	if err != nil {
+		infrastructure.Logger(ctx).Warn("readiness check failed", "check", "database", "error", err)
+		health.Status = domain.HealthStatusUnavailable
+		return health
+	}
+
+	if !detailed {
+		return health
+	}
+
+	// Report whether the root user exists.
+	count, err := hu.userRepo.CountUsersByRole(ctx, "root")
+	if err != nil {
+		infrastructure.Logger(ctx).Warn("readiness check failed", "check", "root_user", "error", err)
+		return health
+	}
+
+	exists := count > 0
+	health.RootUserExists = &exists
+	return health
+}
+
+// A method that marks the API as shutting down. Later readiness checks report it as not ready.
+func (hu *HealthUsecase) Drain() {
+	hu.draining.Store(true)
+}
+
+// A helper function that creates the result of a dependency check. The error is only kept in a detailed report, and
+// the caller logs it otherwise.
+func healthCheck(start time.Time, err error, detailed bool) domain.HealthCheck {
+	check := domain.HealthCheck{
+		Status:     domain.HealthStatusOK,
+		DurationMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		check.Status = domain.HealthStatusUnavailable
+		if detailed {
+			check.Error = err.Error()
+		}
+	}
+
+	return check
+}
@@ -0,0 +1,134 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"task_manager/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// A suite that tests the health usecase.
+type HealthUsecaseSuite struct {
+	suite.Suite
+	pinger   *mocks.Pinger
+	userRepo *mocks.UserRepository
+	build    domain.BuildInfo
+	usecase  *usecase.HealthUsecase
+}
+
+// A method that sets up the test suite.
+func (suite *HealthUsecaseSuite) SetupTest() {
+	suite.pinger = new(mocks.Pinger)
+	suite.userRepo = new(mocks.UserRepository)
+	suite.build = domain.BuildInfo{Version: "1.2.0", Commit: "abc123", GoVersion: "go1.22.5"}
+	suite.usecase = usecase.NewHealthUsecase(suite.pinger, suite.userRepo, suite.build, time.Second)
+}
+
+// A method that tears down the test suite.
+func (suite *HealthUsecaseSuite) TearDownTest() {
+	suite.pinger.AssertExpectations(suite.T())
+	suite.userRepo.AssertExpectations(suite.T())
+}
+
+// A test for the HealthUsecase.Liveness method.
+func (suite *HealthUsecaseSuite) Test_Liveness() {
+	// A testcase where the process is up, whatever the state of the dependencies.
+	suite.Run("Liveness_Success", func() {
+		health := suite.usecase.Liveness(context.Background())
+		suite.Equal(&domain.Health{Status: domain.HealthStatusOK, Build: suite.build}, health)
+	})
+}
+
+// A test for the HealthUsecase.Readiness method.
+func (suite *HealthUsecaseSuite) Test_Readiness() {
+	// A testcase where the database answers.
+	suite.Run("Readiness_Success", func() {
+		suite.pinger.On("Ping", mock.Anything).Return(nil).Once()
+
+		health := suite.usecase.Readiness(context.Background())
+		suite.True(health.Ready())
+		suite.Equal(domain.HealthStatusOK, health.Checks["database"].Status)
+		suite.Equal(suite.build, health.Build)
+		suite.Nil(health.RootUserExists)
+	})
+
+	// A testcase where the database does not answer. The error is logged rather than returned to the anonymous callers.
+	suite.Run("Readiness_DatabaseDown", func() {
+		suite.pinger.On("Ping", mock.Anything).Return(errors.New("server selection timeout")).Once()
+
+		health := suite.usecase.Readiness(context.Background())
+		suite.False(health.Ready())
+		suite.Equal(domain.HealthStatusUnavailable, health.Status)
+		suite.Equal(domain.HealthStatusUnavailable, health.Checks["database"].Status)
+		body, err := json.Marshal(health)
+		suite.Nil(err)
+		suite.NotContains(string(body), "server selection timeout")
+	})
+
+	// A testcase where the checks are bounded by the timeout.
+	suite.Run("Readiness_Timeout", func() {
+		suite.pinger.On("Ping", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			_, ok := args.Get(0).(context.Context).Deadline()
+			suite.True(ok)
+		}).Once()
+
+		suite.usecase.Readiness(context.Background())
+	})
+
+	// A testcase where the API is shutting down, which is reported without checking the dependencies.
+	suite.Run("Readiness_Draining", func() {
+		suite.usecase.Drain()
+
+		health := suite.usecase.Readiness(context.Background())
+		suite.False(health.Ready())
+		suite.Equal(domain.HealthStatusDraining, health.Status)
+	})
+}
+
+// A test for the HealthUsecase.Report method.
+func (suite *HealthUsecaseSuite) Test_Report() {
+	// A testcase where the database answers and the report tells whether a root user exists.
+	suite.Run("Report_RootUser", func() {
+		for _, count := range []int64{0, 1} {
+			suite.pinger.On("Ping", mock.Anything).Return(nil).Once()
+			suite.userRepo.On("CountUsersByRole", mock.Anything, "root").Return(count, nil).Once()
+
+			health := suite.usecase.Report(context.Background())
+			suite.True(health.Ready())
+			suite.Require().NotNil(health.RootUserExists)
+			suite.Equal(count > 0, *health.RootUserExists)
+		}
+	})
+
+	// A testcase where the users cannot be counted, which does not affect the readiness.
+	suite.Run("Report_RootUserError", func() {
+		suite.pinger.On("Ping", mock.Anything).Return(nil).Once()
+		suite.userRepo.On("CountUsersByRole", mock.Anything, "root").Return(int64(0), errors.New("some error")).Once()
+
+		health := suite.usecase.Report(context.Background())
+		suite.True(health.Ready())
+		suite.Nil(health.RootUserExists)
+	})
+
+	// A testcase where the database does not answer, whose reason is reported.
+	suite.Run("Report_DatabaseDown", func() {
+		suite.pinger.On("Ping", mock.Anything).Return(errors.New("server selection timeout")).Once()
+
+		health := suite.usecase.Report(context.Background())
+		suite.False(health.Ready())
+		suite.Equal("server selection timeout", health.Checks["database"].Error)
+		suite.Nil(health.RootUserExists)
+	})
+}
+
+// A function that runs the HealthUsecaseSuite.
+func TestHealthUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(HealthUsecaseSuite))
+}
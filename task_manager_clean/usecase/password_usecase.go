@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A struct that defines the services for password recovery.
+type PasswordUsecase struct {
+	userRepo  domain.UserRepository
+	resetRepo domain.PasswordResetRepository
+	sender    domain.PasswordResetSender
+	tokenTTL  time.Duration
+	requests  chan resetRequest
+}
+
+// A struct that defines a password reset request waiting in the queue of a PasswordUsecase.
+type resetRequest struct {
+	ctx      context.Context
+	username string
+}
+
+// A constructor that creates a new instance of PasswordUsecase with a queue of reset requests of the given size, and
+// starts issuing the tokens of the queued requests.
+func NewPasswordUsecase(userRepo domain.UserRepository, resetRepo domain.PasswordResetRepository, sender domain.PasswordResetSender, tokenTTL time.Duration, queueSize int) *PasswordUsecase {
+	pu := &PasswordUsecase{
+		userRepo:  userRepo,
+		resetRepo: resetRepo,
+		sender:    sender,
+		tokenTTL:  tokenTTL,
+		requests:  make(chan resetRequest, queueSize),
+	}
+	go pu.run()
+
+	return pu
+}
+
+// A method that queues the issuing of a single-use reset token for the given username.
+// The token is issued in the background: looking up the user and storing the token only happen for existing
+// usernames, so waiting for them would let callers tell the existing accounts by the time the requests take. For the
+// same reason, unknown usernames and failed deliveries are only logged. It fails if the queue is full.
+func (pu *PasswordUsecase) ForgotPassword(ctx context.Context, data *domain.ForgotPasswordData) *domain.Error {
+	select {
+	case pu.requests <- resetRequest{ctx: context.WithoutCancel(ctx), username: data.Username}:
+		return nil
+	default:
+		return internalError(errors.New("the queue of password reset requests is full"))
+	}
+}
+
+// A helper method that issues the tokens of the queued requests one at a time.
+func (pu *PasswordUsecase) run() {
+	for request := range pu.requests {
+		_err := pu.issueToken(request.ctx, request.username)
+		if _err != nil {
+			infrastructure.Logger(request.ctx).Error("issuing a password reset token", "error", _err.Error())
+		}
+	}
+}
+
+// A helper method that issues a single-use reset token for the given username and sends it to the user.
+// The token stays valid when it could not be delivered, and the failure is logged.
+func (pu *PasswordUsecase) issueToken(ctx context.Context, username string) *domain.Error {
+	// Get the user from the database.
+	user, err := pu.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	// Generate the token.
+	token, err := infrastructure.GenerateRandomToken()
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	// Invalidate any previously issued tokens of the user.
+	err = pu.resetRepo.DeleteTokensByUserID(ctx, user.ID)
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	// Store only the hash of the token.
+	now := time.Now()
+	resetToken := &domain.PasswordResetToken{
+		ID:        primitive.NewObjectID(),
+		UserID:    user.ID,
+		TokenHash: infrastructure.HashToken(token),
+		ExpiresAt: now.Add(pu.tokenTTL),
+		CreatedAt: now,
+	}
+
+	err = pu.resetRepo.AddToken(ctx, resetToken)
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	// Deliver the token to the user.
+	err = pu.sender.SendPasswordReset(user, token, resetToken.ExpiresAt)
+	if err != nil {
+		infrastructure.Logger(ctx).Error("sending a password reset token", "user_id", user.ID.Hex(), "error", err)
+	}
+
+	return nil
+}
+
+// A method that consumes a reset token and sets the new password of its user.
+func (pu *PasswordUsecase) ResetPassword(ctx context.Context, data *domain.ResetPasswordData) *domain.Error {
+	_err := infrastructure.Validate(data)
+	if _err != nil {
+		return _err
+	}
+
+	invalidToken := &domain.Error{
+		Err:        errors.New("invalid reset token"),
+		StatusCode: http.StatusBadRequest,
+		Code:       domain.CodeInvalidToken,
+		Message:    "Invalid or expired token",
+	}
+
+	// Get the token from the database.
+	resetToken, err := pu.resetRepo.GetTokenByHash(ctx, infrastructure.HashToken(data.Token))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return invalidToken
+		}
+
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	// Check that the token is neither used nor expired.
+	if resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+		return invalidToken
+	}
+
+	// Consume the token before changing the password.
+	err = pu.resetRepo.MarkTokenUsed(ctx, resetToken.ID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return invalidToken
+		}
+
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	// Hash the new password.
+	password, err := infrastructure.HashPassword(data.Password)
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	// Update the user's password.
+	err = pu.userRepo.UpdateUser(ctx, resetToken.UserID, bson.M{"password": password})
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return nil
+}
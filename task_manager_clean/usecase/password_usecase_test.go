@@ -0,0 +1,175 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"task_manager/mocks"
+	"task_manager/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	mockResetToken = mock.AnythingOfType("*domain.PasswordResetToken")
+	mockTime       = mock.AnythingOfType("time.Time")
+)
+
+// A suite that tests the password usecase.
+type PasswordUsecaseSuite struct {
+	suite.Suite
+	userRepo  *mocks.UserRepository
+	resetRepo *mocks.PasswordResetRepository
+	sender    *mocks.PasswordResetSender
+	usecase   *usecase.PasswordUsecase
+}
+
+// A method that sets up the test suite.
+func (suite *PasswordUsecaseSuite) SetupTest() {
+	suite.userRepo = new(mocks.UserRepository)
+	suite.resetRepo = new(mocks.PasswordResetRepository)
+	suite.sender = new(mocks.PasswordResetSender)
+	suite.usecase = usecase.NewPasswordUsecase(suite.userRepo, suite.resetRepo, suite.sender, time.Hour, 10)
+}
+
+// A method that tears down the test suite.
+func (suite *PasswordUsecaseSuite) TearDownTest() {
+	suite.userRepo.AssertExpectations(suite.T())
+	suite.resetRepo.AssertExpectations(suite.T())
+	suite.sender.AssertExpectations(suite.T())
+}
+
+// A helper method that waits for the queued reset request to be handled, which the last expected call signals.
+func (suite *PasswordUsecaseSuite) wait(done chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		suite.Fail("the password reset request was not handled")
+	}
+}
+
+// A test for the PasswordUsecase.ForgotPassword method.
+func (suite *PasswordUsecaseSuite) Test_ForgotPassword() {
+	// A testcase where a token is issued, stored hashed and sent to the user.
+	suite.Run("ForgotPassword_Success", func() {
+		user := mocks.GetNewUser()
+		var sentToken string
+		var storedToken *domain.PasswordResetToken
+
+		suite.userRepo.On("GetUserByUsername", mock.Anything, user.Username).Return(user, nil).Once()
+		suite.resetRepo.On("DeleteTokensByUserID", mock.Anything, user.ID).Return(nil).Once()
+		suite.resetRepo.On("AddToken", mock.Anything, mockResetToken).Return(nil).Run(func(args mock.Arguments) {
+			storedToken = args.Get(1).(*domain.PasswordResetToken)
+		}).Once()
+		done := make(chan struct{})
+		suite.sender.On("SendPasswordReset", user, mockString, mockTime).Return(nil).Run(func(args mock.Arguments) {
+			sentToken = args.String(1)
+			close(done)
+		}).Once()
+
+		err := suite.usecase.ForgotPassword(context.Background(), &domain.ForgotPasswordData{Username: user.Username})
+		suite.Nil(err)
+		suite.wait(done)
+		suite.NotEmpty(sentToken)
+		suite.Equal(user.ID, storedToken.UserID)
+		suite.Equal(infrastructure.HashToken(sentToken), storedToken.TokenHash)
+		suite.NotEqual(sentToken, storedToken.TokenHash)
+		suite.True(storedToken.ExpiresAt.After(time.Now()))
+	})
+
+	// A testcase where the username does not exist.
+	suite.Run("ForgotPassword_UnknownUser", func() {
+		done := make(chan struct{})
+		suite.userRepo.On("GetUserByUsername", mock.Anything, "unknown").Return(nil, mongo.ErrNoDocuments).Run(func(mock.Arguments) {
+			close(done)
+		}).Once()
+
+		err := suite.usecase.ForgotPassword(context.Background(), &domain.ForgotPasswordData{Username: "unknown"})
+		suite.Nil(err)
+		suite.wait(done)
+	})
+
+	// A testcase where the token cannot be delivered, which is only logged.
+	suite.Run("ForgotPassword_SendError", func() {
+		user := mocks.GetNewUser()
+
+		suite.userRepo.On("GetUserByUsername", mock.Anything, user.Username).Return(user, nil).Once()
+		suite.resetRepo.On("DeleteTokensByUserID", mock.Anything, user.ID).Return(nil).Once()
+		suite.resetRepo.On("AddToken", mock.Anything, mockResetToken).Return(nil).Once()
+		done := make(chan struct{})
+		suite.sender.On("SendPasswordReset", user, mockString, mockTime).Return(errors.New("some error")).Run(func(mock.Arguments) {
+			close(done)
+		}).Once()
+
+		err := suite.usecase.ForgotPassword(context.Background(), &domain.ForgotPasswordData{Username: user.Username})
+		suite.Nil(err)
+		suite.wait(done)
+	})
+}
+
+// A test for the PasswordUsecase.ResetPassword method.
+func (suite *PasswordUsecaseSuite) Test_ResetPassword() {
+	// A testcase where the token is consumed and the password is updated.
+	suite.Run("ResetPassword_Success", func() {
+		resetToken := mocks.GetPasswordResetToken("token")
+		var updateData bson.M
+
+		suite.resetRepo.On("GetTokenByHash", mock.Anything, infrastructure.HashToken("token")).Return(resetToken, nil).Once()
+		suite.resetRepo.On("MarkTokenUsed", mock.Anything, resetToken.ID).Return(nil).Once()
+		suite.userRepo.On("UpdateUser", mock.Anything, resetToken.UserID, mockBSON).Return(nil).Run(func(args mock.Arguments) {
+			updateData = args.Get(2).(bson.M)
+		}).Once()
+
+		err := suite.usecase.ResetPassword(context.Background(), &domain.ResetPasswordData{Token: "token", Password: "new_password"})
+		suite.Nil(err)
+		suite.Nil(infrastructure.ComparePasswords(updateData["password"].(string), "new_password"))
+	})
+
+	// A testcase where the token does not exist.
+	suite.Run("ResetPassword_UnknownToken", func() {
+		suite.resetRepo.On("GetTokenByHash", mock.Anything, mockString).Return(nil, mongo.ErrNoDocuments).Once()
+
+		err := suite.usecase.ResetPassword(context.Background(), &domain.ResetPasswordData{Token: "token", Password: "new_password"})
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+		suite.Equal("Invalid or expired token", err.Message)
+	})
+
+	// A testcase where the token has expired.
+	suite.Run("ResetPassword_Expired", func() {
+		resetToken := mocks.GetPasswordResetToken("token")
+		resetToken.ExpiresAt = time.Now().Add(-time.Minute)
+		suite.resetRepo.On("GetTokenByHash", mock.Anything, mockString).Return(resetToken, nil).Once()
+
+		err := suite.usecase.ResetPassword(context.Background(), &domain.ResetPasswordData{Token: "token", Password: "new_password"})
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+	})
+
+	// A testcase where the token was already used.
+	suite.Run("ResetPassword_AlreadyUsed", func() {
+		resetToken := mocks.GetPasswordResetToken("token")
+		suite.resetRepo.On("GetTokenByHash", mock.Anything, mockString).Return(resetToken, nil).Once()
+		suite.resetRepo.On("MarkTokenUsed", mock.Anything, resetToken.ID).Return(mongo.ErrNoDocuments).Once()
+
+		err := suite.usecase.ResetPassword(context.Background(), &domain.ResetPasswordData{Token: "token", Password: "new_password"})
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+	})
+	// A testcase where the new password is too short, which is refused before the token is consumed.
+	suite.Run("ResetPassword_InvalidPassword", func() {
+		err := suite.usecase.ResetPassword(context.Background(), &domain.ResetPasswordData{Token: "token", Password: "short"})
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+		suite.Equal(domain.CodeValidationFailed, err.Code)
+		suite.Equal("password", err.Fields[0].Field)
+	})
+}
+
+// A function that runs the PasswordUsecaseSuite.
+func TestPasswordUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(PasswordUsecaseSuite))
+}
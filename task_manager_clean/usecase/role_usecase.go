@@ -0,0 +1,440 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"task_manager/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A struct that defines the services for role management.
+type RoleUsecase struct {
+	roleRepo      domain.RoleRepository
+	taskRepo      domain.TaskRepository
+	userRepo      domain.UserRepository
+	workspaceRepo domain.WorkspaceRepository
+	authorizer    domain.Authorizer
+}
+
+// A constructor that creates a new instance of RoleUsecase.
+func NewRoleUsecase(roleRepo domain.RoleRepository, taskRepo domain.TaskRepository, userRepo domain.UserRepository, workspaceRepo domain.WorkspaceRepository, authorizer domain.Authorizer) *RoleUsecase {
+	return &RoleUsecase{
+		roleRepo:      roleRepo,
+		taskRepo:      taskRepo,
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		authorizer:    authorizer,
+	}
+}
+
+// A method that returns the built-in roles followed by the custom roles.
+func (ru *RoleUsecase) GetRoles(ctx context.Context, claims *domain.Claims) ([]domain.Role, *domain.Error) {
+	_err := ru.canManageRoles(ctx, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	customRoles, err := ru.roleRepo.GetRoles(ctx)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	roles := []domain.Role{}
+	for _, name := range []string{"user", "admin", "root"} {
+		roles = append(roles, *domain.BuiltinRoles[name])
+	}
+
+	return append(roles, customRoles...), nil
+}
+
+// A method that creates a custom role.
+func (ru *RoleUsecase) CreateRole(ctx context.Context, roleData *domain.RoleData, claims *domain.Claims) (*domain.Role, *domain.Error) {
+	_err := ru.canManageRoles(ctx, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	role := &domain.Role{
+		Name:        strings.TrimSpace(roleData.Name),
+		Description: roleData.Description,
+		Level:       roleData.Level,
+		Permissions: roleData.Permissions,
+	}
+
+	// Check that the role is valid and does not grant more than the user has.
+	_err = ru.validate(ctx, role, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	// Check if the role name is already taken.
+	_, ok := domain.BuiltinRoles[role.Name]
+	if !ok {
+		_, err := ru.roleRepo.GetRoleByName(ctx, role.Name)
+		ok = err == nil
+		if err != nil && err != mongo.ErrNoDocuments {
+			return nil, &domain.Error{
+				Err:        err,
+				StatusCode: http.StatusInternalServerError,
+				Message:    "Internal server error",
+			}
+		}
+	}
+
+	if ok {
+		return nil, &domain.Error{
+			Err:        errors.New("conflict"),
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeRoleExists,
+			Message:    "Role already exists",
+		}
+	}
+
+	err := ru.roleRepo.AddRole(ctx, role)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return role, nil
+}
+
+// A method that replaces the custom role with the given name.
+func (ru *RoleUsecase) ReplaceRole(ctx context.Context, name string, roleData *domain.RoleData, claims *domain.Claims) (*domain.Role, *domain.Error) {
+	_err := ru.canManageRoles(ctx, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	// Get the current role, which must also be one the user is allowed to grant.
+	current, _err := ru.getCustomRole(ctx, name)
+	if _err != nil {
+		return nil, _err
+	}
+
+	_err = ru.validate(ctx, current, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	role := &domain.Role{
+		Name:        name,
+		Description: roleData.Description,
+		Level:       roleData.Level,
+		Permissions: roleData.Permissions,
+	}
+
+	_err = ru.validate(ctx, role, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	err := ru.roleRepo.ReplaceRole(ctx, name, role)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return role, nil
+}
+
+// A method that deletes the custom role with the given name. Roles that are assigned to users cannot be deleted.
+func (ru *RoleUsecase) DeleteRole(ctx context.Context, name string, claims *domain.Claims) *domain.Error {
+	_err := ru.canManageRoles(ctx, claims)
+	if _err != nil {
+		return _err
+	}
+
+	role, _err := ru.getCustomRole(ctx, name)
+	if _err != nil {
+		return _err
+	}
+
+	_err = ru.validate(ctx, role, claims)
+	if _err != nil {
+		return _err
+	}
+
+	// Check that no user has the role.
+	count, err := ru.userRepo.CountUsersByRole(ctx, name)
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	if count > 0 {
+		return &domain.Error{
+			Err:        errors.New("role in use"),
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeRoleInUse,
+			Message:    "Role is assigned to users",
+		}
+	}
+
+	err = ru.roleRepo.DeleteRole(ctx, name)
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return nil
+}
+
+// A method that explains if the logged in user may perform an action, optionally on a task, a user or a workspace.
+func (ru *RoleUsecase) ExplainAccess(ctx context.Context, data *domain.ExplainData, claims *domain.Claims) (*domain.Decision, *domain.Error) {
+	if !isAction(data.Action) {
+		return nil, &domain.Error{
+			Err:        errors.New("unknown action " + data.Action),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "action must be any of: " + strings.Join(domain.Actions, ", "),
+			Fields:     []domain.FieldError{{Field: "action", Reason: "must be any of: " + strings.Join(domain.Actions, ", ")}},
+		}
+	}
+
+	request := &domain.AccessRequest{
+		Action:     data.Action,
+		TargetRole: data.Role,
+	}
+
+	// Use the owner of the task as the owner of the resource, and its workspace as the workspace.
+	workspaceID := data.WorkspaceID
+	if data.TaskID != "" {
+		taskID, err := primitive.ObjectIDFromHex(data.TaskID)
+		if err != nil {
+			return nil, &domain.Error{
+				Err:        err,
+				StatusCode: http.StatusBadRequest,
+				Code:       domain.CodeInvalidID,
+				Message:    "Invalid task ID",
+			}
+		}
+
+		task, err := ru.taskRepo.GetTaskByID(ctx, taskID)
+		if err != nil {
+			return nil, notFoundOrInternal(err, domain.CodeTaskNotFound, "Task not found")
+		}
+
+		request.OwnerID = task.UserID
+		if !task.WorkspaceID.IsZero() {
+			workspaceID = task.WorkspaceID.Hex()
+		}
+	}
+
+	if workspaceID != "" {
+		id, err := primitive.ObjectIDFromHex(workspaceID)
+		if err != nil {
+			return nil, &domain.Error{
+				Err:        err,
+				StatusCode: http.StatusBadRequest,
+				Code:       domain.CodeInvalidID,
+				Message:    "Invalid workspace ID",
+			}
+		}
+
+		request.Workspace, err = ru.workspaceRepo.GetWorkspaceByID(ctx, id)
+		if err != nil {
+			return nil, notFoundOrInternal(err, domain.CodeWorkspaceNotFound, "Workspace not found")
+		}
+	}
+
+	// Use the user as the owner of the resource, and their role as the target role.
+	if data.UserID != "" {
+		userID, err := primitive.ObjectIDFromHex(data.UserID)
+		if err != nil {
+			return nil, &domain.Error{
+				Err:        err,
+				StatusCode: http.StatusBadRequest,
+				Code:       domain.CodeInvalidID,
+				Message:    "Invalid user ID",
+			}
+		}
+
+		user, err := ru.userRepo.GetUserByID(ctx, userID)
+		if err != nil {
+			return nil, notFoundOrInternal(err, domain.CodeUserNotFound, "User not found")
+		}
+
+		request.OwnerID = user.ID
+		if request.TargetRole == "" {
+			request.TargetRole = user.Role
+		}
+	}
+
+	return authorize(ctx, ru.authorizer, claims, request)
+}
+
+// A helper method that checks if the logged in user can manage roles.
+func (ru *RoleUsecase) canManageRoles(ctx context.Context, claims *domain.Claims) *domain.Error {
+	decision, _err := authorize(ctx, ru.authorizer, claims, &domain.AccessRequest{Action: domain.ActionRoleManage})
+	if _err != nil {
+		return _err
+	}
+
+	if !decision.Allowed {
+		return &domain.Error{
+			Err:        errors.New(decision.Reason),
+			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenPermission,
+			Message:    "You do not have permission to manage roles",
+		}
+	}
+
+	return nil
+}
+
+// A helper method that returns the custom role with the given name. Built-in roles cannot be changed.
+func (ru *RoleUsecase) getCustomRole(ctx context.Context, name string) (*domain.Role, *domain.Error) {
+	if _, ok := domain.BuiltinRoles[name]; ok {
+		return nil, &domain.Error{
+			Err:        errors.New("built-in role " + name),
+			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeRoleBuiltin,
+			Message:    "Built-in roles cannot be changed",
+		}
+	}
+
+	role, err := ru.roleRepo.GetRoleByName(ctx, name)
+	if err != nil {
+		return nil, notFoundOrInternal(err, domain.CodeRoleNotFound, "Role not found")
+	}
+
+	return role, nil
+}
+
+// A helper method that checks if a role is valid and that the logged in user may grant it.
+// Unless the user has every permission, the role must rank below the user's role and only grant permissions the
+// user has, so that roles cannot be used to escalate privileges.
+func (ru *RoleUsecase) validate(ctx context.Context, role *domain.Role, claims *domain.Claims) *domain.Error {
+	if role.Name == "" {
+		return &domain.Error{
+			Err:        errors.New("empty role name"),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "name is required",
+			Fields:     []domain.FieldError{{Field: "name", Reason: "is required"}},
+		}
+	}
+
+	if role.Level <= 0 {
+		return &domain.Error{
+			Err:        errors.New("invalid level"),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "level must be positive",
+			Fields:     []domain.FieldError{{Field: "level", Reason: "must be positive"}},
+		}
+	}
+
+	for _, permission := range role.Permissions {
+		if !isPermission(permission) {
+			return &domain.Error{
+				Err:        errors.New("unknown permission " + permission),
+				StatusCode: http.StatusBadRequest,
+				Code:       domain.CodeValidationFailed,
+				Message:    "permissions must be any of: " + strings.Join(domain.Permissions, ", "),
+				Fields:     []domain.FieldError{{Field: "permissions", Reason: "must be any of: " + strings.Join(domain.Permissions, ", ")}},
+			}
+		}
+	}
+
+	// Get the role of the logged in user.
+	callerRole, ok := domain.BuiltinRoles[claims.Role]
+	if !ok {
+		var err error
+		callerRole, err = ru.roleRepo.GetRoleByName(ctx, claims.Role)
+		if err != nil {
+			return &domain.Error{
+				Err:        err,
+				StatusCode: http.StatusInternalServerError,
+				Message:    "Internal server error",
+			}
+		}
+	}
+
+	if callerRole.HasPermission(domain.PermissionAll) {
+		return nil
+	}
+
+	if role.Level >= callerRole.Level {
+		return &domain.Error{
+			Err:        errors.New("role level too high"),
+			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenRoleRank,
+			Message:    "Cannot manage a role that does not rank below your own",
+		}
+	}
+
+	for _, permission := range role.Permissions {
+		if !callerRole.HasPermission(permission) {
+			return &domain.Error{
+				Err:        errors.New("escalation of " + permission),
+				StatusCode: http.StatusForbidden,
+				Code:       domain.CodeForbiddenPermission,
+				Message:    "Cannot grant the " + permission + " permission",
+			}
+		}
+	}
+
+	return nil
+}
+
+// A helper function that returns a 404 error for missing documents, and a 500 error otherwise.
+func notFoundOrInternal(err error, code, message string) *domain.Error {
+	if err == mongo.ErrNoDocuments {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusNotFound,
+			Code:       code,
+			Message:    message,
+		}
+	}
+
+	return &domain.Error{
+		Err:        err,
+		StatusCode: http.StatusInternalServerError,
+		Message:    "Internal server error",
+	}
+}
+
+// A helper function that checks if a permission can be granted to custom roles.
+func isPermission(permission string) bool {
+	for _, p := range domain.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// A helper function that checks if an action can be authorized.
+func isAction(action string) bool {
+	for _, a := range domain.Actions {
+		if a == action {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,252 @@
+package usecase_test
+
+import (
+	"context"
+	"net/http"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"task_manager/mocks"
+	"task_manager/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	mockRole = mock.AnythingOfType("*domain.Role")
+)
+
+// A suite that tests the role usecase.
+type RoleUsecaseSuite struct {
+	suite.Suite
+	roleRepo      *mocks.RoleRepository
+	taskRepo      *mocks.TaskRepository
+	userRepo      *mocks.UserRepository
+	workspaceRepo *mocks.WorkspaceRepository
+	usecase       *usecase.RoleUsecase
+}
+
+// A method that sets up the test suite.
+func (suite *RoleUsecaseSuite) SetupTest() {
+	suite.roleRepo = new(mocks.RoleRepository)
+	suite.taskRepo = new(mocks.TaskRepository)
+	suite.userRepo = new(mocks.UserRepository)
+	suite.workspaceRepo = new(mocks.WorkspaceRepository)
+	authorizer := infrastructure.NewRoleAuthorizer(suite.roleRepo)
+	suite.usecase = usecase.NewRoleUsecase(suite.roleRepo, suite.taskRepo, suite.userRepo, suite.workspaceRepo, authorizer)
+}
+
+// A method that tears down the test suite.
+func (suite *RoleUsecaseSuite) TearDownTest() {
+	suite.roleRepo.AssertExpectations(suite.T())
+	suite.taskRepo.AssertExpectations(suite.T())
+	suite.userRepo.AssertExpectations(suite.T())
+	suite.workspaceRepo.AssertExpectations(suite.T())
+}
+
+// A helper method that returns the claims of a user with a custom role that can manage roles.
+func (suite *RoleUsecaseSuite) managerClaims() *domain.Claims {
+	claims := mocks.GetClaims()
+	claims.Role = "manager"
+	suite.roleRepo.On("GetRoleByName", mock.Anything, "manager").Return(&domain.Role{
+		Name:        "manager",
+		Level:       40,
+		Permissions: []string{domain.PermissionRoleManage, domain.PermissionTaskReadAny},
+	}, nil)
+
+	return claims
+}
+
+// A test for the RoleUsecase.GetRoles method.
+func (suite *RoleUsecaseSuite) Test_GetRoles() {
+	// A testcase where the built-in roles are listed before the custom roles.
+	suite.Run("GetRoles_Success", func() {
+		suite.roleRepo.On("GetRoles", mock.Anything, mock.Anything).Return([]domain.Role{*mocks.GetRole()}, nil).Once()
+
+		roles, err := suite.usecase.GetRoles(context.Background(), mocks.GetClaims3())
+		suite.Nil(err)
+		suite.Equal(4, len(roles))
+		suite.Equal("user", roles[0].Name)
+		suite.True(roles[0].BuiltIn)
+		suite.Equal(*mocks.GetRole(), roles[3])
+	})
+
+	// A testcase where an admin tries to list the roles.
+	suite.Run("GetRoles_Forbidden", func() {
+		roles, err := suite.usecase.GetRoles(context.Background(), mocks.GetClaims2())
+		suite.Nil(roles)
+		suite.Equal(http.StatusForbidden, err.StatusCode)
+	})
+}
+
+// A test for the RoleUsecase.CreateRole method.
+func (suite *RoleUsecaseSuite) Test_CreateRole() {
+	// A testcase where the root user creates a role.
+	suite.Run("CreateRole_Success", func() {
+		role := mocks.GetRole()
+		roleData := &domain.RoleData{Name: role.Name, Description: role.Description, Level: role.Level, Permissions: role.Permissions}
+
+		suite.roleRepo.On("GetRoleByName", mock.Anything, role.Name).Return(nil, mongo.ErrNoDocuments).Once()
+		suite.roleRepo.On("AddRole", mock.Anything, mockRole).Return(nil).Once()
+
+		result, err := suite.usecase.CreateRole(context.Background(), roleData, mocks.GetClaims3())
+		suite.Nil(err)
+		suite.Equal(role, result)
+	})
+
+	// A testcase where the name of a built-in role is used.
+	suite.Run("CreateRole_Conflict", func() {
+		roleData := &domain.RoleData{Name: "admin", Level: 20, Permissions: []string{domain.PermissionTaskReadAny}}
+
+		result, err := suite.usecase.CreateRole(context.Background(), roleData, mocks.GetClaims3())
+		suite.Nil(result)
+		suite.Equal(http.StatusConflict, err.StatusCode)
+	})
+
+	// A testcase where an unknown permission is granted.
+	suite.Run("CreateRole_UnknownPermission", func() {
+		roleData := &domain.RoleData{Name: "editor", Level: 20, Permissions: []string{"task.everything"}}
+
+		result, err := suite.usecase.CreateRole(context.Background(), roleData, mocks.GetClaims3())
+		suite.Nil(result)
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+	})
+
+	// A testcase where a role manager grants a permission they do not have.
+	suite.Run("CreateRole_EscalatePermission", func() {
+		claims := suite.managerClaims()
+		roleData := &domain.RoleData{Name: "editor", Level: 20, Permissions: []string{domain.PermissionTaskUpdateAny}}
+
+		result, err := suite.usecase.CreateRole(context.Background(), roleData, claims)
+		suite.Nil(result)
+		suite.Equal(http.StatusForbidden, err.StatusCode)
+		suite.Equal("Cannot grant the task.update.any permission", err.Message)
+	})
+
+	// A testcase where a role manager creates a role that does not rank below their own.
+	suite.Run("CreateRole_EscalateLevel", func() {
+		claims := suite.managerClaims()
+		roleData := &domain.RoleData{Name: "editor", Level: 40, Permissions: []string{domain.PermissionTaskReadAny}}
+
+		result, err := suite.usecase.CreateRole(context.Background(), roleData, claims)
+		suite.Nil(result)
+		suite.Equal(http.StatusForbidden, err.StatusCode)
+	})
+}
+
+// A test for the RoleUsecase.ReplaceRole method.
+func (suite *RoleUsecaseSuite) Test_ReplaceRole() {
+	// A testcase where a custom role is replaced.
+	suite.Run("ReplaceRole_Success", func() {
+		roleData := &domain.RoleData{Description: "Reads every task", Level: 20, Permissions: []string{domain.PermissionTaskReadAny}}
+
+		suite.roleRepo.On("GetRoleByName", mock.Anything, "editor").Return(mocks.GetRole(), nil).Once()
+		suite.roleRepo.On("ReplaceRole", mock.Anything, "editor", mockRole).Return(nil).Once()
+
+		result, err := suite.usecase.ReplaceRole(context.Background(), "editor", roleData, mocks.GetClaims3())
+		suite.Nil(err)
+		suite.Equal("editor", result.Name)
+		suite.Equal(roleData.Permissions, result.Permissions)
+	})
+
+	// A testcase where a built-in role is replaced.
+	suite.Run("ReplaceRole_BuiltIn", func() {
+		roleData := &domain.RoleData{Level: 20, Permissions: []string{domain.PermissionTaskReadAny}}
+
+		result, err := suite.usecase.ReplaceRole(context.Background(), "admin", roleData, mocks.GetClaims3())
+		suite.Nil(result)
+		suite.Equal(http.StatusForbidden, err.StatusCode)
+	})
+
+	// A testcase where the role does not exist.
+	suite.Run("ReplaceRole_NotFound", func() {
+		roleData := &domain.RoleData{Level: 20, Permissions: []string{domain.PermissionTaskReadAny}}
+
+		suite.roleRepo.On("GetRoleByName", mock.Anything, "missing").Return(nil, mongo.ErrNoDocuments).Once()
+
+		result, err := suite.usecase.ReplaceRole(context.Background(), "missing", roleData, mocks.GetClaims3())
+		suite.Nil(result)
+		suite.Equal(http.StatusNotFound, err.StatusCode)
+	})
+}
+
+// A test for the RoleUsecase.DeleteRole method.
+func (suite *RoleUsecaseSuite) Test_DeleteRole() {
+	// A testcase where an unused role is deleted.
+	suite.Run("DeleteRole_Success", func() {
+		suite.roleRepo.On("GetRoleByName", mock.Anything, "editor").Return(mocks.GetRole(), nil).Once()
+		suite.userRepo.On("CountUsersByRole", mock.Anything, "editor").Return(int64(0), nil).Once()
+		suite.roleRepo.On("DeleteRole", mock.Anything, "editor").Return(nil).Once()
+
+		err := suite.usecase.DeleteRole(context.Background(), "editor", mocks.GetClaims3())
+		suite.Nil(err)
+	})
+
+	// A testcase where the role is still assigned to a user.
+	suite.Run("DeleteRole_InUse", func() {
+		suite.roleRepo.On("GetRoleByName", mock.Anything, "editor").Return(mocks.GetRole(), nil).Once()
+		suite.userRepo.On("CountUsersByRole", mock.Anything, "editor").Return(int64(2), nil).Once()
+
+		err := suite.usecase.DeleteRole(context.Background(), "editor", mocks.GetClaims3())
+		suite.Equal(http.StatusConflict, err.StatusCode)
+		suite.Equal(domain.CodeRoleInUse, err.Code)
+	})
+}
+
+// A test for the RoleUsecase.ExplainAccess method.
+func (suite *RoleUsecaseSuite) Test_ExplainAccess() {
+	// A testcase where a user may only update their own tasks.
+	suite.Run("ExplainAccess_NotOwner", func() {
+		task := mocks.GetNewTask2()
+		task.UserID = mocks.GetPrimitiveID2()
+		data := &domain.ExplainData{Action: domain.ActionTaskUpdate, TaskID: task.ID.Hex()}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(task, nil).Once()
+
+		decision, err := suite.usecase.ExplainAccess(context.Background(), data, mocks.GetClaims())
+		suite.Nil(err)
+		suite.False(decision.Allowed)
+		suite.Equal(domain.DenialNotOwner, decision.Denial)
+	})
+
+	// A testcase where an admin tries to update a root user.
+	suite.Run("ExplainAccess_Rank", func() {
+		user := mocks.GetNewUser()
+		user.Role = "root"
+		data := &domain.ExplainData{Action: domain.ActionUserUpdate, UserID: user.ID.Hex()}
+
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+		decision, err := suite.usecase.ExplainAccess(context.Background(), data, mocks.GetClaims2())
+		suite.Nil(err)
+		suite.False(decision.Allowed)
+		suite.Equal(domain.DenialRank, decision.Denial)
+		suite.Equal("root", decision.TargetRole)
+	})
+
+	// A testcase where the action is allowed.
+	suite.Run("ExplainAccess_Allowed", func() {
+		data := &domain.ExplainData{Action: domain.ActionTaskCreate}
+
+		decision, err := suite.usecase.ExplainAccess(context.Background(), data, mocks.GetClaims())
+		suite.Nil(err)
+		suite.True(decision.Allowed)
+		suite.Equal(domain.PermissionTaskCreate, decision.Grant)
+	})
+
+	// A testcase where the action is unknown.
+	suite.Run("ExplainAccess_UnknownAction", func() {
+		data := &domain.ExplainData{Action: "task.fly"}
+
+		decision, err := suite.usecase.ExplainAccess(context.Background(), data, mocks.GetClaims())
+		suite.Nil(decision)
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+	})
+}
+
+// A function that runs the RoleUsecaseSuite.
+func TestRoleUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(RoleUsecaseSuite))
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The maximum number of characters of the name of an attached file.
+const maxAttachmentFilename = 255
+
+// The number of bytes read to detect the type of a file, which is all http.DetectContentType considers.
+const sniffLength = 512
+
+// A method that attaches a file to a task. The type of the file is detected from its content, so that it does not
+// depend on what the client announces, and must be one of the allowed types.
+func (tu *TaskUsecase) AddAttachment(ctx context.Context, objectID primitive.ObjectID, upload *domain.AttachmentUpload, claims *domain.Claims) (*domain.Attachment, *domain.Error) {
+	task, workspace, _err := tu.getTask(ctx, objectID)
+	if _err != nil {
+		return nil, _err
+	}
+
+	// Check if the user can update the task.
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskUpdate, task.UserID, workspace, "update", "trying to attach a file to another user's task")
+	if _err != nil {
+		return nil, _err
+	}
+
+	filename := strings.TrimSpace(filepath.Base(upload.Filename))
+	if filename == "" || filename == "." || filename == string(filepath.Separator) {
+		return nil, invalidField("file", "must have a name")
+	}
+	if utf8.RuneCountInString(filename) > maxAttachmentFilename {
+		return nil, invalidField("file", "must have a name of at most "+strconv.Itoa(maxAttachmentFilename)+" characters")
+	}
+
+	if upload.Size == 0 {
+		return nil, invalidField("file", "must not be empty")
+	}
+	if upload.Size > tu.limits.MaxSize {
+		return nil, &domain.Error{
+			Err:        errors.New("the file is too large"),
+			StatusCode: http.StatusRequestEntityTooLarge,
+			Code:       domain.CodeAttachmentTooLarge,
+			Message:    "The file is larger than the limit of " + strconv.FormatInt(tu.limits.MaxSize, 10) + " bytes",
+		}
+	}
+
+	// Detect the type from the start of the file, which is then stored with the rest of it.
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(upload.Content, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, internalError(err)
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !slices.Contains(tu.limits.AllowedTypes, mediaType) {
+		return nil, &domain.Error{
+			Err:        errors.New("the type of the file is not allowed"),
+			StatusCode: http.StatusUnsupportedMediaType,
+			Code:       domain.CodeAttachmentTypeNotAllowed,
+			Message:    "Files of type " + mediaType + " cannot be attached",
+		}
+	}
+
+	attachment := &domain.Attachment{
+		ID:          primitive.NewObjectID(),
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        upload.Size,
+		UserID:      claims.ID,
+		CreatedAt:   now(),
+	}
+
+	// Store the content before the metadata, so that a listed attachment can always be downloaded.
+	err = tu.blobs.PutBlob(ctx, domain.AttachmentKey(objectID, attachment.ID), io.MultiReader(bytes.NewReader(head), upload.Content))
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	err = tu.taskRepo.AddAttachment(ctx, objectID, attachment)
+	if err != nil {
+		tu.deleteBlob(ctx, objectID, attachment.ID)
+		return nil, internalError(err)
+	}
+
+	return attachment, nil
+}
+
+// A method that returns an attachment of a task with its content, which the caller must close.
+func (tu *TaskUsecase) GetAttachment(ctx context.Context, objectID primitive.ObjectID, attachmentID primitive.ObjectID, claims *domain.Claims) (*domain.Attachment, io.ReadCloser, *domain.Error) {
+	task, workspace, _err := tu.getTask(ctx, objectID)
+	if _err != nil {
+		return nil, nil, _err
+	}
+
+	// Check if the user can view the task.
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskRead, task.UserID, workspace, "view", "trying to view another user's task")
+	if _err != nil {
+		return nil, nil, _err
+	}
+
+	attachment, _err := findAttachment(task, attachmentID)
+	if _err != nil {
+		return nil, nil, _err
+	}
+
+	content, err := tu.blobs.GetBlob(ctx, domain.AttachmentKey(objectID, attachmentID))
+	if err == domain.ErrBlobNotFound {
+		return nil, nil, attachmentNotFound(err)
+	}
+	if err != nil {
+		return nil, nil, internalError(err)
+	}
+
+	return attachment, content, nil
+}
+
+// A method that removes an attachment from a task and deletes its content.
+func (tu *TaskUsecase) DeleteAttachment(ctx context.Context, objectID primitive.ObjectID, attachmentID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	task, workspace, _err := tu.getTask(ctx, objectID)
+	if _err != nil {
+		return _err
+	}
+
+	// Check if the user can update the task.
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskUpdate, task.UserID, workspace, "update", "trying to remove a file from another user's task")
+	if _err != nil {
+		return _err
+	}
+
+	_, _err = findAttachment(task, attachmentID)
+	if _err != nil {
+		return _err
+	}
+
+	err := tu.taskRepo.RemoveAttachment(ctx, objectID, attachmentID)
+	if err != nil {
+		return internalError(err)
+	}
+
+	tu.deleteBlob(ctx, objectID, attachmentID)
+	return nil
+}
+
+// A helper method that deletes the content of an attachment that is no longer listed on its task. A failure is only
+// logged, since the content can no longer be reached.
+func (tu *TaskUsecase) deleteBlob(ctx context.Context, taskID primitive.ObjectID, attachmentID primitive.ObjectID) {
+	err := tu.blobs.DeleteBlob(ctx, domain.AttachmentKey(taskID, attachmentID))
+	if err != nil {
+		infrastructure.Logger(ctx).Error("deleting the content of an attachment", "task_id", taskID.Hex(), "attachment_id", attachmentID.Hex(), "error", err)
+	}
+}
+
+// A helper function that returns the keys of the contents of the attachments of the tasks matching the filter, so
+// that they can be deleted once the tasks are.
+func attachmentKeys(ctx context.Context, taskRepo domain.TaskRepository, filter *domain.TaskFilter) ([]string, error) {
+	tasks, err := taskRepo.GetTasks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for _, task := range tasks {
+		for _, attachment := range task.Attachments {
+			keys = append(keys, domain.AttachmentKey(task.ID, attachment.ID))
+		}
+	}
+
+	return keys, nil
+}
+
+// A helper function that deletes the contents of the attachments of deleted tasks. A failure is only logged, since
+// the tasks no longer refer to them.
+func deleteBlobs(ctx context.Context, blobs domain.BlobStore, keys []string) {
+	for _, key := range keys {
+		err := blobs.DeleteBlob(ctx, key)
+		if err != nil {
+			infrastructure.Logger(ctx).Error("deleting the content of an attachment", "key", key, "error", err)
+		}
+	}
+}
+
+// A helper function that returns the attachment of a task with the given ID.
+func findAttachment(task *domain.Task, attachmentID primitive.ObjectID) (*domain.Attachment, *domain.Error) {
+	index := slices.IndexFunc(task.Attachments, func(attachment domain.Attachment) bool {
+		return attachment.ID == attachmentID
+	})
+	if index == -1 {
+		return nil, attachmentNotFound(errors.New("the task has no such attachment"))
+	}
+
+	return &task.Attachments[index], nil
+}
+
+// A helper function that returns the error of an attachment that does not exist.
+func attachmentNotFound(err error) *domain.Error {
+	return &domain.Error{
+		Err:        err,
+		StatusCode: http.StatusNotFound,
+		Code:       domain.CodeAttachmentNotFound,
+		Message:    "Attachment not found",
+	}
+}
@@ -0,0 +1,163 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"task_manager/domain"
+	"task_manager/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The content of a PNG image, as far as the detection of its type goes.
+const pngContent = "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
+// A helper function that returns a PNG attachment uploaded by the user.
+func newAttachment(claims *domain.Claims) domain.Attachment {
+	return domain.Attachment{
+		ID:          primitive.NewObjectID(),
+		Filename:    "screenshot.png",
+		ContentType: "image/png",
+		Size:        int64(len(pngContent)),
+		UserID:      claims.ID,
+	}
+}
+
+// A test for the TaskUsecase.AddAttachment method.
+func (suite *TaskUsecaseSuite) Test_AddAttachment() {
+	// A testcase where a PNG image is stored and listed on the task.
+	suite.Run("AddAttachment_Success", func() {
+		claims := mocks.GetClaims()
+		task := newTask("Task", claims, 1)
+		upload := &domain.AttachmentUpload{Filename: "../screenshot.png", Size: int64(len(pngContent)), Content: strings.NewReader(pngContent)}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.blobs.On("PutBlob", mock.Anything, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "tasks/"+task.ID.Hex()+"/")
+		}), mock.Anything).Run(func(args mock.Arguments) {
+			content, _ := io.ReadAll(args.Get(2).(io.Reader))
+			suite.Equal(pngContent, string(content))
+		}).Return(nil).Once()
+		suite.taskRepo.On("AddAttachment", mock.Anything, task.ID, mock.AnythingOfType("*domain.Attachment")).Return(nil).Once()
+
+		attachment, err := suite.usecase.AddAttachment(context.Background(), task.ID, upload, claims)
+		suite.Nil(err)
+		suite.Equal("screenshot.png", attachment.Filename)
+		suite.Equal("image/png", attachment.ContentType)
+		suite.Equal(claims.ID, attachment.UserID)
+	})
+
+	// A testcase where the content of the file is not of an allowed type, whatever its name.
+	suite.Run("AddAttachment_TypeNotAllowed", func() {
+		claims := mocks.GetClaims()
+		task := newTask("Task", claims, 1)
+		content := "<?xml version=\"1.0\"?><svg></svg>"
+		upload := &domain.AttachmentUpload{Filename: "image.png", Size: int64(len(content)), Content: strings.NewReader(content)}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+
+		attachment, err := suite.usecase.AddAttachment(context.Background(), task.ID, upload, claims)
+		suite.Nil(attachment)
+		suite.Equal(domain.CodeAttachmentTypeNotAllowed, err.Code)
+		suite.Equal("Files of type text/xml cannot be attached", err.Message)
+	})
+
+	// A testcase where the file is larger than the limit.
+	suite.Run("AddAttachment_TooLarge", func() {
+		claims := mocks.GetClaims()
+		task := newTask("Task", claims, 1)
+		upload := &domain.AttachmentUpload{Filename: "large.png", Size: attachmentLimits.MaxSize + 1, Content: strings.NewReader(pngContent)}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+
+		_, err := suite.usecase.AddAttachment(context.Background(), task.ID, upload, claims)
+		suite.Equal(domain.CodeAttachmentTooLarge, err.Code)
+	})
+
+	// A testcase where the metadata cannot be stored, so the content is deleted.
+	suite.Run("AddAttachment_StoreFailure", func() {
+		claims := mocks.GetClaims()
+		task := newTask("Task", claims, 1)
+		upload := &domain.AttachmentUpload{Filename: "notes.txt", Size: 5, Content: strings.NewReader("notes")}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.blobs.On("PutBlob", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		suite.taskRepo.On("AddAttachment", mock.Anything, task.ID, mock.AnythingOfType("*domain.Attachment")).Return(errors.New("database error")).Once()
+		suite.blobs.On("DeleteBlob", mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := suite.usecase.AddAttachment(context.Background(), task.ID, upload, claims)
+		suite.Equal(domain.CodeInternal, err.Problem().Code)
+	})
+}
+
+// A test for the TaskUsecase.GetAttachment method.
+func (suite *TaskUsecaseSuite) Test_GetAttachment() {
+	// A testcase where the content of an attachment is returned.
+	suite.Run("GetAttachment_Success", func() {
+		claims := mocks.GetClaims()
+		attachment := newAttachment(claims)
+		task := newTask("Task", claims, 1)
+		task.Attachments = []domain.Attachment{attachment}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.blobs.On("GetBlob", mock.Anything, domain.AttachmentKey(task.ID, attachment.ID)).Return(io.NopCloser(strings.NewReader(pngContent)), nil).Once()
+
+		result, content, err := suite.usecase.GetAttachment(context.Background(), task.ID, attachment.ID, claims)
+		suite.Nil(err)
+		suite.Equal(&attachment, result)
+		data, _ := io.ReadAll(content)
+		suite.Equal(pngContent, string(data))
+	})
+
+	// A testcase where the task has no such attachment.
+	suite.Run("GetAttachment_NotFound", func() {
+		claims := mocks.GetClaims()
+		task := newTask("Task", claims, 1)
+		task.Attachments = []domain.Attachment{newAttachment(claims)}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+
+		_, _, err := suite.usecase.GetAttachment(context.Background(), task.ID, primitive.NewObjectID(), claims)
+		suite.Equal(domain.CodeAttachmentNotFound, err.Code)
+	})
+}
+
+// A test for the TaskUsecase.DeleteAttachment method.
+func (suite *TaskUsecaseSuite) Test_DeleteAttachment() {
+	// A testcase where the attachment is removed from the task and its content is deleted.
+	suite.Run("DeleteAttachment_Success", func() {
+		claims := mocks.GetClaims()
+		attachment := newAttachment(claims)
+		task := newTask("Task", claims, 1)
+		task.Attachments = []domain.Attachment{attachment}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.taskRepo.On("RemoveAttachment", mock.Anything, task.ID, attachment.ID).Return(nil).Once()
+		suite.blobs.On("DeleteBlob", mock.Anything, domain.AttachmentKey(task.ID, attachment.ID)).Return(nil).Once()
+
+		err := suite.usecase.DeleteAttachment(context.Background(), task.ID, attachment.ID, claims)
+		suite.Nil(err)
+	})
+}
+
+// A test for the deletion of the attachments of a task by the TaskUsecase.DeleteTask method.
+func (suite *TaskUsecaseSuite) Test_DeleteTask_Attachments() {
+	// A testcase where the contents of the attachments are deleted with the task.
+	suite.Run("DeleteTask_Attachments", func() {
+		claims := mocks.GetClaims()
+		attachment := newAttachment(claims)
+		task := newTask("Task", claims, 1)
+		task.Attachments = []domain.Attachment{attachment}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.taskRepo.On("DeleteTask", mock.Anything, task.ID).Return(nil).Once()
+		suite.taskRepo.On("RemoveBlocker", mock.Anything, &domain.TaskFilter{BlockedBy: task.ID}, task.ID).Return(nil).Once()
+		suite.blobs.On("DeleteBlob", mock.Anything, domain.AttachmentKey(task.ID, attachment.ID)).Return(nil).Once()
+
+		err := suite.usecase.DeleteTask(context.Background(), task.ID, claims)
+		suite.Nil(err)
+	})
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"task_manager/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A method that returns the tasks the user can view in a column per status, in the order of their ranks. The WIP
+// limits of the columns are returned when the board shows a single workspace.
+func (tu *TaskUsecase) GetBoard(ctx context.Context, query *domain.TaskQuery, claims *domain.Claims) (*domain.Board, *domain.Error) {
+	taskQuery := *query
+	taskQuery.Sort = ""
+
+	tasks, _err := tu.GetTasks(ctx, &taskQuery, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	var limits map[string]int
+	if query.WorkspaceID != "" {
+		workspace, _err := tu.getMemberWorkspace(ctx, query.WorkspaceID, claims)
+		if _err != nil {
+			return nil, _err
+		}
+
+		limits = workspace.WIPLimits
+	}
+
+	board := &domain.Board{Columns: make([]domain.BoardColumn, 0, len(domain.BoardStatuses))}
+	for _, status := range domain.BoardStatuses {
+		column := domain.BoardColumn{Status: status, WIPLimit: limits[status], Tasks: []domain.Task{}}
+		for _, task := range tasks {
+			if task.Status == status {
+				column.Tasks = append(column.Tasks, task)
+			}
+		}
+
+		slices.SortFunc(column.Tasks, func(a, b domain.Task) int {
+			return domain.CompareBoardTasks(&a, &b)
+		})
+		column.Count = len(column.Tasks)
+		board.Columns = append(board.Columns, column)
+	}
+
+	return board, nil
+}
+
+// A method that moves a task to a position in the column of a status, changing its status and its rank together.
+// The position is right after another task of the column, or the top of the column. Moving a task to another column
+// is refused if the column of its workspace is full, or if the task would be completed while it is blocked.
+func (tu *TaskUsecase) MoveTask(ctx context.Context, objectID primitive.ObjectID, moveData *domain.MoveTaskData, claims *domain.Claims) (*domain.Task, *domain.Error) {
+	task, workspace, _err := tu.getTask(ctx, objectID)
+	if _err != nil {
+		return nil, _err
+	}
+
+	// Check if the user can update the task.
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskUpdate, task.UserID, workspace, "update", "trying to move another user's task")
+	if _err != nil {
+		return nil, _err
+	}
+
+	// Read the column and store the ranks and the move with the workspace locked, so that the column is never left
+	// half ordered and the ranks are computed from the column they are stored in.
+	_err = tu.changeTask(ctx, claims, workspace, task, moveData.Status, func(ctx context.Context, current *domain.Task) error {
+		task = current
+		return tu.moveTask(ctx, task, moveData)
+	})
+	if _err != nil {
+		return nil, _err
+	}
+
+	return task, nil
+}
+
+// A helper method that stores the move of a task to a position in the column of a status, and updates the task.
+func (tu *TaskUsecase) moveTask(ctx context.Context, task *domain.Task, moveData *domain.MoveTaskData) error {
+	// Get the other tasks of the column in the workspace of the task, in the order of the board.
+	filter := workspaceTasks(task.WorkspaceID)
+	filter.Statuses = []string{moveData.Status}
+	tasks, err := tu.taskRepo.GetTasks(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	column := slices.DeleteFunc(tasks, func(other domain.Task) bool {
+		return other.ID == task.ID
+	})
+	slices.SortFunc(column, func(a, b domain.Task) int {
+		return domain.CompareBoardTasks(&a, &b)
+	})
+
+	// Find the position of the task in the column.
+	position := 0
+	if !moveData.AfterID.IsZero() {
+		index := slices.IndexFunc(column, func(other domain.Task) bool {
+			return other.ID == moveData.AfterID
+		})
+		if index == -1 {
+			return invalidField("after_id", "must be another task of the column")
+		}
+
+		position = index + 1
+	}
+
+	// Tasks without a rank follow the ranked ones, so the tasks above the position are ranked first if it is among
+	// them. The task below the position can stay unranked, since the new rank sorts before every unranked task.
+	before := ""
+	for i := 0; i < position; i++ {
+		if column[i].Rank == "" {
+			column[i].Rank = domain.RankBetween(before, "")
+			err := tu.taskRepo.UpdateTask(ctx, column[i].ID, bson.M{"rank": column[i].Rank})
+			if err != nil {
+				return err
+			}
+		}
+
+		before = column[i].Rank
+	}
+
+	after := ""
+	if position < len(column) {
+		after = column[position].Rank
+	}
+
+	task.Rank = domain.RankBetween(before, after)
+	moved := bson.M{"status": moveData.Status, "rank": task.Rank}
+	completedAt, changed := completion(task.Status, moveData.Status)
+	if changed {
+		moved["completed_at"] = completedAt
+	}
+
+	err = tu.taskRepo.UpdateTask(ctx, task.ID, moved)
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		task.CompletedAt = completedAt
+	}
+
+	task.Status = moveData.Status
+	return nil
+}
+
+// A helper method that changes a task in a transaction that locks its workspace when the status is set, so that what
+// is checked about the other tasks of the workspace still holds when it commits. The task is then read again once the
+// workspace is locked, and change writes it from that copy. Every change of the status of a task goes through it: it
+// is refused if the task would be completed while it is blocked, or if the column of the new status is full in the
+// workspace. An empty status leaves the status unchanged.
+func (tu *TaskUsecase) changeTask(ctx context.Context, claims *domain.Claims, workspace *domain.Workspace, task *domain.Task, status string, change func(ctx context.Context, task *domain.Task) error) *domain.Error {
+	if status == "" {
+		return tu.withWorkspaceLock(ctx, primitive.NilObjectID, func(ctx context.Context) error {
+			return change(ctx, task)
+		})
+	}
+
+	lockID := primitive.NilObjectID
+	if workspace != nil {
+		lockID = workspace.ID
+	}
+
+	return tu.withWorkspaceLock(ctx, lockID, func(ctx context.Context) error {
+		current, err := tu.taskRepo.GetTaskByID(ctx, task.ID)
+		if err != nil {
+			return notFoundOrInternal(err, domain.CodeTaskNotFound, "Task not found")
+		}
+
+		if status != current.Status {
+			_err := tu.checkBlockers(ctx, claims, workspace, current, status)
+			if _err != nil {
+				return _err
+			}
+
+			_err = tu.checkWIPLimit(ctx, workspace, status)
+			if _err != nil {
+				return _err
+			}
+		}
+
+		return change(ctx, current)
+	})
+}
+
+// A helper method that refuses to add a task to the column of a status if the column is full in the workspace. It is
+// called with the workspace locked, so that two tasks cannot take the last place of the column together.
+func (tu *TaskUsecase) checkWIPLimit(ctx context.Context, workspace *domain.Workspace, status string) *domain.Error {
+	if workspace == nil || workspace.WIPLimits[status] == 0 {
+		return nil
+	}
+
+	limit := workspace.WIPLimits[status]
+	filter := workspaceTasks(workspace.ID)
+	filter.Statuses = []string{status}
+	count, err := tu.taskRepo.CountTasks(ctx, filter)
+	if err != nil {
+		return internalError(err)
+	}
+
+	if count >= int64(limit) {
+		return &domain.Error{
+			Err:        errors.New("the column is full"),
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeWIPLimitReached,
+			Message:    "The " + status + " column is limited to " + strconv.Itoa(limit) + " tasks",
+		}
+	}
+
+	return nil
+}
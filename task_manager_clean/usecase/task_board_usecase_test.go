@@ -0,0 +1,217 @@
+package usecase_test
+
+import (
+	"context"
+	"task_manager/domain"
+	"task_manager/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A test for the TaskUsecase.GetBoard method.
+func (suite *TaskUsecaseSuite) Test_GetBoard() {
+	// A testcase where the tasks are grouped by status and ordered by rank, with the unranked tasks last.
+	suite.Run("GetBoard_Success", func() {
+		claims := mocks.GetClaims()
+		workspace := mocks.GetWorkspace()
+		workspace.WIPLimits = map[string]int{"In Progress": 2}
+		first := newTask("First", claims, 1)
+		first.Rank = "c"
+		second := newTask("Second", claims, 1)
+		second.Rank = "i"
+		unranked := newTask("Unranked", claims, 1)
+		doing := newTask("Doing", claims, 1)
+		doing.Status = "In Progress"
+		doing.Rank = "i"
+
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Twice()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return([]domain.Task{unranked, doing, second, first}, nil).Once()
+
+		board, err := suite.usecase.GetBoard(context.Background(), &domain.TaskQuery{WorkspaceID: workspace.ID.Hex()}, claims)
+		suite.Nil(err)
+		suite.Equal(&domain.Board{Columns: []domain.BoardColumn{
+			{Status: "Pending", Count: 3, Tasks: []domain.Task{first, second, unranked}},
+			{Status: "In Progress", WIPLimit: 2, Count: 1, Tasks: []domain.Task{doing}},
+			{Status: "Completed", Count: 0, Tasks: []domain.Task{}},
+		}}, board)
+	})
+}
+
+// A test for the TaskUsecase.MoveTask method.
+func (suite *TaskUsecaseSuite) Test_MoveTask() {
+	// A testcase where a task is moved between two ranked tasks of another column.
+	suite.Run("MoveTask_BetweenRanked", func() {
+		claims := mocks.GetClaims()
+		task := newTask("Task", claims, 1)
+		task.Rank = "i"
+		above := newTask("Above", claims, 1)
+		above.Status = "In Progress"
+		above.Rank = "c"
+		below := newTask("Below", claims, 1)
+		below.Status = "In Progress"
+		below.Rank = "d"
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Twice()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{}, IncludeUnassigned: true, Statuses: []string{"In Progress"}}).Return([]domain.Task{below, above}, nil).Once()
+		suite.taskRepo.On("UpdateTask", mock.Anything, task.ID, bson.M{"status": "In Progress", "rank": "ci"}).Return(nil).Once()
+
+		result, err := suite.usecase.MoveTask(context.Background(), task.ID, &domain.MoveTaskData{Status: "In Progress", AfterID: above.ID}, claims)
+		suite.Nil(err)
+		suite.Equal("In Progress", result.Status)
+		suite.Equal("ci", result.Rank)
+	})
+
+	// A testcase where a task is moved after an unranked task, which is ranked in the same transaction.
+	suite.Run("MoveTask_AfterUnranked", func() {
+		claims := mocks.GetClaims()
+		task := newTask("Task", claims, 1)
+		ranked := newTask("Ranked", claims, 1)
+		ranked.Rank = "i"
+		unranked := newTask("Unranked", claims, 1)
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Twice()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return([]domain.Task{unranked, task, ranked}, nil).Once()
+		suite.taskRepo.On("UpdateTask", mock.Anything, unranked.ID, bson.M{"rank": "r"}).Return(nil).Once()
+		suite.taskRepo.On("UpdateTask", mock.Anything, task.ID, bson.M{"status": "Pending", "rank": "v"}).Return(nil).Once()
+
+		result, err := suite.usecase.MoveTask(context.Background(), task.ID, &domain.MoveTaskData{Status: "Pending", AfterID: unranked.ID}, claims)
+		suite.Nil(err)
+		suite.Equal("v", result.Rank)
+	})
+
+	// A testcase where the column of the workspace is full.
+	suite.Run("MoveTask_WIPLimit", func() {
+		claims := mocks.GetClaims()
+		workspace := mocks.GetWorkspace()
+		workspace.WIPLimits = map[string]int{"In Progress": 1}
+		task := newTask("Task", claims, 1)
+		task.WorkspaceID = workspace.ID
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Twice()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.workspaceRepo.On("LockWorkspace", mock.Anything, workspace.ID).Return(nil).Once()
+		suite.taskRepo.On("CountTasks", mock.Anything, &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{workspace.ID}, Statuses: []string{"In Progress"}}).Return(int64(1), nil).Once()
+
+		result, err := suite.usecase.MoveTask(context.Background(), task.ID, &domain.MoveTaskData{Status: "In Progress"}, claims)
+		suite.Nil(result)
+		suite.Equal(domain.CodeWIPLimitReached, err.Code)
+		suite.Equal("The In Progress column is limited to 1 tasks", err.Message)
+	})
+
+	// A testcase where the column is read and the task is moved once the workspace is locked, with the blockers
+	// checked against the task read in the lock.
+	suite.Run("MoveTask_LocksWorkspace", func() {
+		claims := mocks.GetClaims()
+		workspace := mocks.GetWorkspace()
+		task := newTask("Task", claims, 1)
+		task.WorkspaceID = workspace.ID
+		blocker := newTask("Blocker", claims, 1)
+		locked := task
+		locked.BlockedBy = []primitive.ObjectID{blocker.ID}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		lock := suite.workspaceRepo.On("LockWorkspace", mock.Anything, workspace.ID).Return(nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&locked, nil).Once().NotBefore(lock)
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{IDs: locked.BlockedBy, Statuses: domain.OpenTaskStatuses}).Return([]domain.Task{blocker}, nil).Once()
+
+		result, err := suite.usecase.MoveTask(context.Background(), task.ID, &domain.MoveTaskData{Status: "Completed"}, claims)
+		suite.Nil(result)
+		suite.Equal(domain.CodeTaskBlocked, err.Code)
+	})
+
+	// A testcase where the task to place the task after is not in the column.
+	suite.Run("MoveTask_UnknownAfter", func() {
+		claims := mocks.GetClaims()
+		task := newTask("Task", claims, 1)
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Twice()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return([]domain.Task{}, nil).Once()
+
+		_, err := suite.usecase.MoveTask(context.Background(), task.ID, &domain.MoveTaskData{Status: "Pending", AfterID: primitive.NewObjectID()}, claims)
+		suite.Equal(domain.CodeValidationFailed, err.Code)
+		suite.Equal("after_id", err.Fields[0].Field)
+	})
+}
+
+// A test for the WIP limits of the TaskUsecase methods that change the status of a task.
+func (suite *TaskUsecaseSuite) Test_WIPLimits() {
+	inProgress := &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{mocks.GetWorkspace().ID}, Statuses: []string{"In Progress"}}
+
+	// A testcase where a task is created in a full column.
+	suite.Run("CreateTask_WIPLimit", func() {
+		taskData := mocks.GetCreateTaskData()
+		project := mocks.GetProject()
+		workspace := mocks.GetWorkspace()
+		workspace.WIPLimits = map[string]int{"In Progress": 2}
+
+		suite.projectRepo.On("GetProjectByID", mock.Anything, taskData.ProjectID).Return(project, nil).Once()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.workspaceRepo.On("LockWorkspace", mock.Anything, workspace.ID).Return(nil).Once()
+		suite.taskRepo.On("CountTasks", mock.Anything, inProgress).Return(int64(2), nil).Once()
+
+		result, err := suite.usecase.CreateTask(context.Background(), taskData, mocks.GetClaims())
+		suite.Nil(result)
+		suite.Equal(domain.CodeWIPLimitReached, err.Code)
+	})
+
+	// A testcase where the status of a task is updated to a column that has room.
+	suite.Run("UpdateTask_WIPLimitRoom", func() {
+		claims := mocks.GetClaims()
+		workspace := mocks.GetWorkspace()
+		workspace.WIPLimits = map[string]int{"In Progress": 2}
+		task := newTask("Task", claims, 1)
+		task.WorkspaceID = workspace.ID
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Twice()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.workspaceRepo.On("LockWorkspace", mock.Anything, workspace.ID).Return(nil).Once()
+		suite.taskRepo.On("CountTasks", mock.Anything, inProgress).Return(int64(1), nil).Once()
+		suite.taskRepo.On("UpdateTask", mock.Anything, task.ID, bson.M{"status": "In Progress"}).Return(nil).Once()
+		suite.timeEntryRepo.On("GetTimeEntries", mock.Anything, mockTimeEntryFilter).Return([]domain.TimeEntry{}, nil).Once()
+
+		result, err := suite.usecase.UpdateTask(context.Background(), task.ID, &domain.UpdateTaskData{Status: "In Progress"}, claims)
+		suite.Nil(err)
+		suite.Equal("In Progress", result.Status)
+	})
+
+	// A testcase where a task is replaced with the status of a full column.
+	suite.Run("ReplaceTask_WIPLimit", func() {
+		claims := mocks.GetClaims()
+		workspace := mocks.GetWorkspace()
+		workspace.WIPLimits = map[string]int{"In Progress": 1}
+		task := newTask("Task", claims, 1)
+		task.WorkspaceID = workspace.ID
+		taskData := mocks.GetReplaceTaskData()
+		taskData.Status = "In Progress"
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Twice()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.workspaceRepo.On("LockWorkspace", mock.Anything, workspace.ID).Return(nil).Once()
+		suite.taskRepo.On("CountTasks", mock.Anything, inProgress).Return(int64(1), nil).Once()
+
+		result, err := suite.usecase.ReplaceTask(context.Background(), task.ID, taskData, claims)
+		suite.Nil(result)
+		suite.Equal(domain.CodeWIPLimitReached, err.Code)
+	})
+
+	// A testcase where a task that stays in its full column is updated without counting the column.
+	suite.Run("UpdateTask_WIPLimitSameStatus", func() {
+		claims := mocks.GetClaims()
+		workspace := mocks.GetWorkspace()
+		workspace.WIPLimits = map[string]int{"In Progress": 1}
+		task := newTask("Task", claims, 1)
+		task.Status = "In Progress"
+		task.WorkspaceID = workspace.ID
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.taskRepo.On("UpdateTask", mock.Anything, task.ID, bson.M{"title": "Renamed"}).Return(nil).Once()
+		suite.timeEntryRepo.On("GetTimeEntries", mock.Anything, mockTimeEntryFilter).Return([]domain.TimeEntry{}, nil).Once()
+
+		_, err := suite.usecase.UpdateTask(context.Background(), task.ID, &domain.UpdateTaskData{Title: "Renamed"}, claims)
+		suite.Nil(err)
+	})
+}
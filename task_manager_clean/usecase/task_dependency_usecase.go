@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"task_manager/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A method that returns the tasks that block a task and the tasks it blocks, among those the user can view.
+func (tu *TaskUsecase) GetDependencies(ctx context.Context, objectID primitive.ObjectID, claims *domain.Claims) (*domain.TaskDependencies, *domain.Error) {
+	task, workspace, _err := tu.getTask(ctx, objectID)
+	if _err != nil {
+		return nil, _err
+	}
+
+	// Check if the user can view the task.
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskRead, task.UserID, workspace, "view", "trying to view another user's task")
+	if _err != nil {
+		return nil, _err
+	}
+
+	return tu.getDependencies(ctx, task, workspace, claims)
+}
+
+// A method that makes a task blocked by another task of the same workspace. Adding a dependency that already exists
+// changes nothing, and a dependency that would make the tasks block each other is refused. The cycle is looked for in
+// a transaction that locks the workspace, so that two dependencies added at once cannot close a cycle together.
+func (tu *TaskUsecase) AddDependency(ctx context.Context, objectID primitive.ObjectID, blockerID primitive.ObjectID, claims *domain.Claims) (*domain.TaskDependencies, *domain.Error) {
+	task, workspace, _err := tu.getTask(ctx, objectID)
+	if _err != nil {
+		return nil, _err
+	}
+
+	// Check if the user can update the task.
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskUpdate, task.UserID, workspace, "update", "trying to change the dependencies of another user's task")
+	if _err != nil {
+		return nil, _err
+	}
+
+	if objectID == blockerID {
+		return nil, &domain.Error{
+			Err:        errors.New("a task cannot block itself"),
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeDependencyCycle,
+			Message:    "A task cannot block itself",
+		}
+	}
+
+	// Check if the user can view the blocker, which must be in the same workspace.
+	blocker, _err := tu.GetTaskByID(ctx, blockerID, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	if blocker.WorkspaceID != task.WorkspaceID {
+		return nil, invalidField("blocker_id", "must be a task of the same workspace")
+	}
+
+	if slices.Contains(task.BlockedBy, blockerID) {
+		return tu.getDependencies(ctx, task, workspace, claims)
+	}
+
+	_err = tu.withWorkspaceLock(ctx, task.WorkspaceID, func(ctx context.Context) error {
+		// Check that the blocker does not already depend on the task.
+		tasks, err := tu.taskRepo.GetTasks(ctx, workspaceTasks(task.WorkspaceID))
+		if err != nil {
+			return err
+		}
+
+		cycle := domain.DependencyCycle(tasks, objectID, blockerID)
+		if cycle != nil {
+			return tu.cycleError(ctx, claims, workspace, tasks, cycle)
+		}
+
+		return tu.taskRepo.AddBlocker(ctx, objectID, blockerID)
+	})
+	if _err != nil {
+		return nil, _err
+	}
+
+	task.BlockedBy = append(task.BlockedBy, blockerID)
+	return tu.getDependencies(ctx, task, workspace, claims)
+}
+
+// A method that removes a dependency between two tasks.
+func (tu *TaskUsecase) RemoveDependency(ctx context.Context, objectID primitive.ObjectID, blockerID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	task, workspace, _err := tu.getTask(ctx, objectID)
+	if _err != nil {
+		return _err
+	}
+
+	// Check if the user can update the task.
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskUpdate, task.UserID, workspace, "update", "trying to change the dependencies of another user's task")
+	if _err != nil {
+		return _err
+	}
+
+	if !slices.Contains(task.BlockedBy, blockerID) {
+		return &domain.Error{
+			Err:        errors.New("the task is not blocked by " + blockerID.Hex()),
+			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeDependencyNotFound,
+			Message:    "Dependency not found",
+		}
+	}
+
+	err := tu.taskRepo.RemoveBlocker(ctx, &domain.TaskFilter{IDs: []primitive.ObjectID{objectID}}, blockerID)
+	if err != nil {
+		return internalError(err)
+	}
+
+	return nil
+}
+
+// A method that returns an order in which the open tasks of a workspace, optionally limited to a project, can be
+// completed, with the longest chain of tasks that block each other. Among the tasks that are not blocked, the order
+// of the query is preferred, by due date by default.
+func (tu *TaskUsecase) GetTaskPlan(ctx context.Context, query *domain.TaskQuery, claims *domain.Claims) (*domain.TaskPlan, *domain.Error) {
+	taskQuery := *query
+	if taskQuery.Sort == "" {
+		taskQuery.Sort = domain.TaskSortDueDate
+	}
+
+	tasks, _err := tu.GetTasks(ctx, &taskQuery, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	plan, err := domain.PlanTasks(tasks)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeDependencyCycle,
+			Message:    "The dependencies of the tasks form a cycle",
+		}
+	}
+
+	return plan, nil
+}
+
+// A helper method that returns the dependencies of a task in the workspace, leaving out the tasks the user cannot
+// view.
+func (tu *TaskUsecase) getDependencies(ctx context.Context, task *domain.Task, workspace *domain.Workspace, claims *domain.Claims) (*domain.TaskDependencies, *domain.Error) {
+	blockers := []domain.Task{}
+	if len(task.BlockedBy) > 0 {
+		var err error
+		blockers, err = tu.taskRepo.GetTasks(ctx, &domain.TaskFilter{IDs: task.BlockedBy})
+		if err != nil {
+			return nil, internalError(err)
+		}
+	}
+
+	blocking, err := tu.taskRepo.GetTasks(ctx, &domain.TaskFilter{BlockedBy: task.ID})
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	dependencies := &domain.TaskDependencies{BlockedBy: []domain.Task{}, Blocking: []domain.Task{}}
+	for _, blocker := range blockers {
+		visible, _err := tu.canView(ctx, claims, workspace, &blocker)
+		if _err != nil {
+			return nil, _err
+		}
+		if visible {
+			dependencies.BlockedBy = append(dependencies.BlockedBy, blocker)
+		}
+	}
+
+	for _, blocked := range blocking {
+		visible, _err := tu.canView(ctx, claims, workspace, &blocked)
+		if _err != nil {
+			return nil, _err
+		}
+		if visible {
+			dependencies.Blocking = append(dependencies.Blocking, blocked)
+		}
+	}
+
+	return dependencies, nil
+}
+
+// A helper method that reports the chain of tasks that a dependency would turn into a cycle, naming only the tasks
+// the user can view.
+func (tu *TaskUsecase) cycleError(ctx context.Context, claims *domain.Claims, workspace *domain.Workspace, tasks []domain.Task, cycle []primitive.ObjectID) *domain.Error {
+	byID := map[primitive.ObjectID]domain.Task{}
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	chain := make([]domain.Task, 0, len(cycle))
+	for _, id := range cycle {
+		chain = append(chain, byID[id])
+	}
+
+	titles, _err := tu.taskTitles(ctx, claims, workspace, chain)
+	if _err != nil {
+		return _err
+	}
+
+	return &domain.Error{
+		Err:        errors.New("the blocker depends on the task"),
+		StatusCode: http.StatusConflict,
+		Code:       domain.CodeDependencyCycle,
+		Message:    "The dependency would create a cycle: " + strings.Join(titles, " is blocked by "),
+	}
+}
+
+// A helper method that returns the quoted titles of the tasks of the workspace, with a placeholder for the tasks the
+// user cannot view, so that the messages do not disclose them.
+func (tu *TaskUsecase) taskTitles(ctx context.Context, claims *domain.Claims, workspace *domain.Workspace, tasks []domain.Task) ([]string, *domain.Error) {
+	titles := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		visible, _err := tu.canView(ctx, claims, workspace, &task)
+		if _err != nil {
+			return nil, _err
+		}
+
+		if visible {
+			titles = append(titles, strconv.Quote(task.Title))
+		} else {
+			titles = append(titles, "a task you cannot view")
+		}
+	}
+
+	return titles, nil
+}
+
+// A helper method that checks whether the user can view a task of the workspace. Unlike checkAccess, a denial is not
+// an error, since it only hides the task from a list.
+func (tu *TaskUsecase) canView(ctx context.Context, claims *domain.Claims, workspace *domain.Workspace, task *domain.Task) (bool, *domain.Error) {
+	decision, err := tu.authorizer.Authorize(ctx, claims, &domain.AccessRequest{Action: domain.ActionTaskRead, OwnerID: task.UserID, Workspace: workspace})
+	if err != nil {
+		return false, internalError(err)
+	}
+
+	return decision.Allowed, nil
+}
+
+// A helper method that refuses to complete a task while some of the tasks that block it are open. The blockers that
+// were deleted do not block the task anymore, and the blockers the user cannot view are not named.
+func (tu *TaskUsecase) checkBlockers(ctx context.Context, claims *domain.Claims, workspace *domain.Workspace, task *domain.Task, status string) *domain.Error {
+	if status != "Completed" || task.Status == "Completed" || len(task.BlockedBy) == 0 {
+		return nil
+	}
+
+	blockers, err := tu.taskRepo.GetTasks(ctx, &domain.TaskFilter{IDs: task.BlockedBy, Statuses: domain.OpenTaskStatuses})
+	if err != nil {
+		return internalError(err)
+	}
+
+	if len(blockers) == 0 {
+		return nil
+	}
+
+	titles, _err := tu.taskTitles(ctx, claims, workspace, blockers)
+	if _err != nil {
+		return _err
+	}
+
+	return &domain.Error{
+		Err:        errors.New("the task has open blockers"),
+		StatusCode: http.StatusConflict,
+		Code:       domain.CodeTaskBlocked,
+		Message:    "The task is blocked by open tasks: " + strings.Join(titles, ", "),
+	}
+}
+
+// A helper function that returns the filter of the tasks of a workspace, or of the tasks that were created before
+// workspaces existed if the ID is not set.
+func workspaceTasks(workspaceID primitive.ObjectID) *domain.TaskFilter {
+	if workspaceID.IsZero() {
+		return &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{}, IncludeUnassigned: true}
+	}
+
+	return &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{workspaceID}}
+}
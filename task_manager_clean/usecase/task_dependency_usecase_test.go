@@ -0,0 +1,244 @@
+package usecase_test
+
+import (
+	"context"
+	"task_manager/domain"
+	"task_manager/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A test for the TaskUsecase.GetDependencies method.
+func (suite *TaskUsecaseSuite) Test_GetDependencies() {
+	// A testcase where the role of the user only grants reading their own tasks, so the tasks of other users are
+	// left out of the dependencies.
+	suite.Run("GetDependencies_HidesTasks", func() {
+		claims := mocks.GetClaims()
+		claims.Role = "reader"
+		suite.roleRepo.On("GetRoleByName", mock.Anything, "reader").Return(&domain.Role{
+			Name:        "reader",
+			Level:       5,
+			Permissions: []string{domain.PermissionTaskReadOwn},
+		}, nil).Times(4)
+		hidden := newTask("Secret", mocks.GetClaims2(), 1)
+		visible := newTask("Design", claims, 1)
+		task := newTask("Build", claims, 2)
+		task.BlockedBy = []primitive.ObjectID{hidden.ID, visible.ID}
+		blocked := newTask("Release", mocks.GetClaims2(), 3)
+		blocked.BlockedBy = []primitive.ObjectID{task.ID}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{IDs: task.BlockedBy}).Return([]domain.Task{hidden, visible}, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{BlockedBy: task.ID}).Return([]domain.Task{blocked}, nil).Once()
+
+		dependencies, err := suite.usecase.GetDependencies(context.Background(), task.ID, claims)
+		suite.Nil(err)
+		suite.Equal([]domain.Task{visible}, dependencies.BlockedBy)
+		suite.Empty(dependencies.Blocking)
+	})
+}
+
+// A test for the TaskUsecase.AddDependency method.
+func (suite *TaskUsecaseSuite) Test_AddDependency() {
+	// A testcase where a task is blocked by another task.
+	suite.Run("AddDependency_Success", func() {
+		claims := mocks.GetClaims()
+		blocker := newTask("Design", claims, 1)
+		task := newTask("Build", claims, 2)
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, blocker.ID).Return(&blocker, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{}, IncludeUnassigned: true}).Return([]domain.Task{blocker, task}, nil).Once()
+		suite.taskRepo.On("AddBlocker", mock.Anything, task.ID, blocker.ID).Return(nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{IDs: []primitive.ObjectID{blocker.ID}}).Return([]domain.Task{blocker}, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{BlockedBy: task.ID}).Return([]domain.Task{}, nil).Once()
+
+		dependencies, err := suite.usecase.AddDependency(context.Background(), task.ID, blocker.ID, claims)
+		suite.Nil(err)
+		suite.Equal([]domain.Task{blocker}, dependencies.BlockedBy)
+		suite.Empty(dependencies.Blocking)
+	})
+
+	// A testcase where the blocker already depends on the task through another task.
+	suite.Run("AddDependency_Cycle", func() {
+		claims := mocks.GetClaims()
+		task := newTask("Design", claims, 1)
+		middle := newTask("Build", claims, 2)
+		middle.BlockedBy = []primitive.ObjectID{task.ID}
+		blocker := newTask("Release", claims, 3)
+		blocker.BlockedBy = []primitive.ObjectID{middle.ID}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, blocker.ID).Return(&blocker, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return([]domain.Task{task, middle, blocker}, nil).Once()
+
+		dependencies, err := suite.usecase.AddDependency(context.Background(), task.ID, blocker.ID, claims)
+		suite.Nil(dependencies)
+		suite.Equal(domain.CodeDependencyCycle, err.Code)
+		suite.Equal(`The dependency would create a cycle: "Release" is blocked by "Build" is blocked by "Design"`, err.Message)
+	})
+
+	// A testcase where the cycle goes through a task of another user, whose title is not given.
+	suite.Run("AddDependency_CycleHidden", func() {
+		claims := mocks.GetClaims()
+		claims.Role = "editor"
+		suite.roleRepo.On("GetRoleByName", mock.Anything, "editor").Return(&domain.Role{
+			Name:        "editor",
+			Level:       5,
+			Permissions: []string{domain.PermissionTaskReadOwn, domain.PermissionTaskUpdateOwn},
+		}, nil).Times(5)
+		task := newTask("Design", claims, 1)
+		middle := newTask("Secret", mocks.GetClaims2(), 2)
+		middle.BlockedBy = []primitive.ObjectID{task.ID}
+		blocker := newTask("Release", claims, 3)
+		blocker.BlockedBy = []primitive.ObjectID{middle.ID}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, blocker.ID).Return(&blocker, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return([]domain.Task{task, middle, blocker}, nil).Once()
+
+		_, err := suite.usecase.AddDependency(context.Background(), task.ID, blocker.ID, claims)
+		suite.Equal(domain.CodeDependencyCycle, err.Code)
+		suite.Equal(`The dependency would create a cycle: "Release" is blocked by a task you cannot view is blocked by "Design"`, err.Message)
+	})
+
+	// A testcase where the workspace of the tasks is locked while the cycle is looked for and the dependency added.
+	suite.Run("AddDependency_LocksWorkspace", func() {
+		claims := mocks.GetClaims()
+		workspace := mocks.GetWorkspace()
+		blocker := newTask("Design", claims, 1)
+		task := newTask("Build", claims, 2)
+		blocker.WorkspaceID = workspace.ID
+		task.WorkspaceID = workspace.ID
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, blocker.ID).Return(&blocker, nil).Once()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Twice()
+		suite.workspaceRepo.On("LockWorkspace", mock.Anything, workspace.ID).Return(nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{workspace.ID}}).Return([]domain.Task{blocker, task}, nil).Once()
+		suite.taskRepo.On("AddBlocker", mock.Anything, task.ID, blocker.ID).Return(nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{IDs: []primitive.ObjectID{blocker.ID}}).Return([]domain.Task{blocker}, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{BlockedBy: task.ID}).Return([]domain.Task{}, nil).Once()
+
+		dependencies, err := suite.usecase.AddDependency(context.Background(), task.ID, blocker.ID, claims)
+		suite.Nil(err)
+		suite.Equal([]domain.Task{blocker}, dependencies.BlockedBy)
+	})
+
+	// A testcase where a task would block itself.
+	suite.Run("AddDependency_Self", func() {
+		claims := mocks.GetClaims()
+		task := newTask("Design", claims, 1)
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+
+		_, err := suite.usecase.AddDependency(context.Background(), task.ID, task.ID, claims)
+		suite.Equal(domain.CodeDependencyCycle, err.Code)
+	})
+
+	// A testcase where the blocker is in another workspace.
+	suite.Run("AddDependency_OtherWorkspace", func() {
+		claims := mocks.GetClaims()
+		workspace := mocks.GetWorkspace()
+		task := newTask("Design", claims, 1)
+		blocker := newTask("Build", claims, 2)
+		blocker.WorkspaceID = workspace.ID
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, blocker.ID).Return(&blocker, nil).Once()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+
+		_, err := suite.usecase.AddDependency(context.Background(), task.ID, blocker.ID, claims)
+		suite.Equal(domain.CodeValidationFailed, err.Code)
+		suite.Equal("blocker_id", err.Fields[0].Field)
+	})
+}
+
+// A test for the TaskUsecase.RemoveDependency method.
+func (suite *TaskUsecaseSuite) Test_RemoveDependency() {
+	// A testcase where the dependency is removed.
+	suite.Run("RemoveDependency_Success", func() {
+		claims := mocks.GetClaims()
+		blockerID := primitive.NewObjectID()
+		task := newTask("Build", claims, 2)
+		task.BlockedBy = []primitive.ObjectID{blockerID}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+		suite.taskRepo.On("RemoveBlocker", mock.Anything, &domain.TaskFilter{IDs: []primitive.ObjectID{task.ID}}, blockerID).Return(nil).Once()
+
+		err := suite.usecase.RemoveDependency(context.Background(), task.ID, blockerID, claims)
+		suite.Nil(err)
+	})
+
+	// A testcase where the task is not blocked by the other task.
+	suite.Run("RemoveDependency_NotFound", func() {
+		claims := mocks.GetClaims()
+		task := newTask("Build", claims, 2)
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Once()
+
+		err := suite.usecase.RemoveDependency(context.Background(), task.ID, primitive.NewObjectID(), claims)
+		suite.Equal(domain.CodeDependencyNotFound, err.Code)
+	})
+}
+
+// A test for the blocking rules of the TaskUsecase.UpdateTask method.
+func (suite *TaskUsecaseSuite) Test_UpdateTask_Blocked() {
+	// A testcase where a task is completed while one of its blockers is open.
+	suite.Run("UpdateTask_CompleteBlocked", func() {
+		claims := mocks.GetClaims()
+		blocker := newTask("Design", claims, 1)
+		task := newTask("Build", claims, 2)
+		task.BlockedBy = []primitive.ObjectID{blocker.ID}
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Twice()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{IDs: task.BlockedBy, Statuses: domain.OpenTaskStatuses}).Return([]domain.Task{blocker}, nil).Once()
+
+		result, err := suite.usecase.UpdateTask(context.Background(), task.ID, &domain.UpdateTaskData{Status: "Completed"}, claims)
+		suite.Nil(result)
+		suite.Equal(domain.CodeTaskBlocked, err.Code)
+		suite.Equal(`The task is blocked by open tasks: "Design"`, err.Message)
+	})
+}
+
+// A test for the TaskUsecase.GetTaskPlan method.
+func (suite *TaskUsecaseSuite) Test_GetTaskPlan() {
+	// A testcase where the tasks are ordered after their blockers, and the critical path is the longest chain.
+	suite.Run("GetTaskPlan_Success", func() {
+		claims := mocks.GetClaims()
+		workspace := mocks.GetWorkspace()
+		design := newTask("Design", claims, 5)
+		build := newTask("Build", claims, 1)
+		build.BlockedBy = []primitive.ObjectID{design.ID}
+		docs := newTask("Docs", claims, 2)
+		release := newTask("Release", claims, 3)
+		release.BlockedBy = []primitive.ObjectID{build.ID, docs.ID}
+		done := newTask("Kickoff", claims, 0)
+		done.Status = "Completed"
+		design.BlockedBy = []primitive.ObjectID{done.ID}
+
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return([]domain.Task{release, build, done, docs, design}, nil).Once()
+
+		plan, err := suite.usecase.GetTaskPlan(context.Background(), &domain.TaskQuery{WorkspaceID: workspace.ID.Hex()}, claims)
+		suite.Nil(err)
+		suite.Equal([]domain.Task{docs, design, build, release}, plan.Order)
+		suite.Equal([]domain.Task{design, build, release}, plan.CriticalPath)
+	})
+
+	// A testcase where the stored dependencies form a cycle.
+	suite.Run("GetTaskPlan_Cycle", func() {
+		claims := mocks.GetClaims()
+		workspace := mocks.GetWorkspace()
+		first := newTask("First", claims, 1)
+		second := newTask("Second", claims, 2)
+		second.BlockedBy = []primitive.ObjectID{first.ID}
+		first.BlockedBy = []primitive.ObjectID{second.ID}
+
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return([]domain.Task{first, second}, nil).Once()
+
+		plan, err := suite.usecase.GetTaskPlan(context.Background(), &domain.TaskQuery{WorkspaceID: workspace.ID.Hex()}, claims)
+		suite.Nil(plan)
+		suite.Equal(domain.CodeDependencyCycle, err.Code)
+	})
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskEventUsecase is a decorator around a domain.TaskUsecase that publishes the changes made through it, so that
+// they reach the subscribers of every API, whichever API made them.
+type TaskEventUsecase struct {
+	domain.TaskUsecase
+	taskRepo domain.TaskRepository
+	events   domain.TaskEventBroker
+}
+
+// A constructor that creates a new instance of TaskEventUsecase.
+func NewTaskEventUsecase(usecase domain.TaskUsecase, taskRepo domain.TaskRepository, events domain.TaskEventBroker) *TaskEventUsecase {
+	return &TaskEventUsecase{
+		TaskUsecase: usecase,
+		taskRepo:    taskRepo,
+		events:      events,
+	}
+}
+
+// A method that creates a task and publishes its creation.
+func (tu *TaskEventUsecase) CreateTask(ctx context.Context, taskData *domain.CreateTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
+	taskView, _err := tu.TaskUsecase.CreateTask(ctx, taskData, claims)
+	if _err == nil {
+		tu.publishStored(ctx, domain.TaskEventCreated, taskView.ID)
+	}
+
+	return taskView, _err
+}
+
+// A method that replaces a task and publishes its update.
+func (tu *TaskEventUsecase) ReplaceTask(ctx context.Context, objectID primitive.ObjectID, taskData *domain.ReplaceTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
+	taskView, _err := tu.TaskUsecase.ReplaceTask(ctx, objectID, taskData, claims)
+	if _err == nil {
+		tu.publishStored(ctx, domain.TaskEventUpdated, taskView.ID)
+	}
+
+	return taskView, _err
+}
+
+// A method that updates a task and publishes its update.
+func (tu *TaskEventUsecase) UpdateTask(ctx context.Context, objectID primitive.ObjectID, taskData *domain.UpdateTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
+	taskView, _err := tu.TaskUsecase.UpdateTask(ctx, objectID, taskData, claims)
+	if _err == nil {
+		tu.publishStored(ctx, domain.TaskEventUpdated, taskView.ID)
+	}
+
+	return taskView, _err
+}
+
+// A method that moves a task on the board and publishes its update.
+func (tu *TaskEventUsecase) MoveTask(ctx context.Context, objectID primitive.ObjectID, moveData *domain.MoveTaskData, claims *domain.Claims) (*domain.Task, *domain.Error) {
+	task, _err := tu.TaskUsecase.MoveTask(ctx, objectID, moveData, claims)
+	if _err == nil {
+		tu.publishStored(ctx, domain.TaskEventUpdated, task.ID.Hex())
+	}
+
+	return task, _err
+}
+
+// A method that attaches a file to a task and publishes the update of the task.
+func (tu *TaskEventUsecase) AddAttachment(ctx context.Context, objectID primitive.ObjectID, upload *domain.AttachmentUpload, claims *domain.Claims) (*domain.Attachment, *domain.Error) {
+	attachment, _err := tu.TaskUsecase.AddAttachment(ctx, objectID, upload, claims)
+	if _err == nil {
+		tu.publishStored(ctx, domain.TaskEventUpdated, objectID.Hex())
+	}
+
+	return attachment, _err
+}
+
+// A method that removes a file attached to a task and publishes the update of the task.
+func (tu *TaskEventUsecase) DeleteAttachment(ctx context.Context, objectID primitive.ObjectID, attachmentID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	_err := tu.TaskUsecase.DeleteAttachment(ctx, objectID, attachmentID, claims)
+	if _err == nil {
+		tu.publishStored(ctx, domain.TaskEventUpdated, objectID.Hex())
+	}
+
+	return _err
+}
+
+// A method that deletes a task and publishes its deletion with the task as it was before.
+func (tu *TaskEventUsecase) DeleteTask(ctx context.Context, objectID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	// Read the task first, so that the subscribers can tell whether they were allowed to see it.
+	task, err := tu.taskRepo.GetTaskByID(ctx, objectID)
+
+	_err := tu.TaskUsecase.DeleteTask(ctx, objectID, claims)
+	if _err != nil {
+		return _err
+	}
+
+	if err != nil {
+		infrastructure.Logger(ctx).Error("reading a deleted task for its event", "task_id", objectID.Hex(), "error", err)
+		return nil
+	}
+
+	tu.events.Publish(domain.TaskEvent{Type: domain.TaskEventDeleted, Task: *task})
+	return nil
+}
+
+// A helper method that reads the task as stored and publishes the change. The change is already made, so a failed
+// read is only logged.
+func (tu *TaskEventUsecase) publishStored(ctx context.Context, eventType string, id string) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err == nil {
+		var task *domain.Task
+		task, err = tu.taskRepo.GetTaskByID(ctx, objectID)
+		if err == nil {
+			tu.events.Publish(domain.TaskEvent{Type: eventType, Task: *task})
+			return
+		}
+	}
+
+	infrastructure.Logger(ctx).Error("reading a changed task for its event", "task_id", id, "error", err)
+}
+
+// A function that subscribes to the changes of the tasks the user can view, optionally limited to a workspace.
+// Each change is checked with the same authorization as reading the task. The channel is closed when the context is
+// done or the broker is closed.
+func WatchTasks(ctx context.Context, tasks domain.TaskUsecase, events domain.TaskEventBroker, workspaceID primitive.ObjectID, claims *domain.Claims) <-chan domain.TaskEvent {
+	subscription := events.Subscribe(ctx)
+	changes := make(chan domain.TaskEvent)
+	go func() {
+		defer close(changes)
+
+		for event := range subscription {
+			if !workspaceID.IsZero() && event.Task.WorkspaceID != workspaceID {
+				continue
+			}
+
+			if tasks.CanViewTask(ctx, &event.Task, claims) != nil {
+				continue
+			}
+
+			select {
+			case changes <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes
+}
@@ -0,0 +1,119 @@
+package usecase_test
+
+import (
+	"context"
+	"net/http"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"task_manager/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A suite for the TaskEventUsecase.
+type TaskEventUsecaseSuite struct {
+	suite.Suite
+	tasks    *mocks.TaskUsecase
+	taskRepo *mocks.TaskRepository
+	events   *mocks.TaskEventBroker
+	usecase  *usecase.TaskEventUsecase
+}
+
+// A method that sets up each testcase.
+func (suite *TaskEventUsecaseSuite) SetupSubTest() {
+	suite.tasks = new(mocks.TaskUsecase)
+	suite.taskRepo = new(mocks.TaskRepository)
+	suite.events = new(mocks.TaskEventBroker)
+	suite.usecase = usecase.NewTaskEventUsecase(suite.tasks, suite.taskRepo, suite.events)
+}
+
+// A method that tears down each testcase.
+func (suite *TaskEventUsecaseSuite) TearDownSubTest() {
+	suite.tasks.AssertExpectations(suite.T())
+	suite.taskRepo.AssertExpectations(suite.T())
+	suite.events.AssertExpectations(suite.T())
+}
+
+// A test for the TaskEventUsecase.UpdateTask method.
+func (suite *TaskEventUsecaseSuite) Test_UpdateTask() {
+	// A testcase where the task is updated and published as stored.
+	suite.Run("UpdateTask_Published", func() {
+		task := mocks.GetNewTask()
+		taskData := mocks.GetUpdateTaskData()
+		claims := mocks.GetClaims()
+
+		suite.tasks.On("UpdateTask", mock.Anything, task.ID, taskData, claims).Return(mocks.GetTaskView(task), nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(task, nil).Once()
+		suite.events.On("Publish", domain.TaskEvent{Type: domain.TaskEventUpdated, Task: *task}).Once()
+
+		_, err := suite.usecase.UpdateTask(context.Background(), task.ID, taskData, claims)
+		suite.Nil(err)
+	})
+
+	// A testcase where the update is refused, so nothing is published.
+	suite.Run("UpdateTask_Refused", func() {
+		task := mocks.GetNewTask()
+		taskData := mocks.GetUpdateTaskData()
+		claims := mocks.GetClaims()
+		refused := &domain.Error{StatusCode: http.StatusForbidden, Message: "Forbidden"}
+
+		suite.tasks.On("UpdateTask", mock.Anything, task.ID, taskData, claims).Return(nil, refused).Once()
+
+		_, err := suite.usecase.UpdateTask(context.Background(), task.ID, taskData, claims)
+		suite.Equal(refused, err)
+	})
+}
+
+// A test for the TaskEventUsecase.DeleteTask method.
+func (suite *TaskEventUsecaseSuite) Test_DeleteTask() {
+	// A testcase where the task is deleted and published as it was before the deletion.
+	suite.Run("DeleteTask_Published", func() {
+		task := mocks.GetNewTask()
+		claims := mocks.GetClaims()
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(task, nil).Once()
+		suite.tasks.On("DeleteTask", mock.Anything, task.ID, claims).Return(nil).Once()
+		suite.events.On("Publish", domain.TaskEvent{Type: domain.TaskEventDeleted, Task: *task}).Once()
+
+		suite.Nil(suite.usecase.DeleteTask(context.Background(), task.ID, claims))
+	})
+}
+
+// A test for the WatchTasks function.
+func (suite *TaskEventUsecaseSuite) Test_WatchTasks() {
+	// A testcase where only the changes of visible tasks in the workspace are delivered.
+	suite.Run("WatchTasks_Filtered", func() {
+		claims := mocks.GetClaims()
+		workspaceID := primitive.NewObjectID()
+		hidden := mocks.GetNewTask()
+		hidden.WorkspaceID = workspaceID
+		visible := mocks.GetNewTask()
+		visible.WorkspaceID = workspaceID
+		elsewhere := mocks.GetNewTask()
+		elsewhere.WorkspaceID = primitive.NewObjectID()
+
+		subscription := make(chan domain.TaskEvent, 3)
+		subscription <- domain.TaskEvent{Type: domain.TaskEventCreated, Task: *elsewhere}
+		subscription <- domain.TaskEvent{Type: domain.TaskEventCreated, Task: *hidden}
+		subscription <- domain.TaskEvent{Type: domain.TaskEventUpdated, Task: *visible}
+		close(subscription)
+
+		suite.events.On("Subscribe", mock.Anything).Return((<-chan domain.TaskEvent)(subscription)).Once()
+		suite.tasks.On("CanViewTask", mock.Anything, hidden, claims).Return(&domain.Error{StatusCode: http.StatusNotFound}).Once()
+		suite.tasks.On("CanViewTask", mock.Anything, visible, claims).Return(nil).Once()
+
+		var received []domain.TaskEvent
+		for event := range usecase.WatchTasks(context.Background(), suite.tasks, suite.events, workspaceID, claims) {
+			received = append(received, event)
+		}
+		suite.Equal([]domain.TaskEvent{{Type: domain.TaskEventUpdated, Task: *visible}}, received)
+	})
+}
+
+// A method that runs the TestSuite.
+func TestTaskEventUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(TaskEventUsecaseSuite))
+}
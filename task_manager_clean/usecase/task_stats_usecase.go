@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"task_manager/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A method that returns the statistics of the tasks the user is allowed to view, over the days of the query.
+// Without a workspace in the query, the tasks of all workspaces the user is a member of are counted, together with
+// the tasks that were created before workspaces existed.
+func (tu *TaskUsecase) GetTaskStats(ctx context.Context, query *domain.TaskStatsQuery, claims *domain.Claims) (*domain.TaskStats, *domain.Error) {
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return nil, invalidField("to", "must not be before from")
+	}
+
+	// Check if the user can view tasks, and whether only their own.
+	decision, _err := tu.checkAccess(ctx, claims, domain.ActionTaskRead, claims.ID, nil, "view", "")
+	if _err != nil {
+		return nil, _err
+	}
+
+	filter, workspaceRoles, _err := tu.workspaceFilter(ctx, query.WorkspaceID, query.ProjectID, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	// Only count the user's own tasks if that is all they can view, except in the workspaces they own. The statistics
+	// are computed by the repository, so the tasks cannot be left out afterwards as in GetTasks.
+	if decision.Grant == domain.PermissionTaskReadOwn {
+		filter.VisibleTo = claims.ID
+		filter.OwnedWorkspaceIDs = []primitive.ObjectID{}
+		for _, workspaceID := range filter.WorkspaceIDs {
+			if workspaceRoles[workspaceID] == domain.WorkspaceRoleOwner {
+				filter.OwnedWorkspaceIDs = append(filter.OwnedWorkspaceIDs, workspaceID)
+			}
+		}
+	}
+
+	period := &domain.TaskStatsPeriod{From: query.From, Now: time.Now()}
+	if !query.To.IsZero() {
+		period.Before = query.To.AddDate(0, 0, 1)
+	}
+
+	groups, err := domain.GetTaskStatsGroups(ctx, tu.taskRepo, filter, period)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	stats := domain.NewTaskStats(groups)
+	if !query.From.IsZero() {
+		stats.From = &query.From
+	}
+	if !query.To.IsZero() {
+		stats.To = &query.To
+	}
+
+	return stats, nil
+}
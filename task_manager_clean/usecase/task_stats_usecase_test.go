@@ -0,0 +1,173 @@
+package usecase_test
+
+import (
+	"context"
+	"net/http"
+	"task_manager/domain"
+	"task_manager/mocks"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A test for the TaskUsecase.GetTaskStats method.
+func (suite *TaskUsecaseSuite) Test_GetTaskStats() {
+	// A testcase where the statistics of a workspace are computed from its tasks, over two weeks.
+	suite.Run("GetTaskStats_Success", func() {
+		claims := mocks.GetClaims2()
+		workspace := mocks.GetWorkspace()
+		user1, user2 := mocks.GetPrimitiveID1(), mocks.GetPrimitiveID2()
+		from := time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 9, 15, 0, 0, 0, 0, time.UTC)
+		day := 24 * time.Hour
+		past := time.Date(2024, 9, 10, 0, 0, 0, 0, time.UTC)
+		future := time.Now().AddDate(1, 0, 0)
+		// The tasks are created at the given time, and completed after the given duration if it is not zero.
+		created := []struct {
+			userID         primitive.ObjectID
+			createdAt      time.Time
+			status         string
+			dueDate        time.Time
+			completedAfter time.Duration
+		}{
+			{user1, from.Add(10 * time.Hour), "Completed", future, 2 * day},
+			{user1, from.Add(day), "Pending", past, 0},
+			{user2, from.Add(7 * day), "Completed", future, day},
+			{user2, from.Add(-13 * day), "Completed", past, 22 * day},
+			{user2, from.Add(3 * day), "In Progress", future, 0},
+			{user1, to.Add(5 * day), "Pending", past, 0},
+		}
+		tasks := make([]domain.Task, 0, len(created))
+		for _, c := range created {
+			task := newTask("Task", &domain.Claims{ID: c.userID}, 0)
+			task.ID = primitive.NewObjectIDFromTimestamp(c.createdAt)
+			task.Status = c.status
+			task.DueDate = c.dueDate
+			if c.completedAfter > 0 {
+				completedAt := c.createdAt.Add(c.completedAfter)
+				task.CompletedAt = &completedAt
+			}
+
+			tasks = append(tasks, task)
+		}
+
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{workspace.ID}}).Return(tasks, nil).Once()
+
+		query := &domain.TaskStatsQuery{WorkspaceID: workspace.ID.Hex(), From: from, To: to}
+		stats, err := suite.usecase.GetTaskStats(context.Background(), query, claims)
+		suite.Nil(err)
+		suite.Equal(&domain.TaskStats{
+			From: &from,
+			To:   &to,
+			TaskCounts: domain.TaskCounts{
+				Total:                    4,
+				ByStatus:                 map[string]int{"Pending": 1, "In Progress": 1, "Completed": 2},
+				Overdue:                  1,
+				Completed:                3,
+				AverageCompletionSeconds: 720000,
+			},
+			CompletedPerWeek: []domain.WeeklyCompletions{{Week: "2024-09-02", Count: 1}, {Week: "2024-09-09", Count: 2}},
+			Users: []domain.UserTaskStats{
+				{UserID: user1, TaskCounts: domain.TaskCounts{
+					Total:                    2,
+					ByStatus:                 map[string]int{"Pending": 1, "In Progress": 0, "Completed": 1},
+					Overdue:                  1,
+					Completed:                1,
+					AverageCompletionSeconds: 172800,
+				}},
+				{UserID: user2, TaskCounts: domain.TaskCounts{
+					Total:                    2,
+					ByStatus:                 map[string]int{"Pending": 0, "In Progress": 1, "Completed": 1},
+					Completed:                2,
+					AverageCompletionSeconds: 993600,
+				}},
+			},
+		}, stats)
+	})
+
+	// A testcase where the role of the user only grants reading their own tasks, except in the workspaces they own.
+	suite.Run("GetTaskStats_OwnOnly", func() {
+		claims := mocks.GetClaims()
+		claims.Role = "reader"
+		suite.roleRepo.On("GetRoleByName", mock.Anything, "reader").Return(&domain.Role{
+			Name:        "reader",
+			Level:       5,
+			Permissions: []string{domain.PermissionTaskReadOwn},
+		}, nil).Once()
+
+		owned := mocks.GetWorkspace()
+		owned.Members[0].Role = domain.WorkspaceRoleOwner
+		joined := mocks.GetWorkspace()
+		joined.ID = primitive.NewObjectID()
+		filter := &domain.TaskFilter{
+			WorkspaceIDs:      []primitive.ObjectID{owned.ID, joined.ID},
+			IncludeUnassigned: true,
+			VisibleTo:         claims.ID,
+			OwnedWorkspaceIDs: []primitive.ObjectID{owned.ID},
+		}
+
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, claims.ID).Return([]domain.Workspace{*owned, *joined}, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, filter).Return([]domain.Task{}, nil).Once()
+
+		stats, err := suite.usecase.GetTaskStats(context.Background(), &domain.TaskStatsQuery{}, claims)
+		suite.Nil(err)
+		suite.Equal(0, stats.Total)
+		suite.Equal(map[string]int{"Pending": 0, "In Progress": 0, "Completed": 0}, stats.ByStatus)
+		suite.Empty(stats.CompletedPerWeek)
+		suite.Empty(stats.Users)
+	})
+
+	// A testcase where the range ends before it starts.
+	suite.Run("GetTaskStats_InvalidRange", func() {
+		query := &domain.TaskStatsQuery{
+			From: time.Date(2024, 9, 15, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+		}
+
+		stats, err := suite.usecase.GetTaskStats(context.Background(), query, mocks.GetClaims())
+		suite.Nil(stats)
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+		suite.Equal(domain.CodeValidationFailed, err.Code)
+		suite.Equal("to", err.Fields[0].Field)
+	})
+}
+
+// A test for the completion time of the tasks.
+func (suite *TaskUsecaseSuite) Test_CompletedAt() {
+	// A testcase where an update completes a task.
+	suite.Run("CompletedAt_Update", func() {
+		claims := mocks.GetClaims()
+		task := newTask("Task", claims, 1)
+		completed := mock.MatchedBy(func(data bson.M) bool {
+			completedAt, ok := data["completed_at"].(*time.Time)
+			return ok && completedAt != nil && time.Since(*completedAt) < time.Minute
+		})
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Twice()
+		suite.taskRepo.On("UpdateTask", mock.Anything, task.ID, completed).Return(nil).Once()
+		suite.timeEntryRepo.On("GetTimeEntries", mock.Anything, mockTimeEntryFilter).Return([]domain.TimeEntry{}, nil).Once()
+
+		_, err := suite.usecase.UpdateTask(context.Background(), task.ID, &domain.UpdateTaskData{Status: "Completed"}, claims)
+		suite.Nil(err)
+	})
+
+	// A testcase where a completed task is moved back to another column, which reopens it.
+	suite.Run("CompletedAt_Reopen", func() {
+		claims := mocks.GetClaims()
+		task := newTask("Task", claims, 1)
+		task.Status = "Completed"
+		completedAt := time.Now().AddDate(0, 0, -1)
+		task.CompletedAt = &completedAt
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(&task, nil).Twice()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return([]domain.Task{}, nil).Once()
+		suite.taskRepo.On("UpdateTask", mock.Anything, task.ID, bson.M{"status": "Pending", "rank": "i", "completed_at": (*time.Time)(nil)}).Return(nil).Once()
+
+		result, err := suite.usecase.MoveTask(context.Background(), task.ID, &domain.MoveTaskData{Status: "Pending"}, claims)
+		suite.Nil(err)
+		suite.Nil(result.CompletedAt)
+	})
+}
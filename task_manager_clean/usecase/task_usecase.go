@@ -1,9 +1,15 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"slices"
+	"sort"
+	"strings"
 	"task_manager/domain"
+	"task_manager/infrastructure"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -12,20 +18,51 @@ import (
 
 // A struct that defines the services for tasks.
 type TaskUsecase struct {
-	taskRepo domain.TaskRepository
+	taskRepo      domain.TaskRepository
+	projectRepo   domain.ProjectRepository
+	workspaceRepo domain.WorkspaceRepository
+	timeEntryRepo domain.TimeEntryRepository
+	transactor    domain.Transactor
+	blobs         domain.BlobStore
+	limits        domain.AttachmentLimits
+	authorizer    domain.Authorizer
 }
 
 // A constructor that creates a new instance of TaskUsecase.
-func NewTaskUsecase(taskRepo domain.TaskRepository) *TaskUsecase {
+func NewTaskUsecase(taskRepo domain.TaskRepository, projectRepo domain.ProjectRepository, workspaceRepo domain.WorkspaceRepository, timeEntryRepo domain.TimeEntryRepository, transactor domain.Transactor, blobs domain.BlobStore, limits domain.AttachmentLimits, authorizer domain.Authorizer) *TaskUsecase {
 	return &TaskUsecase{
-		taskRepo: taskRepo,
+		taskRepo:      taskRepo,
+		projectRepo:   projectRepo,
+		workspaceRepo: workspaceRepo,
+		timeEntryRepo: timeEntryRepo,
+		transactor:    transactor,
+		blobs:         blobs,
+		limits:        limits,
+		authorizer:    authorizer,
 	}
 }
 
-// A method that returns all tasks.
-func (tu *TaskUsecase) GetTasks() ([]domain.Task, *domain.Error) {
-	// Query the database for all tasks.
-	tasks, err := tu.taskRepo.GetAllTasks()
+// A method that returns the tasks the user is allowed to view.
+// Without a workspace in the query, the tasks of all workspaces the user is a member of are returned, together with
+// the tasks that were created before workspaces existed.
+func (tu *TaskUsecase) GetTasks(ctx context.Context, query *domain.TaskQuery, claims *domain.Claims) ([]domain.Task, *domain.Error) {
+	if query.Sort != "" && !slices.Contains(domain.TaskSorts, query.Sort) {
+		return nil, invalidField("sort", "must be one of "+strings.Join(domain.TaskSorts, ", "))
+	}
+
+	// Check if the user can view tasks, and whether only their own.
+	decision, _err := tu.checkAccess(ctx, claims, domain.ActionTaskRead, claims.ID, nil, "view", "")
+	if _err != nil {
+		return nil, _err
+	}
+
+	filter, workspaceRoles, _err := tu.workspaceFilter(ctx, query.WorkspaceID, query.ProjectID, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	// Query the database for the tasks.
+	tasks, err := tu.taskRepo.GetTasks(ctx, filter)
 
 	if err != nil {
 		return nil, &domain.Error{
@@ -35,34 +72,228 @@ func (tu *TaskUsecase) GetTasks() ([]domain.Task, *domain.Error) {
 		}
 	}
 
+	// Only keep the user's own tasks if that is all they can view, except in the workspaces they own.
+	if decision.Grant == domain.PermissionTaskReadOwn {
+		ownTasks := []domain.Task{}
+		for _, task := range tasks {
+			if task.UserID == claims.ID || workspaceRoles[task.WorkspaceID] == domain.WorkspaceRoleOwner {
+				ownTasks = append(ownTasks, task)
+			}
+		}
+
+		tasks = ownTasks
+	}
+
+	sortTasks(tasks, query.Sort, time.Now())
 	return tasks, nil
 }
 
+// A helper method that returns the filter of the tasks of the workspace with the given ID, or of every workspace of
+// the user and of the tasks outside of workspaces, with the role of the user in each of the workspaces.
+func (tu *TaskUsecase) workspaceFilter(ctx context.Context, workspaceID string, projectID string, claims *domain.Claims) (*domain.TaskFilter, map[primitive.ObjectID]string, *domain.Error) {
+	// Get the workspaces to list the tasks of.
+	var workspaces []domain.Workspace
+	filter := &domain.TaskFilter{}
+	if workspaceID != "" {
+		workspace, _err := tu.getMemberWorkspace(ctx, workspaceID, claims)
+		if _err != nil {
+			return nil, nil, _err
+		}
+
+		workspaces = []domain.Workspace{*workspace}
+	} else {
+		var err error
+		workspaces, err = tu.workspaceRepo.GetWorkspacesByUserID(ctx, claims.ID)
+		if err != nil {
+			return nil, nil, &domain.Error{
+				Err:        err,
+				StatusCode: http.StatusInternalServerError,
+				Message:    "Internal server error",
+			}
+		}
+
+		filter.IncludeUnassigned = true
+	}
+
+	workspaceRoles := map[primitive.ObjectID]string{}
+	filter.WorkspaceIDs = []primitive.ObjectID{}
+	for _, workspace := range workspaces {
+		filter.WorkspaceIDs = append(filter.WorkspaceIDs, workspace.ID)
+		workspaceRoles[workspace.ID] = workspace.MemberRole(claims.ID)
+	}
+
+	if projectID != "" {
+		id, err := primitive.ObjectIDFromHex(projectID)
+		if err != nil {
+			return nil, nil, &domain.Error{
+				Err:        err,
+				StatusCode: http.StatusBadRequest,
+				Code:       domain.CodeInvalidID,
+				Message:    "Invalid project ID",
+			}
+		}
+
+		filter.ProjectID = id
+	}
+
+	return filter, workspaceRoles, nil
+}
+
 // A method that returns a task with the given ID.
-func (tu *TaskUsecase) GetTaskByID(objectID primitive.ObjectID) (*domain.Task, *domain.Error) {
-	task, err := tu.taskRepo.GetTaskByID(objectID)
+func (tu *TaskUsecase) GetTaskByID(ctx context.Context, objectID primitive.ObjectID, claims *domain.Claims) (*domain.Task, *domain.Error) {
+	task, workspace, _err := tu.getTask(ctx, objectID)
+	if _err != nil {
+		return nil, _err
+	}
+
+	// Check if the user can view the task.
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskRead, task.UserID, workspace, "view", "trying to view another user's task")
+	if _err != nil {
+		return nil, _err
+	}
+
+	return task, nil
+}
+
+// A method that checks if the user can view a task that was not read through GetTaskByID, such as the task of a
+// change event. The task must still belong to a workspace that exists.
+func (tu *TaskUsecase) CanViewTask(ctx context.Context, task *domain.Task, claims *domain.Claims) *domain.Error {
+	var workspace *domain.Workspace
+	if !task.WorkspaceID.IsZero() {
+		var err error
+		workspace, err = tu.workspaceRepo.GetWorkspaceByID(ctx, task.WorkspaceID)
+		if err != nil {
+			return notFoundOrInternal(err, domain.CodeTaskNotFound, "Task not found")
+		}
+	}
+
+	_, _err := tu.checkAccess(ctx, claims, domain.ActionTaskRead, task.UserID, workspace, "view", "trying to view another user's task")
+	return _err
+}
+
+// A helper method that returns a task with the given ID and its workspace, if it belongs to one.
+func (tu *TaskUsecase) getTask(ctx context.Context, objectID primitive.ObjectID) (*domain.Task, *domain.Workspace, *domain.Error) {
+	task, err := tu.taskRepo.GetTaskByID(ctx, objectID)
 	if err != nil {
 		// Check if the task is not found.
 		if err == mongo.ErrNoDocuments {
-			return nil, &domain.Error{
+			return nil, nil, &domain.Error{
 				Err:        err,
 				StatusCode: http.StatusNotFound,
+				Code:       domain.CodeTaskNotFound,
 				Message:    "Task not found",
 			}
 		}
 
-		return nil, &domain.Error{
+		return nil, nil, &domain.Error{
 			Err:        err,
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal server error",
 		}
 	}
 
-	return task, nil
+	// Tasks created before workspaces existed do not belong to one.
+	if task.WorkspaceID.IsZero() {
+		return task, nil, nil
+	}
+
+	workspace, err := tu.workspaceRepo.GetWorkspaceByID(ctx, task.WorkspaceID)
+	if err != nil {
+		return nil, nil, notFoundOrInternal(err, domain.CodeTaskNotFound, "Task not found")
+	}
+
+	return task, workspace, nil
+}
+
+// A helper method that runs fn in a transaction that first locks the workspace with the given ID, so that what fn
+// checks about the other tasks of the workspace still holds when it commits. The tasks created before workspaces
+// existed have no workspace to lock. The errors of fn that are a domain.Error are returned as they are.
+func (tu *TaskUsecase) withWorkspaceLock(ctx context.Context, workspaceID primitive.ObjectID, fn func(ctx context.Context) error) *domain.Error {
+	err := tu.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if !workspaceID.IsZero() {
+			err := tu.workspaceRepo.LockWorkspace(ctx, workspaceID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return fn(ctx)
+	})
+	if err != nil {
+		var _err *domain.Error
+		if errors.As(err, &_err) {
+			return _err
+		}
+
+		return internalError(err)
+	}
+
+	return nil
+}
+
+// A helper method that returns the workspace with the given ID, if the user is a member of it.
+func (tu *TaskUsecase) getMemberWorkspace(ctx context.Context, workspaceID string, claims *domain.Claims) (*domain.Workspace, *domain.Error) {
+	id, err := primitive.ObjectIDFromHex(workspaceID)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeInvalidID,
+			Message:    "Invalid workspace ID",
+		}
+	}
+
+	workspace, err := tu.workspaceRepo.GetWorkspaceByID(ctx, id)
+	if err != nil {
+		return nil, notFoundOrInternal(err, domain.CodeWorkspaceNotFound, "Workspace not found")
+	}
+
+	// Workspaces of other teams are reported as missing.
+	if workspace.MemberRole(claims.ID) == "" {
+		return nil, &domain.Error{
+			Err:        errors.New("not a member of the workspace"),
+			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeWorkspaceNotFound,
+			Message:    "Workspace not found",
+		}
+	}
+
+	return workspace, nil
 }
 
 // A method that creates a new task.
-func (tu *TaskUsecase) CreateTask(taskData *domain.CreateTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
+func (tu *TaskUsecase) CreateTask(ctx context.Context, taskData *domain.CreateTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
+	priority, _err := taskPriority(taskData.Priority)
+	if _err != nil {
+		return nil, _err
+	}
+
+	// Get the project of the task. Projects in workspaces of other teams are reported as missing.
+	project, err := tu.projectRepo.GetProjectByID(ctx, taskData.ProjectID)
+	if err != nil {
+		return nil, notFoundOrInternal(err, domain.CodeProjectNotFound, "Project not found")
+	}
+
+	workspace, err := tu.workspaceRepo.GetWorkspaceByID(ctx, project.WorkspaceID)
+	if err != nil {
+		return nil, notFoundOrInternal(err, domain.CodeProjectNotFound, "Project not found")
+	}
+
+	if workspace.MemberRole(claims.ID) == "" {
+		return nil, &domain.Error{
+			Err:        errors.New("not a member of the workspace"),
+			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeProjectNotFound,
+			Message:    "Project not found",
+		}
+	}
+
+	// Check if the user can create tasks.
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskCreate, primitive.NilObjectID, workspace, "create", "")
+	if _err != nil {
+		return nil, _err
+	}
+
 	// Create the task object.
 	task := &domain.Task{
 		ID:          primitive.NewObjectID(),
@@ -70,17 +301,29 @@ func (tu *TaskUsecase) CreateTask(taskData *domain.CreateTaskData, claims *domai
 		Description: taskData.Description,
 		DueDate:     taskData.DueDate,
 		Status:      taskData.Status,
+		Priority:    priority,
 		UserID:      claims.ID,
+		WorkspaceID: project.WorkspaceID,
+		ProjectID:   project.ID,
 	}
+	task.CompletedAt, _ = completion("", task.Status)
 
-	// Insert the task into the database.
-	err := tu.taskRepo.AddTask(task)
-	if err != nil {
-		return nil, &domain.Error{
-			Err:        err,
-			StatusCode: http.StatusInternalServerError,
-			Message:    "Internal server error",
+	// Insert the task into the database, if the column of its status has room.
+	lockID := primitive.NilObjectID
+	if workspace.WIPLimits[task.Status] > 0 {
+		lockID = workspace.ID
+	}
+
+	_err = tu.withWorkspaceLock(ctx, lockID, func(ctx context.Context) error {
+		_err := tu.checkWIPLimit(ctx, workspace, task.Status)
+		if _err != nil {
+			return _err
 		}
+
+		return tu.taskRepo.AddTask(ctx, task)
+	})
+	if _err != nil {
+		return nil, _err
 	}
 
 	// Create the task view object.
@@ -90,26 +333,32 @@ func (tu *TaskUsecase) CreateTask(taskData *domain.CreateTaskData, claims *domai
 		Description: task.Description,
 		DueDate:     task.DueDate,
 		Status:      task.Status,
+		Priority:    task.Priority,
+		Urgency:     task.Urgency(time.Now()),
+		WorkspaceID: hexID(task.WorkspaceID),
+		ProjectID:   hexID(task.ProjectID),
 	}
 
 	return taskView, nil
 }
 
 // A method that fully replaces a task with the given ID with the new task data.
-func (tu *TaskUsecase) ReplaceTask(objectID primitive.ObjectID, taskData *domain.ReplaceTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
+func (tu *TaskUsecase) ReplaceTask(ctx context.Context, objectID primitive.ObjectID, taskData *domain.ReplaceTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
+	priority, _err := taskPriority(taskData.Priority)
+	if _err != nil {
+		return nil, _err
+	}
+
 	// Check if the task exists.
-	foundTask, _err := tu.GetTaskByID(objectID)
+	foundTask, workspace, _err := tu.getTask(ctx, objectID)
 	if _err != nil {
 		return nil, _err
 	}
 
-	// Check if the user is an admin or the owner of the task.
-	if claims.Role == "user" && claims.ID != foundTask.UserID {
-		return nil, &domain.Error{
-			Err:        errors.New("trying to replace another user's task"),
-			StatusCode: http.StatusForbidden,
-			Message:    "A User can only update their own task",
-		}
+	// Check if the user can update the task.
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskUpdate, foundTask.UserID, workspace, "update", "trying to replace another user's task")
+	if _err != nil {
+		return nil, _err
 	}
 
 	// Create the new task object.
@@ -119,17 +368,27 @@ func (tu *TaskUsecase) ReplaceTask(objectID primitive.ObjectID, taskData *domain
 		Description: taskData.Description,
 		DueDate:     taskData.DueDate,
 		Status:      taskData.Status,
+		Priority:    priority,
 		UserID:      claims.ID,
+		WorkspaceID: foundTask.WorkspaceID,
+		ProjectID:   foundTask.ProjectID,
 	}
 
-	// Replace the task in the database.
-	err := tu.taskRepo.ReplaceTask(objectID, task)
-	if err != nil {
-		return nil, &domain.Error{
-			Err:        err,
-			StatusCode: http.StatusInternalServerError,
-			Message:    "Internal server error",
+	// Replace the task in the database, if the change of its status is allowed. The fields that are not replaced are
+	// kept from the task read with the workspace locked.
+	_err = tu.changeTask(ctx, claims, workspace, foundTask, task.Status, func(ctx context.Context, current *domain.Task) error {
+		task.Rank = current.Rank
+		task.BlockedBy = current.BlockedBy
+		task.Attachments = current.Attachments
+		task.CompletedAt = current.CompletedAt
+		if completedAt, changed := completion(current.Status, task.Status); changed {
+			task.CompletedAt = completedAt
 		}
+
+		return tu.taskRepo.ReplaceTask(ctx, objectID, task)
+	})
+	if _err != nil {
+		return nil, _err
 	}
 
 	// Create the task view object.
@@ -139,26 +398,38 @@ func (tu *TaskUsecase) ReplaceTask(objectID primitive.ObjectID, taskData *domain
 		Description: taskData.Description,
 		DueDate:     taskData.DueDate,
 		Status:      taskData.Status,
+		Priority:    task.Priority,
+		Urgency:     task.Urgency(time.Now()),
+		WorkspaceID: hexID(foundTask.WorkspaceID),
+		ProjectID:   hexID(foundTask.ProjectID),
 	}
+	taskView.TimeSpentSeconds = tu.timeSpent(ctx, objectID)
 
 	return taskView, nil
 }
 
 // A method that partially updates a task with the given ID with the only the provided task data.
-func (tu *TaskUsecase) UpdateTask(objectID primitive.ObjectID, taskData *domain.UpdateTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
+func (tu *TaskUsecase) UpdateTask(ctx context.Context, objectID primitive.ObjectID, taskData *domain.UpdateTaskData, claims *domain.Claims) (*domain.TaskView, *domain.Error) {
+	// The priority is left unchanged if it is not given.
+	var priority string
+	if taskData.Priority != "" {
+		var _err *domain.Error
+		priority, _err = taskPriority(taskData.Priority)
+		if _err != nil {
+			return nil, _err
+		}
+	}
+
 	// Check if the task exists.
-	foundTask, _err := tu.GetTaskByID(objectID)
+	foundTask, workspace, _err := tu.getTask(ctx, objectID)
 	if _err != nil {
 		return nil, _err
 	}
 
-	// Check if the user is an admin or the owner of the task.
-	if claims.Role == "user" && claims.ID != foundTask.UserID {
-		return nil, &domain.Error{
-			Err:        errors.New("trying to update another user's task"),
-			StatusCode: http.StatusForbidden,
-			Message:    "A User can only update their own task",
-		}
+	// Check if the user can update the task.
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskUpdate, foundTask.UserID, workspace, "update", "trying to update another user's task")
+	if _err != nil {
+		return nil, _err
 	}
 
 	// Get the data to update.
@@ -175,19 +446,27 @@ func (tu *TaskUsecase) UpdateTask(objectID primitive.ObjectID, taskData *domain.
 	if !taskData.DueDate.IsZero() {
 		updateData["due_date"] = taskData.DueDate
 	}
+	if priority != "" {
+		updateData["priority"] = priority
+	}
 
-	// Update the task in the database.
-	err := tu.taskRepo.UpdateTask(objectID, updateData)
-	if err != nil {
-		return nil, &domain.Error{
-			Err:        err,
-			StatusCode: http.StatusInternalServerError,
-			Message:    "Internal server error",
+	// Update the task in the database, if the change of its status is allowed.
+	_err = tu.changeTask(ctx, claims, workspace, foundTask, taskData.Status, func(ctx context.Context, current *domain.Task) error {
+		delete(updateData, "completed_at")
+		if completedAt, changed := completion(current.Status, taskData.Status); taskData.Status != "" && changed {
+			updateData["completed_at"] = completedAt
 		}
+
+		return tu.taskRepo.UpdateTask(ctx, objectID, updateData)
+	})
+	if _err != nil {
+		return nil, _err
 	}
 
 	taskView := &domain.TaskView{}
 	taskView.ID = objectID.Hex()
+	taskView.WorkspaceID = hexID(foundTask.WorkspaceID)
+	taskView.ProjectID = hexID(foundTask.ProjectID)
 	if taskData.Title != "" {
 		taskView.Title = taskData.Title
 	} else {
@@ -212,26 +491,35 @@ func (tu *TaskUsecase) UpdateTask(objectID primitive.ObjectID, taskData *domain.
 		taskView.DueDate = foundTask.DueDate
 	}
 
+	if priority != "" {
+		taskView.Priority = priority
+	} else {
+		taskView.Priority = foundTask.Priority
+	}
+
+	updatedTask := &domain.Task{DueDate: taskView.DueDate, Status: taskView.Status, Priority: taskView.Priority}
+	taskView.Urgency = updatedTask.Urgency(time.Now())
+
+	taskView.TimeSpentSeconds = tu.timeSpent(ctx, objectID)
+
 	return taskView, nil
 }
 
-func (tu *TaskUsecase) DeleteTask(objectID primitive.ObjectID, claims *domain.Claims) *domain.Error {
-	foundTask, _err := tu.GetTaskByID(objectID)
+// A method that deletes a task with the given ID.
+func (tu *TaskUsecase) DeleteTask(ctx context.Context, objectID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	foundTask, workspace, _err := tu.getTask(ctx, objectID)
 	if _err != nil {
 		return _err
 	}
 
-	// Check if the user is an admin or the owner of the task.
-	if claims.Role == "user" && claims.ID != foundTask.UserID {
-		return &domain.Error{
-			Err:        errors.New("trying to delete another user's task"),
-			StatusCode: http.StatusForbidden,
-			Message:    "A User can only delete their own task",
-		}
+	// Check if the user can delete the task.
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskDelete, foundTask.UserID, workspace, "delete", "trying to delete another user's task")
+	if _err != nil {
+		return _err
 	}
 
 	// Delete the task from the database.
-	err := tu.taskRepo.DeleteTask(objectID)
+	err := tu.taskRepo.DeleteTask(ctx, objectID)
 	if err != nil {
 		return &domain.Error{
 			Err:        err,
@@ -240,5 +528,143 @@ func (tu *TaskUsecase) DeleteTask(objectID primitive.ObjectID, claims *domain.Cl
 		}
 	}
 
+	// Remove the task from the blockers of other tasks. A failure is only logged, since deleted blockers are ignored.
+	err = tu.taskRepo.RemoveBlocker(ctx, &domain.TaskFilter{BlockedBy: objectID}, objectID)
+	if err != nil {
+		infrastructure.Logger(ctx).Error("removing a deleted task from the blockers of other tasks", "task_id", objectID.Hex(), "error", err)
+	}
+
+	// Delete the files attached to the task. A failure is only logged, since the task no longer refers to them.
+	for _, attachment := range foundTask.Attachments {
+		tu.deleteBlob(ctx, objectID, attachment.ID)
+	}
+
 	return nil
 }
+
+// A helper method that checks if the user may perform the action on a task owned by ownerID in the workspace.
+// The errMessage describes the attempt when the user may only act on their own tasks.
+func (tu *TaskUsecase) checkAccess(ctx context.Context, claims *domain.Claims, action string, ownerID primitive.ObjectID, workspace *domain.Workspace, verb, errMessage string) (*domain.Decision, *domain.Error) {
+	decision, _err := authorize(ctx, tu.authorizer, claims, &domain.AccessRequest{Action: action, OwnerID: ownerID, Workspace: workspace})
+	if _err != nil {
+		return nil, _err
+	}
+
+	if decision.Allowed {
+		return decision, nil
+	}
+
+	// Tasks in workspaces of other teams are reported as missing.
+	if decision.Denial == domain.DenialNotMember {
+		return nil, &domain.Error{
+			Err:        errors.New(decision.Reason),
+			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeTaskNotFound,
+			Message:    "Task not found",
+		}
+	}
+
+	if decision.Denial == domain.DenialWorkspaceRole {
+		return nil, &domain.Error{
+			Err:        errors.New(decision.Reason),
+			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenWorkspaceRole,
+			Message:    "Your workspace role does not allow you to " + verb + " tasks",
+		}
+	}
+
+	if decision.Denial == domain.DenialNotOwner {
+		return nil, &domain.Error{
+			Err:        errors.New(errMessage),
+			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenNotOwner,
+			Message:    "A " + titleRole(claims.Role) + " can only " + verb + " their own task",
+		}
+	}
+
+	return nil, &domain.Error{
+		Err:        errors.New(decision.Reason),
+		StatusCode: http.StatusForbidden,
+		Code:       domain.CodeForbiddenPermission,
+		Message:    "You do not have permission to " + verb + " tasks",
+	}
+}
+
+// A helper method that returns the seconds logged on a task. The task is already changed when its view is built, so
+// a failed read is only logged and the view has no time.
+func (tu *TaskUsecase) timeSpent(ctx context.Context, taskID primitive.ObjectID) int64 {
+	entries, err := tu.timeEntryRepo.GetTimeEntries(ctx, &domain.TimeEntryFilter{TaskIDs: []primitive.ObjectID{taskID}, ExcludeRunning: true})
+	if err != nil {
+		infrastructure.Logger(ctx).Error("reading the time spent on a task", "task_id", taskID.Hex(), "error", err)
+		return 0
+	}
+
+	return domain.TimeSpent(entries)
+}
+
+// A helper function that returns the hex representation of an ID, or an empty string if the ID is not set.
+func hexID(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+
+	return id.Hex()
+}
+
+// A helper function that checks the priority of a request and normalizes its case. Tasks have the medium priority
+// by default.
+func taskPriority(priority string) (string, *domain.Error) {
+	priority = strings.ToLower(strings.TrimSpace(priority))
+	if priority == "" {
+		return domain.TaskPriorityMedium, nil
+	}
+
+	if !slices.Contains(domain.TaskPriorities, priority) {
+		return "", invalidField("priority", "must be one of "+strings.Join(domain.TaskPriorities, ", "))
+	}
+
+	return priority, nil
+}
+
+// A helper function that sorts the tasks in the given order at the given time. Tasks that are equal in that order
+// are sorted by due date, then by ID, so that the order does not change between requests.
+func sortTasks(tasks []domain.Task, order string, now time.Time) {
+	if order == "" {
+		return
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := &tasks[i], &tasks[j]
+		switch order {
+		case domain.TaskSortPriority:
+			if rankA, rankB := domain.PriorityRank(a.Priority), domain.PriorityRank(b.Priority); rankA != rankB {
+				return rankA > rankB
+			}
+		case domain.TaskSortUrgency:
+			if urgencyA, urgencyB := a.Urgency(now), b.Urgency(now); urgencyA != urgencyB {
+				return urgencyA > urgencyB
+			}
+		}
+
+		if !a.DueDate.Equal(b.DueDate) {
+			return a.DueDate.Before(b.DueDate)
+		}
+
+		return a.ID.Hex() < b.ID.Hex()
+	})
+}
+
+// A helper function that returns when a task whose status changes from one to the other was completed: now if the
+// change completes it, and nil if it reopens it. changed is false if the task stays completed or open.
+func completion(from string, to string) (completedAt *time.Time, changed bool) {
+	if (from == "Completed") == (to == "Completed") {
+		return nil, false
+	}
+
+	if to == "Completed" {
+		completedAt := now()
+		return &completedAt, true
+	}
+
+	return nil, true
+}
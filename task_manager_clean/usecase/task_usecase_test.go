@@ -1,9 +1,11 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"task_manager/domain"
+	"task_manager/infrastructure"
 	"task_manager/mocks"
 	"task_manager/usecase"
 	"testing"
@@ -11,40 +13,82 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 var (
-	mockTask     = mock.AnythingOfType("*domain.Task")
-	mockObjectID = mock.AnythingOfType("primitive.ObjectID")
-	mockBSON     = mock.AnythingOfType("primitive.M")
+	mockTask       = mock.AnythingOfType("*domain.Task")
+	mockTaskFilter = mock.AnythingOfType("*domain.TaskFilter")
+	mockObjectID   = mock.AnythingOfType("primitive.ObjectID")
+	mockBSON       = mock.AnythingOfType("primitive.M")
+
+	mockTimeEntryFilter = mock.AnythingOfType("*domain.TimeEntryFilter")
+
+	attachmentLimits = domain.AttachmentLimits{MaxSize: 1 << 20, AllowedTypes: []string{"image/png", "text/plain"}}
 )
 
+// A helper function that returns a pending task of the user, due in the given number of days.
+func newTask(title string, claims *domain.Claims, dueInDays int) domain.Task {
+	return domain.Task{
+		ID:       primitive.NewObjectID(),
+		Title:    title,
+		DueDate:  time.Now().AddDate(0, 0, dueInDays),
+		Status:   "Pending",
+		Priority: domain.TaskPriorityMedium,
+		UserID:   claims.ID,
+	}
+}
+
 // A suite for the TaskUsecase.
 type TaskUsecaseSuite struct {
 	suite.Suite
-	taskRepo *mocks.TaskRepository
-	usecase  *usecase.TaskUsecase
+	taskRepo      *mocks.TaskRepository
+	projectRepo   *mocks.ProjectRepository
+	workspaceRepo *mocks.WorkspaceRepository
+	timeEntryRepo *mocks.TimeEntryRepository
+	transactor    *mocks.Transactor
+	blobs         *mocks.BlobStore
+	roleRepo      *mocks.RoleRepository
+	usecase       *usecase.TaskUsecase
 }
 
 // A method that sets up the TestSuite.
 func (suite *TaskUsecaseSuite) SetupSuite() {
 	suite.taskRepo = new(mocks.TaskRepository)
-	suite.usecase = usecase.NewTaskUsecase(suite.taskRepo)
+	suite.projectRepo = new(mocks.ProjectRepository)
+	suite.workspaceRepo = new(mocks.WorkspaceRepository)
+	suite.timeEntryRepo = new(mocks.TimeEntryRepository)
+	suite.transactor = new(mocks.Transactor)
+	suite.blobs = new(mocks.BlobStore)
+	suite.roleRepo = new(mocks.RoleRepository)
+	authorizer := infrastructure.NewRoleAuthorizer(suite.roleRepo)
+	suite.usecase = usecase.NewTaskUsecase(suite.taskRepo, suite.projectRepo, suite.workspaceRepo, suite.timeEntryRepo, suite.transactor, suite.blobs, attachmentLimits, authorizer)
+
+	// Run the transactions directly.
+	suite.transactor.On("WithTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}).Maybe()
 }
 
 // A method that tears down the TestSuite.
 func (suite *TaskUsecaseSuite) TearDownSuite() {
 	suite.taskRepo.AssertExpectations(suite.T())
+	suite.projectRepo.AssertExpectations(suite.T())
+	suite.workspaceRepo.AssertExpectations(suite.T())
+	suite.timeEntryRepo.AssertExpectations(suite.T())
+	suite.blobs.AssertExpectations(suite.T())
+	suite.roleRepo.AssertExpectations(suite.T())
 }
 
 // A test for the TaskUsecase.GetTasks method.
 func (suite *TaskUsecaseSuite) Test_GetTasks() {
 	// A testcase where the task repository returns an empty list of tasks.
 	suite.Run("GetTasks_Empty", func() {
-		suite.taskRepo.On("GetAllTasks").Return([]domain.Task{}, nil).Once()
-		tasks, err := suite.usecase.GetTasks()
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, mockObjectID).Return([]domain.Workspace{}, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return([]domain.Task{}, nil).Once()
+		tasks, err := suite.usecase.GetTasks(context.Background(), &domain.TaskQuery{}, mocks.GetClaims())
 
 		suite.Equal(0, len(tasks))
 		suite.Nil(err)
@@ -53,18 +97,20 @@ func (suite *TaskUsecaseSuite) Test_GetTasks() {
 	// A testcase where the task repository returns a non-empty list of tasks.
 	suite.Run("GetTasks_Success", func() {
 		tasks := mocks.GetManyTasks()
-		suite.taskRepo.On("GetAllTasks").Return(tasks, nil).Once()
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, mockObjectID).Return([]domain.Workspace{}, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return(tasks, nil).Once()
 
-		result, err := suite.usecase.GetTasks()
+		result, err := suite.usecase.GetTasks(context.Background(), &domain.TaskQuery{}, mocks.GetClaims())
 		suite.Nil(err)
 		suite.Equal(tasks, result)
 	})
 
 	// A testcase where the task repository returns an error.
 	suite.Run("GetTasks_Error", func() {
-		suite.taskRepo.On("GetAllTasks").Return(nil, errors.New("some error")).Once()
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, mockObjectID).Return([]domain.Workspace{}, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return(nil, errors.New("some error")).Once()
 
-		result, err := suite.usecase.GetTasks()
+		result, err := suite.usecase.GetTasks(context.Background(), &domain.TaskQuery{}, mocks.GetClaims())
 		suite.Nil(result)
 
 		expectedErr := &domain.Error{
@@ -75,6 +121,115 @@ func (suite *TaskUsecaseSuite) Test_GetTasks() {
 
 		suite.Equal(expectedErr, err)
 	})
+
+	// A testcase where the tasks are sorted by priority, then by due date.
+	suite.Run("GetTasks_SortPriority", func() {
+		low, urgent, high, soonHigh := mocks.GetNewTask(), mocks.GetNewTask(), mocks.GetNewTask(), mocks.GetNewTask2()
+		low.Priority = domain.TaskPriorityLow
+		urgent.ID, urgent.Priority = mocks.GetPrimitiveID2(), domain.TaskPriorityUrgent
+		high.ID, high.Priority = mocks.GetPrimitiveID3(), domain.TaskPriorityHigh
+		soonHigh.Priority = domain.TaskPriorityHigh
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, mockObjectID).Return([]domain.Workspace{}, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return([]domain.Task{*low, *urgent, *high, *soonHigh}, nil).Once()
+
+		result, err := suite.usecase.GetTasks(context.Background(), &domain.TaskQuery{Sort: domain.TaskSortPriority}, mocks.GetClaims())
+		suite.Nil(err)
+		suite.Equal([]domain.Task{*urgent, *soonHigh, *high, *low}, result)
+	})
+
+	// A testcase where an overdue task of medium priority is more urgent than a high priority task due next month.
+	suite.Run("GetTasks_SortUrgency", func() {
+		later, overdue, completed := mocks.GetNewTask(), mocks.GetNewTask(), mocks.GetNewTask()
+		later.Priority, later.DueDate = domain.TaskPriorityHigh, time.Now().AddDate(0, 1, 0)
+		overdue.ID, overdue.DueDate = mocks.GetPrimitiveID2(), time.Now().AddDate(0, 0, -2)
+		completed.ID, completed.Priority, completed.Status = mocks.GetPrimitiveID3(), domain.TaskPriorityUrgent, "Completed"
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, mockObjectID).Return([]domain.Workspace{}, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return([]domain.Task{*completed, *later, *overdue}, nil).Once()
+
+		result, err := suite.usecase.GetTasks(context.Background(), &domain.TaskQuery{Sort: domain.TaskSortUrgency}, mocks.GetClaims())
+		suite.Nil(err)
+		suite.Equal([]domain.Task{*overdue, *later, *completed}, result)
+	})
+
+	// A testcase where the order is not supported.
+	suite.Run("GetTasks_InvalidSort", func() {
+		result, err := suite.usecase.GetTasks(context.Background(), &domain.TaskQuery{Sort: "title"}, mocks.GetClaims())
+		suite.Nil(result)
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+		suite.Equal("sort", err.Fields[0].Field)
+	})
+
+	// A testcase where the role of the user only grants reading their own tasks.
+	suite.Run("GetTasks_OwnOnly", func() {
+		claims := mocks.GetClaims()
+		claims.Role = "reader"
+		suite.roleRepo.On("GetRoleByName", mock.Anything, "reader").Return(&domain.Role{
+			Name:        "reader",
+			Level:       5,
+			Permissions: []string{domain.PermissionTaskReadOwn},
+		}, nil).Once()
+
+		tasks := mocks.GetManyTasks()
+		for i := range tasks {
+			tasks[i].UserID = mocks.GetPrimitiveID2()
+		}
+		tasks[0].UserID = claims.ID
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, mockObjectID).Return([]domain.Workspace{}, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, mockTaskFilter).Return(tasks, nil).Once()
+
+		result, err := suite.usecase.GetTasks(context.Background(), &domain.TaskQuery{}, claims)
+		suite.Nil(err)
+		suite.Equal(tasks[:1], result)
+	})
+
+	// A testcase where the user owns a workspace and can view every task in it.
+	suite.Run("GetTasks_OwnOnlyWorkspaceOwner", func() {
+		claims := mocks.GetClaims()
+		claims.Role = "reader"
+		suite.roleRepo.On("GetRoleByName", mock.Anything, "reader").Return(&domain.Role{
+			Name:        "reader",
+			Level:       5,
+			Permissions: []string{domain.PermissionTaskReadOwn},
+		}, nil).Once()
+
+		workspace := mocks.GetWorkspace()
+		workspace.Members[0].Role = domain.WorkspaceRoleOwner
+		tasks := mocks.GetManyTasks()
+		for i := range tasks {
+			tasks[i].UserID = mocks.GetPrimitiveID2()
+			tasks[i].WorkspaceID = workspace.ID
+		}
+
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{workspace.ID}}).Return(tasks, nil).Once()
+
+		result, err := suite.usecase.GetTasks(context.Background(), &domain.TaskQuery{WorkspaceID: workspace.ID.Hex()}, claims)
+		suite.Nil(err)
+		suite.Equal(tasks, result)
+	})
+
+	// A testcase where the user is not a member of the workspace.
+	suite.Run("GetTasks_NotMember", func() {
+		workspace := mocks.GetWorkspace()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+
+		result, err := suite.usecase.GetTasks(context.Background(), &domain.TaskQuery{WorkspaceID: workspace.ID.Hex()}, mocks.GetClaims3())
+		suite.Nil(result)
+		suite.Equal(http.StatusNotFound, err.StatusCode)
+		suite.Equal("Workspace not found", err.Message)
+	})
+
+	// A testcase where the role of the user is not defined.
+	suite.Run("GetTasks_UnknownRole", func() {
+		claims := mocks.GetClaims()
+		claims.Role = "ghost"
+		suite.roleRepo.On("GetRoleByName", mock.Anything, "ghost").Return(nil, mongo.ErrNoDocuments).Once()
+
+		result, err := suite.usecase.GetTasks(context.Background(), &domain.TaskQuery{}, claims)
+		suite.Nil(result)
+		suite.Equal(http.StatusForbidden, err.StatusCode)
+		suite.Equal("You do not have permission to view tasks", err.Message)
+	})
 }
 
 // A test for the TaskUsecase.GetTaskByID method.
@@ -82,9 +237,9 @@ func (suite *TaskUsecaseSuite) Test_GetTaskByID() {
 	// A testcase where the task repository returns a task.
 	suite.Run("GetTaskByID_Success", func() {
 		task := mocks.GetNewTask()
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(task, nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Once()
 
-		result, err := suite.usecase.GetTaskByID(task.ID)
+		result, err := suite.usecase.GetTaskByID(context.Background(), task.ID, mocks.GetClaims())
 		suite.Equal(task, result)
 		suite.Nil(err)
 	})
@@ -92,14 +247,15 @@ func (suite *TaskUsecaseSuite) Test_GetTaskByID() {
 	// A testcase where the task repository fails to find a task.
 	suite.Run("GetTaskByID_NotFound", func() {
 		id := primitive.NewObjectID()
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(nil, mongo.ErrNoDocuments).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(nil, mongo.ErrNoDocuments).Once()
 
-		result, err := suite.usecase.GetTaskByID(id)
+		result, err := suite.usecase.GetTaskByID(context.Background(), id, mocks.GetClaims())
 		suite.Nil(result)
 
 		expectedErr := &domain.Error{
 			Err:        mongo.ErrNoDocuments,
 			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeTaskNotFound,
 			Message:    "Task not found",
 		}
 
@@ -109,9 +265,9 @@ func (suite *TaskUsecaseSuite) Test_GetTaskByID() {
 	// A testcase where the task repository returns an error.
 	suite.Run("GetTaskByID_Error", func() {
 		id := primitive.NewObjectID()
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(nil, errors.New("some error")).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(nil, errors.New("some error")).Once()
 
-		result, err := suite.usecase.GetTaskByID(id)
+		result, err := suite.usecase.GetTaskByID(context.Background(), id, mocks.GetClaims())
 		suite.Nil(result)
 
 		expectedErr := &domain.Error{
@@ -124,33 +280,115 @@ func (suite *TaskUsecaseSuite) Test_GetTaskByID() {
 	})
 }
 
+// A test for the TaskUsecase.GetTaskByID method in workspaces.
+func (suite *TaskUsecaseSuite) Test_GetTaskByID_Workspace() {
+	// A testcase where the task belongs to a workspace the user is not a member of.
+	suite.Run("GetTaskByID_NotMember", func() {
+		task := mocks.GetNewTask()
+		task.WorkspaceID = mocks.GetWorkspace().ID
+		suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(task, nil).Once()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, task.WorkspaceID).Return(mocks.GetWorkspace(), nil).Once()
+
+		result, err := suite.usecase.GetTaskByID(context.Background(), task.ID, mocks.GetClaims3())
+		suite.Nil(result)
+		suite.Equal(http.StatusNotFound, err.StatusCode)
+		suite.Equal("Task not found", err.Message)
+	})
+}
+
+// A test for the TaskUsecase.CanViewTask method.
+func (suite *TaskUsecaseSuite) Test_CanViewTask() {
+	// A testcase where the user owns a task that does not belong to a workspace.
+	suite.Run("CanViewTask_Owner", func() {
+		task := mocks.GetNewTask()
+		task.UserID = mocks.GetClaims().ID
+
+		suite.Nil(suite.usecase.CanViewTask(context.Background(), task, mocks.GetClaims()))
+	})
+
+	// A testcase where the task belongs to a workspace the user is not a member of.
+	suite.Run("CanViewTask_NotMember", func() {
+		task := mocks.GetNewTask()
+		task.WorkspaceID = mocks.GetWorkspace().ID
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, task.WorkspaceID).Return(mocks.GetWorkspace(), nil).Once()
+
+		err := suite.usecase.CanViewTask(context.Background(), task, mocks.GetClaims3())
+		suite.Equal(http.StatusNotFound, err.StatusCode)
+	})
+
+	// A testcase where the workspace of the task was deleted.
+	suite.Run("CanViewTask_WorkspaceDeleted", func() {
+		task := mocks.GetNewTask()
+		task.WorkspaceID = mocks.GetWorkspace().ID
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, task.WorkspaceID).Return(nil, mongo.ErrNoDocuments).Once()
+
+		err := suite.usecase.CanViewTask(context.Background(), task, mocks.GetClaims())
+		suite.Equal(domain.CodeTaskNotFound, err.Code)
+	})
+}
+
 // A test for the TaskUsecase.CreateTask method.
 func (suite *TaskUsecaseSuite) Test_CreateTask() {
 	// A testcase where the task repository successfully creates a task.
 	suite.Run("CreateTask_Success", func() {
 		taskData := mocks.GetCreateTaskData()
 		claims := mocks.GetClaims()
+		project := mocks.GetProject()
+		suite.projectRepo.On("GetProjectByID", mock.Anything, taskData.ProjectID).Return(project, nil).Once()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, project.WorkspaceID).Return(mocks.GetWorkspace(), nil).Once()
+
 		task := mocks.GetTask(taskData, claims)
 		task.ID = mocks.GetNextID(primitive.NewObjectID())
 		taskView := mocks.GetTaskView(task)
+		taskView.WorkspaceID = project.WorkspaceID.Hex()
+		taskView.ProjectID = project.ID.Hex()
 
-		suite.taskRepo.On("AddTask", mockTask).Return(nil).Once()
+		suite.taskRepo.On("AddTask", mock.Anything, mockTask).Return(nil).Once()
 
-		result, err := suite.usecase.CreateTask(taskData, claims)
+		result, err := suite.usecase.CreateTask(context.Background(), taskData, claims)
 		suite.Equal(taskView, result)
 		suite.Nil(err)
 	})
 
+	// A testcase where the priority is given in another case.
+	suite.Run("CreateTask_Priority", func() {
+		taskData := mocks.GetCreateTaskData()
+		taskData.Priority = " Urgent"
+		project := mocks.GetProject()
+		suite.projectRepo.On("GetProjectByID", mock.Anything, taskData.ProjectID).Return(project, nil).Once()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, project.WorkspaceID).Return(mocks.GetWorkspace(), nil).Once()
+		suite.taskRepo.On("AddTask", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+			return task.Priority == domain.TaskPriorityUrgent
+		})).Return(nil).Once()
+
+		result, err := suite.usecase.CreateTask(context.Background(), taskData, mocks.GetClaims())
+		suite.Nil(err)
+		suite.Equal(domain.TaskPriorityUrgent, result.Priority)
+		suite.Greater(result.Urgency, 4.0)
+	})
+
+	// A testcase where the priority is not supported.
+	suite.Run("CreateTask_InvalidPriority", func() {
+		taskData := mocks.GetCreateTaskData()
+		taskData.Priority = "critical"
+
+		result, err := suite.usecase.CreateTask(context.Background(), taskData, mocks.GetClaims())
+		suite.Nil(result)
+		suite.Equal(domain.CodeValidationFailed, err.Code)
+		suite.Equal([]domain.FieldError{{Field: "priority", Reason: "must be one of low, medium, high, urgent"}}, err.Fields)
+	})
+
 	// A testcase where the task repository returns an error.
 	suite.Run("CreateTask_Error", func() {
 		taskData := mocks.GetCreateTaskData()
 		claims := mocks.GetClaims()
-		task := mocks.GetTask(taskData, claims)
-		task.ID = mocks.GetNextID(primitive.NewObjectID())
+		project := mocks.GetProject()
+		suite.projectRepo.On("GetProjectByID", mock.Anything, taskData.ProjectID).Return(project, nil).Once()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, project.WorkspaceID).Return(mocks.GetWorkspace(), nil).Once()
 
-		suite.taskRepo.On("AddTask", mockTask).Return(errors.New("some error")).Once()
+		suite.taskRepo.On("AddTask", mock.Anything, mockTask).Return(errors.New("some error")).Once()
 
-		result, err := suite.usecase.CreateTask(taskData, claims)
+		result, err := suite.usecase.CreateTask(context.Background(), taskData, claims)
 		suite.Nil(result)
 
 		expectedErr := &domain.Error{
@@ -161,6 +399,34 @@ func (suite *TaskUsecaseSuite) Test_CreateTask() {
 
 		suite.Equal(expectedErr, err)
 	})
+
+	// A testcase where the project belongs to a workspace the user is not a member of.
+	suite.Run("CreateTask_NotMember", func() {
+		taskData := mocks.GetCreateTaskData()
+		project := mocks.GetProject()
+		suite.projectRepo.On("GetProjectByID", mock.Anything, taskData.ProjectID).Return(project, nil).Once()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, project.WorkspaceID).Return(mocks.GetWorkspace(), nil).Once()
+
+		result, err := suite.usecase.CreateTask(context.Background(), taskData, mocks.GetClaims3())
+		suite.Nil(result)
+		suite.Equal(http.StatusNotFound, err.StatusCode)
+		suite.Equal("Project not found", err.Message)
+	})
+
+	// A testcase where the user can only view the workspace.
+	suite.Run("CreateTask_Viewer", func() {
+		taskData := mocks.GetCreateTaskData()
+		project := mocks.GetProject()
+		workspace := mocks.GetWorkspace()
+		workspace.Members[0].Role = domain.WorkspaceRoleViewer
+		suite.projectRepo.On("GetProjectByID", mock.Anything, taskData.ProjectID).Return(project, nil).Once()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, project.WorkspaceID).Return(workspace, nil).Once()
+
+		result, err := suite.usecase.CreateTask(context.Background(), taskData, mocks.GetClaims())
+		suite.Nil(result)
+		suite.Equal(http.StatusForbidden, err.StatusCode)
+		suite.Equal("Your workspace role does not allow you to create tasks", err.Message)
+	})
 }
 
 // A test for the TaskUsecase.ReplaceTask method.
@@ -173,11 +439,14 @@ func (suite *TaskUsecaseSuite) Test_ReplaceTask() {
 		task.ID = mocks.GetNextID(primitive.NewObjectID())
 		objectID := task.ID
 		taskView := mocks.GetTaskView(task)
+		taskView.TimeSpentSeconds = 5400
+		entries := []domain.TimeEntry{*mocks.GetTimeEntry(claims.ID, nil, 3600), *mocks.GetTimeEntry(claims.ID, nil, 1800)}
 
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(task, nil).Once()
-		suite.taskRepo.On("ReplaceTask", mockObjectID, mockTask).Return(nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Twice()
+		suite.taskRepo.On("ReplaceTask", mock.Anything, mockObjectID, mockTask).Return(nil).Once()
+		suite.timeEntryRepo.On("GetTimeEntries", mock.Anything, &domain.TimeEntryFilter{TaskIDs: []primitive.ObjectID{objectID}, ExcludeRunning: true}).Return(entries, nil).Once()
 
-		result, err := suite.usecase.ReplaceTask(objectID, taskData, claims)
+		result, err := suite.usecase.ReplaceTask(context.Background(), objectID, taskData, claims)
 		suite.Equal(taskView, result)
 		suite.Nil(err)
 	})
@@ -190,9 +459,9 @@ func (suite *TaskUsecaseSuite) Test_ReplaceTask() {
 		task.ID = mocks.GetNextID(primitive.NewObjectID())
 		objectID := task.ID
 
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(nil, errors.New("some error")).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(nil, errors.New("some error")).Once()
 
-		result, err := suite.usecase.ReplaceTask(objectID, taskData, claims)
+		result, err := suite.usecase.ReplaceTask(context.Background(), objectID, taskData, claims)
 		suite.Nil(result)
 
 		expectedErr := &domain.Error{
@@ -213,14 +482,15 @@ func (suite *TaskUsecaseSuite) Test_ReplaceTask() {
 		objectID := task.ID
 		task.UserID = mocks.GetNextID(primitive.NewObjectID())
 
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(task, nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Once()
 
-		result, err := suite.usecase.ReplaceTask(objectID, taskData, claims)
+		result, err := suite.usecase.ReplaceTask(context.Background(), objectID, taskData, claims)
 		suite.Nil(result)
 
 		expectedErr := &domain.Error{
 			Err:        errors.New("trying to replace another user's task"),
 			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenNotOwner,
 			Message:    "A User can only update their own task",
 		}
 
@@ -235,10 +505,10 @@ func (suite *TaskUsecaseSuite) Test_ReplaceTask() {
 		task.ID = mocks.GetNextID(primitive.NewObjectID())
 		objectID := task.ID
 
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(task, nil).Once()
-		suite.taskRepo.On("ReplaceTask", mockObjectID, mockTask).Return(errors.New("some error")).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Twice()
+		suite.taskRepo.On("ReplaceTask", mock.Anything, mockObjectID, mockTask).Return(errors.New("some error")).Once()
 
-		result, err := suite.usecase.ReplaceTask(objectID, taskData, claims)
+		result, err := suite.usecase.ReplaceTask(context.Background(), objectID, taskData, claims)
 		suite.Nil(result)
 
 		expectedErr := &domain.Error{
@@ -259,15 +529,16 @@ func (suite *TaskUsecaseSuite) Test_UpdateTask() {
 		claims := mocks.GetClaims()
 		id := primitive.NewObjectID()
 
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(nil, mongo.ErrNoDocuments).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(nil, mongo.ErrNoDocuments).Once()
 
 		expectedErr := &domain.Error{
 			Err:        mongo.ErrNoDocuments,
 			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeTaskNotFound,
 			Message:    "Task not found",
 		}
 
-		result, err := suite.usecase.UpdateTask(id, taskData, claims)
+		result, err := suite.usecase.UpdateTask(context.Background(), id, taskData, claims)
 		suite.Nil(result)
 		suite.Equal(expectedErr, err)
 	})
@@ -281,16 +552,34 @@ func (suite *TaskUsecaseSuite) Test_UpdateTask() {
 		objectID := task.ID
 		taskView := mocks.GetTaskView(task)
 
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(task, nil).Once()
-		suite.taskRepo.On("UpdateTask", mockObjectID, mockBSON).Return(nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Once()
+		suite.taskRepo.On("UpdateTask", mock.Anything, mockObjectID, mockBSON).Return(nil).Once()
+		suite.timeEntryRepo.On("GetTimeEntries", mock.Anything, mockTimeEntryFilter).Return([]domain.TimeEntry{}, nil).Once()
 		taskData.DueDate = time.Time{}
 		taskData.Status = ""
 
-		result, err := suite.usecase.UpdateTask(objectID, taskData, claims)
+		result, err := suite.usecase.UpdateTask(context.Background(), objectID, taskData, claims)
 		suite.Equal(taskView, result)
 		suite.Nil(err)
 	})
 
+	// A testcase where only the priority of a task is updated.
+	suite.Run("UpdateTask_Priority", func() {
+		claims := mocks.GetClaims()
+		task := mocks.GetTask3(mocks.GetUpdateTaskData(), claims)
+		taskView := mocks.GetTaskView(task)
+		taskView.Priority = domain.TaskPriorityHigh
+		taskView.Urgency = (&domain.Task{DueDate: task.DueDate, Status: task.Status, Priority: domain.TaskPriorityHigh}).Urgency(time.Now())
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Once()
+		suite.taskRepo.On("UpdateTask", mock.Anything, mockObjectID, bson.M{"priority": domain.TaskPriorityHigh}).Return(nil).Once()
+		suite.timeEntryRepo.On("GetTimeEntries", mock.Anything, mockTimeEntryFilter).Return([]domain.TimeEntry{}, nil).Once()
+
+		result, err := suite.usecase.UpdateTask(context.Background(), task.ID, &domain.UpdateTaskData{Priority: "HIGH"}, claims)
+		suite.Nil(err)
+		suite.Equal(taskView, result)
+	})
+
 	// A second testcase where the task repository successfully updates a task.
 	suite.Run("UpdateTask_Success2", func() {
 		taskData := mocks.GetUpdateTaskData()
@@ -300,12 +589,13 @@ func (suite *TaskUsecaseSuite) Test_UpdateTask() {
 		objectID := task.ID
 		taskView := mocks.GetTaskView(task)
 
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(task, nil).Once()
-		suite.taskRepo.On("UpdateTask", mockObjectID, mockBSON).Return(nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Twice()
+		suite.taskRepo.On("UpdateTask", mock.Anything, mockObjectID, mockBSON).Return(nil).Once()
+		suite.timeEntryRepo.On("GetTimeEntries", mock.Anything, mockTimeEntryFilter).Return([]domain.TimeEntry{}, nil).Once()
 		taskData.Title = ""
 		taskData.Description = ""
 
-		result, err := suite.usecase.UpdateTask(objectID, taskData, claims)
+		result, err := suite.usecase.UpdateTask(context.Background(), objectID, taskData, claims)
 		suite.Equal(taskView, result)
 		suite.Nil(err)
 	})
@@ -318,10 +608,10 @@ func (suite *TaskUsecaseSuite) Test_UpdateTask() {
 		task.UserID = claims.ID
 		objectID := task.ID
 
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(task, nil).Once()
-		suite.taskRepo.On("UpdateTask", mockObjectID, mockBSON).Return(errors.New("some error")).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Twice()
+		suite.taskRepo.On("UpdateTask", mock.Anything, mockObjectID, mockBSON).Return(errors.New("some error")).Once()
 
-		result, err := suite.usecase.UpdateTask(objectID, taskData, claims)
+		result, err := suite.usecase.UpdateTask(context.Background(), objectID, taskData, claims)
 		suite.Nil(result)
 
 		expectedErr := &domain.Error{
@@ -341,19 +631,62 @@ func (suite *TaskUsecaseSuite) Test_UpdateTask() {
 		task.UserID = mocks.GetNextID(primitive.NewObjectID())
 		objectID := task.ID
 
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(task, nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Once()
 
-		result, err := suite.usecase.UpdateTask(objectID, taskData, claims)
+		result, err := suite.usecase.UpdateTask(context.Background(), objectID, taskData, claims)
 		suite.Nil(result)
 
 		expectedErr := &domain.Error{
 			Err:        errors.New("trying to update another user's task"),
 			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenNotOwner,
 			Message:    "A User can only update their own task",
 		}
 
 		suite.Equal(expectedErr, err)
 	})
+	// A testcase where the owner of a workspace updates another user's task in it.
+	suite.Run("UpdateTask_WorkspaceOwner", func() {
+		taskData := mocks.GetUpdateTaskData()
+		claims := mocks.GetClaims()
+		workspace := mocks.GetWorkspace()
+		workspace.Members[0].Role = domain.WorkspaceRoleOwner
+		task := mocks.GetTask3(taskData, claims)
+		task.UserID = mocks.GetPrimitiveID2()
+		task.WorkspaceID = workspace.ID
+
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Twice()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.workspaceRepo.On("LockWorkspace", mock.Anything, workspace.ID).Return(nil).Once()
+		suite.taskRepo.On("UpdateTask", mock.Anything, mockObjectID, mockBSON).Return(nil).Once()
+		suite.timeEntryRepo.On("GetTimeEntries", mock.Anything, mockTimeEntryFilter).Return([]domain.TimeEntry{}, nil).Once()
+
+		result, err := suite.usecase.UpdateTask(context.Background(), task.ID, taskData, claims)
+		suite.Nil(err)
+		suite.Equal(workspace.ID.Hex(), result.WorkspaceID)
+	})
+
+	// A testcase where a custom role allows updating other users' tasks.
+	suite.Run("UpdateTask_CustomRole", func() {
+		taskData := mocks.GetUpdateTaskData()
+		claims := mocks.GetClaims()
+		claims.Role = "editor"
+		task := mocks.GetTask3(taskData, claims)
+		task.UserID = mocks.GetNextID(primitive.NewObjectID())
+		objectID := task.ID
+		taskView := mocks.GetTaskView(task)
+
+		suite.roleRepo.On("GetRoleByName", mock.Anything, "editor").Return(mocks.GetRole(), nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Once()
+		suite.taskRepo.On("UpdateTask", mock.Anything, mockObjectID, mockBSON).Return(nil).Once()
+		suite.timeEntryRepo.On("GetTimeEntries", mock.Anything, mockTimeEntryFilter).Return([]domain.TimeEntry{}, nil).Once()
+		taskData.DueDate = time.Time{}
+		taskData.Status = ""
+
+		result, err := suite.usecase.UpdateTask(context.Background(), objectID, taskData, claims)
+		suite.Equal(taskView, result)
+		suite.Nil(err)
+	})
 }
 
 // A test for the TaskUsecase.DeleteTask method.
@@ -365,10 +698,11 @@ func (suite *TaskUsecaseSuite) Test_DeleteTask() {
 		task.UserID = claims.ID
 		objectID := task.ID
 
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(task, nil).Once()
-		suite.taskRepo.On("DeleteTask", mockObjectID).Return(nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Once()
+		suite.taskRepo.On("DeleteTask", mock.Anything, mockObjectID).Return(nil).Once()
+		suite.taskRepo.On("RemoveBlocker", mock.Anything, &domain.TaskFilter{BlockedBy: objectID}, objectID).Return(nil).Once()
 
-		err := suite.usecase.DeleteTask(objectID, claims)
+		err := suite.usecase.DeleteTask(context.Background(), objectID, claims)
 		suite.Nil(err)
 	})
 
@@ -379,10 +713,10 @@ func (suite *TaskUsecaseSuite) Test_DeleteTask() {
 		task.UserID = claims.ID
 		objectID := task.ID
 
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(task, nil).Once()
-		suite.taskRepo.On("DeleteTask", mockObjectID).Return(errors.New("some error")).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Once()
+		suite.taskRepo.On("DeleteTask", mock.Anything, mockObjectID).Return(errors.New("some error")).Once()
 
-		err := suite.usecase.DeleteTask(objectID, claims)
+		err := suite.usecase.DeleteTask(context.Background(), objectID, claims)
 
 		expectedErr := &domain.Error{
 			Err:        errors.New("some error"),
@@ -400,13 +734,14 @@ func (suite *TaskUsecaseSuite) Test_DeleteTask() {
 		objectID := task.ID
 		task.UserID = mocks.GetNextID(primitive.NewObjectID())
 
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(task, nil).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(task, nil).Once()
 
-		err := suite.usecase.DeleteTask(objectID, claims)
+		err := suite.usecase.DeleteTask(context.Background(), objectID, claims)
 
 		expectedErr := &domain.Error{
 			Err:        errors.New("trying to delete another user's task"),
 			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenNotOwner,
 			Message:    "A User can only delete their own task",
 		}
 
@@ -418,13 +753,14 @@ func (suite *TaskUsecaseSuite) Test_DeleteTask() {
 		claims := mocks.GetClaims()
 		id := primitive.NewObjectID()
 
-		suite.taskRepo.On("GetTaskByID", mockObjectID).Return(nil, mongo.ErrNoDocuments).Once()
+		suite.taskRepo.On("GetTaskByID", mock.Anything, mockObjectID).Return(nil, mongo.ErrNoDocuments).Once()
 
-		err := suite.usecase.DeleteTask(id, claims)
+		err := suite.usecase.DeleteTask(context.Background(), id, claims)
 
 		expectedErr := &domain.Error{
 			Err:        mongo.ErrNoDocuments,
 			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeTaskNotFound,
 			Message:    "Task not found",
 		}
 
@@ -0,0 +1,574 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"task_manager/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A struct that defines the services for tracking the time spent on tasks.
+// Time entries belong to the user who logged them: logging time requires the permission to update one's own tasks
+// in the workspace of the task, so viewers of a workspace cannot log time, and every member who can view a task can
+// see the time logged on it.
+type TimeEntryUsecase struct {
+	timeEntryRepo domain.TimeEntryRepository
+	taskRepo      domain.TaskRepository
+	workspaceRepo domain.WorkspaceRepository
+	userRepo      domain.UserRepository
+	authorizer    domain.Authorizer
+}
+
+// A constructor that creates a new instance of TimeEntryUsecase.
+func NewTimeEntryUsecase(timeEntryRepo domain.TimeEntryRepository, taskRepo domain.TaskRepository, workspaceRepo domain.WorkspaceRepository, userRepo domain.UserRepository, authorizer domain.Authorizer) *TimeEntryUsecase {
+	return &TimeEntryUsecase{
+		timeEntryRepo: timeEntryRepo,
+		taskRepo:      taskRepo,
+		workspaceRepo: workspaceRepo,
+		userRepo:      userRepo,
+		authorizer:    authorizer,
+	}
+}
+
+// A method that starts a timer on a task. A user can only have one running timer.
+func (tu *TimeEntryUsecase) StartTimer(ctx context.Context, taskID primitive.ObjectID, timerData *domain.StartTimerData, claims *domain.Claims) (*domain.TimeEntry, *domain.Error) {
+	task, _err := tu.getTrackedTask(ctx, taskID, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	// Check if the user already has a running timer.
+	running, err := tu.timeEntryRepo.GetRunningTimeEntry(ctx, claims.ID)
+	if err == nil {
+		return nil, timerAlreadyRunning(running.TaskID)
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, internalError(err)
+	}
+
+	entry := &domain.TimeEntry{
+		ID:          primitive.NewObjectID(),
+		TaskID:      task.ID,
+		UserID:      claims.ID,
+		WorkspaceID: task.WorkspaceID,
+		StartedAt:   now(),
+		Running:     true,
+		Note:        timerData.Note,
+		Tags:        normalizeTags(timerData.Tags),
+	}
+
+	// The store keeps a single running timer per user, which catches the timers started at the same time.
+	err = tu.timeEntryRepo.AddTimeEntry(ctx, entry)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, timerAlreadyRunning(primitive.NilObjectID)
+	}
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	return entry, nil
+}
+
+// A method that stops the running timer of the user on a task, and returns the stopped entry.
+func (tu *TimeEntryUsecase) StopTimer(ctx context.Context, taskID primitive.ObjectID, claims *domain.Claims) (*domain.TimeEntry, *domain.Error) {
+	entry, err := tu.timeEntryRepo.GetRunningTimeEntry(ctx, claims.ID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, internalError(err)
+	}
+	if err != nil || entry.TaskID != taskID {
+		return nil, timerNotRunning(http.StatusConflict, "No timer is running on this task")
+	}
+
+	endedAt := now()
+	durationSeconds := int64(endedAt.Sub(entry.StartedAt) / time.Second)
+	err = tu.timeEntryRepo.StopTimeEntry(ctx, entry.ID, endedAt, durationSeconds)
+	if err == mongo.ErrNoDocuments {
+		return nil, timerNotRunning(http.StatusConflict, "No timer is running on this task")
+	}
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	entry.Running = false
+	entry.EndedAt = &endedAt
+	entry.DurationSeconds = durationSeconds
+	return entry, nil
+}
+
+// A method that returns the running timer of the user.
+func (tu *TimeEntryUsecase) GetRunningTimer(ctx context.Context, claims *domain.Claims) (*domain.TimeEntry, *domain.Error) {
+	entry, err := tu.timeEntryRepo.GetRunningTimeEntry(ctx, claims.ID)
+	if err == mongo.ErrNoDocuments {
+		return nil, timerNotRunning(http.StatusNotFound, "No timer is running")
+	}
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	return entry, nil
+}
+
+// A method that logs the time spent on a task between two times, with a note.
+func (tu *TimeEntryUsecase) AddTimeEntry(ctx context.Context, taskID primitive.ObjectID, entryData *domain.TimeEntryData, claims *domain.Claims) (*domain.TimeEntry, *domain.Error) {
+	// Check the range of the entry, which the validate tags cannot compare.
+	switch {
+	case !entryData.EndedAt.After(entryData.StartedAt):
+		return nil, invalidField("ended_at", "must be after started_at")
+	case entryData.EndedAt.After(time.Now()):
+		return nil, invalidField("ended_at", "must not be in the future")
+	}
+
+	task, _err := tu.getTrackedTask(ctx, taskID, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	startedAt := entryData.StartedAt.UTC().Truncate(time.Second)
+	endedAt := entryData.EndedAt.UTC().Truncate(time.Second)
+	entry := &domain.TimeEntry{
+		ID:              primitive.NewObjectID(),
+		TaskID:          task.ID,
+		UserID:          claims.ID,
+		WorkspaceID:     task.WorkspaceID,
+		StartedAt:       startedAt,
+		EndedAt:         &endedAt,
+		DurationSeconds: int64(endedAt.Sub(startedAt) / time.Second),
+		Note:            entryData.Note,
+		Tags:            normalizeTags(entryData.Tags),
+	}
+
+	err := tu.timeEntryRepo.AddTimeEntry(ctx, entry)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	return entry, nil
+}
+
+// A method that returns the time entries of every user on a task, including the running timers.
+func (tu *TimeEntryUsecase) GetTimeEntries(ctx context.Context, taskID primitive.ObjectID, claims *domain.Claims) ([]domain.TimeEntry, *domain.Error) {
+	task, workspace, _err := tu.getTask(ctx, taskID)
+	if _err != nil {
+		return nil, _err
+	}
+
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskRead, task.UserID, workspace, "view tasks")
+	if _err != nil {
+		return nil, _err
+	}
+
+	entries, err := tu.timeEntryRepo.GetTimeEntries(ctx, &domain.TimeEntryFilter{TaskIDs: []primitive.ObjectID{taskID}})
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	return entries, nil
+}
+
+// A method that deletes a time entry of a task. Users can delete their own entries, and those who can update every
+// task of the workspace can delete the entries of others. The entry can be deleted after its task.
+func (tu *TimeEntryUsecase) DeleteTimeEntry(ctx context.Context, taskID primitive.ObjectID, entryID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	entry, err := tu.timeEntryRepo.GetTimeEntryByID(ctx, entryID)
+	if err == nil && entry.TaskID != taskID {
+		err = mongo.ErrNoDocuments
+	}
+	if err != nil {
+		return notFoundOrInternal(err, domain.CodeTimeEntryNotFound, "Time entry not found")
+	}
+
+	// The entry keeps the workspace of its task, which decides who may delete it.
+	var workspace *domain.Workspace
+	if !entry.WorkspaceID.IsZero() {
+		workspace, err = tu.workspaceRepo.GetWorkspaceByID(ctx, entry.WorkspaceID)
+		if err != nil {
+			return notFoundOrInternal(err, domain.CodeTimeEntryNotFound, "Time entry not found")
+		}
+	}
+
+	_, _err := tu.checkAccess(ctx, claims, domain.ActionTaskUpdate, entry.UserID, workspace, "delete time entries")
+	if _err != nil {
+		return _err
+	}
+
+	err = tu.timeEntryRepo.DeleteTimeEntry(ctx, entryID)
+	if err != nil {
+		return internalError(err)
+	}
+
+	return nil
+}
+
+// A method that adds up the stopped time entries the user can view, grouped by user, task, tag or day.
+// As for tasks, users who can only view their own tasks only see their own time, except in the workspaces they own.
+func (tu *TimeEntryUsecase) GetTimeReport(ctx context.Context, query *domain.TimeReportQuery, claims *domain.Claims) (*domain.TimeReport, *domain.Error) {
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return nil, invalidField("to", "must not be before from")
+	}
+
+	decision, _err := tu.checkAccess(ctx, claims, domain.ActionTaskRead, claims.ID, nil, "view tasks")
+	if _err != nil {
+		return nil, _err
+	}
+
+	filter, workspaceRoles, _err := tu.reportFilter(ctx, query, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	entries, err := tu.timeEntryRepo.GetTimeEntries(ctx, filter)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	if decision.Grant == domain.PermissionTaskReadOwn {
+		ownEntries := []domain.TimeEntry{}
+		for _, entry := range entries {
+			if entry.UserID == claims.ID || workspaceRoles[entry.WorkspaceID] == domain.WorkspaceRoleOwner {
+				ownEntries = append(ownEntries, entry)
+			}
+		}
+
+		entries = ownEntries
+	}
+
+	groupBy := query.GroupBy
+	if groupBy == "" {
+		groupBy = domain.TimeReportByUser
+	}
+
+	report := &domain.TimeReport{
+		GroupBy:      groupBy,
+		Entries:      len(entries),
+		TotalSeconds: domain.TimeSpent(entries),
+		Rows:         groupTimeEntries(entries, groupBy),
+	}
+	if !query.From.IsZero() {
+		report.From = &query.From
+	}
+	if !query.To.IsZero() {
+		report.To = &query.To
+	}
+
+	_err = tu.labelRows(ctx, report)
+	if _err != nil {
+		return nil, _err
+	}
+
+	return report, nil
+}
+
+// A helper method that converts the query of a report into a filter of the time entries, limited to the workspaces
+// of the user, and returns the role of the user in each of them.
+func (tu *TimeEntryUsecase) reportFilter(ctx context.Context, query *domain.TimeReportQuery, claims *domain.Claims) (*domain.TimeEntryFilter, map[primitive.ObjectID]string, *domain.Error) {
+	filter := &domain.TimeEntryFilter{
+		Tag:            strings.ToLower(strings.TrimSpace(query.Tag)),
+		StartedFrom:    query.From,
+		ExcludeRunning: true,
+	}
+	if !query.To.IsZero() {
+		filter.StartedBefore = query.To.AddDate(0, 0, 1)
+	}
+
+	var workspaces []domain.Workspace
+	if query.WorkspaceID != "" {
+		workspaceID, _err := parseQueryID(query.WorkspaceID, "workspace")
+		if _err != nil {
+			return nil, nil, _err
+		}
+
+		workspace, err := tu.workspaceRepo.GetWorkspaceByID(ctx, workspaceID)
+		if err == nil && workspace.MemberRole(claims.ID) == "" {
+			err = mongo.ErrNoDocuments
+		}
+		if err != nil {
+			return nil, nil, notFoundOrInternal(err, domain.CodeWorkspaceNotFound, "Workspace not found")
+		}
+
+		workspaces = []domain.Workspace{*workspace}
+	} else {
+		var err error
+		workspaces, err = tu.workspaceRepo.GetWorkspacesByUserID(ctx, claims.ID)
+		if err != nil {
+			return nil, nil, internalError(err)
+		}
+
+		filter.IncludeUnassigned = true
+	}
+
+	workspaceRoles := map[primitive.ObjectID]string{}
+	filter.WorkspaceIDs = []primitive.ObjectID{}
+	for _, workspace := range workspaces {
+		filter.WorkspaceIDs = append(filter.WorkspaceIDs, workspace.ID)
+		workspaceRoles[workspace.ID] = workspace.MemberRole(claims.ID)
+	}
+
+	if query.UserID != "" {
+		userID, _err := parseQueryID(query.UserID, "user")
+		if _err != nil {
+			return nil, nil, _err
+		}
+
+		filter.UserID = userID
+	}
+
+	if query.TaskID != "" {
+		taskID, _err := parseQueryID(query.TaskID, "task")
+		if _err != nil {
+			return nil, nil, _err
+		}
+
+		filter.TaskIDs = []primitive.ObjectID{taskID}
+	}
+
+	return filter, workspaceRoles, nil
+}
+
+// A helper method that names the rows of a report after their users or tasks. The rows of deleted users and tasks
+// keep an empty label.
+func (tu *TimeEntryUsecase) labelRows(ctx context.Context, report *domain.TimeReport) *domain.Error {
+	switch report.GroupBy {
+	case domain.TimeReportByUser:
+		ids := []primitive.ObjectID{}
+		for _, row := range report.Rows {
+			id, _ := primitive.ObjectIDFromHex(row.Key)
+			ids = append(ids, id)
+		}
+
+		users, err := tu.userRepo.GetUsersByIDs(ctx, ids)
+		if err != nil {
+			return internalError(err)
+		}
+
+		usernames := map[string]string{}
+		for _, user := range users {
+			usernames[user.ID.Hex()] = user.Username
+		}
+
+		for i := range report.Rows {
+			report.Rows[i].Label = usernames[report.Rows[i].Key]
+		}
+	case domain.TimeReportByTask:
+		for i := range report.Rows {
+			id, _ := primitive.ObjectIDFromHex(report.Rows[i].Key)
+			task, err := tu.taskRepo.GetTaskByID(ctx, id)
+			if err != nil && err != mongo.ErrNoDocuments {
+				return internalError(err)
+			}
+			if err == nil {
+				report.Rows[i].Label = task.Title
+			}
+		}
+	}
+
+	sort.SliceStable(report.Rows, func(i, j int) bool {
+		if report.Rows[i].Label != report.Rows[j].Label {
+			return report.Rows[i].Label < report.Rows[j].Label
+		}
+
+		return report.Rows[i].Key < report.Rows[j].Key
+	})
+
+	return nil
+}
+
+// A helper method that returns a task that the user can view and log time on.
+func (tu *TimeEntryUsecase) getTrackedTask(ctx context.Context, taskID primitive.ObjectID, claims *domain.Claims) (*domain.Task, *domain.Error) {
+	task, workspace, _err := tu.getTask(ctx, taskID)
+	if _err != nil {
+		return nil, _err
+	}
+
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskRead, task.UserID, workspace, "view tasks")
+	if _err != nil {
+		return nil, _err
+	}
+
+	// The entries belong to the user, so logging time is updating a task of their own.
+	_, _err = tu.checkAccess(ctx, claims, domain.ActionTaskUpdate, claims.ID, workspace, "log time")
+	if _err != nil {
+		return nil, _err
+	}
+
+	return task, nil
+}
+
+// A helper method that returns a task with the given ID and its workspace, if it belongs to one.
+func (tu *TimeEntryUsecase) getTask(ctx context.Context, taskID primitive.ObjectID) (*domain.Task, *domain.Workspace, *domain.Error) {
+	task, err := tu.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, nil, notFoundOrInternal(err, domain.CodeTaskNotFound, "Task not found")
+	}
+
+	if task.WorkspaceID.IsZero() {
+		return task, nil, nil
+	}
+
+	workspace, err := tu.workspaceRepo.GetWorkspaceByID(ctx, task.WorkspaceID)
+	if err != nil {
+		return nil, nil, notFoundOrInternal(err, domain.CodeTaskNotFound, "Task not found")
+	}
+
+	return task, workspace, nil
+}
+
+// A helper method that checks if the user may perform the action on a resource owned by ownerID in the workspace.
+// The verb describes the action in the messages of the denials.
+func (tu *TimeEntryUsecase) checkAccess(ctx context.Context, claims *domain.Claims, action string, ownerID primitive.ObjectID, workspace *domain.Workspace, verb string) (*domain.Decision, *domain.Error) {
+	decision, _err := authorize(ctx, tu.authorizer, claims, &domain.AccessRequest{Action: action, OwnerID: ownerID, Workspace: workspace})
+	if _err != nil {
+		return nil, _err
+	}
+
+	switch {
+	case decision.Allowed:
+		return decision, nil
+	case decision.Denial == domain.DenialNotMember:
+		// Tasks in workspaces of other teams are reported as missing.
+		return nil, &domain.Error{
+			Err:        errors.New(decision.Reason),
+			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeTaskNotFound,
+			Message:    "Task not found",
+		}
+	case decision.Denial == domain.DenialWorkspaceRole:
+		return nil, &domain.Error{
+			Err:        errors.New(decision.Reason),
+			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenWorkspaceRole,
+			Message:    "Your workspace role does not allow you to " + verb,
+		}
+	case decision.Denial == domain.DenialNotOwner:
+		return nil, &domain.Error{
+			Err:        errors.New(decision.Reason),
+			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenNotOwner,
+			Message:    "A " + titleRole(claims.Role) + " cannot " + verb,
+		}
+	}
+
+	return nil, &domain.Error{
+		Err:        errors.New(decision.Reason),
+		StatusCode: http.StatusForbidden,
+		Code:       domain.CodeForbiddenPermission,
+		Message:    "You do not have permission to " + verb,
+	}
+}
+
+// A helper function that adds up the time entries per user, task, tag or day. Entries without tags are grouped
+// under an empty tag.
+func groupTimeEntries(entries []domain.TimeEntry, groupBy string) []domain.TimeReportRow {
+	rows := []domain.TimeReportRow{}
+	indexes := map[string]int{}
+	add := func(key string, entry domain.TimeEntry) {
+		i, ok := indexes[key]
+		if !ok {
+			i = len(rows)
+			indexes[key] = i
+			rows = append(rows, domain.TimeReportRow{Key: key})
+			if groupBy == domain.TimeReportByTag || groupBy == domain.TimeReportByDay {
+				rows[i].Label = key
+			}
+		}
+
+		rows[i].Entries++
+		rows[i].Seconds += entry.DurationSeconds
+	}
+
+	for _, entry := range entries {
+		switch groupBy {
+		case domain.TimeReportByUser:
+			add(entry.UserID.Hex(), entry)
+		case domain.TimeReportByTask:
+			add(entry.TaskID.Hex(), entry)
+		case domain.TimeReportByDay:
+			add(entry.StartedAt.UTC().Format(time.DateOnly), entry)
+		case domain.TimeReportByTag:
+			if len(entry.Tags) == 0 {
+				add("", entry)
+			}
+			for _, tag := range entry.Tags {
+				add(tag, entry)
+			}
+		}
+	}
+
+	return rows
+}
+
+// A helper function that trims and lowercases the tags and removes the duplicates, so that a tag is spelled the
+// same way in every entry.
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+
+	return normalized
+}
+
+// A helper function that parses an ID of a query parameter.
+func parseQueryID(id string, idType string) (primitive.ObjectID, *domain.Error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeInvalidID,
+			Message:    "Invalid " + idType + " ID",
+		}
+	}
+
+	return objectID, nil
+}
+
+// A helper function that reports a field of a request that breaks a rule the validate tags cannot express.
+func invalidField(field string, reason string) *domain.Error {
+	return &domain.Error{
+		Err:        errors.New(field + " " + reason),
+		StatusCode: http.StatusBadRequest,
+		Code:       domain.CodeValidationFailed,
+		Message:    field + " " + reason,
+		Fields:     []domain.FieldError{{Field: field, Reason: reason}},
+	}
+}
+
+// A helper function that reports that the user already has a running timer, on the given task if it is known.
+func timerAlreadyRunning(taskID primitive.ObjectID) *domain.Error {
+	message := "A timer is already running"
+	if !taskID.IsZero() {
+		message += " on task " + taskID.Hex()
+	}
+
+	return &domain.Error{
+		Err:        errors.New("timer already running"),
+		StatusCode: http.StatusConflict,
+		Code:       domain.CodeTimerAlreadyRunning,
+		Message:    message,
+	}
+}
+
+// A helper function that reports that the user has no running timer.
+func timerNotRunning(statusCode int, message string) *domain.Error {
+	return &domain.Error{
+		Err:        errors.New("timer not running"),
+		StatusCode: statusCode,
+		Code:       domain.CodeTimerNotRunning,
+		Message:    message,
+	}
+}
+
+// A helper function that returns the current time as stored, to the second, so that durations are whole seconds.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
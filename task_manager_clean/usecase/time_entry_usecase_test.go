@@ -0,0 +1,346 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"task_manager/mocks"
+	"task_manager/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	mockTimeEntry = mock.AnythingOfType("*domain.TimeEntry")
+)
+
+// A suite that tests the time entry usecase.
+type TimeEntryUsecaseSuite struct {
+	suite.Suite
+	timeEntryRepo *mocks.TimeEntryRepository
+	taskRepo      *mocks.TaskRepository
+	workspaceRepo *mocks.WorkspaceRepository
+	userRepo      *mocks.UserRepository
+	usecase       *usecase.TimeEntryUsecase
+}
+
+// A method that sets up the test suite.
+func (suite *TimeEntryUsecaseSuite) SetupTest() {
+	suite.timeEntryRepo = new(mocks.TimeEntryRepository)
+	suite.taskRepo = new(mocks.TaskRepository)
+	suite.workspaceRepo = new(mocks.WorkspaceRepository)
+	suite.userRepo = new(mocks.UserRepository)
+	authorizer := infrastructure.NewRoleAuthorizer(new(mocks.RoleRepository))
+	suite.usecase = usecase.NewTimeEntryUsecase(suite.timeEntryRepo, suite.taskRepo, suite.workspaceRepo, suite.userRepo, authorizer)
+}
+
+// A method that tears down the test suite.
+func (suite *TimeEntryUsecaseSuite) TearDownTest() {
+	suite.timeEntryRepo.AssertExpectations(suite.T())
+	suite.taskRepo.AssertExpectations(suite.T())
+	suite.workspaceRepo.AssertExpectations(suite.T())
+	suite.userRepo.AssertExpectations(suite.T())
+}
+
+// A helper method that returns a task of the workspace created by the user, and expects it to be read.
+func (suite *TimeEntryUsecaseSuite) workspaceTask(claims *domain.Claims, workspace *domain.Workspace) *domain.Task {
+	task := mocks.GetNewTask()
+	task.UserID = claims.ID
+	task.WorkspaceID = workspace.ID
+	suite.taskRepo.On("GetTaskByID", mock.Anything, task.ID).Return(task, nil).Once()
+	suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+	return task
+}
+
+// A test for the TimeEntryUsecase.StartTimer method.
+func (suite *TimeEntryUsecaseSuite) Test_StartTimer() {
+	// A testcase where a member starts a timer with tags that are normalized.
+	suite.Run("StartTimer_Success", func() {
+		claims := mocks.GetClaims()
+		task := suite.workspaceTask(claims, mocks.GetWorkspace())
+		suite.timeEntryRepo.On("GetRunningTimeEntry", mock.Anything, claims.ID).Return(nil, mongo.ErrNoDocuments).Once()
+		suite.timeEntryRepo.On("AddTimeEntry", mock.Anything, mockTimeEntry).Return(nil).Once()
+
+		entry, err := suite.usecase.StartTimer(context.Background(), task.ID, &domain.StartTimerData{Note: "Review", Tags: []string{" Billable", "billable", "QA "}}, claims)
+		suite.Nil(err)
+		suite.True(entry.Running)
+		suite.Equal(task.WorkspaceID, entry.WorkspaceID)
+		suite.Equal(claims.ID, entry.UserID)
+		suite.Equal([]string{"billable", "qa"}, entry.Tags)
+	})
+
+	// A testcase where the user already has a running timer on another task.
+	suite.Run("StartTimer_AlreadyRunning", func() {
+		claims := mocks.GetClaims()
+		task := suite.workspaceTask(claims, mocks.GetWorkspace())
+		running := mocks.GetRunningTimeEntry()
+		running.TaskID = mocks.GetPrimitiveID3()
+		suite.timeEntryRepo.On("GetRunningTimeEntry", mock.Anything, claims.ID).Return(running, nil).Once()
+
+		entry, err := suite.usecase.StartTimer(context.Background(), task.ID, &domain.StartTimerData{}, claims)
+		suite.Nil(entry)
+		suite.Equal(http.StatusConflict, err.StatusCode)
+		suite.Equal(domain.CodeTimerAlreadyRunning, err.Code)
+		suite.Contains(err.Message, running.TaskID.Hex())
+	})
+
+	// A testcase where a timer is started at the same time by another request.
+	suite.Run("StartTimer_DuplicateKey", func() {
+		claims := mocks.GetClaims()
+		task := suite.workspaceTask(claims, mocks.GetWorkspace())
+		suite.timeEntryRepo.On("GetRunningTimeEntry", mock.Anything, claims.ID).Return(nil, mongo.ErrNoDocuments).Once()
+		suite.timeEntryRepo.On("AddTimeEntry", mock.Anything, mockTimeEntry).Return(mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}).Once()
+
+		entry, err := suite.usecase.StartTimer(context.Background(), task.ID, &domain.StartTimerData{}, claims)
+		suite.Nil(entry)
+		suite.Equal(domain.CodeTimerAlreadyRunning, err.Code)
+	})
+
+	// A testcase where a viewer of the workspace cannot log time.
+	suite.Run("StartTimer_Viewer", func() {
+		claims := mocks.GetClaims()
+		workspace := mocks.GetWorkspace()
+		workspace.Members[0].Role = domain.WorkspaceRoleViewer
+		task := suite.workspaceTask(claims, workspace)
+
+		entry, err := suite.usecase.StartTimer(context.Background(), task.ID, &domain.StartTimerData{}, claims)
+		suite.Nil(entry)
+		suite.Equal(http.StatusForbidden, err.StatusCode)
+		suite.Equal(domain.CodeForbiddenWorkspaceRole, err.Code)
+	})
+
+	// A testcase where the task is in a workspace of another team.
+	suite.Run("StartTimer_NotMember", func() {
+		claims := mocks.GetClaims3()
+		claims.Role = "user"
+		task := suite.workspaceTask(mocks.GetClaims(), mocks.GetWorkspace())
+
+		entry, err := suite.usecase.StartTimer(context.Background(), task.ID, &domain.StartTimerData{}, claims)
+		suite.Nil(entry)
+		suite.Equal(http.StatusNotFound, err.StatusCode)
+	})
+}
+
+// A test for the TimeEntryUsecase.StopTimer method.
+func (suite *TimeEntryUsecaseSuite) Test_StopTimer() {
+	// A testcase where the running timer of the task is stopped.
+	suite.Run("StopTimer_Success", func() {
+		claims := mocks.GetClaims()
+		running := mocks.GetRunningTimeEntry()
+		suite.timeEntryRepo.On("GetRunningTimeEntry", mock.Anything, claims.ID).Return(running, nil).Once()
+		suite.timeEntryRepo.On("StopTimeEntry", mock.Anything, running.ID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("int64")).Return(nil).Once()
+
+		entry, err := suite.usecase.StopTimer(context.Background(), running.TaskID, claims)
+		suite.Nil(err)
+		suite.False(entry.Running)
+		suite.NotNil(entry.EndedAt)
+		suite.InDelta(3600, entry.DurationSeconds, 5)
+	})
+
+	// A testcase where the timer of the user runs on another task.
+	suite.Run("StopTimer_OtherTask", func() {
+		claims := mocks.GetClaims()
+		suite.timeEntryRepo.On("GetRunningTimeEntry", mock.Anything, claims.ID).Return(mocks.GetRunningTimeEntry(), nil).Once()
+
+		entry, err := suite.usecase.StopTimer(context.Background(), mocks.GetPrimitiveID3(), claims)
+		suite.Nil(entry)
+		suite.Equal(http.StatusConflict, err.StatusCode)
+		suite.Equal(domain.CodeTimerNotRunning, err.Code)
+	})
+
+	// A testcase where the timer was stopped by another request in the meantime.
+	suite.Run("StopTimer_AlreadyStopped", func() {
+		claims := mocks.GetClaims()
+		running := mocks.GetRunningTimeEntry()
+		suite.timeEntryRepo.On("GetRunningTimeEntry", mock.Anything, claims.ID).Return(running, nil).Once()
+		suite.timeEntryRepo.On("StopTimeEntry", mock.Anything, running.ID, mock.Anything, mock.Anything).Return(mongo.ErrNoDocuments).Once()
+
+		entry, err := suite.usecase.StopTimer(context.Background(), running.TaskID, claims)
+		suite.Nil(entry)
+		suite.Equal(domain.CodeTimerNotRunning, err.Code)
+	})
+}
+
+// A test for the TimeEntryUsecase.GetRunningTimer method.
+func (suite *TimeEntryUsecaseSuite) Test_GetRunningTimer() {
+	// A testcase where the user has no running timer.
+	suite.Run("GetRunningTimer_NotRunning", func() {
+		claims := mocks.GetClaims()
+		suite.timeEntryRepo.On("GetRunningTimeEntry", mock.Anything, claims.ID).Return(nil, mongo.ErrNoDocuments).Once()
+
+		entry, err := suite.usecase.GetRunningTimer(context.Background(), claims)
+		suite.Nil(entry)
+		suite.Equal(http.StatusNotFound, err.StatusCode)
+		suite.Equal(domain.CodeTimerNotRunning, err.Code)
+	})
+}
+
+// A test for the TimeEntryUsecase.AddTimeEntry method.
+func (suite *TimeEntryUsecaseSuite) Test_AddTimeEntry() {
+	// A testcase where an entry is logged manually.
+	suite.Run("AddTimeEntry_Success", func() {
+		claims := mocks.GetClaims()
+		task := suite.workspaceTask(claims, mocks.GetWorkspace())
+		startedAt := time.Now().Add(-3 * time.Hour)
+		suite.timeEntryRepo.On("AddTimeEntry", mock.Anything, mockTimeEntry).Return(nil).Once()
+
+		entry, err := suite.usecase.AddTimeEntry(context.Background(), task.ID, &domain.TimeEntryData{StartedAt: startedAt, EndedAt: startedAt.Add(90 * time.Minute), Note: "Call"}, claims)
+		suite.Nil(err)
+		suite.False(entry.Running)
+		suite.Equal(int64(5400), entry.DurationSeconds)
+		suite.Equal("Call", entry.Note)
+	})
+
+	// A testcase where the entry ends before it starts.
+	suite.Run("AddTimeEntry_InvalidRange", func() {
+		startedAt := time.Now().Add(-time.Hour)
+
+		entry, err := suite.usecase.AddTimeEntry(context.Background(), mocks.GetPrimitiveID1(), &domain.TimeEntryData{StartedAt: startedAt, EndedAt: startedAt}, mocks.GetClaims())
+		suite.Nil(entry)
+		suite.Equal(domain.CodeValidationFailed, err.Code)
+		suite.Equal("ended_at", err.Fields[0].Field)
+	})
+
+	// A testcase where the entry ends in the future.
+	suite.Run("AddTimeEntry_Future", func() {
+		startedAt := time.Now()
+
+		entry, err := suite.usecase.AddTimeEntry(context.Background(), mocks.GetPrimitiveID1(), &domain.TimeEntryData{StartedAt: startedAt, EndedAt: startedAt.Add(time.Hour)}, mocks.GetClaims())
+		suite.Nil(entry)
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+	})
+}
+
+// A test for the TimeEntryUsecase.DeleteTimeEntry method.
+func (suite *TimeEntryUsecaseSuite) Test_DeleteTimeEntry() {
+	// A testcase where the owner of the workspace deletes the entry of a member.
+	suite.Run("DeleteTimeEntry_WorkspaceOwner", func() {
+		workspace := mocks.GetWorkspace()
+		entry := mocks.GetTimeEntry(mocks.GetPrimitiveID1(), nil, 60)
+		suite.timeEntryRepo.On("GetTimeEntryByID", mock.Anything, entry.ID).Return(entry, nil).Once()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.timeEntryRepo.On("DeleteTimeEntry", mock.Anything, entry.ID).Return(nil).Once()
+
+		claims := mocks.GetClaims2()
+		claims.Role = "user"
+		err := suite.usecase.DeleteTimeEntry(context.Background(), entry.TaskID, entry.ID, claims)
+		suite.Nil(err)
+	})
+
+	// A testcase where a member deletes the entry of another member.
+	suite.Run("DeleteTimeEntry_OtherUser", func() {
+		workspace := mocks.GetWorkspace()
+		entry := mocks.GetTimeEntry(mocks.GetPrimitiveID2(), nil, 60)
+		suite.timeEntryRepo.On("GetTimeEntryByID", mock.Anything, entry.ID).Return(entry, nil).Once()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+
+		err := suite.usecase.DeleteTimeEntry(context.Background(), entry.TaskID, entry.ID, mocks.GetClaims())
+		suite.Equal(http.StatusForbidden, err.StatusCode)
+		suite.Equal(domain.CodeForbiddenNotOwner, err.Code)
+	})
+
+	// A testcase where the entry belongs to another task.
+	suite.Run("DeleteTimeEntry_OtherTask", func() {
+		entry := mocks.GetTimeEntry(mocks.GetPrimitiveID1(), nil, 60)
+		suite.timeEntryRepo.On("GetTimeEntryByID", mock.Anything, entry.ID).Return(entry, nil).Once()
+
+		err := suite.usecase.DeleteTimeEntry(context.Background(), mocks.GetPrimitiveID3(), entry.ID, mocks.GetClaims())
+		suite.Equal(http.StatusNotFound, err.StatusCode)
+		suite.Equal(domain.CodeTimeEntryNotFound, err.Code)
+	})
+}
+
+// A test for the TimeEntryUsecase.GetTimeReport method.
+func (suite *TimeEntryUsecaseSuite) Test_GetTimeReport() {
+	// A testcase where an admin aggregates the time of a workspace by tag.
+	suite.Run("GetTimeReport_ByTag", func() {
+		claims := mocks.GetClaims2()
+		workspace := mocks.GetWorkspace()
+		from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC)
+		entries := []domain.TimeEntry{
+			*mocks.GetTimeEntry(mocks.GetPrimitiveID1(), []string{"billable", "qa"}, 3600),
+			*mocks.GetTimeEntry(mocks.GetPrimitiveID2(), []string{"billable"}, 1800),
+			*mocks.GetTimeEntry(mocks.GetPrimitiveID2(), nil, 600),
+		}
+		filter := &domain.TimeEntryFilter{
+			WorkspaceIDs:   []primitive.ObjectID{workspace.ID},
+			StartedFrom:    from,
+			StartedBefore:  to.AddDate(0, 0, 1),
+			ExcludeRunning: true,
+		}
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.timeEntryRepo.On("GetTimeEntries", mock.Anything, filter).Return(entries, nil).Once()
+
+		query := &domain.TimeReportQuery{WorkspaceID: workspace.ID.Hex(), From: from, To: to, GroupBy: domain.TimeReportByTag}
+		report, err := suite.usecase.GetTimeReport(context.Background(), query, claims)
+		suite.Nil(err)
+		suite.Equal(3, report.Entries)
+		suite.Equal(int64(6000), report.TotalSeconds)
+		suite.Equal([]domain.TimeReportRow{
+			{Key: "", Label: "", Entries: 1, Seconds: 600},
+			{Key: "billable", Label: "billable", Entries: 2, Seconds: 5400},
+			{Key: "qa", Label: "qa", Entries: 1, Seconds: 3600},
+		}, report.Rows)
+	})
+
+	// A testcase where a user aggregates the time of their workspaces by user.
+	suite.Run("GetTimeReport_ByUser", func() {
+		claims := mocks.GetClaims()
+		workspace := mocks.GetWorkspace()
+		entries := []domain.TimeEntry{
+			*mocks.GetTimeEntry(mocks.GetPrimitiveID2(), nil, 1800),
+			*mocks.GetTimeEntry(mocks.GetPrimitiveID1(), nil, 3600),
+			*mocks.GetTimeEntry(mocks.GetPrimitiveID2(), nil, 600),
+		}
+		users := []domain.User{*mocks.GetUser2(mocks.GetClaims2()), *mocks.GetUser2(claims)}
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, claims.ID).Return([]domain.Workspace{*workspace}, nil).Once()
+		suite.timeEntryRepo.On("GetTimeEntries", mock.Anything, &domain.TimeEntryFilter{
+			WorkspaceIDs:      []primitive.ObjectID{workspace.ID},
+			IncludeUnassigned: true,
+			ExcludeRunning:    true,
+		}).Return(entries, nil).Once()
+		suite.userRepo.On("GetUsersByIDs", mock.Anything, []primitive.ObjectID{mocks.GetPrimitiveID2(), mocks.GetPrimitiveID1()}).Return(users, nil).Once()
+
+		report, err := suite.usecase.GetTimeReport(context.Background(), &domain.TimeReportQuery{}, claims)
+		suite.Nil(err)
+		suite.Equal(domain.TimeReportByUser, report.GroupBy)
+		suite.Equal(int64(6000), report.TotalSeconds)
+		suite.Equal([]domain.TimeReportRow{
+			{Key: mocks.GetPrimitiveID1().Hex(), Label: "user1", Entries: 1, Seconds: 3600},
+			{Key: mocks.GetPrimitiveID2().Hex(), Label: "user2", Entries: 2, Seconds: 2400},
+		}, report.Rows)
+	})
+
+	// A testcase where the range of the report ends before it starts.
+	suite.Run("GetTimeReport_InvalidRange", func() {
+		query := &domain.TimeReportQuery{From: time.Now(), To: time.Now().AddDate(0, 0, -1)}
+
+		report, err := suite.usecase.GetTimeReport(context.Background(), query, mocks.GetClaims())
+		suite.Nil(report)
+		suite.Equal("to", err.Fields[0].Field)
+	})
+
+	// A testcase where the time entries cannot be read.
+	suite.Run("GetTimeReport_Error", func() {
+		claims := mocks.GetClaims2()
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, claims.ID).Return([]domain.Workspace{}, nil).Once()
+		suite.timeEntryRepo.On("GetTimeEntries", mock.Anything, mockTimeEntryFilter).Return(nil, errors.New("connection lost")).Once()
+
+		report, err := suite.usecase.GetTimeReport(context.Background(), &domain.TimeReportQuery{}, claims)
+		suite.Nil(report)
+		suite.Equal(http.StatusInternalServerError, err.StatusCode)
+	})
+}
+
+// A function that runs the TimeEntryUsecaseSuite.
+func TestTimeEntryUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(TimeEntryUsecaseSuite))
+}
@@ -0,0 +1,459 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// The number of recovery codes issued when two-factor authentication is activated.
+	recoveryCodeCount = 10
+
+	// The number of codes that can be tried with an intermediate login token.
+	twoFactorTokenAttempts = 3
+
+	// The number of codes that can be tried for a user in a window, whatever the intermediate tokens.
+	twoFactorUserAttempts  = 5
+	twoFactorAttemptWindow = 15 * time.Minute
+)
+
+// A struct that defines the services for two-factor authentication.
+type TwoFactorUsecase struct {
+	userRepo     domain.UserRepository
+	settingsRepo domain.SettingsRepository
+	authorizer   domain.Authorizer
+	tokens       domain.TokenService
+	issuer       string
+
+	// The number of codes tried with each intermediate token that has not expired, by the hash of the token.
+	mu            sync.Mutex
+	tokenAttempts map[string]*tokenAttempts
+}
+
+// A struct that defines the codes tried with an intermediate token.
+type tokenAttempts struct {
+	count     int
+	expiresAt time.Time
+}
+
+// A constructor that creates a new instance of TwoFactorUsecase.
+// The issuer is the name shown for the account in authenticator apps.
+func NewTwoFactorUsecase(userRepo domain.UserRepository, settingsRepo domain.SettingsRepository, authorizer domain.Authorizer, tokens domain.TokenService, issuer string) *TwoFactorUsecase {
+	return &TwoFactorUsecase{
+		userRepo:      userRepo,
+		settingsRepo:  settingsRepo,
+		authorizer:    authorizer,
+		tokens:        tokens,
+		issuer:        issuer,
+		tokenAttempts: map[string]*tokenAttempts{},
+	}
+}
+
+// A method that generates a new TOTP secret for the logged in user. It has to be activated with a valid code.
+func (tu *TwoFactorUsecase) SetupTwoFactor(ctx context.Context, claims *domain.Claims) (*domain.TwoFactorSetup, *domain.Error) {
+	user, _err := tu.getUser(ctx, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	if user.TOTPEnabled {
+		return nil, &domain.Error{
+			Err:        errors.New("two-factor authentication already enabled"),
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeTwoFactorAlreadyEnabled,
+			Message:    "Two-factor authentication is already enabled",
+		}
+	}
+
+	// Generate and store the pending secret.
+	secret, err := infrastructure.GenerateTOTPSecret()
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	err = tu.userRepo.UpdateUser(ctx, user.ID, bson.M{"totp_secret": secret})
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return &domain.TwoFactorSetup{
+		Secret:     secret,
+		OTPAuthURI: infrastructure.TOTPURI(tu.issuer, user.Username, secret),
+	}, nil
+}
+
+// A method that activates two-factor authentication after verifying a code of the pending secret.
+// It returns the recovery codes, which are only shown once.
+func (tu *TwoFactorUsecase) ActivateTwoFactor(ctx context.Context, data *domain.TwoFactorCodeData, claims *domain.Claims) ([]string, *domain.Error) {
+	user, _err := tu.getUser(ctx, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	if user.TOTPEnabled {
+		return nil, &domain.Error{
+			Err:        errors.New("two-factor authentication already enabled"),
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeTwoFactorAlreadyEnabled,
+			Message:    "Two-factor authentication is already enabled",
+		}
+	}
+
+	if user.TOTPSecret == "" {
+		return nil, &domain.Error{
+			Err:        errors.New("two-factor authentication not set up"),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeTwoFactorNotSetUp,
+			Message:    "Two-factor authentication has not been set up",
+		}
+	}
+
+	_err = tu.claimTOTPCode(ctx, user, data.Code)
+	if _err != nil {
+		return nil, _err
+	}
+
+	// Generate the recovery codes and store only their hashes.
+	codes, err := infrastructure.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = infrastructure.HashToken(code)
+	}
+
+	err = tu.userRepo.UpdateUser(ctx, user.ID, bson.M{"totp_enabled": true, "recovery_codes": hashes})
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return codes, nil
+}
+
+// A method that disables two-factor authentication after verifying a TOTP or recovery code.
+func (tu *TwoFactorUsecase) DisableTwoFactor(ctx context.Context, data *domain.TwoFactorCodeData, claims *domain.Claims) *domain.Error {
+	user, _err := tu.getUser(ctx, claims)
+	if _err != nil {
+		return _err
+	}
+
+	if !user.TOTPEnabled {
+		return &domain.Error{
+			Err:        errors.New("two-factor authentication not enabled"),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeTwoFactorNotEnabled,
+			Message:    "Two-factor authentication is not enabled",
+		}
+	}
+
+	// Admins cannot opt out while the root user requires two-factor authentication.
+	if user.Role == "admin" {
+		settings, err := tu.settingsRepo.GetSecuritySettings(ctx)
+		if err != nil {
+			return &domain.Error{
+				Err:        err,
+				StatusCode: http.StatusInternalServerError,
+				Message:    "Internal server error",
+			}
+		}
+
+		if settings.RequireAdminTwoFactor {
+			return &domain.Error{
+				Err:        errors.New("two-factor authentication required for admins"),
+				StatusCode: http.StatusForbidden,
+				Code:       domain.CodeTwoFactorRequired,
+				Message:    "Two-factor authentication is required for admin users",
+			}
+		}
+	}
+
+	_err = tu.verifyCode(ctx, user, data.Code)
+	if _err != nil {
+		return _err
+	}
+
+	err := tu.userRepo.UpdateUser(ctx, user.ID, bson.M{"totp_enabled": false, "totp_secret": "", "recovery_codes": []string{}})
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return nil
+}
+
+// A method that completes a two-factor login by exchanging the intermediate token and a code for a full token.
+func (tu *TwoFactorUsecase) VerifyLogin(ctx context.Context, data *domain.TwoFactorLoginData) (string, *domain.Error) {
+	// Validate the intermediate token.
+	claims, err := tu.tokens.ParseToken(data.MFAToken)
+	if err != nil || claims.Purpose != domain.PurposeTwoFactorLogin {
+		return "", &domain.Error{
+			Err:        errors.New("invalid mfa token"),
+			StatusCode: http.StatusUnauthorized,
+			Code:       domain.CodeInvalidToken,
+			Message:    "Invalid or expired token",
+		}
+	}
+
+	// Limit the codes that can be tried with the token, so that guessing a code needs a new password login.
+	if !tu.addTokenAttempt(data.MFAToken, time.Unix(claims.StandardClaims.ExpiresAt, 0)) {
+		return "", tooManyAttemptsError()
+	}
+
+	user, _err := tu.getUser(ctx, claims)
+	if _err != nil {
+		return "", _err
+	}
+
+	if !user.TOTPEnabled {
+		return "", &domain.Error{
+			Err:        errors.New("two-factor authentication not enabled"),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeTwoFactorNotEnabled,
+			Message:    "Two-factor authentication is not enabled",
+		}
+	}
+
+	// Limit the codes that can be tried for the user across tokens and instances.
+	allowed, err := tu.userRepo.AddTwoFactorAttempt(ctx, user.ID, time.Now(), twoFactorAttemptWindow, twoFactorUserAttempts)
+	if err != nil {
+		return "", &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	if !allowed {
+		return "", tooManyAttemptsError()
+	}
+
+	_err = tu.verifyCode(ctx, user, data.Code)
+	if _err != nil {
+		return "", _err
+	}
+
+	err = tu.userRepo.UpdateUser(ctx, user.ID, bson.M{"two_factor_attempts": 0})
+	if err != nil {
+		return "", &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	// Generate the full JWT token for the user.
+	token, err := tu.tokens.GenerateToken(user)
+	if err != nil {
+		return "", &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return token, nil
+}
+
+// A method that returns the security settings. Only roles with the settings.manage permission can view them.
+func (tu *TwoFactorUsecase) GetSecuritySettings(ctx context.Context, claims *domain.Claims) (*domain.SecuritySettings, *domain.Error) {
+	_err := tu.canManageSettings(ctx, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	settings, err := tu.settingsRepo.GetSecuritySettings(ctx)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return settings, nil
+}
+
+// A method that updates the security settings. Only roles with the settings.manage permission can change them.
+func (tu *TwoFactorUsecase) UpdateSecuritySettings(ctx context.Context, settings *domain.SecuritySettings, claims *domain.Claims) (*domain.SecuritySettings, *domain.Error) {
+	_err := tu.canManageSettings(ctx, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	err := tu.settingsRepo.UpdateSecuritySettings(ctx, settings)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return settings, nil
+}
+
+// A helper method that checks if the logged in user can manage the security settings.
+func (tu *TwoFactorUsecase) canManageSettings(ctx context.Context, claims *domain.Claims) *domain.Error {
+	decision, _err := authorize(ctx, tu.authorizer, claims, &domain.AccessRequest{Action: domain.ActionSettingsManage})
+	if _err != nil {
+		return _err
+	}
+
+	if !decision.Allowed {
+		return &domain.Error{
+			Err:        errors.New(decision.Reason),
+			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenPermission,
+			Message:    "You need the " + domain.PermissionSettingsManage + " permission to manage security settings",
+		}
+	}
+
+	return nil
+}
+
+// A helper method that gets the user the claims belong to.
+func (tu *TwoFactorUsecase) getUser(ctx context.Context, claims *domain.Claims) (*domain.User, *domain.Error) {
+	user, err := tu.userRepo.GetUserByID(ctx, claims.ID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.Error{
+				Err:        err,
+				StatusCode: http.StatusNotFound,
+				Code:       domain.CodeUserNotFound,
+				Message:    "User not found",
+			}
+		}
+
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return user, nil
+}
+
+// A helper method that accepts a TOTP code that was not used before. A code is refused once it or a later code was
+// accepted, so that an intercepted code cannot be replayed while it is valid.
+func (tu *TwoFactorUsecase) claimTOTPCode(ctx context.Context, user *domain.User, code string) *domain.Error {
+	counter, ok := infrastructure.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return invalidCodeError()
+	}
+
+	claimed, err := tu.userRepo.ClaimTOTPCounter(ctx, user.ID, counter)
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	if !claimed {
+		return invalidCodeError()
+	}
+
+	return nil
+}
+
+// A helper method that accepts either an unused TOTP code or an unused recovery code, which is consumed.
+func (tu *TwoFactorUsecase) verifyCode(ctx context.Context, user *domain.User, code string) *domain.Error {
+	if _, ok := infrastructure.ValidateTOTP(user.TOTPSecret, code, time.Now()); ok {
+		return tu.claimTOTPCode(ctx, user, code)
+	}
+
+	// Consume the recovery code, which fails if the user does not have it or a concurrent request used it first.
+	consumed, err := tu.userRepo.ConsumeRecoveryCode(ctx, user.ID, infrastructure.HashToken(code))
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	if !consumed {
+		return invalidCodeError()
+	}
+
+	return nil
+}
+
+// A helper method that records a code tried with an intermediate token that expires at the given time. It reports
+// false if the token was already used for the maximum number of codes. The expired tokens are forgotten.
+func (tu *TwoFactorUsecase) addTokenAttempt(token string, expiresAt time.Time) bool {
+	tu.mu.Lock()
+	defer tu.mu.Unlock()
+
+	now := time.Now()
+	for hash, attempts := range tu.tokenAttempts {
+		if now.After(attempts.expiresAt) {
+			delete(tu.tokenAttempts, hash)
+		}
+	}
+
+	hash := infrastructure.HashToken(token)
+	attempts, ok := tu.tokenAttempts[hash]
+	if !ok {
+		attempts = &tokenAttempts{expiresAt: expiresAt}
+		tu.tokenAttempts[hash] = attempts
+	}
+
+	if attempts.count >= twoFactorTokenAttempts {
+		return false
+	}
+
+	attempts.count++
+	return true
+}
+
+// A helper function that creates the error returned when too many codes were tried.
+func tooManyAttemptsError() *domain.Error {
+	return &domain.Error{
+		Err:        errors.New("too many two-factor attempts"),
+		StatusCode: http.StatusTooManyRequests,
+		Code:       domain.CodeTooManyAttempts,
+		Message:    "Too many attempts, please log in again later",
+	}
+}
+
+// A helper function that creates the error returned for a wrong code.
+func invalidCodeError() *domain.Error {
+	return &domain.Error{
+		Err:        errors.New("invalid two-factor code"),
+		StatusCode: http.StatusUnauthorized,
+		Code:       domain.CodeInvalidTwoFactorCode,
+		Message:    "Invalid two-factor code",
+	}
+}
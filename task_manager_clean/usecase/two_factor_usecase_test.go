@@ -0,0 +1,314 @@
+package usecase_test
+
+import (
+	"context"
+	"net/http"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"task_manager/mocks"
+	"task_manager/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// A suite that tests the two-factor usecase.
+type TwoFactorUsecaseSuite struct {
+	suite.Suite
+	userRepo     *mocks.UserRepository
+	settingsRepo *mocks.SettingsRepository
+	tokens       *infrastructure.JWTService
+	usecase      *usecase.TwoFactorUsecase
+}
+
+// A method that sets up the test suite.
+func (suite *TwoFactorUsecaseSuite) SetupTest() {
+	suite.userRepo = new(mocks.UserRepository)
+	suite.settingsRepo = new(mocks.SettingsRepository)
+	suite.tokens = infrastructure.NewJWTService("test_key", time.Hour)
+	suite.usecase = usecase.NewTwoFactorUsecase(suite.userRepo, suite.settingsRepo, infrastructure.NewRoleAuthorizer(new(mocks.RoleRepository)), suite.tokens, "Task Manager")
+}
+
+// A method that sets up each subtest with a new usecase, so that the attempts of the intermediate tokens are not shared.
+func (suite *TwoFactorUsecaseSuite) SetupSubTest() {
+	suite.usecase = usecase.NewTwoFactorUsecase(suite.userRepo, suite.settingsRepo, infrastructure.NewRoleAuthorizer(new(mocks.RoleRepository)), suite.tokens, "Task Manager")
+}
+
+// A method that tears down the test suite.
+func (suite *TwoFactorUsecaseSuite) TearDownTest() {
+	suite.userRepo.AssertExpectations(suite.T())
+	suite.settingsRepo.AssertExpectations(suite.T())
+}
+
+// A helper method that returns a user with a TOTP secret.
+func (suite *TwoFactorUsecaseSuite) getTOTPUser(enabled bool) *domain.User {
+	user := mocks.GetUser2(mocks.GetClaims())
+	secret, err := infrastructure.GenerateTOTPSecret()
+	suite.Nil(err)
+
+	user.TOTPSecret = secret
+	user.TOTPEnabled = enabled
+	return user
+}
+
+// A helper method that returns the current TOTP code of a user.
+func (suite *TwoFactorUsecaseSuite) getCode(user *domain.User) string {
+	code, err := infrastructure.GenerateTOTPCode(user.TOTPSecret, time.Now())
+	suite.Nil(err)
+	return code
+}
+
+// A test for the TwoFactorUsecase.SetupTwoFactor method.
+func (suite *TwoFactorUsecaseSuite) Test_SetupTwoFactor() {
+	// A testcase where a pending secret is generated.
+	suite.Run("SetupTwoFactor_Success", func() {
+		claims := mocks.GetClaims()
+		user := mocks.GetUser2(claims)
+
+		suite.userRepo.On("GetUserByID", mock.Anything, claims.ID).Return(user, nil).Once()
+		suite.userRepo.On("UpdateUser", mock.Anything, claims.ID, mockBSON).Return(nil).Once()
+
+		setup, err := suite.usecase.SetupTwoFactor(context.Background(), claims)
+		suite.Nil(err)
+		suite.NotEmpty(setup.Secret)
+		suite.Contains(setup.OTPAuthURI, "otpauth://totp/")
+		suite.Contains(setup.OTPAuthURI, "secret="+setup.Secret)
+	})
+
+	// A testcase where two-factor authentication is already enabled.
+	suite.Run("SetupTwoFactor_AlreadyEnabled", func() {
+		claims := mocks.GetClaims()
+		suite.userRepo.On("GetUserByID", mock.Anything, claims.ID).Return(suite.getTOTPUser(true), nil).Once()
+
+		setup, err := suite.usecase.SetupTwoFactor(context.Background(), claims)
+		suite.Nil(setup)
+		suite.Equal(http.StatusConflict, err.StatusCode)
+	})
+}
+
+// A test for the TwoFactorUsecase.ActivateTwoFactor method.
+func (suite *TwoFactorUsecaseSuite) Test_ActivateTwoFactor() {
+	// A testcase where a valid code activates two-factor authentication.
+	suite.Run("ActivateTwoFactor_Success", func() {
+		claims := mocks.GetClaims()
+		user := suite.getTOTPUser(false)
+		var updateData bson.M
+
+		suite.userRepo.On("GetUserByID", mock.Anything, claims.ID).Return(user, nil).Once()
+		suite.userRepo.On("ClaimTOTPCounter", mock.Anything, claims.ID, mock.AnythingOfType("int64")).Return(true, nil).Once()
+		suite.userRepo.On("UpdateUser", mock.Anything, claims.ID, mockBSON).Return(nil).Run(func(args mock.Arguments) {
+			updateData = args.Get(2).(bson.M)
+		}).Once()
+
+		codes, err := suite.usecase.ActivateTwoFactor(context.Background(), &domain.TwoFactorCodeData{Code: suite.getCode(user)}, claims)
+		suite.Nil(err)
+		suite.Len(codes, 10)
+		suite.Equal(true, updateData["totp_enabled"])
+		suite.Equal(infrastructure.HashToken(codes[0]), updateData["recovery_codes"].([]string)[0])
+	})
+
+	// A testcase where the code is wrong.
+	suite.Run("ActivateTwoFactor_InvalidCode", func() {
+		claims := mocks.GetClaims()
+		suite.userRepo.On("GetUserByID", mock.Anything, claims.ID).Return(suite.getTOTPUser(false), nil).Once()
+
+		codes, err := suite.usecase.ActivateTwoFactor(context.Background(), &domain.TwoFactorCodeData{Code: "abcdef"}, claims)
+		suite.Nil(codes)
+		suite.Equal(http.StatusUnauthorized, err.StatusCode)
+	})
+}
+
+// A test for the TwoFactorUsecase.DisableTwoFactor method.
+func (suite *TwoFactorUsecaseSuite) Test_DisableTwoFactor() {
+	// A testcase where a regular user disables two-factor authentication.
+	suite.Run("DisableTwoFactor_Success", func() {
+		claims := mocks.GetClaims()
+		user := suite.getTOTPUser(true)
+
+		suite.userRepo.On("GetUserByID", mock.Anything, claims.ID).Return(user, nil).Once()
+		suite.userRepo.On("ClaimTOTPCounter", mock.Anything, claims.ID, mock.AnythingOfType("int64")).Return(true, nil).Once()
+		suite.userRepo.On("UpdateUser", mock.Anything, claims.ID, mockBSON).Return(nil).Once()
+
+		err := suite.usecase.DisableTwoFactor(context.Background(), &domain.TwoFactorCodeData{Code: suite.getCode(user)}, claims)
+		suite.Nil(err)
+	})
+
+	// A testcase where an admin cannot opt out while it is required.
+	suite.Run("DisableTwoFactor_RequiredForAdmin", func() {
+		claims := mocks.GetClaims()
+		user := suite.getTOTPUser(true)
+		user.Role = "admin"
+
+		suite.userRepo.On("GetUserByID", mock.Anything, claims.ID).Return(user, nil).Once()
+		suite.settingsRepo.On("GetSecuritySettings", mock.Anything, mock.Anything).Return(&domain.SecuritySettings{RequireAdminTwoFactor: true}, nil).Once()
+
+		err := suite.usecase.DisableTwoFactor(context.Background(), &domain.TwoFactorCodeData{Code: suite.getCode(user)}, claims)
+		suite.Equal(http.StatusForbidden, err.StatusCode)
+	})
+}
+
+// A test for the TwoFactorUsecase.VerifyLogin method.
+func (suite *TwoFactorUsecaseSuite) Test_VerifyLogin() {
+	// A testcase where a TOTP code completes the login.
+	suite.Run("VerifyLogin_Success", func() {
+		user := suite.getTOTPUser(true)
+		mfaToken, tokenErr := suite.tokens.GenerateMFAToken(user, domain.PurposeTwoFactorLogin)
+		suite.Nil(tokenErr)
+
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		suite.userRepo.On("AddTwoFactorAttempt", mock.Anything, user.ID, mock.Anything, 15*time.Minute, 5).Return(true, nil).Once()
+		suite.userRepo.On("ClaimTOTPCounter", mock.Anything, user.ID, time.Now().Unix()/30).Return(true, nil).Once()
+		suite.userRepo.On("UpdateUser", mock.Anything, user.ID, bson.M{"two_factor_attempts": 0}).Return(nil).Once()
+
+		token, err := suite.usecase.VerifyLogin(context.Background(), &domain.TwoFactorLoginData{MFAToken: mfaToken, Code: suite.getCode(user)})
+		suite.Nil(err)
+
+		claims, parseErr := suite.tokens.ParseToken(token)
+		suite.Nil(parseErr)
+		suite.Empty(claims.Purpose)
+		suite.Equal(user.ID, claims.ID)
+	})
+
+	// A testcase where a TOTP code that was already used is replayed.
+	suite.Run("VerifyLogin_ReplayedCode", func() {
+		user := suite.getTOTPUser(true)
+		mfaToken, tokenErr := suite.tokens.GenerateMFAToken(user, domain.PurposeTwoFactorLogin)
+		suite.Nil(tokenErr)
+
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		suite.userRepo.On("AddTwoFactorAttempt", mock.Anything, user.ID, mock.Anything, 15*time.Minute, 5).Return(true, nil).Once()
+		suite.userRepo.On("ClaimTOTPCounter", mock.Anything, user.ID, mock.AnythingOfType("int64")).Return(false, nil).Once()
+
+		token, err := suite.usecase.VerifyLogin(context.Background(), &domain.TwoFactorLoginData{MFAToken: mfaToken, Code: suite.getCode(user)})
+		suite.Empty(token)
+		suite.Equal(http.StatusUnauthorized, err.StatusCode)
+		suite.Equal(domain.CodeInvalidTwoFactorCode, err.Code)
+	})
+
+	// A testcase where a recovery code completes the login and is consumed.
+	suite.Run("VerifyLogin_RecoveryCode", func() {
+		user := suite.getTOTPUser(true)
+		user.RecoveryCodes = []string{infrastructure.HashToken("aaaa-bbbb"), infrastructure.HashToken("cccc-dddd")}
+		mfaToken, tokenErr := suite.tokens.GenerateMFAToken(user, domain.PurposeTwoFactorLogin)
+		suite.Nil(tokenErr)
+
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		suite.userRepo.On("AddTwoFactorAttempt", mock.Anything, user.ID, mock.Anything, 15*time.Minute, 5).Return(true, nil).Once()
+		suite.userRepo.On("ConsumeRecoveryCode", mock.Anything, user.ID, infrastructure.HashToken("aaaa-bbbb")).Return(true, nil).Once()
+		suite.userRepo.On("UpdateUser", mock.Anything, user.ID, bson.M{"two_factor_attempts": 0}).Return(nil).Once()
+
+		token, err := suite.usecase.VerifyLogin(context.Background(), &domain.TwoFactorLoginData{MFAToken: mfaToken, Code: "aaaa-bbbb"})
+		suite.Nil(err)
+		suite.NotEmpty(token)
+	})
+
+	// A testcase where a recovery code was already used by a concurrent login.
+	suite.Run("VerifyLogin_RecoveryCodeUsed", func() {
+		user := suite.getTOTPUser(true)
+		user.RecoveryCodes = []string{infrastructure.HashToken("aaaa-bbbb")}
+		mfaToken, tokenErr := suite.tokens.GenerateMFAToken(user, domain.PurposeTwoFactorLogin)
+		suite.Nil(tokenErr)
+
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		suite.userRepo.On("AddTwoFactorAttempt", mock.Anything, user.ID, mock.Anything, 15*time.Minute, 5).Return(true, nil).Once()
+		suite.userRepo.On("ConsumeRecoveryCode", mock.Anything, user.ID, infrastructure.HashToken("aaaa-bbbb")).Return(false, nil).Once()
+
+		token, err := suite.usecase.VerifyLogin(context.Background(), &domain.TwoFactorLoginData{MFAToken: mfaToken, Code: "aaaa-bbbb"})
+		suite.Empty(token)
+		suite.Equal(http.StatusUnauthorized, err.StatusCode)
+	})
+
+	// A testcase where a full token is used instead of the intermediate token.
+	suite.Run("VerifyLogin_WrongPurpose", func() {
+		user := suite.getTOTPUser(true)
+		fullToken, tokenErr := suite.tokens.GenerateToken(user)
+		suite.Nil(tokenErr)
+
+		token, err := suite.usecase.VerifyLogin(context.Background(), &domain.TwoFactorLoginData{MFAToken: fullToken, Code: suite.getCode(user)})
+		suite.Empty(token)
+		suite.Equal(http.StatusUnauthorized, err.StatusCode)
+	})
+
+	// A testcase where the code is wrong.
+	suite.Run("VerifyLogin_InvalidCode", func() {
+		user := suite.getTOTPUser(true)
+		mfaToken, tokenErr := suite.tokens.GenerateMFAToken(user, domain.PurposeTwoFactorLogin)
+		suite.Nil(tokenErr)
+
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		suite.userRepo.On("AddTwoFactorAttempt", mock.Anything, user.ID, mock.Anything, 15*time.Minute, 5).Return(true, nil).Once()
+		suite.userRepo.On("ConsumeRecoveryCode", mock.Anything, user.ID, infrastructure.HashToken("zzzz-zzzz")).Return(false, nil).Once()
+
+		token, err := suite.usecase.VerifyLogin(context.Background(), &domain.TwoFactorLoginData{MFAToken: mfaToken, Code: "zzzz-zzzz"})
+		suite.Empty(token)
+		suite.Equal(http.StatusUnauthorized, err.StatusCode)
+	})
+
+	// A testcase where the intermediate token was already used for the maximum number of codes.
+	suite.Run("VerifyLogin_TokenAttempts", func() {
+		user := suite.getTOTPUser(true)
+		mfaToken, tokenErr := suite.tokens.GenerateMFAToken(user, domain.PurposeTwoFactorLogin)
+		suite.Nil(tokenErr)
+
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Times(3)
+		suite.userRepo.On("AddTwoFactorAttempt", mock.Anything, user.ID, mock.Anything, 15*time.Minute, 5).Return(true, nil).Times(3)
+		suite.userRepo.On("ConsumeRecoveryCode", mock.Anything, user.ID, infrastructure.HashToken("zzzz-zzzz")).Return(false, nil).Times(3)
+
+		data := &domain.TwoFactorLoginData{MFAToken: mfaToken, Code: "zzzz-zzzz"}
+		for i := 0; i < 3; i++ {
+			_, err := suite.usecase.VerifyLogin(context.Background(), data)
+			suite.Equal(http.StatusUnauthorized, err.StatusCode)
+		}
+
+		token, err := suite.usecase.VerifyLogin(context.Background(), data)
+		suite.Empty(token)
+		suite.Equal(http.StatusTooManyRequests, err.StatusCode)
+		suite.Equal(domain.CodeTooManyAttempts, err.Code)
+	})
+
+	// A testcase where the user reached the maximum number of codes with other tokens.
+	suite.Run("VerifyLogin_UserAttempts", func() {
+		user := suite.getTOTPUser(true)
+		mfaToken, tokenErr := suite.tokens.GenerateMFAToken(user, domain.PurposeTwoFactorLogin)
+		suite.Nil(tokenErr)
+
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		suite.userRepo.On("AddTwoFactorAttempt", mock.Anything, user.ID, mock.Anything, 15*time.Minute, 5).Return(false, nil).Once()
+
+		token, err := suite.usecase.VerifyLogin(context.Background(), &domain.TwoFactorLoginData{MFAToken: mfaToken, Code: suite.getCode(user)})
+		suite.Empty(token)
+		suite.Equal(http.StatusTooManyRequests, err.StatusCode)
+		suite.Equal(domain.CodeTooManyAttempts, err.Code)
+	})
+}
+
+// A test for the TwoFactorUsecase.UpdateSecuritySettings method.
+func (suite *TwoFactorUsecaseSuite) Test_UpdateSecuritySettings() {
+	// A testcase where the root user requires two-factor authentication for admins.
+	suite.Run("UpdateSecuritySettings_Root", func() {
+		settings := &domain.SecuritySettings{RequireAdminTwoFactor: true}
+		suite.settingsRepo.On("UpdateSecuritySettings", mock.Anything, settings).Return(nil).Once()
+
+		result, err := suite.usecase.UpdateSecuritySettings(context.Background(), settings, mocks.GetClaims3())
+		suite.Nil(err)
+		suite.Equal(settings, result)
+	})
+
+	// A testcase where an admin tries to change the settings.
+	suite.Run("UpdateSecuritySettings_Admin", func() {
+		settings := &domain.SecuritySettings{RequireAdminTwoFactor: false}
+
+		result, err := suite.usecase.UpdateSecuritySettings(context.Background(), settings, mocks.GetClaims2())
+		suite.Nil(result)
+		suite.Equal(http.StatusForbidden, err.StatusCode)
+		suite.Equal("You need the settings.manage permission to manage security settings", err.Message)
+	})
+}
+
+// A function that runs the TwoFactorUsecaseSuite.
+func TestTwoFactorUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorUsecaseSuite))
+}
@@ -1,8 +1,11 @@
 package usecase
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"task_manager/domain"
 	"task_manager/infrastructure"
 
@@ -13,53 +16,77 @@ import (
 
 // A struct that defines the services for users.
 type UserUsecase struct {
-	userRepo domain.UserRepository
+	userRepo       domain.UserRepository
+	settingsRepo   domain.SettingsRepository
+	taskRepo       domain.TaskRepository
+	workspaceRepo  domain.WorkspaceRepository
+	timeEntryRepo  domain.TimeEntryRepository
+	tokenRepo      domain.AccessTokenRepository
+	blobs          domain.BlobStore
+	transactor     domain.Transactor
+	authorizer     domain.Authorizer
+	tokens         domain.TokenService
+	deletionPolicy string
 }
 
 // A constructor that creates a new instance of UserUsecase.
-func NewUserUsecase(userRepo domain.UserRepository) *UserUsecase {
+// The deletionPolicy decides what happens to the tasks of a deleted user when the request does not choose.
+func NewUserUsecase(userRepo domain.UserRepository, settingsRepo domain.SettingsRepository, taskRepo domain.TaskRepository, workspaceRepo domain.WorkspaceRepository, timeEntryRepo domain.TimeEntryRepository, tokenRepo domain.AccessTokenRepository, blobs domain.BlobStore, transactor domain.Transactor, authorizer domain.Authorizer, tokens domain.TokenService, deletionPolicy string) *UserUsecase {
 	return &UserUsecase{
-		userRepo: userRepo,
+		userRepo:       userRepo,
+		settingsRepo:   settingsRepo,
+		taskRepo:       taskRepo,
+		workspaceRepo:  workspaceRepo,
+		timeEntryRepo:  timeEntryRepo,
+		tokenRepo:      tokenRepo,
+		blobs:          blobs,
+		transactor:     transactor,
+		authorizer:     authorizer,
+		tokens:         tokens,
+		deletionPolicy: deletionPolicy,
 	}
 }
 
 // A method that adds a new user.
-func (u *UserUsecase) AddUser(userData *domain.CreateUserData, claims *domain.Claims) (*domain.User, *domain.Error) {
+func (u *UserUsecase) AddUser(ctx context.Context, userData *domain.CreateUserData, claims *domain.Claims) (*domain.User, *domain.Error) {
 	// Create a new user object.
 	user := &domain.User{
 		ID:       primitive.NewObjectID(),
 		Username: userData.Username,
 		Password: userData.Password,
 		Role:     userData.Role,
+		Email:    userData.Email,
 	}
 
 	// Check if the user has the correct role.
-	_err := canManipulateUser(claims, user, "add")
+	_err := u.canManipulateUser(ctx, claims, user, domain.ActionUserCreate, "add")
 	if _err != nil {
 		return nil, _err
 	}
 
 	// Check if the username is already taken and hash the password.
-	_err = u.validate(user)
+	_err = u.validate(ctx, user)
 	if _err != nil {
 		return nil, _err
 	}
 
 	// Add the user to the database.
-	err := u.userRepo.AddUser(user)
+	err := u.userRepo.AddUser(ctx, user)
 	if err != nil {
-		return nil, &domain.Error{
-			Err:        err,
-			StatusCode: http.StatusInternalServerError,
-			Message:    "Internal server error",
-		}
+		return nil, writeUserError(err)
 	}
 
 	return user, nil
 }
 
 // A method that registers a new user.
-func (u *UserUsecase) RegisterUser(userData *domain.AuthUserData) (*domain.User, *domain.Error) {
+func (u *UserUsecase) RegisterUser(ctx context.Context, userData *domain.AuthUserData) (*domain.User, *domain.Error) {
+	// Check the username and password against the same rules as the users created by admins.
+	_err := infrastructure.Validate(userData)
+	if _err != nil {
+		return nil, _err
+	}
+
 	// Create a new user.
 	user := &domain.User{
 		ID:       primitive.NewObjectID(),
@@ -69,32 +96,30 @@ func (u *UserUsecase) RegisterUser(userData *domain.AuthUserData) (*domain.User,
 	}
 
 	// Check if the user name is already taken and hash the password.
-	_err := u.validate(user)
+	_err = u.validate(ctx, user)
 	if _err != nil {
 		return nil, _err
 	}
 
 	// Add the user to the database.
-	err := u.userRepo.AddUser(user)
+	err := u.userRepo.AddUser(ctx, user)
 	if err != nil {
-		return nil, &domain.Error{
-			Err:        err,
-			StatusCode: http.StatusInternalServerError,
-			Message:    "Internal server error",
-		}
+		return nil, writeUserError(err)
 	}
 
 	return user, nil
 }
 
 // A method that logs in a user.
-func (u *UserUsecase) LoginUser(userData *domain.AuthUserData) (string, *domain.Error) {
+// If the user has two-factor authentication enabled, or must enroll in it, an intermediate token is returned instead.
+func (u *UserUsecase) LoginUser(ctx context.Context, userData *domain.AuthUserData) (*domain.LoginResult, *domain.Error) {
 	// Get the user from the database.
-	user, err := u.userRepo.GetUserByUsername(userData.Username)
+	user, err := u.userRepo.GetUserByUsername(ctx, userData.Username)
 	if err != nil {
-		return "", &domain.Error{
+		return nil, &domain.Error{
 			Err:        err,
 			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeInvalidCredentials,
 			Message:    "Invalid username or password",
 		}
 	}
@@ -102,30 +127,71 @@ func (u *UserUsecase) LoginUser(userData *domain.AuthUserData) (string, *domain.
 	// Compare the user's password with the given password.
 	err = infrastructure.ComparePasswords(user.Password, userData.Password)
 	if err != nil {
-		return "", &domain.Error{
+		return nil, &domain.Error{
 			Err:        err,
 			StatusCode: http.StatusUnauthorized,
+			Code:       domain.CodeInvalidCredentials,
 			Message:    "Invalid username or password",
 		}
 	}
 
+	// Require the second factor if the user has enrolled.
+	if user.TOTPEnabled {
+		return u.mfaLoginResult(user, domain.PurposeTwoFactorLogin)
+	}
+
+	// Require admins to enroll if the root user enforces two-factor authentication.
+	if user.Role == "admin" {
+		settings, err := u.settingsRepo.GetSecuritySettings(ctx)
+		if err != nil {
+			return nil, &domain.Error{
+				Err:        err,
+				StatusCode: http.StatusInternalServerError,
+				Message:    "Internal server error",
+			}
+		}
+
+		if settings.RequireAdminTwoFactor {
+			return u.mfaLoginResult(user, domain.PurposeTwoFactorSetup)
+		}
+	}
+
 	// Generate a JWT token for the user.
-	token, err := infrastructure.GenerateToken(user)
+	token, err := u.tokens.GenerateToken(user)
 	if err != nil {
-		return "", &domain.Error{
+		return nil, &domain.Error{
 			Err:        err,
 			StatusCode: http.StatusInternalServerError,
 			Message:    "Internal server error",
 		}
 	}
 
-	return token, nil
+	infrastructure.Logger(ctx).Info("user logged in", "user_id", user.ID.Hex(), "role", user.Role)
+	return &domain.LoginResult{Token: token}, nil
+}
+
+// A helper method that creates the login result of a login that needs a second step.
+func (u *UserUsecase) mfaLoginResult(user *domain.User, purpose string) (*domain.LoginResult, *domain.Error) {
+	mfaToken, err := u.tokens.GenerateMFAToken(user, purpose)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return &domain.LoginResult{
+		MFARequired:      purpose == domain.PurposeTwoFactorLogin,
+		MFASetupRequired: purpose == domain.PurposeTwoFactorSetup,
+		MFAToken:         mfaToken,
+	}, nil
 }
 
 // A method that gets all users.
-func (u *UserUsecase) GetUsers() ([]domain.User, *domain.Error) {
+func (u *UserUsecase) GetUsers(ctx context.Context) ([]domain.User, *domain.Error) {
 	// Get all users from the database.
-	users, err := u.userRepo.GetUsers()
+	users, err := u.userRepo.GetUsers(ctx)
 	if err != nil {
 		return nil, &domain.Error{
 			Err:        err,
@@ -138,14 +204,15 @@ func (u *UserUsecase) GetUsers() ([]domain.User, *domain.Error) {
 }
 
 // A method that gets a user by ID.
-func (u *UserUsecase) GetUserByID(objectID primitive.ObjectID) (*domain.User, *domain.Error) {
+func (u *UserUsecase) GetUserByID(ctx context.Context, objectID primitive.ObjectID) (*domain.User, *domain.Error) {
 	// Get the user from the database.
-	user, err := u.userRepo.GetUserByID(objectID)
+	user, err := u.userRepo.GetUserByID(ctx, objectID)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, &domain.Error{
 				Err:        err,
 				StatusCode: http.StatusNotFound,
+				Code:       domain.CodeUserNotFound,
 				Message:    "User not found",
 			}
 		}
@@ -160,26 +227,41 @@ func (u *UserUsecase) GetUserByID(objectID primitive.ObjectID) (*domain.User, *d
 	return user, nil
 }
 
+// A method that gets the users with the given IDs. Missing users are skipped.
+func (u *UserUsecase) GetUsersByIDs(ctx context.Context, objectIDs []primitive.ObjectID) ([]domain.User, *domain.Error) {
+	users, err := u.userRepo.GetUsersByIDs(ctx, objectIDs)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return users, nil
+}
+
 // A method that updates a user by ID.
-func (u *UserUsecase) UpdateUser(objectID primitive.ObjectID, userData *domain.UpdateUserData, claims *domain.Claims) (*domain.User, *domain.Error) {
+func (u *UserUsecase) UpdateUser(ctx context.Context, objectID primitive.ObjectID, userData *domain.UpdateUserData, claims *domain.Claims) (*domain.User, *domain.Error) {
 	// Get the user from the database.
-	user, err := u.userRepo.GetUserByID(objectID)
+	user, err := u.userRepo.GetUserByID(ctx, objectID)
 	if err != nil {
 		return nil, &domain.Error{
 			Err:        err,
 			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeUserNotFound,
 			Message:    "User not found",
 		}
 	}
 
 	// Check if the user has the correct role.
-	_err := canManipulateUser(claims, user, "update")
+	_err := u.canManipulateUser(ctx, claims, user, domain.ActionUserUpdate, "update")
 	if _err != nil {
 		return nil, _err
 	}
 
 	// Check if the username is already taken and hash the password.
-	_err = u.validate(&domain.User{Username: userData.Username, Password: userData.Password})
+	_err = u.validate(ctx, &domain.User{Username: userData.Username, Password: userData.Password})
 	if _err != nil {
 		return nil, _err
 	}
@@ -192,11 +274,34 @@ func (u *UserUsecase) UpdateUser(objectID primitive.ObjectID, userData *domain.U
 	if userData.Password != "" {
 		updateData["password"] = userData.Password
 	}
-	if userData.Role != "" {
-		if userData.Role != "user" && claims.Role != "root" {
+	if userData.Email != "" {
+		updateData["email"] = userData.Email
+	}
+	if userData.Role != "" && userData.Role != user.Role {
+		// Check if the user can assign the new role.
+		decision, _err := authorize(ctx, u.authorizer, claims, &domain.AccessRequest{
+			Action:     domain.ActionUserRoleAssign,
+			TargetRole: userData.Role,
+		})
+		if _err != nil {
+			return nil, _err
+		}
+
+		if decision.Denial == domain.DenialUnknownTargetRole {
+			return nil, &domain.Error{
+				Err:        errors.New(decision.Reason),
+				StatusCode: http.StatusBadRequest,
+				Code:       domain.CodeValidationFailed,
+				Message:    "Unknown role",
+				Fields:     []domain.FieldError{{Field: "role", Reason: "is not a known role"}},
+			}
+		}
+
+		if !decision.Allowed {
 			return nil, &domain.Error{
 				Err:        errors.New("forbidden"),
 				StatusCode: http.StatusForbidden,
+				Code:       domain.CodeForbiddenRoleAssign,
 				Message:    "Only root user can update role",
 			}
 		}
@@ -205,17 +310,13 @@ func (u *UserUsecase) UpdateUser(objectID primitive.ObjectID, userData *domain.U
 	}
 
 	// Update the user in the database.
-	err = u.userRepo.UpdateUser(objectID, updateData)
+	err = u.userRepo.UpdateUser(ctx, objectID, updateData)
 	if err != nil {
-		return nil, &domain.Error{
-			Err:        err,
-			StatusCode: http.StatusInternalServerError,
-			Message:    "Internal server error",
-		}
+		return nil, writeUserError(err)
 	}
 
 	// Get the updated user from the database.
-	updatedUser, err := u.userRepo.GetUserByID(objectID)
+	updatedUser, err := u.userRepo.GetUserByID(ctx, objectID)
 	if err != nil {
 		return nil, &domain.Error{
 			Err:        err,
@@ -228,26 +329,113 @@ func (u *UserUsecase) UpdateUser(objectID primitive.ObjectID, userData *domain.U
 }
 
 // A method that deletes a user by ID.
-func (u *UserUsecase) DeleteUser(objectID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+// The tasks of the user are deleted, given to another user, or prevent the deletion, depending on the policy. Their
+// time entries are deleted unless the policy prevents it, and their memberships and access tokens are deleted. A user
+// who is the only owner of a workspace cannot be deleted.
+// The tasks and the user are changed in a single transaction where the storage backend supports it. The files attached
+// to the deleted tasks are deleted once it commits.
+func (u *UserUsecase) DeleteUser(ctx context.Context, objectID primitive.ObjectID, query *domain.DeleteUserQuery, claims *domain.Claims) *domain.Error {
 	// Get the user from the database.
-	user, err := u.userRepo.GetUserByID(objectID)
+	user, err := u.userRepo.GetUserByID(ctx, objectID)
 	if err != nil {
 		return &domain.Error{
 			Err:        err,
 			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeUserNotFound,
 			Message:    "User not found",
 		}
 	}
 
 	// Check if the user has the correct role.
-	_err := canManipulateUser(claims, user, "delete")
+	_err := u.canManipulateUser(ctx, claims, user, domain.ActionUserDelete, "delete")
 	if _err != nil {
 		return _err
 	}
 
-	// Delete the user from the database.
-	err = u.userRepo.DeleteUser(objectID)
+	policy, reassignTo, _err := u.deletionOptions(ctx, user, query)
+	if _err != nil {
+		return _err
+	}
+
+	// Handle the data of the user and delete the user.
+	var keys []string
+	err = u.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := u.checkOwnedWorkspaces(ctx, objectID)
+		if err != nil {
+			return err
+		}
+
+		tasks := &domain.TaskFilter{UserID: objectID}
+		entries := &domain.TimeEntryFilter{UserID: objectID}
+
+		switch policy {
+		case domain.UserDeletionCascade:
+			var err error
+			keys, err = attachmentKeys(ctx, u.taskRepo, tasks)
+			if err == nil {
+				err = u.taskRepo.DeleteTasks(ctx, tasks)
+			}
+			if err == nil {
+				err = u.timeEntryRepo.DeleteTimeEntries(ctx, entries)
+			}
+			if err != nil {
+				return err
+			}
+		case domain.UserDeletionReassign:
+			// The time entries record the work of the deleted user, so they are not given to the other user.
+			err := u.taskRepo.ReassignTasks(ctx, tasks, reassignTo)
+			if err == nil {
+				err = u.timeEntryRepo.DeleteTimeEntries(ctx, entries)
+			}
+			if err != nil {
+				return err
+			}
+		default:
+			count, err := u.taskRepo.CountTasks(ctx, tasks)
+			if err != nil {
+				return err
+			}
+
+			if count > 0 {
+				return &domain.Error{
+					Err:        errors.New("user has tasks"),
+					StatusCode: http.StatusConflict,
+					Code:       domain.CodeUserHasTasks,
+					Message:    fmt.Sprintf("User still owns %d task(s) Hint: delete them with tasks=cascade or reassign them with reassign_to", count),
+				}
+			}
+
+			count, err = u.timeEntryRepo.CountTimeEntries(ctx, entries)
+			if err != nil {
+				return err
+			}
+
+			if count > 0 {
+				return &domain.Error{
+					Err:        errors.New("user has time entries"),
+					StatusCode: http.StatusConflict,
+					Code:       domain.CodeUserHasTimeEntries,
+					Message:    fmt.Sprintf("User still has %d time entry(ies) Hint: they are deleted with tasks=cascade, or with reassign_to", count),
+				}
+			}
+		}
+
+		err = u.workspaceRepo.RemoveMemberships(ctx, objectID)
+		if err == nil {
+			err = u.tokenRepo.DeleteAccessTokensByUserID(ctx, objectID)
+		}
+		if err != nil {
+			return err
+		}
+
+		return u.userRepo.DeleteUser(ctx, objectID)
+	})
 	if err != nil {
+		var _err *domain.Error
+		if errors.As(err, &_err) {
+			return _err
+		}
+
 		return &domain.Error{
 			Err:        err,
 			StatusCode: http.StatusInternalServerError,
@@ -255,63 +443,222 @@ func (u *UserUsecase) DeleteUser(objectID primitive.ObjectID, claims *domain.Cla
 		}
 	}
 
+	deleteBlobs(ctx, u.blobs, keys)
+	infrastructure.Logger(ctx).Info("user deleted", "deleted_user_id", objectID.Hex(), "tasks", policy)
 	return nil
 }
 
-// A function that checks if a the logged in user can manipulate the target user.
-func canManipulateUser(claims *domain.Claims, user *domain.User, manip string) *domain.Error {
-	// If the user is a regular user, they can only manipulate their own account.
-	if claims.Role == "user" {
-		if user.ID != claims.ID {
-			var message string
-			if manip == "add" {
-				message = "A User cannot add a new user"
-			} else {
-				message = "A User cannot " + manip + " another user"
-			}
+// A helper method that returns the deletion policy chosen by the request, or the configured one, and the user to
+// reassign the tasks to.
+func (u *UserUsecase) deletionOptions(ctx context.Context, user *domain.User, query *domain.DeleteUserQuery) (string, primitive.ObjectID, *domain.Error) {
+	policy := query.Tasks
+	if policy == "" && query.ReassignTo != "" {
+		policy = domain.UserDeletionReassign
+	}
+	if policy == "" {
+		policy = u.deletionPolicy
+	}
+
+	if !isUserDeletionPolicy(policy) {
+		reason := "must be any of: " + strings.Join(domain.UserDeletionPolicies, ", ")
+		return "", primitive.NilObjectID, &domain.Error{
+			Err:        errors.New("unknown deletion policy " + policy),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "tasks " + reason,
+			Fields:     []domain.FieldError{{Field: "tasks", Reason: reason}},
+		}
+	}
+
+	if policy != domain.UserDeletionReassign {
+		return policy, primitive.NilObjectID, nil
+	}
+
+	// Check that the tasks are given to another existing user.
+	invalid := func(err error, reason string) *domain.Error {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "reassign_to " + reason,
+			Fields:     []domain.FieldError{{Field: "reassign_to", Reason: reason}},
+		}
+	}
+
+	if query.ReassignTo == "" {
+		return "", primitive.NilObjectID, invalid(errors.New("missing reassign_to"), "is required to reassign the tasks")
+	}
+
+	reassignTo, err := primitive.ObjectIDFromHex(query.ReassignTo)
+	if err != nil {
+		return "", primitive.NilObjectID, invalid(err, "must be a valid ID")
+	}
+
+	if reassignTo == user.ID {
+		return "", primitive.NilObjectID, invalid(errors.New("reassign to deleted user"), "must not be the deleted user")
+	}
+
+	_, err = u.userRepo.GetUserByID(ctx, reassignTo)
+	if err == mongo.ErrNoDocuments {
+		return "", primitive.NilObjectID, invalid(err, "must be the ID of an existing user")
+	}
+	if err != nil {
+		return "", primitive.NilObjectID, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	// Check that the other user can keep working on the tasks, in every workspace they belong to.
+	_err := u.checkReassignMemberships(ctx, user.ID, reassignTo)
+	if _err != nil {
+		return "", primitive.NilObjectID, _err
+	}
+
+	return policy, reassignTo, nil
+}
 
+// A helper method that checks that the user to reassign the tasks to is a member of every workspace of the tasks of
+// the deleted user.
+func (u *UserUsecase) checkReassignMemberships(ctx context.Context, userID primitive.ObjectID, reassignTo primitive.ObjectID) *domain.Error {
+	tasks, err := u.taskRepo.GetTasks(ctx, &domain.TaskFilter{UserID: userID})
+	if err != nil {
+		return internalError(err)
+	}
+
+	workspaces, err := u.workspaceRepo.GetWorkspacesByUserID(ctx, reassignTo)
+	if err != nil {
+		return internalError(err)
+	}
+
+	member := map[primitive.ObjectID]bool{}
+	for _, workspace := range workspaces {
+		member[workspace.ID] = true
+	}
+
+	for _, task := range tasks {
+		if !task.WorkspaceID.IsZero() && !member[task.WorkspaceID] {
+			reason := "must be a member of every workspace of the tasks"
 			return &domain.Error{
-				Err:        errors.New("unauthorized"),
-				StatusCode: http.StatusForbidden,
-				Message:    message,
+				Err:        errors.New("reassign to a user outside the workspace " + task.WorkspaceID.Hex()),
+				StatusCode: http.StatusBadRequest,
+				Code:       domain.CodeValidationFailed,
+				Message:    "reassign_to " + reason,
+				Fields:     []domain.FieldError{{Field: "reassign_to", Reason: reason}},
 			}
 		}
+	}
 
-		return nil
+	return nil
+}
+
+// A helper method that refuses to delete a user who is the only owner of a workspace, which would be left without
+// anyone to manage it.
+func (u *UserUsecase) checkOwnedWorkspaces(ctx context.Context, userID primitive.ObjectID) error {
+	workspaces, err := u.workspaceRepo.GetWorkspacesByUserID(ctx, userID)
+	if err != nil {
+		return err
 	}
 
-	// If the user is an admin, they can manipulate all users except root user and other admin users.
-	if claims.Role == "admin" {
-		if user.Role == "root" {
-			return &domain.Error{
-				Err:        errors.New("forbidden"),
-				StatusCode: http.StatusForbidden,
-				Message:    "Cannot " + manip + " root user",
+	count := 0
+	for _, workspace := range workspaces {
+		owners := 0
+		owner := false
+		for _, member := range workspace.Members {
+			if member.Role == domain.WorkspaceRoleOwner {
+				owners++
+				owner = owner || member.UserID == userID
 			}
 		}
 
-		if user.Role == "admin" && claims.ID != user.ID {
+		if owner && owners == 1 {
+			count++
+		}
+	}
+
+	if count > 0 {
+		return &domain.Error{
+			Err:        errors.New("user is the last owner of a workspace"),
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeLastOwner,
+			Message:    fmt.Sprintf("User is the only owner of %d workspace(s) Hint: make another member an owner or delete the workspaces", count),
+		}
+	}
+
+	return nil
+}
+
+// A helper method that checks if the logged in user can manipulate the target user.
+// Users can act on their own account, and on other users only if their role outranks the target's role.
+func (u *UserUsecase) canManipulateUser(ctx context.Context, claims *domain.Claims, user *domain.User, action, manip string) *domain.Error {
+	decision, _err := authorize(ctx, u.authorizer, claims, &domain.AccessRequest{
+		Action:     action,
+		OwnerID:    user.ID,
+		TargetRole: user.Role,
+	})
+	if _err != nil {
+		return _err
+	}
+
+	if decision.Allowed {
+		return nil
+	}
+
+	switch decision.Denial {
+	case domain.DenialUnknownTargetRole:
+		return &domain.Error{
+			Err:        errors.New(decision.Reason),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "Unknown role",
+			Fields:     []domain.FieldError{{Field: "role", Reason: "is not a known role"}},
+		}
+
+	case domain.DenialRank:
+		// Acting on a user of the same role is reported differently from acting on a higher role.
+		if user.Role == claims.Role {
 			return &domain.Error{
 				Err:        errors.New("unauthorized"),
 				StatusCode: http.StatusForbidden,
-				Message:    "Admin cannot " + manip + " another admin user",
+				Code:       domain.CodeForbiddenRoleRank,
+				Message:    titleRole(claims.Role) + " cannot " + manip + " another " + user.Role + " user",
 			}
 		}
+
+		return &domain.Error{
+			Err:        errors.New("forbidden"),
+			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenRoleRank,
+			Message:    "Cannot " + manip + " " + user.Role + " user",
+		}
 	}
 
-	// If the user is a root user, they can manipulate all users.
-	return nil
+	var message string
+	if manip == "add" {
+		message = "A " + titleRole(claims.Role) + " cannot add a new user"
+	} else {
+		message = "A " + titleRole(claims.Role) + " cannot " + manip + " another user"
+	}
+
+	return &domain.Error{
+		Err:        errors.New("unauthorized"),
+		StatusCode: http.StatusForbidden,
+		Code:       domain.CodeForbidden,
+		Message:    message,
+	}
 }
 
 // A helper method that checks if a username is already taken and hashes the password.
-func (u *UserUsecase) validate(user *domain.User) *domain.Error {
+func (u *UserUsecase) validate(ctx context.Context, user *domain.User) *domain.Error {
 	// Check if the username is already taken.
 	if user.Username != "" {
-		_, err := u.userRepo.GetUserByUsername(user.Username)
+		_, err := u.userRepo.GetUserByUsername(ctx, user.Username)
 		if err == nil {
 			return &domain.Error{
 				Err:        errors.New("conflict"),
 				StatusCode: http.StatusConflict,
+				Code:       domain.CodeUsernameTaken,
 				Message:    "Username already exists",
 			}
 		}
@@ -332,3 +679,33 @@ func (u *UserUsecase) validate(user *domain.User) *domain.Error {
 
 	return nil
 }
+
+// A helper function that converts the error of a write to the users into a domain error.
+// The unique username index rejects the writes that race with the username check of validate.
+func writeUserError(err error) *domain.Error {
+	if mongo.IsDuplicateKeyError(err) {
+		return &domain.Error{
+			Err:        errors.New("conflict"),
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeUsernameTaken,
+			Message:    "Username already exists",
+		}
+	}
+
+	return &domain.Error{
+		Err:        err,
+		StatusCode: http.StatusInternalServerError,
+		Message:    "Internal server error",
+	}
+}
+
+// A helper function that checks if a policy is one of the user deletion policies.
+func isUserDeletionPolicy(policy string) bool {
+	for _, p := range domain.UserDeletionPolicies {
+		if p == policy {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,14 +1,16 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
-	"os"
+	"strings"
 	"task_manager/domain"
 	"task_manager/infrastructure"
 	"task_manager/mocks"
 	"task_manager/usecase"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -24,21 +26,54 @@ var (
 // A suite that tests the user usecase.
 type UserUsecaseSuite struct {
 	suite.Suite
-	userRepo    *mocks.UserRepository
-	userUsecase *usecase.UserUsecase
+	userRepo      *mocks.UserRepository
+	settingsRepo  *mocks.SettingsRepository
+	roleRepo      *mocks.RoleRepository
+	taskRepo      *mocks.TaskRepository
+	workspaceRepo *mocks.WorkspaceRepository
+	timeEntryRepo *mocks.TimeEntryRepository
+	tokenRepo     *mocks.AccessTokenRepository
+	blobs         *mocks.BlobStore
+	transactor    *mocks.Transactor
+	tokens        *infrastructure.JWTService
+	userUsecase   *usecase.UserUsecase
 }
 
 // A method that sets up the test suite.
 func (suite *UserUsecaseSuite) SetupTest() {
 	suite.userRepo = new(mocks.UserRepository)
-	suite.userUsecase = usecase.NewUserUsecase(suite.userRepo)
-	os.Setenv("JWT_KEY", "test_key")
+	suite.settingsRepo = new(mocks.SettingsRepository)
+	suite.roleRepo = new(mocks.RoleRepository)
+	suite.taskRepo = new(mocks.TaskRepository)
+	suite.workspaceRepo = new(mocks.WorkspaceRepository)
+	suite.timeEntryRepo = new(mocks.TimeEntryRepository)
+	suite.tokenRepo = new(mocks.AccessTokenRepository)
+	suite.blobs = new(mocks.BlobStore)
+	suite.transactor = new(mocks.Transactor)
+	suite.tokens = infrastructure.NewJWTService("test_key", time.Hour)
+	suite.userUsecase = usecase.NewUserUsecase(suite.userRepo, suite.settingsRepo, suite.taskRepo, suite.workspaceRepo, suite.timeEntryRepo, suite.tokenRepo, suite.blobs, suite.transactor, infrastructure.NewRoleAuthorizer(suite.roleRepo), suite.tokens, domain.UserDeletionRestrict)
+
+	// Run the transactions directly.
+	suite.transactor.On("WithTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}).Maybe()
 }
 
 // A method that tears down the test suite.
 func (suite *UserUsecaseSuite) TearDownTest() {
 	suite.userRepo.AssertExpectations(suite.T())
-	os.Unsetenv("JWT_KEY")
+	suite.settingsRepo.AssertExpectations(suite.T())
+	suite.taskRepo.AssertExpectations(suite.T())
+	suite.workspaceRepo.AssertExpectations(suite.T())
+	suite.timeEntryRepo.AssertExpectations(suite.T())
+	suite.tokenRepo.AssertExpectations(suite.T())
+	suite.blobs.AssertExpectations(suite.T())
+}
+
+// A helper method that expects the memberships and the access tokens of a deleted user to be deleted.
+func (suite *UserUsecaseSuite) expectUserDataDeleted(userID primitive.ObjectID) {
+	suite.workspaceRepo.On("RemoveMemberships", mock.Anything, userID).Return(nil).Once()
+	suite.tokenRepo.On("DeleteAccessTokensByUserID", mock.Anything, userID).Return(nil).Once()
 }
 
 // A test for the UserUsecase.AddUser method.
@@ -50,10 +85,10 @@ func (suite *UserUsecaseSuite) Test_AddUser() {
 		user := mocks.GetUser(userData)
 		passWord := user.Password
 
-		suite.userRepo.On("AddUser", mockUser).Return(nil).Once()
-		suite.userRepo.On("GetUserByUsername", mockString).Return(nil, errors.New("some error")).Once()
+		suite.userRepo.On("AddUser", mock.Anything, mockUser).Return(nil).Once()
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(nil, errors.New("some error")).Once()
 
-		foundUser, err := suite.userUsecase.AddUser(userData, claims)
+		foundUser, err := suite.userUsecase.AddUser(context.Background(), userData, claims)
 		suite.Nil(err)
 		suite.Nil(infrastructure.ComparePasswords(foundUser.Password, passWord))
 		user.Password = foundUser.Password
@@ -65,8 +100,8 @@ func (suite *UserUsecaseSuite) Test_AddUser() {
 		userData := mocks.GetCreateUserData()
 		claims := mocks.GetClaims2() // An admin user.
 
-		suite.userRepo.On("AddUser", mockUser).Return(errors.New("some error")).Once()
-		suite.userRepo.On("GetUserByUsername", mockString).Return(nil, errors.New("some error")).Once()
+		suite.userRepo.On("AddUser", mock.Anything, mockUser).Return(errors.New("some error")).Once()
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(nil, errors.New("some error")).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("some error"),
@@ -74,7 +109,7 @@ func (suite *UserUsecaseSuite) Test_AddUser() {
 			Message:    "Internal server error",
 		}
 
-		foundUser, err := suite.userUsecase.AddUser(userData, claims)
+		foundUser, err := suite.userUsecase.AddUser(context.Background(), userData, claims)
 		suite.Nil(foundUser)
 		suite.Equal(expectedError, err)
 	})
@@ -84,15 +119,16 @@ func (suite *UserUsecaseSuite) Test_AddUser() {
 		userData := mocks.GetCreateUserData()
 		claims := mocks.GetClaims2() // An admin user.
 
-		suite.userRepo.On("GetUserByUsername", mockString).Return(mocks.GetUser(userData), nil).Once()
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(mocks.GetUser(userData), nil).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("conflict"),
 			StatusCode: http.StatusConflict,
+			Code:       domain.CodeUsernameTaken,
 			Message:    "Username already exists",
 		}
 
-		foundUser, err := suite.userUsecase.AddUser(userData, claims)
+		foundUser, err := suite.userUsecase.AddUser(context.Background(), userData, claims)
 		suite.Nil(foundUser)
 		suite.Equal(expectedError, err)
 	})
@@ -105,10 +141,11 @@ func (suite *UserUsecaseSuite) Test_AddUser() {
 		expectedError := &domain.Error{
 			Err:        errors.New("unauthorized"),
 			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbidden,
 			Message:    "A User cannot add a new user",
 		}
 
-		foundUser, err := suite.userUsecase.AddUser(userData, claims)
+		foundUser, err := suite.userUsecase.AddUser(context.Background(), userData, claims)
 		suite.Nil(foundUser)
 		suite.Equal(expectedError, err)
 	})
@@ -122,10 +159,10 @@ func (suite *UserUsecaseSuite) Test_RegisterUser() {
 		user := mocks.GetUser3(userData)
 		passWord := user.Password
 
-		suite.userRepo.On("AddUser", mockUser).Return(nil).Once()
-		suite.userRepo.On("GetUserByUsername", mockString).Return(nil, errors.New("some error")).Once()
+		suite.userRepo.On("AddUser", mock.Anything, mockUser).Return(nil).Once()
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(nil, errors.New("some error")).Once()
 
-		foundUser, err := suite.userUsecase.RegisterUser(userData)
+		foundUser, err := suite.userUsecase.RegisterUser(context.Background(), userData)
 		suite.Nil(err)
 		suite.Nil(infrastructure.ComparePasswords(foundUser.Password, passWord))
 		user.Password = foundUser.Password
@@ -136,8 +173,8 @@ func (suite *UserUsecaseSuite) Test_RegisterUser() {
 	suite.Run("RegisterUser_Failure", func() {
 		userData := mocks.GetAuthUserData()
 
-		suite.userRepo.On("AddUser", mockUser).Return(errors.New("some error")).Once()
-		suite.userRepo.On("GetUserByUsername", mockString).Return(nil, errors.New("some error")).Once()
+		suite.userRepo.On("AddUser", mock.Anything, mockUser).Return(errors.New("some error")).Once()
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(nil, errors.New("some error")).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("some error"),
@@ -145,7 +182,7 @@ func (suite *UserUsecaseSuite) Test_RegisterUser() {
 			Message:    "Internal server error",
 		}
 
-		foundUser, err := suite.userUsecase.RegisterUser(userData)
+		foundUser, err := suite.userUsecase.RegisterUser(context.Background(), userData)
 		suite.Nil(foundUser)
 		suite.Equal(expectedError, err)
 	})
@@ -154,18 +191,62 @@ func (suite *UserUsecaseSuite) Test_RegisterUser() {
 	suite.Run("RegisterUser_UserExists", func() {
 		userData := mocks.GetAuthUserData()
 
-		suite.userRepo.On("GetUserByUsername", mockString).Return(mocks.GetUser3(userData), nil).Once()
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(mocks.GetUser3(userData), nil).Once()
+
+		expectedError := &domain.Error{
+			Err:        errors.New("conflict"),
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeUsernameTaken,
+			Message:    "Username already exists",
+		}
+
+		foundUser, err := suite.userUsecase.RegisterUser(context.Background(), userData)
+		suite.Nil(foundUser)
+		suite.Equal(expectedError, err)
+	})
+
+	// A testcase where the username is taken between the check and the insertion, and the unique index rejects it.
+	suite.Run("RegisterUser_DuplicateKey", func() {
+		userData := mocks.GetAuthUserData()
+		duplicate := mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "E11000 duplicate key error"}}}
+
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(nil, mongo.ErrNoDocuments).Once()
+		suite.userRepo.On("AddUser", mock.Anything, mockUser).Return(duplicate).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("conflict"),
 			StatusCode: http.StatusConflict,
+			Code:       domain.CodeUsernameTaken,
 			Message:    "Username already exists",
 		}
 
-		foundUser, err := suite.userUsecase.RegisterUser(userData)
+		foundUser, err := suite.userUsecase.RegisterUser(context.Background(), userData)
 		suite.Nil(foundUser)
 		suite.Equal(expectedError, err)
 	})
+
+	// A testcase where the username and password break the rules of the users created by admins.
+	suite.Run("RegisterUser_Invalid", func() {
+		userData := &domain.AuthUserData{Username: "a b", Password: "short"}
+
+		foundUser, err := suite.userUsecase.RegisterUser(context.Background(), userData)
+		suite.Nil(foundUser)
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+		suite.Equal(domain.CodeValidationFailed, err.Code)
+		suite.Equal([]domain.FieldError{
+			{Field: "username", Reason: "must be 3 to 32 letters, digits, '.', '_' or '-'"},
+			{Field: "password", Reason: "must be at least 8 characters long"},
+		}, err.Fields)
+	})
+
+	// A testcase where the password has fewer than 72 characters but more than 72 bytes, which bcrypt would truncate.
+	suite.Run("RegisterUser_PasswordBytes", func() {
+		userData := &domain.AuthUserData{Username: "new_user", Password: strings.Repeat("é", 40)}
+
+		foundUser, err := suite.userUsecase.RegisterUser(context.Background(), userData)
+		suite.Nil(foundUser)
+		suite.Equal([]domain.FieldError{{Field: "password", Reason: "must be at most 72 bytes long"}}, err.Fields)
+	})
 }
 
 // A test for the UserUsecase.LoginUser method.
@@ -176,28 +257,88 @@ func (suite *UserUsecaseSuite) Test_LoginUser() {
 		userData := mocks.GetAuthData(user)
 		user.Password, _ = infrastructure.HashPassword(user.Password)
 
-		suite.userRepo.On("GetUserByUsername", mockString).Return(user, nil).Once()
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(user, nil).Once()
 
-		token, err := suite.userUsecase.LoginUser(userData)
+		result, err := suite.userUsecase.LoginUser(context.Background(), userData)
 		suite.Nil(err)
-		suite.NotEmpty(token)
+		suite.NotEmpty(result.Token)
+		suite.Empty(result.MFAToken)
 
+		// The token expires after the configured lifetime.
+		claims, parseErr := suite.tokens.ParseToken(result.Token)
+		suite.Nil(parseErr)
+		suite.InDelta(time.Now().Add(time.Hour).Unix(), claims.StandardClaims.ExpiresAt, 5)
+	})
+
+	// A testcase where the user has two-factor authentication enabled.
+	suite.Run("LoginUser_TwoFactor", func() {
+		user := mocks.GetNewUser()
+		userData := mocks.GetAuthData(user)
+		user.Password, _ = infrastructure.HashPassword(user.Password)
+		user.TOTPEnabled = true
+
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(user, nil).Once()
+
+		result, err := suite.userUsecase.LoginUser(context.Background(), userData)
+		suite.Nil(err)
+		suite.Empty(result.Token)
+		suite.True(result.MFARequired)
+
+		claims, parseErr := suite.tokens.ParseToken(result.MFAToken)
+		suite.Nil(parseErr)
+		suite.Equal(domain.PurposeTwoFactorLogin, claims.Purpose)
+	})
+
+	// A testcase where an admin must enroll in two-factor authentication.
+	suite.Run("LoginUser_AdminSetupRequired", func() {
+		user := mocks.GetNewUser()
+		userData := mocks.GetAuthData(user)
+		user.Password, _ = infrastructure.HashPassword(user.Password)
+		user.Role = "admin"
+
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(user, nil).Once()
+		suite.settingsRepo.On("GetSecuritySettings", mock.Anything, mock.Anything).Return(&domain.SecuritySettings{RequireAdminTwoFactor: true}, nil).Once()
+
+		result, err := suite.userUsecase.LoginUser(context.Background(), userData)
+		suite.Nil(err)
+		suite.Empty(result.Token)
+		suite.True(result.MFASetupRequired)
+
+		claims, parseErr := suite.tokens.ParseToken(result.MFAToken)
+		suite.Nil(parseErr)
+		suite.Equal(domain.PurposeTwoFactorSetup, claims.Purpose)
+	})
+
+	// A testcase where an admin logs in without two-factor authentication being required.
+	suite.Run("LoginUser_AdminNotRequired", func() {
+		user := mocks.GetNewUser()
+		userData := mocks.GetAuthData(user)
+		user.Password, _ = infrastructure.HashPassword(user.Password)
+		user.Role = "admin"
+
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(user, nil).Once()
+		suite.settingsRepo.On("GetSecuritySettings", mock.Anything, mock.Anything).Return(&domain.SecuritySettings{}, nil).Once()
+
+		result, err := suite.userUsecase.LoginUser(context.Background(), userData)
+		suite.Nil(err)
+		suite.NotEmpty(result.Token)
 	})
 
 	// A testcase that tests the failure of logging in a user.
 	suite.Run("LoginUser_Failure", func() {
 		userData := mocks.GetAuthUserData()
 
-		suite.userRepo.On("GetUserByUsername", mockString).Return(nil, errors.New("some error")).Once()
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(nil, errors.New("some error")).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeInvalidCredentials,
 			Message:    "Invalid username or password",
 		}
 
-		token, err := suite.userUsecase.LoginUser(userData)
-		suite.Empty(token)
+		result, err := suite.userUsecase.LoginUser(context.Background(), userData)
+		suite.Nil(result)
 		suite.Equal(expectedError, err)
 	})
 
@@ -208,16 +349,17 @@ func (suite *UserUsecaseSuite) Test_LoginUser() {
 		userData.Password = "wrong_password"
 		user.Password, _ = infrastructure.HashPassword(user.Password)
 
-		suite.userRepo.On("GetUserByUsername", mockString).Return(user, nil).Once()
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(user, nil).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("crypto/bcrypt: hashedPassword is not the hash of the given password"),
 			StatusCode: http.StatusUnauthorized,
+			Code:       domain.CodeInvalidCredentials,
 			Message:    "Invalid username or password",
 		}
 
-		token, err := suite.userUsecase.LoginUser(userData)
-		suite.Empty(token)
+		result, err := suite.userUsecase.LoginUser(context.Background(), userData)
+		suite.Nil(result)
 		suite.Equal(expectedError, err)
 	})
 }
@@ -228,16 +370,16 @@ func (suite *UserUsecaseSuite) Test_GetUsers() {
 	suite.Run("GetUsers_Success", func() {
 		users := mocks.GetManyUsers()
 
-		suite.userRepo.On("GetUsers").Return(users, nil).Once()
+		suite.userRepo.On("GetUsers", mock.Anything, mock.Anything).Return(users, nil).Once()
 
-		foundUsers, err := suite.userUsecase.GetUsers()
+		foundUsers, err := suite.userUsecase.GetUsers(context.Background())
 		suite.Nil(err)
 		suite.Equal(users, foundUsers)
 	})
 
 	// A testcase that tests the failure of getting all users.
 	suite.Run("GetUsers_Failure", func() {
-		suite.userRepo.On("GetUsers").Return(nil, errors.New("some error")).Once()
+		suite.userRepo.On("GetUsers", mock.Anything, mock.Anything).Return(nil, errors.New("some error")).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("some error"),
@@ -245,7 +387,7 @@ func (suite *UserUsecaseSuite) Test_GetUsers() {
 			Message:    "Internal server error",
 		}
 
-		foundUsers, err := suite.userUsecase.GetUsers()
+		foundUsers, err := suite.userUsecase.GetUsers(context.Background())
 		suite.Nil(foundUsers)
 		suite.Equal(expectedError, err)
 	})
@@ -257,16 +399,16 @@ func (suite *UserUsecaseSuite) Test_GetUserByID() {
 	suite.Run("GetUserByID_Success", func() {
 		user := mocks.GetNewUser()
 
-		suite.userRepo.On("GetUserByID", mockObjectID).Return(user, nil).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(user, nil).Once()
 
-		foundUser, err := suite.userUsecase.GetUserByID(user.ID)
+		foundUser, err := suite.userUsecase.GetUserByID(context.Background(), user.ID)
 		suite.Nil(err)
 		suite.Equal(user, foundUser)
 	})
 
 	// A testcase that tests the failure of getting a user by ID.
 	suite.Run("GetUserByID_Failure", func() {
-		suite.userRepo.On("GetUserByID", mockObjectID).Return(nil, errors.New("some error")).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(nil, errors.New("some error")).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("some error"),
@@ -274,27 +416,43 @@ func (suite *UserUsecaseSuite) Test_GetUserByID() {
 			Message:    "Internal server error",
 		}
 
-		foundUser, err := suite.userUsecase.GetUserByID(mocks.GetPrimitiveID1())
+		foundUser, err := suite.userUsecase.GetUserByID(context.Background(), mocks.GetPrimitiveID1())
 		suite.Nil(foundUser)
 		suite.Equal(expectedError, err)
 	})
 
 	// A testcase where the user is not found.
 	suite.Run("GetUserByID_NotFound", func() {
-		suite.userRepo.On("GetUserByID", mockObjectID).Return(nil, mongo.ErrNoDocuments).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(nil, mongo.ErrNoDocuments).Once()
 
 		expectedError := &domain.Error{
 			Err:        mongo.ErrNoDocuments,
 			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeUserNotFound,
 			Message:    "User not found",
 		}
 
-		foundUser, err := suite.userUsecase.GetUserByID(mocks.GetPrimitiveID1())
+		foundUser, err := suite.userUsecase.GetUserByID(context.Background(), mocks.GetPrimitiveID1())
 		suite.Nil(foundUser)
 		suite.Equal(expectedError, err)
 	})
 }
 
+// A test for the UserUsecase.GetUsersByIDs method.
+func (suite *UserUsecaseSuite) Test_GetUsersByIDs() {
+	// A testcase that tests the retrieval of several users at once.
+	suite.Run("GetUsersByIDs_Success", func() {
+		users := mocks.GetManyUsers()
+		ids := []primitive.ObjectID{users[0].ID, users[1].ID}
+
+		suite.userRepo.On("GetUsersByIDs", mock.Anything, ids).Return(users[:2], nil).Once()
+
+		foundUsers, err := suite.userUsecase.GetUsersByIDs(context.Background(), ids)
+		suite.Nil(err)
+		suite.Equal(users[:2], foundUsers)
+	})
+}
+
 // A test for the UserUsecase.UpdateUser method.
 func (suite *UserUsecaseSuite) Test_UpdateUser() {
 	// A testcase that tests the successful update of a user.
@@ -303,11 +461,11 @@ func (suite *UserUsecaseSuite) Test_UpdateUser() {
 		user := mocks.GetUser4(userData)
 		claims := mocks.GetClaims2() // An admin user.
 
-		suite.userRepo.On("GetUserByID", mockObjectID).Return(user, nil).Twice()
-		suite.userRepo.On("GetUserByUsername", mockString).Return(nil, errors.New("some error")).Once()
-		suite.userRepo.On("UpdateUser", mockObjectID, mockBSON).Return(nil).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(user, nil).Twice()
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(nil, errors.New("some error")).Once()
+		suite.userRepo.On("UpdateUser", mock.Anything, mockObjectID, mockBSON).Return(nil).Once()
 
-		foundUser, err := suite.userUsecase.UpdateUser(user.ID, userData, claims)
+		foundUser, err := suite.userUsecase.UpdateUser(context.Background(), user.ID, userData, claims)
 		suite.Nil(err)
 		suite.Equal(user, foundUser)
 	})
@@ -317,15 +475,16 @@ func (suite *UserUsecaseSuite) Test_UpdateUser() {
 		userData := mocks.GetUpdateUserData()
 		claims := mocks.GetClaims2() // An admin user.
 
-		suite.userRepo.On("GetUserByID", mockObjectID).Return(nil, errors.New("some error")).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(nil, errors.New("some error")).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeUserNotFound,
 			Message:    "User not found",
 		}
 
-		foundUser, err := suite.userUsecase.UpdateUser(mocks.GetPrimitiveID1(), userData, claims)
+		foundUser, err := suite.userUsecase.UpdateUser(context.Background(), mocks.GetPrimitiveID1(), userData, claims)
 		suite.Nil(foundUser)
 		suite.Equal(expectedError, err)
 	})
@@ -336,9 +495,9 @@ func (suite *UserUsecaseSuite) Test_UpdateUser() {
 		user := mocks.GetUser4(userData)
 		claims := mocks.GetClaims2() // An admin user.
 
-		suite.userRepo.On("GetUserByID", mockObjectID).Return(user, nil).Once()
-		suite.userRepo.On("GetUserByUsername", mockString).Return(nil, errors.New("some error")).Once()
-		suite.userRepo.On("UpdateUser", mockObjectID, mockBSON).Return(errors.New("some error")).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(user, nil).Once()
+		suite.userRepo.On("GetUserByUsername", mock.Anything, mockString).Return(nil, errors.New("some error")).Once()
+		suite.userRepo.On("UpdateUser", mock.Anything, mockObjectID, mockBSON).Return(errors.New("some error")).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("some error"),
@@ -346,7 +505,7 @@ func (suite *UserUsecaseSuite) Test_UpdateUser() {
 			Message:    "Internal server error",
 		}
 
-		foundUser, err := suite.userUsecase.UpdateUser(user.ID, userData, claims)
+		foundUser, err := suite.userUsecase.UpdateUser(context.Background(), user.ID, userData, claims)
 		suite.Nil(foundUser)
 		suite.Equal(expectedError, err)
 	})
@@ -359,15 +518,16 @@ func (suite *UserUsecaseSuite) Test_UpdateUser() {
 		claims := mocks.GetClaims2() // An admin user.
 		objectID := primitive.NewObjectID()
 
-		suite.userRepo.On("GetUserByID", mockObjectID).Return(user, nil).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(user, nil).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("forbidden"),
 			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenRoleRank,
 			Message:    "Cannot update root user",
 		}
 
-		foundUser, err := suite.userUsecase.UpdateUser(objectID, userData, claims)
+		foundUser, err := suite.userUsecase.UpdateUser(context.Background(), objectID, userData, claims)
 		suite.Nil(foundUser)
 		suite.Equal(expectedError, err)
 	})
@@ -380,26 +540,167 @@ func (suite *UserUsecaseSuite) Test_DeleteUser() {
 		user := mocks.GetNewUser()
 		claims := mocks.GetClaims2() // An admin user.
 
-		suite.userRepo.On("GetUserByID", mockObjectID).Return(user, nil).Once()
-		suite.userRepo.On("DeleteUser", mockObjectID).Return(nil).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(user, nil).Once()
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, user.ID).Return([]domain.Workspace{}, nil).Once()
+		suite.taskRepo.On("CountTasks", mock.Anything, &domain.TaskFilter{UserID: user.ID}).Return(int64(0), nil).Once()
+		suite.timeEntryRepo.On("CountTimeEntries", mock.Anything, &domain.TimeEntryFilter{UserID: user.ID}).Return(int64(0), nil).Once()
+		suite.expectUserDataDeleted(user.ID)
+		suite.userRepo.On("DeleteUser", mock.Anything, mockObjectID).Return(nil).Once()
 
-		err := suite.userUsecase.DeleteUser(user.ID, claims)
+		err := suite.userUsecase.DeleteUser(context.Background(), user.ID, &domain.DeleteUserQuery{}, claims)
 		suite.Nil(err)
 	})
 
+	// A testcase where the user still owns tasks and the default policy refuses the deletion.
+	suite.Run("DeleteUser_HasTasks", func() {
+		user := mocks.GetNewUser()
+		claims := mocks.GetClaims2() // An admin user.
+
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(user, nil).Once()
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, user.ID).Return([]domain.Workspace{}, nil).Once()
+		suite.taskRepo.On("CountTasks", mock.Anything, &domain.TaskFilter{UserID: user.ID}).Return(int64(2), nil).Once()
+
+		expectedError := &domain.Error{
+			Err:        errors.New("user has tasks"),
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeUserHasTasks,
+			Message:    "User still owns 2 task(s) Hint: delete them with tasks=cascade or reassign them with reassign_to",
+		}
+
+		err := suite.userUsecase.DeleteUser(context.Background(), user.ID, &domain.DeleteUserQuery{}, claims)
+		suite.Equal(expectedError, err)
+	})
+
+	// A testcase where the user still has time entries and the default policy refuses the deletion.
+	suite.Run("DeleteUser_HasTimeEntries", func() {
+		user := mocks.GetNewUser()
+		claims := mocks.GetClaims2() // An admin user.
+
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(user, nil).Once()
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, user.ID).Return([]domain.Workspace{}, nil).Once()
+		suite.taskRepo.On("CountTasks", mock.Anything, &domain.TaskFilter{UserID: user.ID}).Return(int64(0), nil).Once()
+		suite.timeEntryRepo.On("CountTimeEntries", mock.Anything, &domain.TimeEntryFilter{UserID: user.ID}).Return(int64(3), nil).Once()
+
+		err := suite.userUsecase.DeleteUser(context.Background(), user.ID, &domain.DeleteUserQuery{}, claims)
+		suite.Equal(http.StatusConflict, err.StatusCode)
+		suite.Equal(domain.CodeUserHasTimeEntries, err.Code)
+	})
+
+	// A testcase where the user is the only owner of a workspace, which would be left without owner.
+	suite.Run("DeleteUser_LastOwner", func() {
+		claims := mocks.GetClaims2() // An admin user.
+		workspace := mocks.GetWorkspace()
+		user := mocks.GetNewUser()
+		user.ID = workspace.Members[1].UserID
+
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, user.ID).Return([]domain.Workspace{*workspace}, nil).Once()
+
+		err := suite.userUsecase.DeleteUser(context.Background(), user.ID, &domain.DeleteUserQuery{Tasks: domain.UserDeletionCascade}, claims)
+		suite.Equal(http.StatusConflict, err.StatusCode)
+		suite.Equal(domain.CodeLastOwner, err.Code)
+	})
+
+	// A testcase where the tasks and time entries are deleted with the user, and the files attached to the tasks once
+	// the transaction commits.
+	suite.Run("DeleteUser_Cascade", func() {
+		user := mocks.GetNewUser()
+		claims := mocks.GetClaims2() // An admin user.
+		task := domain.Task{ID: primitive.NewObjectID(), UserID: user.ID, Attachments: []domain.Attachment{{ID: primitive.NewObjectID()}}}
+
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(user, nil).Once()
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, user.ID).Return([]domain.Workspace{}, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{UserID: user.ID}).Return([]domain.Task{task}, nil).Once()
+		suite.taskRepo.On("DeleteTasks", mock.Anything, &domain.TaskFilter{UserID: user.ID}).Return(nil).Once()
+		suite.timeEntryRepo.On("DeleteTimeEntries", mock.Anything, &domain.TimeEntryFilter{UserID: user.ID}).Return(nil).Once()
+		suite.expectUserDataDeleted(user.ID)
+		suite.userRepo.On("DeleteUser", mock.Anything, mockObjectID).Return(nil).Once()
+		suite.blobs.On("DeleteBlob", mock.Anything, domain.AttachmentKey(task.ID, task.Attachments[0].ID)).Return(nil).Once()
+
+		err := suite.userUsecase.DeleteUser(context.Background(), user.ID, &domain.DeleteUserQuery{Tasks: domain.UserDeletionCascade}, claims)
+		suite.Nil(err)
+	})
+
+	// A testcase where the tasks are given to another user.
+	suite.Run("DeleteUser_Reassign", func() {
+		user := mocks.GetNewUser()
+		target := mocks.GetNewUser()
+		claims := mocks.GetClaims2() // An admin user.
+
+		workspace := mocks.GetWorkspace()
+		tasks := []domain.Task{{ID: primitive.NewObjectID(), UserID: user.ID, WorkspaceID: workspace.ID}, {ID: primitive.NewObjectID(), UserID: user.ID}}
+
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, target.ID).Return(target, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{UserID: user.ID}).Return(tasks, nil).Once()
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, target.ID).Return([]domain.Workspace{*workspace}, nil).Once()
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, user.ID).Return([]domain.Workspace{*workspace}, nil).Once()
+		suite.taskRepo.On("ReassignTasks", mock.Anything, &domain.TaskFilter{UserID: user.ID}, target.ID).Return(nil).Once()
+		suite.timeEntryRepo.On("DeleteTimeEntries", mock.Anything, &domain.TimeEntryFilter{UserID: user.ID}).Return(nil).Once()
+		suite.expectUserDataDeleted(user.ID)
+		suite.userRepo.On("DeleteUser", mock.Anything, user.ID).Return(nil).Once()
+
+		err := suite.userUsecase.DeleteUser(context.Background(), user.ID, &domain.DeleteUserQuery{ReassignTo: target.ID.Hex()}, claims)
+		suite.Nil(err)
+	})
+
+	// A testcase where the user to reassign the tasks to is not a member of the workspace of a task.
+	suite.Run("DeleteUser_ReassignOutsideWorkspace", func() {
+		user := mocks.GetNewUser()
+		target := mocks.GetNewUser()
+		claims := mocks.GetClaims2() // An admin user.
+		tasks := []domain.Task{{ID: primitive.NewObjectID(), UserID: user.ID, WorkspaceID: mocks.GetWorkspace().ID}}
+
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, target.ID).Return(target, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, &domain.TaskFilter{UserID: user.ID}).Return(tasks, nil).Once()
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, target.ID).Return([]domain.Workspace{}, nil).Once()
+
+		err := suite.userUsecase.DeleteUser(context.Background(), user.ID, &domain.DeleteUserQuery{ReassignTo: target.ID.Hex()}, claims)
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+		suite.Equal([]domain.FieldError{{Field: "reassign_to", Reason: "must be a member of every workspace of the tasks"}}, err.Fields)
+	})
+
+	// A testcase where the user to reassign the tasks to does not exist.
+	suite.Run("DeleteUser_ReassignUnknownUser", func() {
+		user := mocks.GetNewUser()
+		claims := mocks.GetClaims2() // An admin user.
+		targetID := mocks.GetPrimitiveID3()
+
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, targetID).Return(nil, mongo.ErrNoDocuments).Once()
+
+		err := suite.userUsecase.DeleteUser(context.Background(), user.ID, &domain.DeleteUserQuery{ReassignTo: targetID.Hex()}, claims)
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+		suite.Equal([]domain.FieldError{{Field: "reassign_to", Reason: "must be the ID of an existing user"}}, err.Fields)
+	})
+
+	// A testcase where the policy is unknown.
+	suite.Run("DeleteUser_UnknownPolicy", func() {
+		user := mocks.GetNewUser()
+		claims := mocks.GetClaims2() // An admin user.
+
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(user, nil).Once()
+
+		err := suite.userUsecase.DeleteUser(context.Background(), user.ID, &domain.DeleteUserQuery{Tasks: "archive"}, claims)
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+		suite.Equal(domain.CodeValidationFailed, err.Code)
+	})
+
 	// A testcase where the user is not found.
 	suite.Run("DeleteUser_NotFound", func() {
 		claims := mocks.GetClaims2() // An admin user.
 
-		suite.userRepo.On("GetUserByID", mockObjectID).Return(nil, errors.New("some error")).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(nil, errors.New("some error")).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("some error"),
 			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeUserNotFound,
 			Message:    "User not found",
 		}
 
-		err := suite.userUsecase.DeleteUser(mocks.GetPrimitiveID1(), claims)
+		err := suite.userUsecase.DeleteUser(context.Background(), mocks.GetPrimitiveID1(), &domain.DeleteUserQuery{}, claims)
 		suite.Equal(expectedError, err)
 	})
 
@@ -408,8 +709,12 @@ func (suite *UserUsecaseSuite) Test_DeleteUser() {
 		user := mocks.GetNewUser()
 		claims := mocks.GetClaims2() // An admin user.
 
-		suite.userRepo.On("GetUserByID", mockObjectID).Return(user, nil).Once()
-		suite.userRepo.On("DeleteUser", mockObjectID).Return(errors.New("some error")).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(user, nil).Once()
+		suite.workspaceRepo.On("GetWorkspacesByUserID", mock.Anything, user.ID).Return([]domain.Workspace{}, nil).Once()
+		suite.taskRepo.On("CountTasks", mock.Anything, &domain.TaskFilter{UserID: user.ID}).Return(int64(0), nil).Once()
+		suite.timeEntryRepo.On("CountTimeEntries", mock.Anything, &domain.TimeEntryFilter{UserID: user.ID}).Return(int64(0), nil).Once()
+		suite.expectUserDataDeleted(user.ID)
+		suite.userRepo.On("DeleteUser", mock.Anything, mockObjectID).Return(errors.New("some error")).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("some error"),
@@ -417,7 +722,7 @@ func (suite *UserUsecaseSuite) Test_DeleteUser() {
 			Message:    "Internal server error",
 		}
 
-		err := suite.userUsecase.DeleteUser(user.ID, claims)
+		err := suite.userUsecase.DeleteUser(context.Background(), user.ID, &domain.DeleteUserQuery{}, claims)
 		suite.Equal(expectedError, err)
 	})
 
@@ -426,15 +731,16 @@ func (suite *UserUsecaseSuite) Test_DeleteUser() {
 		user := mocks.GetNewUser()
 		claims := mocks.GetClaims() // A regular user.
 
-		suite.userRepo.On("GetUserByID", mockObjectID).Return(user, nil).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, mockObjectID).Return(user, nil).Once()
 
 		expectedError := &domain.Error{
 			Err:        errors.New("unauthorized"),
 			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbidden,
 			Message:    "A User cannot delete another user",
 		}
 
-		err := suite.userUsecase.DeleteUser(user.ID, claims)
+		err := suite.userUsecase.DeleteUser(context.Background(), user.ID, &domain.DeleteUserQuery{}, claims)
 		suite.Equal(expectedError, err)
 	})
 }
@@ -0,0 +1,418 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"task_manager/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A struct that defines the services for workspaces, their members and their projects.
+type WorkspaceUsecase struct {
+	workspaceRepo domain.WorkspaceRepository
+	projectRepo   domain.ProjectRepository
+	taskRepo      domain.TaskRepository
+	userRepo      domain.UserRepository
+	blobs         domain.BlobStore
+	authorizer    domain.Authorizer
+}
+
+// A constructor that creates a new instance of WorkspaceUsecase.
+func NewWorkspaceUsecase(workspaceRepo domain.WorkspaceRepository, projectRepo domain.ProjectRepository, taskRepo domain.TaskRepository, userRepo domain.UserRepository, blobs domain.BlobStore, authorizer domain.Authorizer) *WorkspaceUsecase {
+	return &WorkspaceUsecase{
+		workspaceRepo: workspaceRepo,
+		projectRepo:   projectRepo,
+		taskRepo:      taskRepo,
+		userRepo:      userRepo,
+		blobs:         blobs,
+		authorizer:    authorizer,
+	}
+}
+
+// A method that creates a workspace. The user who creates it becomes its owner.
+func (wu *WorkspaceUsecase) CreateWorkspace(ctx context.Context, workspaceData *domain.WorkspaceData, claims *domain.Claims) (*domain.Workspace, *domain.Error) {
+	workspace := &domain.Workspace{
+		ID:          primitive.NewObjectID(),
+		Name:        strings.TrimSpace(workspaceData.Name),
+		Description: workspaceData.Description,
+		Members:     []domain.Membership{{UserID: claims.ID, Role: domain.WorkspaceRoleOwner}},
+		CreatedAt:   time.Now(),
+	}
+
+	if workspace.Name == "" {
+		return nil, &domain.Error{
+			Err:        errors.New("empty workspace name"),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "name is required",
+			Fields:     []domain.FieldError{{Field: "name", Reason: "is required"}},
+		}
+	}
+
+	err := wu.workspaceRepo.AddWorkspace(ctx, workspace)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return workspace, nil
+}
+
+// A method that returns the workspaces the logged in user is a member of.
+func (wu *WorkspaceUsecase) GetWorkspaces(ctx context.Context, claims *domain.Claims) ([]domain.Workspace, *domain.Error) {
+	workspaces, err := wu.workspaceRepo.GetWorkspacesByUserID(ctx, claims.ID)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return workspaces, nil
+}
+
+// A method that returns a workspace the logged in user is a member of.
+func (wu *WorkspaceUsecase) GetWorkspaceByID(ctx context.Context, id primitive.ObjectID, claims *domain.Claims) (*domain.Workspace, *domain.Error) {
+	return wu.getWorkspace(ctx, id, domain.ActionWorkspaceRead, claims)
+}
+
+// A method that renames a workspace or changes its description.
+func (wu *WorkspaceUsecase) UpdateWorkspace(ctx context.Context, id primitive.ObjectID, workspaceData *domain.WorkspaceData, claims *domain.Claims) (*domain.Workspace, *domain.Error) {
+	workspace, _err := wu.getWorkspace(ctx, id, domain.ActionWorkspaceManage, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	workspace.Name = strings.TrimSpace(workspaceData.Name)
+	workspace.Description = workspaceData.Description
+	if workspace.Name == "" {
+		return nil, &domain.Error{
+			Err:        errors.New("empty workspace name"),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "name is required",
+			Fields:     []domain.FieldError{{Field: "name", Reason: "is required"}},
+		}
+	}
+
+	err := wu.workspaceRepo.UpdateWorkspace(ctx, id, bson.M{"name": workspace.Name, "description": workspace.Description})
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return workspace, nil
+}
+
+// A method that sets the WIP limits of the columns of the board of a workspace, replacing the previous limits.
+// The limits of 0 are removed.
+func (wu *WorkspaceUsecase) SetWIPLimits(ctx context.Context, id primitive.ObjectID, limitsData *domain.WIPLimitsData, claims *domain.Claims) (*domain.Workspace, *domain.Error) {
+	workspace, _err := wu.getWorkspace(ctx, id, domain.ActionWorkspaceManage, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	limits := map[string]int{}
+	for status, limit := range limitsData.Limits {
+		if limit > 0 {
+			limits[status] = limit
+		}
+	}
+
+	err := wu.workspaceRepo.UpdateWorkspace(ctx, id, bson.M{"wip_limits": limits})
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	workspace.WIPLimits = limits
+	return workspace, nil
+}
+
+// A method that deletes a workspace together with its projects and tasks, and the files attached to the tasks.
+func (wu *WorkspaceUsecase) DeleteWorkspace(ctx context.Context, id primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	_, _err := wu.getWorkspace(ctx, id, domain.ActionWorkspaceManage, claims)
+	if _err != nil {
+		return _err
+	}
+
+	// Delete the contents first, so that nothing is left behind if a step fails.
+	tasks := &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{id}}
+	keys, err := attachmentKeys(ctx, wu.taskRepo, tasks)
+	if err == nil {
+		err = wu.taskRepo.DeleteTasks(ctx, tasks)
+	}
+	if err == nil {
+		err = wu.projectRepo.DeleteProjectsByWorkspaceID(ctx, id)
+	}
+	if err == nil {
+		err = wu.workspaceRepo.DeleteWorkspace(ctx, id)
+	}
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	deleteBlobs(ctx, wu.blobs, keys)
+	return nil
+}
+
+// A method that adds a user to a workspace or changes their role in it.
+func (wu *WorkspaceUsecase) SetMember(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID, membershipData *domain.MembershipData, claims *domain.Claims) (*domain.Workspace, *domain.Error) {
+	workspace, _err := wu.getWorkspace(ctx, id, domain.ActionWorkspaceManage, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	if !isWorkspaceRole(membershipData.Role) {
+		return nil, &domain.Error{
+			Err:        errors.New("unknown workspace role " + membershipData.Role),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "role must be any of: " + strings.Join(domain.WorkspaceRoles, ", "),
+			Fields:     []domain.FieldError{{Field: "role", Reason: "must be any of: " + strings.Join(domain.WorkspaceRoles, ", ")}},
+		}
+	}
+
+	// Check that the user exists.
+	_, err := wu.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, notFoundOrInternal(err, domain.CodeUserNotFound, "User not found")
+	}
+
+	// Change the role of the member, or add them.
+	found := false
+	for i := range workspace.Members {
+		if workspace.Members[i].UserID == userID {
+			workspace.Members[i].Role = membershipData.Role
+			found = true
+		}
+	}
+
+	if !found {
+		workspace.Members = append(workspace.Members, domain.Membership{UserID: userID, Role: membershipData.Role})
+	}
+
+	_err = wu.saveMembers(ctx, workspace)
+	if _err != nil {
+		return nil, _err
+	}
+
+	return workspace, nil
+}
+
+// A method that removes a user from a workspace. Members can always leave a workspace themselves.
+func (wu *WorkspaceUsecase) RemoveMember(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	action := domain.ActionWorkspaceManage
+	if userID == claims.ID {
+		action = domain.ActionWorkspaceRead
+	}
+
+	workspace, _err := wu.getWorkspace(ctx, id, action, claims)
+	if _err != nil {
+		return _err
+	}
+
+	members := []domain.Membership{}
+	for _, member := range workspace.Members {
+		if member.UserID != userID {
+			members = append(members, member)
+		}
+	}
+
+	if len(members) == len(workspace.Members) {
+		return &domain.Error{
+			Err:        errors.New("not a member of the workspace"),
+			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeMemberNotFound,
+			Message:    "Member not found",
+		}
+	}
+
+	workspace.Members = members
+	return wu.saveMembers(ctx, workspace)
+}
+
+// A method that creates a project in a workspace.
+func (wu *WorkspaceUsecase) CreateProject(ctx context.Context, workspaceID primitive.ObjectID, projectData *domain.ProjectData, claims *domain.Claims) (*domain.Project, *domain.Error) {
+	_, _err := wu.getWorkspace(ctx, workspaceID, domain.ActionWorkspaceManage, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	project := &domain.Project{
+		ID:          primitive.NewObjectID(),
+		WorkspaceID: workspaceID,
+		Name:        strings.TrimSpace(projectData.Name),
+		Description: projectData.Description,
+		CreatedAt:   time.Now(),
+	}
+
+	if project.Name == "" {
+		return nil, &domain.Error{
+			Err:        errors.New("empty project name"),
+			StatusCode: http.StatusBadRequest,
+			Code:       domain.CodeValidationFailed,
+			Message:    "name is required",
+			Fields:     []domain.FieldError{{Field: "name", Reason: "is required"}},
+		}
+	}
+
+	err := wu.projectRepo.AddProject(ctx, project)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return project, nil
+}
+
+// A method that returns the projects of a workspace.
+func (wu *WorkspaceUsecase) GetProjects(ctx context.Context, workspaceID primitive.ObjectID, claims *domain.Claims) ([]domain.Project, *domain.Error) {
+	_, _err := wu.getWorkspace(ctx, workspaceID, domain.ActionWorkspaceRead, claims)
+	if _err != nil {
+		return nil, _err
+	}
+
+	projects, err := wu.projectRepo.GetProjectsByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		return nil, &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return projects, nil
+}
+
+// A method that deletes a project together with its tasks.
+func (wu *WorkspaceUsecase) DeleteProject(ctx context.Context, workspaceID primitive.ObjectID, projectID primitive.ObjectID, claims *domain.Claims) *domain.Error {
+	_, _err := wu.getWorkspace(ctx, workspaceID, domain.ActionWorkspaceManage, claims)
+	if _err != nil {
+		return _err
+	}
+
+	// Check that the project belongs to the workspace.
+	project, err := wu.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return notFoundOrInternal(err, domain.CodeProjectNotFound, "Project not found")
+	}
+
+	if project.WorkspaceID != workspaceID {
+		return &domain.Error{
+			Err:        errors.New("project of another workspace"),
+			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeProjectNotFound,
+			Message:    "Project not found",
+		}
+	}
+
+	tasks := &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{workspaceID}, ProjectID: projectID}
+	keys, err := attachmentKeys(ctx, wu.taskRepo, tasks)
+	if err == nil {
+		err = wu.taskRepo.DeleteTasks(ctx, tasks)
+	}
+	if err == nil {
+		err = wu.projectRepo.DeleteProject(ctx, projectID)
+	}
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	deleteBlobs(ctx, wu.blobs, keys)
+	return nil
+}
+
+// A helper method that returns a workspace if the logged in user may perform the action on it.
+// Workspaces of other teams are reported as missing.
+func (wu *WorkspaceUsecase) getWorkspace(ctx context.Context, id primitive.ObjectID, action string, claims *domain.Claims) (*domain.Workspace, *domain.Error) {
+	workspace, err := wu.workspaceRepo.GetWorkspaceByID(ctx, id)
+	if err != nil {
+		return nil, notFoundOrInternal(err, domain.CodeWorkspaceNotFound, "Workspace not found")
+	}
+
+	decision, _err := authorize(ctx, wu.authorizer, claims, &domain.AccessRequest{Action: action, Workspace: workspace})
+	if _err != nil {
+		return nil, _err
+	}
+
+	if decision.Denial == domain.DenialNotMember {
+		return nil, &domain.Error{
+			Err:        errors.New(decision.Reason),
+			StatusCode: http.StatusNotFound,
+			Code:       domain.CodeWorkspaceNotFound,
+			Message:    "Workspace not found",
+		}
+	}
+
+	if !decision.Allowed {
+		return nil, &domain.Error{
+			Err:        errors.New(decision.Reason),
+			StatusCode: http.StatusForbidden,
+			Code:       domain.CodeForbiddenWorkspaceRole,
+			Message:    "Only workspace owners can manage the workspace",
+		}
+	}
+
+	return workspace, nil
+}
+
+// A helper method that saves the members of a workspace, which must keep at least one owner.
+func (wu *WorkspaceUsecase) saveMembers(ctx context.Context, workspace *domain.Workspace) *domain.Error {
+	if workspace.OwnerCount() == 0 {
+		return &domain.Error{
+			Err:        errors.New("no owner left"),
+			StatusCode: http.StatusConflict,
+			Code:       domain.CodeLastOwner,
+			Message:    "A workspace must have at least one owner",
+		}
+	}
+
+	err := wu.workspaceRepo.SetMembers(ctx, workspace.ID, workspace.Members)
+	if err != nil {
+		return &domain.Error{
+			Err:        err,
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Internal server error",
+		}
+	}
+
+	return nil
+}
+
+// A helper function that checks if a role can be given to members of a workspace.
+func isWorkspaceRole(role string) bool {
+	for _, r := range domain.WorkspaceRoles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,272 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"task_manager/domain"
+	"task_manager/infrastructure"
+	"task_manager/mocks"
+	"task_manager/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	mockWorkspace = mock.AnythingOfType("*domain.Workspace")
+	mockProject   = mock.AnythingOfType("*domain.Project")
+	mockMembers   = mock.AnythingOfType("[]domain.Membership")
+)
+
+// A suite that tests the workspace usecase.
+type WorkspaceUsecaseSuite struct {
+	suite.Suite
+	workspaceRepo *mocks.WorkspaceRepository
+	projectRepo   *mocks.ProjectRepository
+	taskRepo      *mocks.TaskRepository
+	userRepo      *mocks.UserRepository
+	blobs         *mocks.BlobStore
+	usecase       *usecase.WorkspaceUsecase
+}
+
+// A method that sets up the test suite.
+func (suite *WorkspaceUsecaseSuite) SetupTest() {
+	suite.workspaceRepo = new(mocks.WorkspaceRepository)
+	suite.projectRepo = new(mocks.ProjectRepository)
+	suite.taskRepo = new(mocks.TaskRepository)
+	suite.userRepo = new(mocks.UserRepository)
+	suite.blobs = new(mocks.BlobStore)
+	authorizer := infrastructure.NewRoleAuthorizer(new(mocks.RoleRepository))
+	suite.usecase = usecase.NewWorkspaceUsecase(suite.workspaceRepo, suite.projectRepo, suite.taskRepo, suite.userRepo, suite.blobs, authorizer)
+}
+
+// A method that tears down the test suite.
+func (suite *WorkspaceUsecaseSuite) TearDownTest() {
+	suite.workspaceRepo.AssertExpectations(suite.T())
+	suite.projectRepo.AssertExpectations(suite.T())
+	suite.taskRepo.AssertExpectations(suite.T())
+	suite.userRepo.AssertExpectations(suite.T())
+	suite.blobs.AssertExpectations(suite.T())
+}
+
+// A test for the WorkspaceUsecase.CreateWorkspace method.
+func (suite *WorkspaceUsecaseSuite) Test_CreateWorkspace() {
+	// A testcase where the creator becomes the owner of the workspace.
+	suite.Run("CreateWorkspace_Success", func() {
+		claims := mocks.GetClaims()
+		suite.workspaceRepo.On("AddWorkspace", mock.Anything, mockWorkspace).Return(nil).Once()
+
+		workspace, err := suite.usecase.CreateWorkspace(context.Background(), &domain.WorkspaceData{Name: " Team "}, claims)
+		suite.Nil(err)
+		suite.Equal("Team", workspace.Name)
+		suite.Equal(domain.WorkspaceRoleOwner, workspace.MemberRole(claims.ID))
+	})
+}
+
+// A test for the WorkspaceUsecase.GetWorkspaceByID method.
+func (suite *WorkspaceUsecaseSuite) Test_GetWorkspaceByID() {
+	// A testcase where a member gets the workspace.
+	suite.Run("GetWorkspaceByID_Success", func() {
+		workspace := mocks.GetWorkspace()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+
+		result, err := suite.usecase.GetWorkspaceByID(context.Background(), workspace.ID, mocks.GetClaims())
+		suite.Nil(err)
+		suite.Equal(workspace, result)
+	})
+
+	// A testcase where a user who is not a member gets the workspace.
+	suite.Run("GetWorkspaceByID_NotMember", func() {
+		workspace := mocks.GetWorkspace()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+
+		result, err := suite.usecase.GetWorkspaceByID(context.Background(), workspace.ID, mocks.GetClaims3())
+		suite.Nil(result)
+		suite.Equal(http.StatusNotFound, err.StatusCode)
+	})
+
+	// A testcase where the workspace does not exist.
+	suite.Run("GetWorkspaceByID_NotFound", func() {
+		id := primitive.NewObjectID()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, id).Return(nil, mongo.ErrNoDocuments).Once()
+
+		result, err := suite.usecase.GetWorkspaceByID(context.Background(), id, mocks.GetClaims())
+		suite.Nil(result)
+		suite.Equal(http.StatusNotFound, err.StatusCode)
+	})
+}
+
+// A test for the WorkspaceUsecase.DeleteWorkspace method.
+func (suite *WorkspaceUsecaseSuite) Test_DeleteWorkspace() {
+	// A testcase where the owner deletes the workspace with its projects and tasks, and the files attached to them.
+	suite.Run("DeleteWorkspace_Success", func() {
+		workspace := mocks.GetWorkspace()
+		filter := &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{workspace.ID}}
+		task := domain.Task{ID: primitive.NewObjectID(), WorkspaceID: workspace.ID, Attachments: []domain.Attachment{{ID: primitive.NewObjectID()}, {ID: primitive.NewObjectID()}}}
+
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, filter).Return([]domain.Task{task, {ID: primitive.NewObjectID()}}, nil).Once()
+		suite.taskRepo.On("DeleteTasks", mock.Anything, filter).Return(nil).Once()
+		suite.projectRepo.On("DeleteProjectsByWorkspaceID", mock.Anything, workspace.ID).Return(nil).Once()
+		suite.workspaceRepo.On("DeleteWorkspace", mock.Anything, workspace.ID).Return(nil).Once()
+		for _, attachment := range task.Attachments {
+			suite.blobs.On("DeleteBlob", mock.Anything, domain.AttachmentKey(task.ID, attachment.ID)).Return(nil).Once()
+		}
+
+		err := suite.usecase.DeleteWorkspace(context.Background(), workspace.ID, mocks.GetClaims2())
+		suite.Nil(err)
+	})
+
+	// A testcase where a member who is not an owner deletes the workspace.
+	suite.Run("DeleteWorkspace_Forbidden", func() {
+		workspace := mocks.GetWorkspace()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+
+		err := suite.usecase.DeleteWorkspace(context.Background(), workspace.ID, mocks.GetClaims())
+		suite.Equal(http.StatusForbidden, err.StatusCode)
+	})
+}
+
+// A test for the WorkspaceUsecase.SetWIPLimits method.
+func (suite *WorkspaceUsecaseSuite) Test_SetWIPLimits() {
+	// A testcase where the owner replaces the limits, and a limit of 0 is removed.
+	suite.Run("SetWIPLimits_Success", func() {
+		workspace := mocks.GetWorkspace()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.workspaceRepo.On("UpdateWorkspace", mock.Anything, workspace.ID, bson.M{"wip_limits": map[string]int{"In Progress": 3}}).Return(nil).Once()
+
+		result, err := suite.usecase.SetWIPLimits(context.Background(), workspace.ID, &domain.WIPLimitsData{Limits: map[string]int{"In Progress": 3, "Pending": 0}}, mocks.GetClaims2())
+		suite.Nil(err)
+		suite.Equal(map[string]int{"In Progress": 3}, result.WIPLimits)
+	})
+
+	// A testcase where a member who is not an owner sets the limits.
+	suite.Run("SetWIPLimits_Forbidden", func() {
+		workspace := mocks.GetWorkspace()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+
+		_, err := suite.usecase.SetWIPLimits(context.Background(), workspace.ID, &domain.WIPLimitsData{Limits: map[string]int{}}, mocks.GetClaims())
+		suite.Equal(http.StatusForbidden, err.StatusCode)
+	})
+}
+
+// A test for the WorkspaceUsecase.SetMember method.
+func (suite *WorkspaceUsecaseSuite) Test_SetMember() {
+	// A testcase where the owner adds a user.
+	suite.Run("SetMember_Add", func() {
+		workspace := mocks.GetWorkspace()
+		user := mocks.GetNewUser()
+		user.ID = mocks.GetPrimitiveID3()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+		suite.workspaceRepo.On("SetMembers", mock.Anything, workspace.ID, mockMembers).Return(nil).Once()
+
+		result, err := suite.usecase.SetMember(context.Background(), workspace.ID, user.ID, &domain.MembershipData{Role: domain.WorkspaceRoleViewer}, mocks.GetClaims2())
+		suite.Nil(err)
+		suite.Equal(domain.WorkspaceRoleViewer, result.MemberRole(user.ID))
+		suite.Equal(3, len(result.Members))
+	})
+
+	// A testcase where the last owner demotes themselves.
+	suite.Run("SetMember_LastOwner", func() {
+		workspace := mocks.GetWorkspace()
+		user := mocks.GetNewUser2()
+		user.ID = mocks.GetPrimitiveID2()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+		result, err := suite.usecase.SetMember(context.Background(), workspace.ID, user.ID, &domain.MembershipData{Role: domain.WorkspaceRoleMember}, mocks.GetClaims2())
+		suite.Nil(result)
+		suite.Equal(http.StatusConflict, err.StatusCode)
+	})
+
+	// A testcase where the role is unknown.
+	suite.Run("SetMember_UnknownRole", func() {
+		workspace := mocks.GetWorkspace()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+
+		result, err := suite.usecase.SetMember(context.Background(), workspace.ID, mocks.GetPrimitiveID3(), &domain.MembershipData{Role: "admin"}, mocks.GetClaims2())
+		suite.Nil(result)
+		suite.Equal(http.StatusBadRequest, err.StatusCode)
+	})
+}
+
+// A test for the WorkspaceUsecase.RemoveMember method.
+func (suite *WorkspaceUsecaseSuite) Test_RemoveMember() {
+	// A testcase where a member leaves the workspace.
+	suite.Run("RemoveMember_Leave", func() {
+		workspace := mocks.GetWorkspace()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.workspaceRepo.On("SetMembers", mock.Anything, workspace.ID, []domain.Membership{workspace.Members[1]}).Return(nil).Once()
+
+		err := suite.usecase.RemoveMember(context.Background(), workspace.ID, mocks.GetPrimitiveID1(), mocks.GetClaims())
+		suite.Nil(err)
+	})
+
+	// A testcase where a member removes another member.
+	suite.Run("RemoveMember_Forbidden", func() {
+		workspace := mocks.GetWorkspace()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+
+		err := suite.usecase.RemoveMember(context.Background(), workspace.ID, mocks.GetPrimitiveID2(), mocks.GetClaims())
+		suite.Equal(http.StatusForbidden, err.StatusCode)
+	})
+}
+
+// A test for the WorkspaceUsecase.CreateProject method.
+func (suite *WorkspaceUsecaseSuite) Test_CreateProject() {
+	// A testcase where the owner creates a project.
+	suite.Run("CreateProject_Success", func() {
+		workspace := mocks.GetWorkspace()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.projectRepo.On("AddProject", mock.Anything, mockProject).Return(nil).Once()
+
+		project, err := suite.usecase.CreateProject(context.Background(), workspace.ID, &domain.ProjectData{Name: "Backend"}, mocks.GetClaims2())
+		suite.Nil(err)
+		suite.Equal(workspace.ID, project.WorkspaceID)
+	})
+}
+
+// A test for the WorkspaceUsecase.DeleteProject method.
+func (suite *WorkspaceUsecaseSuite) Test_DeleteProject() {
+	// A testcase where the owner deletes a project with its tasks, and a failure to delete the files attached to them
+	// is only logged.
+	suite.Run("DeleteProject_Success", func() {
+		workspace := mocks.GetWorkspace()
+		project := mocks.GetProject()
+		filter := &domain.TaskFilter{WorkspaceIDs: []primitive.ObjectID{workspace.ID}, ProjectID: project.ID}
+		task := domain.Task{ID: primitive.NewObjectID(), ProjectID: project.ID, Attachments: []domain.Attachment{{ID: primitive.NewObjectID()}}}
+
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.projectRepo.On("GetProjectByID", mock.Anything, project.ID).Return(project, nil).Once()
+		suite.taskRepo.On("GetTasks", mock.Anything, filter).Return([]domain.Task{task}, nil).Once()
+		suite.taskRepo.On("DeleteTasks", mock.Anything, filter).Return(nil).Once()
+		suite.projectRepo.On("DeleteProject", mock.Anything, project.ID).Return(nil).Once()
+		suite.blobs.On("DeleteBlob", mock.Anything, domain.AttachmentKey(task.ID, task.Attachments[0].ID)).Return(errors.New("unavailable")).Once()
+
+		err := suite.usecase.DeleteProject(context.Background(), workspace.ID, project.ID, mocks.GetClaims2())
+		suite.Nil(err)
+	})
+
+	// A testcase where the project belongs to another workspace.
+	suite.Run("DeleteProject_OtherWorkspace", func() {
+		workspace := mocks.GetWorkspace()
+		project := mocks.GetProject()
+		project.WorkspaceID = primitive.NewObjectID()
+		suite.workspaceRepo.On("GetWorkspaceByID", mock.Anything, workspace.ID).Return(workspace, nil).Once()
+		suite.projectRepo.On("GetProjectByID", mock.Anything, project.ID).Return(project, nil).Once()
+
+		err := suite.usecase.DeleteProject(context.Background(), workspace.ID, project.ID, mocks.GetClaims2())
+		suite.Equal(http.StatusNotFound, err.StatusCode)
+	})
+}
+
+// A function that runs the WorkspaceUsecaseSuite.
+func TestWorkspaceUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(WorkspaceUsecaseSuite))
+}